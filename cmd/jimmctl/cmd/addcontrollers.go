@@ -0,0 +1,132 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var addControllersCommandDoc = `
+	add-controllers command adds all the controllers listed in a YAML
+	manifest to jimm. Each entry in the manifest has the same format as
+	the file read by add-controller. A failure to add one controller
+	does not prevent the remaining controllers from being added, the
+	result for each controller is reported.
+
+	Example:
+		jimmctl add-controllers <filename>
+		jimmctl add-controllers <filename> --format json
+`
+
+// NewAddControllersCommand returns a command to add the controllers
+// listed in a manifest.
+func NewAddControllersCommand() cmd.Command {
+	cmd := &addControllersCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// addControllersCommand adds the controllers listed in a manifest.
+type addControllersCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	file     cmd.FileVar
+}
+
+// addControllerResult holds the result of adding a single controller
+// from a manifest.
+type addControllerResult struct {
+	Name  string `json:"name" yaml:"name"`
+	Added bool   `json:"added" yaml:"added"`
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func (c *addControllersCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add-controllers",
+		Args:    "<filename>",
+		Purpose: "Add the controllers listed in a manifest to jimm",
+		Doc:     addControllersCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addControllersCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	c.file.StdinMarkers = stdinMarkers
+}
+
+// Init implements the cmd.Command interface.
+func (c *addControllersCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("filename not specified")
+	}
+	c.file.Path = args[0]
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *addControllersCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	var manifest []apiparams.AddControllerRequest
+	if err = unmarshalYAMLFile(ctxt, &manifest, c.file); err != nil {
+		return errors.E(err)
+	}
+	if len(manifest) == 0 {
+		return errors.E("no controllers specified")
+	}
+
+	client := api.NewClient(apiCaller)
+	results := make([]addControllerResult, len(manifest))
+	var failed int
+	for i := range manifest {
+		results[i].Name = manifest[i].Name
+		if _, err := client.AddController(&manifest[i]); err != nil {
+			results[i].Error = err.Error()
+			failed++
+			continue
+		}
+		results[i].Added = true
+	}
+
+	err = c.out.Write(ctxt, results)
+	if err != nil {
+		return errors.E(err)
+	}
+	if failed > 0 {
+		return errors.E(fmt.Sprintf("failed to add %d of %d controllers", failed, len(manifest)))
+	}
+	return nil
+}
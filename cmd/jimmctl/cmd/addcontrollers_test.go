@@ -0,0 +1,70 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+	"os"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type addControllersSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&addControllersSuite{})
+
+func (s *addControllersSuite) TestAddControllers(c *gc.C) {
+	info := s.APIInfo(c)
+	manifest := []apiparams.AddControllerRequest{{
+		Name:          "controller-1",
+		CACertificate: info.CACert,
+		APIAddresses:  []string{"127.0.0.1:1"},
+		Username:      info.Tag.Id(),
+		Password:      info.Password,
+	}, {
+		Name:          "controller-2",
+		CACertificate: info.CACert,
+		APIAddresses:  info.Addrs,
+		Username:      info.Tag.Id(),
+		Password:      info.Password,
+	}}
+	tmpdir, tmpfile := writeYAMLTempFile(c, manifest)
+	defer os.RemoveAll(tmpdir)
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	ctx, err := cmdtesting.RunCommand(c, cmd.NewAddControllersCommandForTesting(s.ClientStore(), bClient), tmpfile)
+	c.Assert(err, gc.ErrorMatches, `failed to add 1 of 2 controllers`)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Matches, `(?s)- name: controller-1
+  added: false
+  error: .+
+- name: controller-2
+  added: true
+`)
+
+	// The failure to add controller-1 did not prevent controller-2
+	// from being added.
+	ctl := dbmodel.Controller{Name: "controller-2"}
+	err = s.JIMM.Database.GetController(context.Background(), &ctl)
+	c.Assert(err, gc.IsNil)
+	ctl = dbmodel.Controller{Name: "controller-1"}
+	err = s.JIMM.Database.GetController(context.Background(), &ctl)
+	c.Assert(err, gc.ErrorMatches, `controller not found`)
+}
+
+func (s *addControllersSuite) TestAddControllersEmptyManifest(c *gc.C) {
+	tmpdir, tmpfile := writeYAMLTempFile(c, []apiparams.AddControllerRequest{})
+	defer os.RemoveAll(tmpdir)
+
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewAddControllersCommandForTesting(s.ClientStore(), bClient), tmpfile)
+	c.Assert(err, gc.ErrorMatches, `no controllers specified`)
+}
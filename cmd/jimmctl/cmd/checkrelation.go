@@ -0,0 +1,112 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var checkUserRelationCommandDoc = `
+	check-relation command checks whether a user has a relation to a
+	model, controller, application offer, group or cloud and displays
+	the result along with a trace of the relations stored against the
+	target that apply to the user.
+
+	Example:
+		jimmctl check-relation <user> <relation> <target>
+		jimmctl check-relation alice@canonical.com reader model-alice@canonical.com/my-model
+		jimmctl check-relation alice@canonical.com administrator controller-my-controller --format json
+`
+
+// NewCheckUserRelationCommand returns a command to check a user's
+// relation to an entity.
+func NewCheckUserRelationCommand() cmd.Command {
+	cmd := &checkUserRelationCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// checkUserRelationCommand checks a user's relation to an entity.
+type checkUserRelationCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	req apiparams.CheckUserRelationRequest
+}
+
+func (c *checkUserRelationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "check-relation",
+		Args:    "<user> <relation> <target>",
+		Purpose: "Checks a user's relation to an entity",
+		Doc:     checkUserRelationCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *checkUserRelationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *checkUserRelationCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.E("user, relation and target must be specified")
+	}
+	if len(args) > 3 {
+		return errors.E("unknown arguments")
+	}
+	if !names.IsValidUser(args[0]) {
+		return errors.E("invalid user name")
+	}
+	c.req = apiparams.CheckUserRelationRequest{
+		UserTag:   names.NewUserTag(args[0]).String(),
+		Relation:  args[1],
+		TargetTag: args[2],
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *checkUserRelationCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.CheckUserRelation(&c.req)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
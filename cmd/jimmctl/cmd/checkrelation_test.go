@@ -0,0 +1,64 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+type checkUserRelationSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&checkUserRelationSuite{})
+
+func (s *checkUserRelationSuite) TestCheckUserRelationSuperuser(c *gc.C) {
+	ctx := context.Background()
+
+	_, err := s.JIMM.Database.AddGroup(ctx, "test-group")
+	c.Assert(err, gc.IsNil)
+	group := dbmodel.GroupEntry{Name: "test-group"}
+	err = s.JIMM.Database.GetGroup(ctx, &group)
+	c.Assert(err, gc.IsNil)
+
+	err = s.JIMM.OpenFGAClient.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag("bob@canonical.com")),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group.Tag().(jimmnames.GroupTag)),
+	})
+	c.Assert(err, gc.IsNil)
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	cmdCtx, err := cmdtesting.RunCommand(c, cmd.NewCheckUserRelationCommandForTesting(s.ClientStore(), bClient), "bob@canonical.com", "member", "group-test-group")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(cmdCtx), gc.Equals, `allowed: true
+trace:
+- checked user:bob@canonical.com member group:`+group.UUID+`
+- user:bob@canonical.com member group:`+group.UUID+` (direct)
+`)
+}
+
+func (s *checkUserRelationSuite) TestCheckUserRelationUnauthorized(c *gc.C) {
+	// bob is not a superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewCheckUserRelationCommandForTesting(s.ClientStore(), bClient), "alice@canonical.com", "administrator", "controller-jimm")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *checkUserRelationSuite) TestCheckUserRelationMissingArguments(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewCheckUserRelationCommandForTesting(s.ClientStore(), bClient), "alice@canonical.com", "administrator")
+	c.Assert(err, gc.ErrorMatches, `user, relation and target must be specified`)
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var exportInventoryCommandDoc = `
+	export-inventory command displays a snapshot of the controllers,
+	clouds, models and groups known to JIMM along with the access
+	relations between them. Secrets, such as controller passwords and
+	cloud credential attributes, are never included. The inventory is
+	written as a JSON document as it is received from JIMM.
+
+	Example:
+		jimmctl export-inventory
+		jimmctl export-inventory --output inventory.json
+`
+
+// NewExportInventoryCommand returns a command to export the JIMM
+// inventory.
+func NewExportInventoryCommand() cmd.Command {
+	cmd := &exportInventoryCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// exportInventoryCommand displays the JIMM inventory.
+type exportInventoryCommand struct {
+	modelcmd.ControllerCommandBase
+	outputFile string
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+func (c *exportInventoryCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "export-inventory",
+		Purpose: "Displays a snapshot of the entities known to JIMM",
+		Doc:     exportInventoryCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *exportInventoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.outputFile, "o", "", "Specify an output file")
+	f.StringVar(&c.outputFile, "output", "", "")
+}
+
+// Init implements the cmd.Command interface.
+func (c *exportInventoryCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *exportInventoryCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client, err := apiCaller.RootHTTPClient()
+	if err != nil {
+		return errors.E(err)
+	}
+	var resp *http.Response
+	if err := client.Get(ctxt, apiparams.InventoryPath, &resp); err != nil {
+		return errors.E(err)
+	}
+	defer resp.Body.Close()
+
+	var w io.Writer = ctxt.Stdout
+	if c.outputFile != "" {
+		f, err := os.Create(ctxt.AbsPath(c.outputFile))
+		if err != nil {
+			return errors.E(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.E(err, "cannot read inventory")
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"encoding/json"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type exportInventorySuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&exportInventorySuite{})
+
+func (s *exportInventorySuite) TestExportInventorySuperuser(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-2", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewExportInventoryCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.IsNil)
+
+	var inv apiparams.Inventory
+	err = json.Unmarshal([]byte(cmdtesting.Stdout(context)), &inv)
+	c.Assert(err, gc.IsNil)
+	c.Check(inv.Version, gc.Equals, apiparams.InventoryVersion)
+	c.Assert(inv.Controllers, gc.HasLen, 1)
+	c.Check(inv.Controllers[0].Name, gc.Equals, "controller-1")
+	c.Assert(inv.Models, gc.HasLen, 1)
+	c.Check(inv.Models[0].UUID, gc.Equals, mt.Id())
+	c.Check(inv.Models[0].Name, gc.Equals, "model-2")
+	c.Check(inv.Models[0].CloudCredential, gc.Equals, cct.Id())
+}
+
+func (s *exportInventorySuite) TestExportInventoryUnauthorized(c *gc.C) {
+	// bob is not a superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewExportInventoryCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `.*Forbidden - unauthorized`)
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var exportModelCommandDoc = `
+	export-model command displays the specification of a model, this
+	includes the cloud, region, cloud credential name, model config
+	and constraints and can be used to re-create the model elsewhere.
+	Credential attributes are never included.
+
+	Example:
+		jimmctl export-model <model uuid>
+		jimmctl export-model <model uuid> --output model.yaml
+		jimmctl export-model <model uuid> --format json
+`
+
+// NewExportModelCommand returns a command to export the specification
+// of a model.
+func NewExportModelCommand() cmd.Command {
+	cmd := &exportModelCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// exportModelCommand displays the specification
+// of a model.
+type exportModelCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store     jujuclient.ClientStore
+	dialOpts  *jujuapi.DialOpts
+	modelUUID string
+}
+
+func (c *exportModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "export-model",
+		Purpose: "Displays the specification of a model",
+		Doc:     exportModelCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *exportModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *exportModelCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("missing model uuid")
+	}
+	c.modelUUID, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	if !names.IsValidModel(c.modelUUID) {
+		return errors.E("invalid model uuid")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *exportModelCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	spec, err := client.ExportModelSpec(&apiparams.ExportModelSpecRequest{
+		ModelTag: names.NewModelTag(c.modelUUID).String(),
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, spec)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v3"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type exportModelSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&exportModelSuite{})
+
+func (s *exportModelSuite) TestExportModelSuperuser(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-2", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewExportModelCommandForTesting(s.ClientStore(), bClient), mt.Id())
+	c.Assert(err, gc.IsNil)
+
+	var spec apiparams.ModelSpec
+	err = yaml.Unmarshal([]byte(cmdtesting.Stdout(context)), &spec)
+	c.Assert(err, gc.IsNil)
+	c.Check(spec.Config["name"], gc.IsNil)
+	c.Check(spec.Config["uuid"], gc.IsNil)
+
+	// The exported specification can be used to re-create the model.
+	args := jujuparams.ModelCreateArgs{
+		Name:               spec.Name,
+		OwnerTag:           names.NewUserTag(spec.Owner).String(),
+		CloudTag:           names.NewCloudTag(spec.Cloud).String(),
+		CloudRegion:        spec.CloudRegion,
+		CloudCredentialTag: names.NewCloudCredentialTag(spec.Cloud + "/" + spec.Owner + "/" + spec.CloudCredential).String(),
+	}
+	c.Check(args, gc.DeepEquals, jujuparams.ModelCreateArgs{
+		Name:               "model-2",
+		OwnerTag:           names.NewUserTag("charlie@canonical.com").String(),
+		CloudTag:           names.NewCloudTag(jimmtest.TestCloudName).String(),
+		CloudRegion:        jimmtest.TestCloudRegionName,
+		CloudCredentialTag: cct.String(),
+	})
+}
+
+func (s *exportModelSuite) TestExportModelUnauthorized(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-2", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	// bob has no access to the model
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewExportModelCommandForTesting(s.ClientStore(), bClient), mt.Id())
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *exportModelSuite) TestExportModelInvalidUUID(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewExportModelCommandForTesting(s.ClientStore(), bClient), "not-a-uuid")
+	c.Assert(err, gc.ErrorMatches, `invalid model uuid`)
+}
@@ -52,6 +52,7 @@ list command lists all groups in jimm.
 
 Example:
 	jimmctl auth group list
+	jimmctl auth group list --name-prefix team- --limit 10
 `
 )
 
@@ -324,8 +325,9 @@ type listGroupsCommand struct {
 	store    jujuclient.ClientStore
 	dialOpts *jujuapi.DialOpts
 
-	limit  int
-	offset int
+	limit      int
+	offset     int
+	namePrefix string
 }
 
 // Info implements the cmd.Command interface.
@@ -354,6 +356,7 @@ func (c *listGroupsCommand) SetFlags(f *gnuflag.FlagSet) {
 	})
 	f.IntVar(&c.limit, "limit", 0, "The maximum number of groups to return")
 	f.IntVar(&c.offset, "offset", 0, "The offset to use when requesting groups")
+	f.StringVar(&c.namePrefix, "name-prefix", "", "Only list groups whose name starts with this prefix")
 }
 
 // Run implements Command.Run.
@@ -369,7 +372,7 @@ func (c *listGroupsCommand) Run(ctxt *cmd.Context) error {
 	}
 
 	client := api.NewClient(apiCaller)
-	req := apiparams.ListGroupsRequest{Limit: c.limit, Offset: c.offset}
+	req := apiparams.ListGroupsRequest{Limit: c.limit, Offset: c.offset, NamePrefix: c.namePrefix}
 	groups, err := client.ListGroups(&req)
 	if err != nil {
 		return errors.E(err)
@@ -26,6 +26,9 @@ const importModelCommandDoc = `
 	local user and it will switch the model owner to the desired external user.
 	E.g. --owner my-user@canonical.com
 
+	The --uuid flag guards against importing the wrong model, the import is
+	rejected unless the model on the controller has the given uuid.
+
 	Example:
 		jimmctl import-model <controller name> <model-uuid>
 		jimmctl import-model <controller name> <model-uuid> --owner <username>
@@ -62,6 +65,7 @@ func (c *importModelCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *importModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.req.Owner, "owner", "", "switch the model owner to the desired user")
+	f.StringVar(&c.req.UUID, "uuid", "", "reject the import unless the model has the given uuid")
 }
 
 // Init implements the cmd.Command interface.
@@ -0,0 +1,109 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+const pruneAuditEventsDoc = `
+	prune-audit-events removes audit events older than the given cutoff.
+	The cutoff is either an ISO8601 date or a duration before the current
+	time. If JIMM is configured with an audit sink any events that have
+	not yet been exported are sent to the sink before they are removed.
+
+	Examples:
+		jimmctl prune-audit-events 2021-02-03
+		jimmctl prune-audit-events 2021-02-03T15:04:05Z
+		jimmctl prune-audit-events 720h
+`
+
+// NewPruneAuditEventsCommand returns a command to prune audit events.
+func NewPruneAuditEventsCommand() cmd.Command {
+	cmd := &pruneAuditEventsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+	return modelcmd.WrapBase(cmd)
+}
+
+// pruneAuditEventsCommand prunes audit events.
+type pruneAuditEventsCommand struct {
+	modelcmd.ControllerCommandBase
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	out      cmd.Output
+
+	olderThan time.Time
+}
+
+// Info implements Command.Info.
+func (c *pruneAuditEventsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "prune-audit-events",
+		Args:    "<ISO8601 date|duration>",
+		Purpose: "removes audit events older than the given cutoff",
+		Doc:     pruneAuditEventsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *pruneAuditEventsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements Command.Init.
+func (c *pruneAuditEventsCommand) Init(args []string) error {
+	if len(args) != 1 {
+		return errors.E("expected one argument (ISO8601 date or duration)")
+	}
+	if d, err := time.ParseDuration(args[0]); err == nil {
+		if d <= 0 {
+			return errors.E("duration must be positive")
+		}
+		c.olderThan = time.Now().Add(-d)
+		return nil
+	}
+	var err error
+	c.olderThan, err = parseDate(args[0])
+	if err != nil {
+		return errors.E("invalid cutoff. Expected ISO8601 date or duration")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *pruneAuditEventsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	response, err := client.PruneAuditEvents(&apiparams.PruneAuditEventsRequest{
+		OlderThan: c.olderThan,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	return c.out.Write(ctxt, response)
+}
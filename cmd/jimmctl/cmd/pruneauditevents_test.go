@@ -0,0 +1,54 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+)
+
+type pruneAuditEventsSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&pruneAuditEventsSuite{})
+
+func (s *pruneAuditEventsSuite) TestPruneAuditEvents(c *gc.C) {
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Millisecond)
+	for _, t := range []time.Time{now.AddDate(0, 0, -10), now.AddDate(0, 0, -5), now.Add(-time.Hour)} {
+		err := s.JIMM.Database.AddAuditLogEntry(ctx, &dbmodel.AuditLogEntry{
+			Time:        t,
+			IdentityTag: names.NewUserTag("alice@canonical.com").String(),
+		})
+		c.Assert(err, gc.IsNil)
+	}
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	cmdCtx, err := cmdtesting.RunCommand(c, cmd.NewPruneAuditEventsCommandForTesting(s.ClientStore(), bClient), "24h")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdCtx.Stdout.(*bytes.Buffer).String(), gc.Equals, "deleted-count: 2\n")
+}
+
+func (s *pruneAuditEventsSuite) TestPruneAuditEventsUnauthorized(c *gc.C) {
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewPruneAuditEventsCommandForTesting(s.ClientStore(), bClient), "2021-01-01")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *pruneAuditEventsSuite) TestPruneAuditEventsInvalidCutoff(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewPruneAuditEventsCommandForTesting(s.ClientStore(), bClient), "13/01/2021")
+	c.Assert(err, gc.ErrorMatches, `invalid cutoff. Expected ISO8601 date or duration`)
+}
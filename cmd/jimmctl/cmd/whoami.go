@@ -0,0 +1,111 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	"github.com/juju/juju/api/controller/controller"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+var whoamiCommandDoc = `
+	whoami command displays the user authenticated with JIMM, along
+	with the user's access level on the JIMM controller and whether
+	the user is a JIMM administrator.
+
+	Example:
+		jimmctl whoami
+		jimmctl whoami --format json
+`
+
+// NewWhoamiCommand returns a command to display the authenticated
+// user's access to JIMM.
+func NewWhoamiCommand() cmd.Command {
+	cmd := &whoamiCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// whoamiResult holds the output of the whoami command.
+type whoamiResult struct {
+	User             string `yaml:"user" json:"user"`
+	ControllerAccess string `yaml:"controller-access" json:"controller-access"`
+	JIMMAdmin        bool   `yaml:"jimm-admin" json:"jimm-admin"`
+}
+
+// whoamiCommand displays the authenticated user's access to JIMM.
+type whoamiCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+func (c *whoamiCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "whoami",
+		Purpose: "Displays the authenticated user's access to JIMM",
+		Doc:     whoamiCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *whoamiCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *whoamiCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *whoamiCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	userTag, ok := apiCaller.AuthTag().(names.UserTag)
+	if !ok {
+		return errors.E("not logged in as a user")
+	}
+
+	access, err := controller.NewClient(apiCaller).GetControllerAccess(userTag.Id())
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, whoamiResult{
+		User:             userTag.Id(),
+		ControllerAccess: string(access),
+		JIMMAdmin:        access == permission.SuperuserAccess,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
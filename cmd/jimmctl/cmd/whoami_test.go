@@ -0,0 +1,43 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+)
+
+type whoamiSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&whoamiSuite{})
+
+func (s *whoamiSuite) TestWhoamiSuperuser(c *gc.C) {
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewWhoamiCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, `user: alice@canonical.com
+controller-access: superuser
+jimm-admin: true
+`)
+}
+
+func (s *whoamiSuite) TestWhoami(c *gc.C) {
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	context, err := cmdtesting.RunCommand(c, cmd.NewWhoamiCommandForTesting(s.ClientStore(), bClient), "--format", "json")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, `{"user":"bob@canonical.com","controller-access":"login","jimm-admin":false}
+`)
+}
+
+func (s *whoamiSuite) TestWhoamiUnknownArguments(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewWhoamiCommandForTesting(s.ClientStore(), bClient), "extra")
+	c.Assert(err, gc.ErrorMatches, `unknown arguments`)
+}
@@ -21,6 +21,7 @@ func NewSuperCommand() *jujucmd.SuperCommand {
 		Doc:  jimmctlDoc,
 	})
 	jimmcmd.Register(cmd.NewAddControllerCommand())
+	jimmcmd.Register(cmd.NewAddControllersCommand())
 	jimmcmd.Register(cmd.NewControllerInfoCommand())
 	jimmcmd.Register(cmd.NewGrantAuditLogAccessCommand())
 	jimmcmd.Register(cmd.NewImportCloudCredentialsCommand())
@@ -37,7 +38,12 @@ func NewSuperCommand() *jujucmd.SuperCommand {
 	jimmcmd.Register(cmd.NewAuthCommand())
 	jimmcmd.Register(cmd.NewCrossModelQueryCommand())
 	jimmcmd.Register(cmd.NewPurgeLogsCommand())
+	jimmcmd.Register(cmd.NewPruneAuditEventsCommand())
 	jimmcmd.Register(cmd.NewMigrateModelCommand())
+	jimmcmd.Register(cmd.NewExportModelCommand())
+	jimmcmd.Register(cmd.NewExportInventoryCommand())
+	jimmcmd.Register(cmd.NewCheckUserRelationCommand())
+	jimmcmd.Register(cmd.NewWhoamiCommand())
 	return jimmcmd
 }
 
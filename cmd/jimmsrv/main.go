@@ -17,6 +17,7 @@ import (
 	"go.uber.org/zap"
 
 	jimmsvc "github.com/canonical/jimm/v3/cmd/jimmsrv/service"
+	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/version"
 )
@@ -72,6 +73,108 @@ func start(ctx context.Context, s *service.Service) error {
 		}
 	}
 
+	modelReconcileInterval := time.Duration(0)
+	durationString = os.Getenv("JIMM_MODEL_RECONCILE_INTERVAL")
+	if durationString != "" {
+		interval, err := time.ParseDuration(durationString)
+		if err != nil {
+			return errors.E("unable to parse model reconcile interval")
+		}
+		modelReconcileInterval = interval
+	}
+
+	modelExpiryInterval := time.Duration(0)
+	durationString = os.Getenv("JIMM_MODEL_EXPIRY_INTERVAL")
+	if durationString != "" {
+		interval, err := time.ParseDuration(durationString)
+		if err != nil {
+			return errors.E("unable to parse model expiry interval")
+		}
+		modelExpiryInterval = interval
+	}
+
+	modelCreationCleanupInterval := time.Duration(0)
+	durationString = os.Getenv("JIMM_MODEL_CREATION_CLEANUP_INTERVAL")
+	if durationString != "" {
+		interval, err := time.ParseDuration(durationString)
+		if err != nil {
+			return errors.E("unable to parse model creation cleanup interval")
+		}
+		modelCreationCleanupInterval = interval
+	}
+
+	var dbPool db.PoolConfig
+	if v := os.Getenv("JIMM_DB_MAX_OPEN_CONNS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.E("unable to parse database max open connections")
+		}
+		dbPool.MaxOpenConns = n
+	}
+	if v := os.Getenv("JIMM_DB_MAX_IDLE_CONNS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.E("unable to parse database max idle connections")
+		}
+		dbPool.MaxIdleConns = n
+	}
+	durationString = os.Getenv("JIMM_DB_CONN_MAX_LIFETIME")
+	if durationString != "" {
+		lifetime, err := time.ParseDuration(durationString)
+		if err != nil {
+			return errors.E("unable to parse database connection max lifetime")
+		}
+		dbPool.ConnMaxLifetime = lifetime
+	}
+
+	modelCacheTTL := time.Duration(0)
+	durationString = os.Getenv("JIMM_MODEL_CACHE_TTL")
+	if durationString != "" {
+		ttl, err := time.ParseDuration(durationString)
+		if err != nil {
+			return errors.E("unable to parse model cache TTL")
+		}
+		modelCacheTTL = ttl
+	}
+
+	loginTimeout := time.Minute
+	durationString = os.Getenv("JIMM_LOGIN_TIMEOUT")
+	if durationString != "" {
+		timeout, err := time.ParseDuration(durationString)
+		if err != nil {
+			return errors.E("unable to parse login timeout")
+		}
+		loginTimeout = timeout
+	}
+
+	var loginFailureLimit int
+	if v := os.Getenv("JIMM_LOGIN_FAILURE_LIMIT"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.E("unable to parse login failure limit")
+		}
+		loginFailureLimit = n
+	}
+	loginLockoutWindow := 15 * time.Minute
+	durationString = os.Getenv("JIMM_LOGIN_LOCKOUT_WINDOW")
+	if durationString != "" {
+		window, err := time.ParseDuration(durationString)
+		if err != nil {
+			return errors.E("unable to parse login lockout window")
+		}
+		loginLockoutWindow = window
+	}
+
+	drainTimeout := 30 * time.Second
+	durationString = os.Getenv("JIMM_SHUTDOWN_DRAIN_TIMEOUT")
+	if durationString != "" {
+		timeout, err := time.ParseDuration(durationString)
+		if err != nil {
+			return errors.E("unable to parse shutdown drain timeout")
+		}
+		drainTimeout = timeout
+	}
+
 	sessionTokenExpiryDuration := time.Duration(0)
 	durationString = os.Getenv("JIMM_ACCESS_TOKEN_EXPIRY_DURATION")
 	if durationString != "" {
@@ -128,6 +231,11 @@ func start(ctx context.Context, s *service.Service) error {
 		secureSessionCookies = true
 	}
 
+	enableIDTokenLogin := false
+	if _, ok := os.LookupEnv("JIMM_OAUTH_ID_TOKEN_LOGIN"); ok {
+		enableIDTokenLogin = true
+	}
+
 	sessionCookieMaxAge := os.Getenv("JIMM_SESSION_COOKIE_MAX_AGE")
 	sessionCookieMaxAgeInt, err := strconv.Atoi(sessionCookieMaxAge)
 	if err != nil {
@@ -145,16 +253,18 @@ func start(ctx context.Context, s *service.Service) error {
 	corsAllowedOrigins := strings.Split(os.Getenv("CORS_ALLOWED_ORIGINS"), " ")
 
 	logSQL, _ := strconv.ParseBool(os.Getenv("JIMM_LOG_SQL"))
+	warmControllerConnections, _ := strconv.ParseBool(os.Getenv("JIMM_WARM_CONTROLLER_CONNECTIONS"))
 
 	jimmsvc, err := jimmsvc.NewService(ctx, jimmsvc.Params{
-		ControllerUUID:    os.Getenv("JIMM_UUID"),
-		DSN:               os.Getenv("JIMM_DSN"),
-		ControllerAdmins:  strings.Fields(os.Getenv("JIMM_ADMINS")),
-		VaultRoleID:       os.Getenv("VAULT_ROLE_ID"),
-		VaultRoleSecretID: os.Getenv("VAULT_ROLE_SECRET_ID"),
-		VaultAddress:      os.Getenv("VAULT_ADDR"),
-		VaultPath:         os.Getenv("VAULT_PATH"),
-		PublicDNSName:     os.Getenv("JIMM_DNS_NAME"),
+		ControllerUUID:              os.Getenv("JIMM_UUID"),
+		DSN:                         os.Getenv("JIMM_DSN"),
+		ControllerAdmins:            strings.Fields(os.Getenv("JIMM_ADMINS")),
+		VaultRoleID:                 os.Getenv("VAULT_ROLE_ID"),
+		VaultRoleSecretID:           os.Getenv("VAULT_ROLE_SECRET_ID"),
+		VaultAddress:                os.Getenv("VAULT_ADDR"),
+		VaultPath:                   os.Getenv("VAULT_PATH"),
+		VaultCredentialPathTemplate: os.Getenv("VAULT_CREDENTIAL_PATH_TEMPLATE"),
+		PublicDNSName:               os.Getenv("JIMM_DNS_NAME"),
 		OpenFGAParams: jimmsvc.OpenFGAParams{
 			Scheme:    os.Getenv("OPENFGA_SCHEME"),
 			Host:      os.Getenv("OPENFGA_HOST"),
@@ -178,11 +288,23 @@ func start(ctx context.Context, s *service.Service) error {
 			SessionCookieMaxAge:  sessionCookieMaxAgeInt,
 			JWTSessionKey:        sessionSecretKey,
 			SecureSessionCookies: secureSessionCookies,
+			EnableIDTokenLogin:   enableIDTokenLogin,
+			IDTokenUsernameClaim: os.Getenv("JIMM_OAUTH_ID_TOKEN_USERNAME_CLAIM"),
 		},
-		DashboardFinalRedirectURL: os.Getenv("JIMM_DASHBOARD_FINAL_REDIRECT_URL"),
-		CookieSessionKey:          []byte(sessionSecretKey),
-		CorsAllowedOrigins:        corsAllowedOrigins,
-		LogSQL:                    logSQL,
+		DashboardFinalRedirectURL:    os.Getenv("JIMM_DASHBOARD_FINAL_REDIRECT_URL"),
+		CookieSessionKey:             []byte(sessionSecretKey),
+		CorsAllowedOrigins:           corsAllowedOrigins,
+		LogSQL:                       logSQL,
+		ModelReconcileInterval:       modelReconcileInterval,
+		ModelExpiryInterval:          modelExpiryInterval,
+		ModelCreationCleanupInterval: modelCreationCleanupInterval,
+		DBPool:                       dbPool,
+		ModelCacheTTL:                modelCacheTTL,
+		LoginTimeout:                 loginTimeout,
+		LoginFailureLimit:            loginFailureLimit,
+		LoginLockoutWindow:           loginLockoutWindow,
+		WarmControllerConnections:    warmControllerConnections,
+		AuditSinkURL:                 os.Getenv("JIMM_AUDIT_SINK_URL"),
 	})
 	if err != nil {
 		return err
@@ -191,8 +313,16 @@ func start(ctx context.Context, s *service.Service) error {
 	isLeader := os.Getenv("JIMM_IS_LEADER") != ""
 	if isLeader {
 		s.Go(func() error { return jimmsvc.WatchControllers(ctx) }) // Deletes dead/dying models, updates model config.
+		s.Go(func() error { return jimmsvc.ReconcileModels(ctx) })
+		s.Go(func() error { return jimmsvc.ExpireModels(ctx) })
+		s.Go(func() error { return jimmsvc.CleanupModelCreations(ctx) })
+		s.Go(func() error { return jimmsvc.ExportAuditLog(ctx) })
 	}
 	s.Go(func() error { return jimmsvc.WatchModelSummaries(ctx) })
+	s.Go(func() error {
+		jimmsvc.WarmControllerConnections(ctx)
+		return nil
+	})
 
 	if isLeader {
 		zapctx.Info(ctx, "attempting to start JWKS rotator and generate OAuth secret key")
@@ -224,6 +354,13 @@ func start(ctx context.Context, s *service.Service) error {
 		if err != nil {
 			zapctx.Error(ctx, "failed to shutdown server gracefully", zap.Error(err))
 		}
+
+		// The server no longer tracks the hijacked websocket
+		// connections, give the proxied model sessions a chance to
+		// finish before they are closed.
+		drainCtx, drainCancel := context.WithTimeout(context.Background(), drainTimeout)
+		defer drainCancel()
+		jimmsvc.JIMM().DrainModelSessions(drainCtx)
 		jimmsvc.Cleanup()
 	})
 	s.Go(httpsrv.ListenAndServe)
@@ -26,6 +26,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/discharger"
 	"github.com/canonical/jimm/v3/internal/errors"
@@ -42,6 +43,7 @@ import (
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 	"github.com/canonical/jimm/v3/internal/pubsub"
 	"github.com/canonical/jimm/v3/internal/vault"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
 
 const (
@@ -89,6 +91,14 @@ type OAuthAuthenticatorParams struct {
 	// JWTSessionKey holds the secret key used for signing/verifying JWT tokens.
 	// See internal/auth/oauth2.go AuthenticationService.SessionSecretkey for more details.
 	JWTSessionKey string
+
+	// EnableIDTokenLogin allows users to log in by presenting an ID token
+	// issued by the OAuth2.0 server to the client.
+	EnableIDTokenLogin bool
+
+	// IDTokenUsernameClaim holds the ID token claim used to identify the
+	// user when logging in with an ID token, defaults to email.
+	IDTokenUsernameClaim string
 }
 
 // A Params structure contains the parameters required to initialise a new
@@ -136,6 +146,12 @@ type Params struct {
 	// secrets engine JIMM will use to store secrets.
 	VaultPath string
 
+	// VaultCredentialPathTemplate is the template for the path, within
+	// VaultPath, at which cloud credential attributes are stored. See
+	// vault.VaultStore.CredentialPathTemplate for details. If this is
+	// empty vault.DefaultCredentialPathTemplate is used.
+	VaultCredentialPathTemplate string
+
 	// PublicDNSName is the name to advertise as the public address of
 	// the juju controller.
 	PublicDNSName string
@@ -184,6 +200,60 @@ type Params struct {
 	// LogSQL determines whether ORM queries are printed when debug logs are enabled.
 	// This may leak secrets in logs when sensitive values are stored in the DB like OAuth tokens.
 	LogSQL bool
+
+	// ModelReconcileInterval holds the interval at which dying and dead
+	// models are checked with their controller and removed once they
+	// have been destroyed. If this is zero a default of 10 minutes is used.
+	ModelReconcileInterval time.Duration
+
+	// ModelExpiryInterval holds the interval at which models that have
+	// passed their expiry time, or been idle for longer than their idle
+	// timeout, are destroyed. If this is zero a default of 10 minutes is
+	// used.
+	ModelExpiryInterval time.Duration
+
+	// ModelCreationCleanupInterval holds the interval at which
+	// asynchronous model creations that did not complete are removed,
+	// along with any model they left on the controller. If this is zero a
+	// default of 10 minutes is used.
+	ModelCreationCleanupInterval time.Duration
+
+	// DBPool holds the configuration of the database connection pool.
+	DBPool db.PoolConfig
+
+	// ModelCacheTTL holds the length of time models retrieved from the
+	// database by UUID are cached for. If this is zero models are not
+	// cached. Each JIMM process has its own cache, so changes made by
+	// other processes may not be seen for up to this long.
+	ModelCacheTTL time.Duration
+
+	// LoginTimeout holds the maximum time allowed for a client connecting
+	// to a model to log in. If this is zero there is no limit.
+	LoginTimeout time.Duration
+
+	// LoginFailureLimit holds the number of consecutive failed logins,
+	// either with invalid client credentials or to a model or controller
+	// the user may not access, after which further logins by the user
+	// are rejected for the LoginLockoutWindow. Failures are counted per
+	// user, so anyone that knows a service account's client ID can lock
+	// it out for the LoginLockoutWindow. If this is zero logins are
+	// never rejected.
+	LoginFailureLimit int
+
+	// LoginLockoutWindow holds the length of time, since its last
+	// failed login, for which a user that has reached the
+	// LoginFailureLimit is prevented from logging in.
+	LoginLockoutWindow time.Duration
+
+	// WarmControllerConnections enables connecting to all available
+	// controllers when the service starts, so that the connections are
+	// cached before they are needed. This has no effect if the
+	// connection cache is disabled.
+	WarmControllerConnections bool
+
+	// AuditSinkURL, if set, is the URL of an HTTP endpoint that every
+	// audit log entry is posted to.
+	AuditSinkURL string
 }
 
 // A Service is the implementation of a JIMM server.
@@ -192,6 +262,11 @@ type Service struct {
 
 	mux      *chi.Mux
 	cleanups []func() error
+
+	modelReconcileInterval       time.Duration
+	modelExpiryInterval          time.Duration
+	modelCreationCleanupInterval time.Duration
+	warmControllerConnections    bool
 }
 
 func (s *Service) JIMM() *jimm.JIMM {
@@ -227,6 +302,54 @@ func (s *Service) WatchModelSummaries(ctx context.Context) error {
 	return w.WatchAllModelSummaries(ctx, 10*time.Minute)
 }
 
+// ReconcileModels periodically removes models that have been destroyed
+// on their controller. ReconcileModels finishes when the given context is
+// canceled.
+func (s *Service) ReconcileModels(ctx context.Context) error {
+	r := jimm.ModelReconciler{
+		Database:      s.jimm.Database,
+		Dialer:        s.jimm.Dialer,
+		OpenFGAClient: s.jimm.OpenFGAClient,
+	}
+	return r.Run(ctx, s.modelReconcileInterval)
+}
+
+// ExpireModels periodically destroys models that have passed their expiry
+// time, or been idle for longer than their idle timeout. ExpireModels
+// finishes when the given context is canceled.
+func (s *Service) ExpireModels(ctx context.Context) error {
+	return s.jimm.RunModelExpiry(ctx, s.modelExpiryInterval)
+}
+
+// CleanupModelCreations periodically removes asynchronous model
+// creations that did not complete and the failures of old model
+// creations. CleanupModelCreations finishes when the given context is
+// canceled.
+func (s *Service) CleanupModelCreations(ctx context.Context) error {
+	return s.jimm.RunModelCreationCleanup(ctx, s.modelCreationCleanupInterval)
+}
+
+// ExportAuditLog periodically retries sending audit log entries that
+// could not be delivered to the audit sink when they were written, by any
+// JIMM process. ExportAuditLog finishes when the given context is
+// canceled.
+func (s *Service) ExportAuditLog(ctx context.Context) error {
+	if s.jimm.AuditSink == nil {
+		return nil
+	}
+	return s.jimm.RunAuditExport(ctx, time.Minute)
+}
+
+// WarmControllerConnections connects to all available controllers so
+// that the connections are cached before the first requests need them,
+// if enabled.
+func (s *Service) WarmControllerConnections(ctx context.Context) {
+	if !s.warmControllerConnections {
+		return
+	}
+	s.jimm.WarmControllerConnections(ctx, 10, 30*time.Second)
+}
+
 // StartJWKSRotator see internal/jimmjwx/jwks.go for details.
 func (s *Service) StartJWKSRotator(ctx context.Context, checkRotateRequired <-chan time.Time, initialRotateRequiredTime time.Time) error {
 	if s.jimm.JWKService == nil {
@@ -272,6 +395,19 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 
 	s := new(Service)
 	s.mux = chi.NewRouter()
+	s.modelReconcileInterval = p.ModelReconcileInterval
+	s.warmControllerConnections = p.WarmControllerConnections && !p.DisableConnectionCache
+	if s.modelReconcileInterval == 0 {
+		s.modelReconcileInterval = 10 * time.Minute
+	}
+	s.modelExpiryInterval = p.ModelExpiryInterval
+	if s.modelExpiryInterval == 0 {
+		s.modelExpiryInterval = 10 * time.Minute
+	}
+	s.modelCreationCleanupInterval = p.ModelCreationCleanupInterval
+	if s.modelCreationCleanupInterval == 0 {
+		s.modelCreationCleanupInterval = 10 * time.Minute
+	}
 
 	// Setup all dependency services
 
@@ -294,10 +430,23 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
+	if err := s.jimm.Database.ConfigurePool(p.DBPool); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if p.ModelCacheTTL > 0 {
+		s.jimm.Database.ModelCache = db.NewModelCache(p.ModelCacheTTL)
+	}
+	if p.LoginFailureLimit > 0 {
+		s.jimm.LoginAttempts = jimm.NewLoginAttemptTracker(p.LoginFailureLimit, p.LoginLockoutWindow)
+	}
 	if err := s.jimm.Database.Migrate(ctx, false); err != nil {
 		return nil, errors.E(op, err)
 	}
 
+	if p.AuditSinkURL != "" {
+		s.jimm.AuditSink = &jimm.HTTPAuditSink{URL: p.AuditSinkURL}
+	}
+
 	if p.AuditLogRetentionPeriodInDays != "" {
 		period, err := strconv.Atoi(p.AuditLogRetentionPeriodInDays)
 		if err != nil {
@@ -360,6 +509,19 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 		return nil, errors.E(op, err, "failed to setup authentication service")
 	}
 
+	if p.OAuthAuthenticatorParams.EnableIDTokenLogin {
+		idTokenAuthenticator, err := auth.NewIDTokenAuthenticator(ctx, auth.IDTokenAuthenticatorParams{
+			IssuerURL:     p.OAuthAuthenticatorParams.IssuerURL,
+			ClientID:      p.OAuthAuthenticatorParams.ClientID,
+			UsernameClaim: p.OAuthAuthenticatorParams.IDTokenUsernameClaim,
+		})
+		if err != nil {
+			zapctx.Error(ctx, "failed to setup id token authenticator", zap.Error(err))
+			return nil, errors.E(op, err, "failed to setup id token authenticator")
+		}
+		s.jimm.IDTokenAuthenticator = idTokenAuthenticator
+	}
+
 	if p.JWTExpiryDuration == 0 {
 		p.JWTExpiryDuration = 24 * time.Hour
 	}
@@ -411,12 +573,20 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 			map[string]jimmhttp.StatusCheck{
 				"start_time": jimmhttp.ServerStartTime,
 			},
+			map[string]jimmhttp.StatusCheck{
+				"database": jimmhttp.MakePingCheck("database", &s.jimm.Database),
+				"openfga":  jimmhttp.MakePingCheck("openfga", s.jimm.OpenFGAClient),
+			},
 		),
 	)
 	mountHandler(
 		"/.well-known",
 		jimmhttp.NewWellKnownHandler(s.jimm.CredentialStore),
 	)
+	mountHandler(
+		apiparams.InventoryPath,
+		jimmhttp.NewInventoryHandler(&s.jimm),
+	)
 
 	if p.DashboardFinalRedirectURL == "" {
 		zapctx.Warn(ctx, "OAuth handler not enabled, due to unset dashboard redirect URL")
@@ -444,6 +614,7 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 	params := jujuapi.Params{
 		ControllerUUID: p.ControllerUUID,
 		PublicDNSName:  p.PublicDNSName,
+		LoginTimeout:   p.LoginTimeout,
 	}
 
 	// Websockets require extra care when cookies are used for authentication
@@ -451,6 +622,7 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 	websocketCors := middleware.NewWebsocketCors(p.CorsAllowedOrigins)
 	s.mux.Handle("/api", websocketCors.Handler(jujuapi.APIHandler(ctx, &s.jimm, params)))
 	s.mux.Handle("/model/*", websocketCors.Handler(http.StripPrefix("/model", jujuapi.ModelHandler(ctx, &s.jimm, params))))
+	s.mux.Handle("/audit-events", websocketCors.Handler(jujuapi.AuditEventsHandler(ctx, &s.jimm, params)))
 	mountHandler(
 		"/model/{uuid}/{type:charms|applications}",
 		jimmhttp.NewHTTPProxyHandler(&s.jimm),
@@ -557,6 +729,12 @@ func newVaultStore(ctx context.Context, p Params) (jimmcreds.CredentialStore, er
 		zap.String("VaultRoleID", p.VaultRoleID),
 	)
 
+	if p.VaultCredentialPathTemplate != "" {
+		if err := vault.ValidateCredentialPathTemplate(p.VaultCredentialPathTemplate); err != nil {
+			return nil, err
+		}
+	}
+
 	cfg := vaultapi.DefaultConfig()
 	if p.VaultAddress != "" {
 		cfg.Address = p.VaultAddress
@@ -568,10 +746,11 @@ func newVaultStore(ctx context.Context, p Params) (jimmcreds.CredentialStore, er
 	}
 
 	return &vault.VaultStore{
-		Client:       client,
-		RoleID:       p.VaultRoleID,
-		RoleSecretID: p.VaultRoleSecretID,
-		KVPath:       strings.ReplaceAll(p.VaultPath, "/", ""),
+		Client:                 client,
+		RoleID:                 p.VaultRoleID,
+		RoleSecretID:           p.VaultRoleSecretID,
+		KVPath:                 strings.ReplaceAll(p.VaultPath, "/", ""),
+		CredentialPathTemplate: p.VaultCredentialPathTemplate,
 	}, nil
 }
 
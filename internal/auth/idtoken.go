@@ -0,0 +1,146 @@
+// Copyright 2024 Canonical.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+const (
+	// emailClaim is the standard ID token claim holding the user's
+	// email address. It is only trusted when the email_verified claim is
+	// true.
+	emailClaim         = "email"
+	emailVerifiedClaim = "email_verified"
+
+	// defaultUsernameClaim is the ID token claim used to identify the
+	// user when no other claim has been configured.
+	defaultUsernameClaim = emailClaim
+)
+
+// IDTokenAuthenticatorParams holds the parameters used to initialise an
+// IDTokenAuthenticator.
+type IDTokenAuthenticatorParams struct {
+	// IssuerURL is the URL of the OIDC provider that issues the ID
+	// tokens. The iss claim of presented tokens must match this value.
+	IssuerURL string
+
+	// ClientID is the client ID that presented tokens must have been
+	// issued to, i.e. the expected aud claim.
+	ClientID string
+
+	// JWKSURL holds the URL of the JWKS used to verify the signature of
+	// presented tokens. If this is empty the JWKS advertised by the
+	// provider's discovery document is used.
+	JWKSURL string
+
+	// UsernameClaim holds the name of the claim identifying the user,
+	// if this is empty the email claim is used.
+	UsernameClaim string
+}
+
+// IDTokenAuthenticator authenticates users with an ID token issued by an
+// external OIDC provider, presented as a bearer token.
+type IDTokenAuthenticator struct {
+	verifier      *oidc.IDTokenVerifier
+	usernameClaim string
+}
+
+// NewIDTokenAuthenticator returns a new IDTokenAuthenticator that
+// validates ID tokens against the configured issuer and JWKS.
+func NewIDTokenAuthenticator(ctx context.Context, params IDTokenAuthenticatorParams) (*IDTokenAuthenticator, error) {
+	const op = errors.Op("auth.NewIDTokenAuthenticator")
+
+	if params.IssuerURL == "" {
+		return nil, errors.E(op, errors.CodeServerConfiguration, "missing id token issuer url")
+	}
+	if params.ClientID == "" {
+		return nil, errors.E(op, errors.CodeServerConfiguration, "missing id token client id")
+	}
+
+	config := &oidc.Config{
+		ClientID: params.ClientID,
+	}
+	var verifier *oidc.IDTokenVerifier
+	if params.JWKSURL != "" {
+		verifier = oidc.NewVerifier(params.IssuerURL, oidc.NewRemoteKeySet(ctx, params.JWKSURL), config)
+	} else {
+		provider, err := oidc.NewProvider(ctx, params.IssuerURL)
+		if err != nil {
+			zapctx.Error(ctx, "failed to create oidc provider", zap.Error(err))
+			return nil, errors.E(op, errors.CodeServerConfiguration, err, "failed to create oidc provider")
+		}
+		verifier = provider.Verifier(config)
+	}
+
+	usernameClaim := params.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = defaultUsernameClaim
+	}
+	return &IDTokenAuthenticator{
+		verifier:      verifier,
+		usernameClaim: usernameClaim,
+	}, nil
+}
+
+// Authenticate verifies the signature, issuer, audience and expiry of the
+// given raw ID token and returns the value of the configured username
+// claim. When the username is taken from the email claim the token must
+// also have an email_verified claim that is true. The username must be a
+// valid user name with a domain, and must not be in the service account
+// domain. If the token is not valid, or does not identify an acceptable
+// user, an error with a code of CodeUnauthorized is returned.
+func (a *IDTokenAuthenticator) Authenticate(ctx context.Context, rawIDToken string) (_ string, err error) {
+	const op = errors.Op("auth.IDTokenAuthenticator.Authenticate")
+	defer func() {
+		if err != nil {
+			servermon.AuthenticationFailCount.WithLabelValues("AuthenticateIDToken").Inc()
+		} else {
+			servermon.AuthenticationSuccessCount.WithLabelValues("AuthenticateIDToken").Inc()
+		}
+	}()
+
+	if rawIDToken == "" {
+		return "", errors.E(op, errors.CodeUnauthorized, "no id token presented")
+	}
+
+	token, err := a.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		zapctx.Debug(ctx, "failed to verify id token", zap.Error(err))
+		return "", errors.E(op, errors.CodeUnauthorized, fmt.Sprintf("invalid id token: %s", err))
+	}
+
+	var claims map[string]interface{}
+	if err := token.Claims(&claims); err != nil {
+		return "", errors.E(op, errors.CodeUnauthorized, err, "failed to extract claims")
+	}
+	username, _ := claims[a.usernameClaim].(string)
+	if username == "" {
+		return "", errors.E(op, errors.CodeUnauthorized, fmt.Sprintf("id token has no %s claim", a.usernameClaim))
+	}
+	if a.usernameClaim == emailClaim {
+		if verified, _ := claims[emailVerifiedClaim].(bool); !verified {
+			return "", errors.E(op, errors.CodeUnauthorized, "id token email address is not verified")
+		}
+	}
+	if !names.IsValidUser(username) {
+		return "", errors.E(op, errors.CodeUnauthorized, fmt.Sprintf("invalid username %q", username))
+	}
+	switch names.NewUserTag(username).Domain() {
+	case "":
+		return "", errors.E(op, errors.CodeUnauthorized, fmt.Sprintf("username %q has no domain", username))
+	case jimmnames.ServiceAccountDomain:
+		return "", errors.E(op, errors.CodeUnauthorized, fmt.Sprintf("username %q is in the service account domain", username))
+	}
+	return username, nil
+}
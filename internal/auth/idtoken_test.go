@@ -0,0 +1,175 @@
+// Copyright 2024 Canonical.
+
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+
+	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+const (
+	testIDTokenIssuer   = "https://idp.example.com"
+	testIDTokenClientID = "jimm"
+)
+
+// setupIDTokenAuthenticator starts a JWKS server for a newly generated
+// RSA key and returns an authenticator trusting that key, along with the
+// key to sign tokens with.
+func setupIDTokenAuthenticator(c *qt.C, usernameClaim string) (*auth.IDTokenAuthenticator, jwk.Key) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	c.Assert(err, qt.IsNil)
+	key, err := jwk.FromRaw(rsaKey)
+	c.Assert(err, qt.IsNil)
+	c.Assert(key.Set(jwk.KeyIDKey, "test-key"), qt.IsNil)
+	c.Assert(key.Set(jwk.AlgorithmKey, jwa.RS256), qt.IsNil)
+
+	publicKey, err := key.PublicKey()
+	c.Assert(err, qt.IsNil)
+	set := jwk.NewSet()
+	c.Assert(set.AddKey(publicKey), qt.IsNil)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(set)
+	}))
+	c.Cleanup(srv.Close)
+
+	a, err := auth.NewIDTokenAuthenticator(context.Background(), auth.IDTokenAuthenticatorParams{
+		IssuerURL:     testIDTokenIssuer,
+		ClientID:      testIDTokenClientID,
+		JWKSURL:       srv.URL,
+		UsernameClaim: usernameClaim,
+	})
+	c.Assert(err, qt.IsNil)
+	return a, key
+}
+
+func signIDToken(c *qt.C, key jwk.Key, issuer string, expiry time.Time, claims map[string]interface{}) string {
+	b := jwt.NewBuilder().
+		Issuer(issuer).
+		Audience([]string{testIDTokenClientID}).
+		Subject("user-1").
+		IssuedAt(time.Now()).
+		Expiration(expiry)
+	for k, v := range claims {
+		b = b.Claim(k, v)
+	}
+	token, err := b.Build()
+	c.Assert(err, qt.IsNil)
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.RS256, key))
+	c.Assert(err, qt.IsNil)
+	return string(signed)
+}
+
+func TestIDTokenAuthenticator(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	a, key := setupIDTokenAuthenticator(c, "")
+
+	tests := []struct {
+		name          string
+		token         string
+		expectUser    string
+		expectError   string
+		expectErrCode errors.Code
+	}{{
+		name:       "valid token",
+		token:      signIDToken(c, key, testIDTokenIssuer, time.Now().Add(time.Hour), map[string]interface{}{"email": "alice@canonical.com", "email_verified": true}),
+		expectUser: "alice@canonical.com",
+	}, {
+		name:          "expired token",
+		token:         signIDToken(c, key, testIDTokenIssuer, time.Now().Add(-time.Hour), map[string]interface{}{"email": "alice@canonical.com", "email_verified": true}),
+		expectError:   `invalid id token: .*token is expired.*`,
+		expectErrCode: errors.CodeUnauthorized,
+	}, {
+		name:          "wrong issuer",
+		token:         signIDToken(c, key, "https://evil.example.com", time.Now().Add(time.Hour), map[string]interface{}{"email": "alice@canonical.com", "email_verified": true}),
+		expectError:   `invalid id token: .*issued by a different provider.*`,
+		expectErrCode: errors.CodeUnauthorized,
+	}, {
+		name:          "missing username claim",
+		token:         signIDToken(c, key, testIDTokenIssuer, time.Now().Add(time.Hour), nil),
+		expectError:   `id token has no email claim`,
+		expectErrCode: errors.CodeUnauthorized,
+	}, {
+		name:          "unverified email",
+		token:         signIDToken(c, key, testIDTokenIssuer, time.Now().Add(time.Hour), map[string]interface{}{"email": "alice@canonical.com", "email_verified": false}),
+		expectError:   `id token email address is not verified`,
+		expectErrCode: errors.CodeUnauthorized,
+	}, {
+		name:          "missing email verification",
+		token:         signIDToken(c, key, testIDTokenIssuer, time.Now().Add(time.Hour), map[string]interface{}{"email": "alice@canonical.com"}),
+		expectError:   `id token email address is not verified`,
+		expectErrCode: errors.CodeUnauthorized,
+	}, {
+		name:          "service account domain",
+		token:         signIDToken(c, key, testIDTokenIssuer, time.Now().Add(time.Hour), map[string]interface{}{"email": "x@serviceaccount", "email_verified": true}),
+		expectError:   `username "x@serviceaccount" is in the service account domain`,
+		expectErrCode: errors.CodeUnauthorized,
+	}, {
+		name:          "no token",
+		expectError:   `no id token presented`,
+		expectErrCode: errors.CodeUnauthorized,
+	}}
+
+	for _, test := range tests {
+		c.Run(test.name, func(c *qt.C) {
+			user, err := a.Authenticate(ctx, test.token)
+			if test.expectError != "" {
+				c.Check(err, qt.ErrorMatches, test.expectError)
+				c.Check(errors.ErrorCode(err), qt.Equals, test.expectErrCode)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Check(user, qt.Equals, test.expectUser)
+		})
+	}
+}
+
+func TestIDTokenAuthenticatorRejectsUntrustedKey(t *testing.T) {
+	c := qt.New(t)
+
+	a, _ := setupIDTokenAuthenticator(c, "")
+	_, otherKey := setupIDTokenAuthenticator(c, "")
+
+	token := signIDToken(c, otherKey, testIDTokenIssuer, time.Now().Add(time.Hour), map[string]interface{}{"email": "alice@canonical.com", "email_verified": true})
+	_, err := a.Authenticate(context.Background(), token)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+}
+
+func TestIDTokenAuthenticatorUsernameClaim(t *testing.T) {
+	c := qt.New(t)
+
+	a, key := setupIDTokenAuthenticator(c, "preferred_username")
+
+	token := signIDToken(c, key, testIDTokenIssuer, time.Now().Add(time.Hour), map[string]interface{}{
+		"email":              "alice@canonical.com",
+		"preferred_username": "alice@example.com",
+	})
+	user, err := a.Authenticate(context.Background(), token)
+	c.Assert(err, qt.IsNil)
+	c.Check(user, qt.Equals, "alice@example.com")
+
+	// Usernames without a domain are rejected.
+	token = signIDToken(c, key, testIDTokenIssuer, time.Now().Add(time.Hour), map[string]interface{}{
+		"preferred_username": "alice",
+	})
+	_, err = a.Authenticate(context.Background(), token)
+	c.Check(err, qt.ErrorMatches, `username "alice" has no domain`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+}
@@ -6,6 +6,7 @@
 // - OAuth2.0 (Device flow)
 // - OAuth2.0 (Browser flow)
 // - JWTs (For CLI based sessions)
+// - OIDC ID tokens issued by an external provider
 package auth
 
 import (
@@ -0,0 +1,77 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// UpsertApplication stores the given application, replacing any
+// application already stored with the same model and name.
+func (d *Database) UpsertApplication(ctx context.Context, application *dbmodel.Application) (err error) {
+	const op = errors.Op("db.UpsertApplication")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "model_id"},
+			{Name: "name"},
+		},
+		UpdateAll: true,
+	}).Create(application).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// DeleteApplication removes the application with the given model and
+// name. Deleting an application that is not stored is not an error.
+func (d *Database) DeleteApplication(ctx context.Context, application *dbmodel.Application) (err error) {
+	const op = errors.Op("db.DeleteApplication")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("model_id = ? AND name = ?", application.ModelID, application.Name).Delete(&dbmodel.Application{}).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetModelApplications returns the applications stored for the given
+// model, ordered by name.
+func (d *Database) GetModelApplications(ctx context.Context, model *dbmodel.Model) (_ []dbmodel.Application, err error) {
+	const op = errors.Op("db.GetModelApplications")
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	var applications []dbmodel.Application
+	if err := db.Where("model_id = ?", model.ID).Order("name").Find(&applications).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return applications, nil
+}
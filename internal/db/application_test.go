@@ -0,0 +1,84 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestUpsertApplicationUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+	var d db.Database
+
+	err := d.UpsertApplication(context.Background(), &dbmodel.Application{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestApplications(c *qt.C) {
+	ctx := context.Background()
+	env := initTestEnvironment(c, s.Database)
+
+	app1 := dbmodel.Application{
+		ModelID:  env.model.ID,
+		Name:     "app-1",
+		CharmURL: "ch:amd64/app-1-1",
+		Life:     "alive",
+		Status:   dbmodel.Status{Status: "waiting"},
+	}
+	err := s.Database.UpsertApplication(ctx, &app1)
+	c.Assert(err, qt.IsNil)
+	app0 := dbmodel.Application{
+		ModelID:  env.model.ID,
+		Name:     "app-0",
+		CharmURL: "ch:amd64/app-0-7",
+		Life:     "alive",
+		Status:   dbmodel.Status{Status: "active"},
+		Units:    2,
+	}
+	err = s.Database.UpsertApplication(ctx, &app0)
+	c.Assert(err, qt.IsNil)
+
+	// Upserting an application with the same name replaces it.
+	app1Update := dbmodel.Application{
+		ModelID:  env.model.ID,
+		Name:     "app-1",
+		CharmURL: "ch:amd64/app-1-2",
+		Life:     "alive",
+		Status:   dbmodel.Status{Status: "active"},
+		Units:    1,
+	}
+	err = s.Database.UpsertApplication(ctx, &app1Update)
+	c.Assert(err, qt.IsNil)
+
+	apps, err := s.Database.GetModelApplications(ctx, &env.model)
+	c.Assert(err, qt.IsNil)
+	c.Assert(apps, qt.HasLen, 2)
+	c.Check(apps[0].Name, qt.Equals, "app-0")
+	c.Check(apps[0].Units, qt.Equals, int64(2))
+	c.Check(apps[1].ID, qt.Equals, app1.ID)
+	c.Check(apps[1].CharmURL, qt.Equals, "ch:amd64/app-1-2")
+	c.Check(apps[1].Status.Status, qt.Equals, "active")
+	c.Check(apps[1].Units, qt.Equals, int64(1))
+
+	err = s.Database.DeleteApplication(ctx, &dbmodel.Application{ModelID: env.model.ID, Name: "app-0"})
+	c.Assert(err, qt.IsNil)
+	apps, err = s.Database.GetModelApplications(ctx, &env.model)
+	c.Assert(err, qt.IsNil)
+	c.Assert(apps, qt.HasLen, 1)
+	c.Check(apps[0].Name, qt.Equals, "app-1")
+
+	// Applications are removed with their model.
+	err = s.Database.DeleteModel(ctx, &env.model)
+	c.Assert(err, qt.IsNil)
+	apps, err = s.Database.GetModelApplications(ctx, &env.model)
+	c.Assert(err, qt.IsNil)
+	c.Check(apps, qt.HasLen, 0)
+}
@@ -65,7 +65,6 @@ func (d *Database) UpdateApplicationOffer(ctx context.Context, offer *dbmodel.Ap
 	if err != nil {
 		return errors.E(op, dbError(err))
 	}
-
 	if err := d.GetApplicationOffer(ctx, offer); err != nil {
 		return err
 	}
@@ -154,6 +153,14 @@ func ApplicationOfferFilterByModel(modelName string) ApplicationOfferFilter {
 	}
 }
 
+// ApplicationOfferFilterByModelID filters application offers by the ID of
+// the model they are made from.
+func ApplicationOfferFilterByModelID(id uint) ApplicationOfferFilter {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("offers.model_id = ?", id)
+	}
+}
+
 // ApplicationOfferFilterByApplication filters application offers by application name.
 func ApplicationOfferFilterByApplication(applicationName string) ApplicationOfferFilter {
 	return func(db *gorm.DB) *gorm.DB {
@@ -225,3 +232,32 @@ func (d *Database) FindApplicationOffers(ctx context.Context, filters ...Applica
 
 	return offers, nil
 }
+
+// ListApplicationOffers returns a page of all the application offers
+// known to JIMM, ordered by URL. Each offer includes its connections and
+// its model, along with the model's controller.
+func (d *Database) ListApplicationOffers(ctx context.Context, limit, offset int) (_ []dbmodel.ApplicationOffer, err error) {
+	const op = errors.Op("db.ListApplicationOffers")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var offers []dbmodel.ApplicationOffer
+	db := d.DB.WithContext(ctx)
+	err = db.Preload("Connections").
+		Preload("Model").
+		Preload("Model.Controller").
+		Order("url asc").
+		Limit(limit).
+		Offset(offset).
+		Find(&offers).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return offers, nil
+}
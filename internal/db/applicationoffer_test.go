@@ -127,6 +127,15 @@ func TestGetApplicationOfferUnconfiguredDatabase(t *testing.T) {
 	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
 }
 
+func TestListApplicationOffersUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, err := d.ListApplicationOffers(context.Background(), 10, 0)
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
 func (s *dbSuite) TestGetApplicationOffer(c *qt.C) {
 	env := initTestEnvironment(c, s.Database)
 
@@ -319,6 +328,12 @@ func (s *dbSuite) TestFindApplicationOffers(c *qt.C) {
 			db.ApplicationOfferFilterByModel(env.model.Name),
 		},
 		expectedOffers: []dbmodel.ApplicationOffer{offer1, offer2, offer3},
+	}, {
+		about: "filter by model ID",
+		filters: []db.ApplicationOfferFilter{
+			db.ApplicationOfferFilterByModelID(env.model.ID),
+		},
+		expectedOffers: []dbmodel.ApplicationOffer{offer1, offer2, offer3},
 	}, {
 		about: "filter by model - not found",
 		filters: []db.ApplicationOfferFilter{
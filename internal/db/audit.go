@@ -144,3 +144,88 @@ func (d *Database) DeleteAuditLogsBefore(ctx context.Context, before time.Time)
 	}
 	return tx.RowsAffected, nil
 }
+
+// DeleteAuditLogsBatchBefore deletes up to limit audit log entries with a
+// time before the given time, oldest first. It returns the number of
+// entries deleted, which is less than limit once no more entries remain
+// to be deleted.
+func (d *Database) DeleteAuditLogsBatchBefore(ctx context.Context, before time.Time, limit int) (_ int64, err error) {
+	const op = errors.Op("db.DeleteAuditLogsBatchBefore")
+
+	if err := d.ready(); err != nil {
+		return 0, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	ids := db.
+		Model(&dbmodel.AuditLogEntry{}).
+		Select("id").
+		Where("time < ?", before).
+		Order("id asc").
+		Limit(limit)
+	tx := db.
+		Unscoped().
+		Where("id IN (?)", ids).
+		Delete(&dbmodel.AuditLogEntry{})
+	if tx.Error != nil {
+		return 0, errors.E(op, dbError(tx.Error))
+	}
+	return tx.RowsAffected, nil
+}
+
+// GetAuditLogEntriesPendingExport returns up to limit audit log entries
+// that are waiting to be exported, oldest first.
+func (d *Database) GetAuditLogEntriesPendingExport(ctx context.Context, limit int) (_ []dbmodel.AuditLogEntry, err error) {
+	const op = errors.Op("db.GetAuditLogEntriesPendingExport")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var entries []dbmodel.AuditLogEntry
+	err = d.DB.
+		WithContext(ctx).
+		Where("pending_export").
+		Order("id asc").
+		Limit(limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return entries, nil
+}
+
+// ClearAuditLogPendingExport marks the audit log entries with the given
+// IDs as exported.
+func (d *Database) ClearAuditLogPendingExport(ctx context.Context, ids []uint) (err error) {
+	const op = errors.Op("db.ClearAuditLogPendingExport")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if len(ids) == 0 {
+		return nil
+	}
+	err = d.DB.
+		WithContext(ctx).
+		Model(&dbmodel.AuditLogEntry{}).
+		Where("id IN ?", ids).
+		Update("pending_export", false).Error
+	if err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ package db_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -204,6 +205,42 @@ func (s *dbSuite) TestDeleteAuditLogsBefore(c *qt.C) {
 	c.Assert(logs, qt.HasLen, 1)
 }
 
+func (s *dbSuite) TestDeleteAuditLogsBatchBefore(c *qt.C) {
+	ctx := context.Background()
+	now := time.Now()
+
+	_, err := s.Database.DeleteAuditLogsBatchBefore(ctx, now, 10)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(context.Background(), false)
+	c.Assert(err, qt.IsNil)
+
+	for i := 1; i <= 5; i++ {
+		c.Assert(s.Database.AddAuditLogEntry(ctx, &dbmodel.AuditLogEntry{
+			Time: now.AddDate(0, 0, -i),
+		}), qt.IsNil)
+	}
+
+	// Only 2 entries are deleted at a time.
+	before := now.AddDate(0, 0, -1).Add(-time.Hour)
+	deleted, err := s.Database.DeleteAuditLogsBatchBefore(ctx, before, 2)
+	c.Assert(err, qt.IsNil)
+	c.Check(deleted, qt.Equals, int64(2))
+
+	deleted, err = s.Database.DeleteAuditLogsBatchBefore(ctx, before, 2)
+	c.Assert(err, qt.IsNil)
+	c.Check(deleted, qt.Equals, int64(2))
+
+	deleted, err = s.Database.DeleteAuditLogsBatchBefore(ctx, before, 2)
+	c.Assert(err, qt.IsNil)
+	c.Check(deleted, qt.Equals, int64(0))
+
+	var logs []dbmodel.AuditLogEntry
+	err = s.Database.DB.Find(&logs).Error
+	c.Assert(err, qt.IsNil)
+	c.Assert(logs, qt.HasLen, 1)
+}
+
 func (s *dbSuite) TestPurgeLogsFromDb(c *qt.C) {
 
 	ctx := context.Background()
@@ -234,3 +271,39 @@ func (s *dbSuite) TestPurgeLogsFromDb(c *qt.C) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(deleted_count, qt.Equals, int64(2))
 }
+
+func (s *dbSuite) TestAuditLogPendingExport(c *qt.C) {
+	ctx := context.Background()
+
+	_, err := s.Database.GetAuditLogEntriesPendingExport(ctx, 10)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(context.Background(), false)
+	c.Assert(err, qt.IsNil)
+
+	var ids []uint
+	for i := 0; i < 4; i++ {
+		ale := dbmodel.AuditLogEntry{
+			Time:          time.Now().UTC().Round(time.Millisecond),
+			FacadeMethod:  fmt.Sprintf("Method%d", i),
+			PendingExport: i != 1,
+		}
+		c.Assert(s.Database.AddAuditLogEntry(ctx, &ale), qt.IsNil)
+		ids = append(ids, ale.ID)
+	}
+
+	entries, err := s.Database.GetAuditLogEntriesPendingExport(ctx, 2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(entries, qt.HasLen, 2)
+	c.Check(entries[0].ID, qt.Equals, ids[0])
+	c.Check(entries[1].ID, qt.Equals, ids[2])
+
+	err = s.Database.ClearAuditLogPendingExport(ctx, []uint{ids[0], ids[2]})
+	c.Assert(err, qt.IsNil)
+
+	entries, err = s.Database.GetAuditLogEntriesPendingExport(ctx, 10)
+	c.Assert(err, qt.IsNil)
+	c.Assert(entries, qt.HasLen, 1)
+	c.Check(entries[0].ID, qt.Equals, ids[3])
+	c.Check(entries[0].FacadeMethod, qt.Equals, "Method3")
+}
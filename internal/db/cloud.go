@@ -212,3 +212,41 @@ func (d *Database) DeleteCloudRegionControllerPriority(ctx context.Context, c *d
 	}
 	return nil
 }
+
+// CloudRegionName holds the name of a cloud region along with the name
+// of its cloud.
+type CloudRegionName struct {
+	// CloudName is the name of the cloud.
+	CloudName string
+
+	// RegionName is the name of the region.
+	RegionName string
+}
+
+// GetUncoveredCloudRegions returns the cloud regions that no controller
+// is able to host models in, ordered by cloud and then region name.
+func (d *Database) GetUncoveredCloudRegions(ctx context.Context) (_ []CloudRegionName, err error) {
+	const op = errors.Op("db.GetUncoveredCloudRegions")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var regions []CloudRegionName
+	db := d.DB.WithContext(ctx)
+	err = db.Model(&dbmodel.CloudRegion{}).
+		Select("cloud_regions.cloud_name AS cloud_name, cloud_regions.name AS region_name").
+		Where(`NOT EXISTS (SELECT 1 FROM cloud_region_controller_priorities
+WHERE cloud_region_controller_priorities.cloud_region_id = cloud_regions.id
+AND cloud_region_controller_priorities.deleted_at IS NULL)`).
+		Order("cloud_regions.cloud_name, cloud_regions.name").
+		Scan(&regions).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return regions, nil
+}
@@ -5,6 +5,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm/clause"
 
@@ -35,7 +36,7 @@ func (d *Database) SetCloudCredential(ctx context.Context, cred *dbmodel.CloudCr
 			{Name: "owner_identity_name"},
 			{Name: "name"},
 		},
-		DoUpdates: clause.AssignmentColumns([]string{"auth_type", "label", "attributes_in_vault", "attributes", "valid"}),
+		DoUpdates: clause.AssignmentColumns([]string{"auth_type", "label", "attributes_in_vault", "attributes", "labels", "valid", "last_checked_at"}),
 	}).Create(&cred).Error; err != nil {
 		return errors.E(op, dbError(err))
 	}
@@ -116,6 +117,60 @@ func (d *Database) ForEachCloudCredential(ctx context.Context, identityName, clo
 	return nil
 }
 
+// GetCloudCredentialsCheckedBefore returns all cloud credentials that were
+// last checked before the given time, including those that have never been
+// checked. The returned credentials are ordered by ID.
+func (d *Database) GetCloudCredentialsCheckedBefore(ctx context.Context, t time.Time) (_ []dbmodel.CloudCredential, err error) {
+	const op = errors.Op("db.GetCloudCredentialsCheckedBefore")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var creds []dbmodel.CloudCredential
+	db := d.DB.WithContext(ctx)
+	err = db.Where("last_checked_at IS NULL OR last_checked_at < ?", t).
+		Order("id asc").
+		Find(&creds).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return creds, nil
+}
+
+// GetUnusedCloudCredentials returns all cloud credentials that are not
+// used by any model, ignoring models with any of the given life values.
+// The returned credentials are ordered by ID.
+func (d *Database) GetUnusedCloudCredentials(ctx context.Context, ignoreLife ...string) (_ []dbmodel.CloudCredential, err error) {
+	const op = errors.Op("db.GetUnusedCloudCredentials")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	models := db.Model(&dbmodel.Model{}).Select("1").Where("models.cloud_credential_id = cloud_credentials.id")
+	if len(ignoreLife) > 0 {
+		models = models.Where("models.life NOT IN ?", ignoreLife)
+	}
+	var creds []dbmodel.CloudCredential
+	err = db.Where("NOT EXISTS (?)", models).
+		Order("id asc").
+		Find(&creds).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return creds, nil
+}
+
 // DeleteCloudCredential removes the given CloudCredential from the database.
 func (d *Database) DeleteCloudCredential(ctx context.Context, cred *dbmodel.CloudCredential) (err error) {
 	const op = errors.Op("db.DeleteCloudCredential")
@@ -135,3 +190,108 @@ func (d *Database) DeleteCloudCredential(ctx context.Context, cred *dbmodel.Clou
 	}
 	return nil
 }
+
+// AddPendingCredentialUpdates records that the latest update to the given
+// cloud credential has not yet been applied on the given controllers.
+// Recording an update that is already pending is not an error.
+func (d *Database) AddPendingCredentialUpdates(ctx context.Context, cred *dbmodel.CloudCredential, controllers []dbmodel.Controller) (err error) {
+	const op = errors.Op("db.AddPendingCredentialUpdates")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if len(controllers) == 0 {
+		return nil
+	}
+	updates := make([]dbmodel.PendingCredentialUpdate, len(controllers))
+	for i, ctl := range controllers {
+		updates[i] = dbmodel.PendingCredentialUpdate{
+			CloudCredentialID: cred.ID,
+			ControllerID:      ctl.ID,
+		}
+	}
+	db := d.DB.WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "cloud_credential_id"},
+			{Name: "controller_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at"}),
+	}).Create(&updates).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// DeletePendingCredentialUpdate records that the latest update to the
+// given cloud credential has been applied on the given controller.
+func (d *Database) DeletePendingCredentialUpdate(ctx context.Context, cred *dbmodel.CloudCredential, ctl *dbmodel.Controller) (err error) {
+	const op = errors.Op("db.DeletePendingCredentialUpdate")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("cloud_credential_id = ? AND controller_id = ?", cred.ID, ctl.ID).Delete(&dbmodel.PendingCredentialUpdate{}).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// A PendingCredentialController identifies a controller on which the
+// latest update to a cloud credential has not yet been applied.
+type PendingCredentialController struct {
+	// CloudName, OwnerIdentityName and CredentialName identify the
+	// cloud credential.
+	CloudName         string
+	OwnerIdentityName string
+	CredentialName    string
+
+	// ControllerName is the name of the controller.
+	ControllerName string
+}
+
+// GetPendingCredentialUpdates returns the controllers on which updates to
+// cloud credentials have not yet been applied, ordered by credential and
+// then controller name. If any credential IDs are given only the pending
+// updates to those credentials are returned, otherwise the pending
+// updates to every credential are returned. The updates are read in a
+// single query.
+func (d *Database) GetPendingCredentialUpdates(ctx context.Context, credentialIDs ...uint) (_ []PendingCredentialController, err error) {
+	const op = errors.Op("db.GetPendingCredentialUpdates")
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var pending []PendingCredentialController
+	db := d.DB.WithContext(ctx)
+	db = db.Model(&dbmodel.PendingCredentialUpdate{}).
+		Select(`cloud_credentials.cloud_name AS cloud_name,
+cloud_credentials.owner_identity_name AS owner_identity_name,
+cloud_credentials.name AS credential_name,
+controllers.name AS controller_name`).
+		Joins("JOIN cloud_credentials ON pending_credential_updates.cloud_credential_id = cloud_credentials.id").
+		Joins("JOIN controllers ON pending_credential_updates.controller_id = controllers.id").
+		Where("cloud_credentials.deleted_at IS NULL")
+	if len(credentialIDs) > 0 {
+		db = db.Where("pending_credential_updates.cloud_credential_id IN ?", credentialIDs)
+	}
+	err = db.Order("cloud_credentials.cloud_name, cloud_credentials.owner_identity_name, cloud_credentials.name, controllers.name").
+		Scan(&pending).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return pending, nil
+}
@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"fmt"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/juju/names/v5"
@@ -302,3 +303,153 @@ func (s *dbSuite) TestForEachCloudCredential(c *qt.C) {
 		})
 	}
 }
+
+func TestGetCloudCredentialsCheckedBeforeUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, err := d.GetCloudCredentialsCheckedBefore(context.Background(), time.Now())
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func TestGetUnusedCloudCredentialsUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, err := d.GetUnusedCloudCredentials(context.Background())
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestGetCloudCredentialsCheckedBefore(c *qt.C) {
+	ctx := context.Background()
+
+	env := jimmtest.ParseEnvironment(c, forEachCloudCredentialEnv)
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env.PopulateDB(c, *s.Database)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	checked := map[string]time.Time{
+		"cred-1": now.Add(-2 * time.Hour),
+		"cred-2": now.Add(-time.Minute),
+		"cred-3": now.Add(-3 * time.Hour),
+	}
+	for _, cred := range env.CloudCredentials {
+		t, ok := checked[cred.Name]
+		if !ok {
+			continue
+		}
+		dbCred := cred.DBObject(c, *s.Database)
+		dbCred.LastCheckedAt = sql.NullTime{Time: t, Valid: true}
+		err := s.Database.SetCloudCredential(ctx, &dbCred)
+		c.Assert(err, qt.IsNil)
+	}
+
+	creds, err := s.Database.GetCloudCredentialsCheckedBefore(ctx, now.Add(-time.Hour))
+	c.Assert(err, qt.IsNil)
+	var tags []string
+	for _, cred := range creds {
+		tags = append(tags, cred.Tag().String())
+	}
+	c.Check(tags, qt.DeepEquals, []string{
+		names.NewCloudCredentialTag("cloud-1/alice@canonical.com/cred-1").String(),
+		names.NewCloudCredentialTag("cloud-2/alice@canonical.com/cred-3").String(),
+		names.NewCloudCredentialTag("cloud-2/bob@canonical.com/cred-4").String(),
+		names.NewCloudCredentialTag("cloud-1/alice@canonical.com/cred-5").String(),
+	})
+}
+
+func TestGetPendingCredentialUpdatesUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, err := d.GetPendingCredentialUpdates(context.Background())
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+const pendingCredentialUpdatesEnv = `clouds:
+- name: cloud-1
+  regions:
+  - name: default
+cloud-credentials:
+- name: cred-1
+  cloud: cloud-1
+  owner: alice@canonical.com
+- name: cred-2
+  cloud: cloud-1
+  owner: bob@canonical.com
+- name: cred-3
+  cloud: cloud-1
+  owner: alice@canonical.com
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: cloud-1
+  region: default
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: cloud-1
+  region: default
+`
+
+func (s *dbSuite) TestPendingCredentialUpdates(c *qt.C) {
+	ctx := context.Background()
+
+	env := jimmtest.ParseEnvironment(c, pendingCredentialUpdatesEnv)
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env.PopulateDB(c, *s.Database)
+
+	cred1 := env.CloudCredentials[0].DBObject(c, *s.Database)
+	cred2 := env.CloudCredentials[1].DBObject(c, *s.Database)
+	ctl1 := env.Controller("controller-1").DBObject(c, *s.Database)
+	ctl2 := env.Controller("controller-2").DBObject(c, *s.Database)
+
+	err = s.Database.AddPendingCredentialUpdates(ctx, &cred1, []dbmodel.Controller{ctl2, ctl1})
+	c.Assert(err, qt.IsNil)
+	err = s.Database.AddPendingCredentialUpdates(ctx, &cred2, []dbmodel.Controller{ctl2})
+	c.Assert(err, qt.IsNil)
+	// Adding an update that is already pending is not an error.
+	err = s.Database.AddPendingCredentialUpdates(ctx, &cred1, []dbmodel.Controller{ctl1})
+	c.Assert(err, qt.IsNil)
+
+	pending, err := s.Database.GetPendingCredentialUpdates(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(pending, qt.DeepEquals, []db.PendingCredentialController{{
+		CloudName:         "cloud-1",
+		OwnerIdentityName: "alice@canonical.com",
+		CredentialName:    "cred-1",
+		ControllerName:    "controller-1",
+	}, {
+		CloudName:         "cloud-1",
+		OwnerIdentityName: "alice@canonical.com",
+		CredentialName:    "cred-1",
+		ControllerName:    "controller-2",
+	}, {
+		CloudName:         "cloud-1",
+		OwnerIdentityName: "bob@canonical.com",
+		CredentialName:    "cred-2",
+		ControllerName:    "controller-2",
+	}})
+
+	err = s.Database.DeletePendingCredentialUpdate(ctx, &cred1, &ctl1)
+	c.Assert(err, qt.IsNil)
+	pending, err = s.Database.GetPendingCredentialUpdates(ctx, cred1.ID)
+	c.Assert(err, qt.IsNil)
+	c.Check(pending, qt.DeepEquals, []db.PendingCredentialController{{
+		CloudName:         "cloud-1",
+		OwnerIdentityName: "alice@canonical.com",
+		CredentialName:    "cred-1",
+		ControllerName:    "controller-2",
+	}})
+
+	// Updates to deleted credentials are no longer pending.
+	err = s.Database.DeleteCloudCredential(ctx, &cred2)
+	c.Assert(err, qt.IsNil)
+	pending, err = s.Database.GetPendingCredentialUpdates(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(pending, qt.HasLen, 1)
+}
@@ -84,9 +84,20 @@ func (d *Database) UpdateController(ctx context.Context, controller *dbmodel.Con
 	if err := db.Save(controller).Error; err != nil {
 		return errors.E(op, dbError(err))
 	}
+	d.invalidateControllerModels(controller)
 	return nil
 }
 
+// invalidateControllerModels removes the models hosted on the given
+// controller from the model cache, as cached models include their
+// controller.
+func (d *Database) invalidateControllerModels(controller *dbmodel.Controller) {
+	id := controller.ID
+	d.invalidateModels(func(m *dbmodel.Model) bool {
+		return m.ControllerID == id
+	})
+}
+
 // DeleteController removes the specified controller from the database.
 func (d *Database) DeleteController(ctx context.Context, controller *dbmodel.Controller) (err error) {
 	const op = errors.Op("db.DeleteController")
@@ -113,6 +124,7 @@ func (d *Database) DeleteController(ctx context.Context, controller *dbmodel.Con
 	if err := db.Select(clause.Associations).Delete(controller).Error; err != nil {
 		return errors.E(op, err)
 	}
+	d.invalidateControllerModels(controller)
 	return nil
 }
 
@@ -23,6 +23,19 @@ type Database struct {
 	// DB contains the gorm database storing the data.
 	DB *gorm.DB
 
+	// ModelCache, if set, holds recently retrieved models so that
+	// repeated lookups of a model by UUID can be served without querying
+	// the database.
+	ModelCache *ModelCache
+
+	// inTransaction holds whether the Database is being used within a
+	// transaction. Models read within a transaction are never cached.
+	inTransaction bool
+
+	// invalidations holds the model cache invalidations made within the
+	// outermost transaction, which are applied once it has finished.
+	invalidations *[]func(*dbmodel.Model) bool
+
 	// migrated holds whether the database has been successfully migrated
 	// to the current database version. The value of migrated should always
 	// be read using atomic.LoadUint32 and will contain a 0 if the
@@ -42,11 +55,27 @@ func (d *Database) Transaction(f func(*Database) error) error {
 	if err := d.ready(); err != nil {
 		return err
 	}
-	return d.DB.Transaction(func(tx *gorm.DB) error {
+	if d.inTransaction {
+		return d.DB.Transaction(func(tx *gorm.DB) error {
+			d := *d
+			d.DB = tx
+			return f(&d)
+		})
+	}
+	var invalidations []func(*dbmodel.Model) bool
+	err := d.DB.Transaction(func(tx *gorm.DB) error {
 		d := *d
 		d.DB = tx
+		d.inTransaction = true
+		d.invalidations = &invalidations
 		return f(&d)
 	})
+	// Models are invalidated even if the transaction failed, as it
+	// cannot always be known whether it was committed.
+	for _, match := range invalidations {
+		d.ModelCache.invalidate(match)
+	}
+	return err
 }
 
 // Migrate migrates the configured database to have the structure required
@@ -114,6 +143,77 @@ func (d *Database) ready() error {
 	return nil
 }
 
+// Ping checks that the database can be reached and is ready to accept
+// requests.
+func (d *Database) Ping(ctx context.Context) error {
+	const op = errors.Op("db.Ping")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return errors.E(op, err, "failed to get the internal DB object")
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// PoolConfig holds the configuration of the connection pool used to
+// connect to the database. Zero values leave the corresponding setting at
+// its default.
+type PoolConfig struct {
+	// MaxOpenConns is the maximum number of open connections to the
+	// database.
+	MaxOpenConns int
+
+	// MaxIdleConns is the maximum number of connections kept in the
+	// idle connection pool.
+	MaxIdleConns int
+
+	// ConnMaxLifetime is the maximum amount of time a connection may be
+	// reused.
+	ConnMaxLifetime time.Duration
+}
+
+// ConfigurePool applies the given connection pool configuration to the
+// underlying database connection.
+func (d *Database) ConfigurePool(cfg PoolConfig) error {
+	const op = errors.Op("db.ConfigurePool")
+	if d == nil || d.DB == nil {
+		return errors.E(op, errors.CodeServerConfiguration, "database not configured")
+	}
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return errors.E(op, err, "failed to get the internal DB object")
+	}
+	if cfg.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	return nil
+}
+
+// Stats returns the connection pool statistics of the underlying database
+// connection.
+func (d *Database) Stats() (sql.DBStats, error) {
+	const op = errors.Op("db.Stats")
+	if d == nil || d.DB == nil {
+		return sql.DBStats{}, errors.E(op, errors.CodeServerConfiguration, "database not configured")
+	}
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return sql.DBStats{}, errors.E(op, err, "failed to get the internal DB object")
+	}
+	return sqlDB.Stats(), nil
+}
+
 // Close closes open connections to the underlying database backend.
 func (d *Database) Close() error {
 	sqlDB, err := d.DB.DB()
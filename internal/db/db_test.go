@@ -4,7 +4,9 @@ package db_test
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 
@@ -72,3 +74,69 @@ func (s *dbSuite) TestTransaction(c *qt.C) {
 	})
 	c.Check(err, qt.ErrorMatches, `test error`)
 }
+
+func TestPingUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var database db.Database
+	err := database.Ping(context.Background())
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestPing(c *qt.C) {
+	err := s.Database.Ping(context.Background())
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(context.Background(), false)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.Ping(context.Background())
+	c.Assert(err, qt.IsNil)
+}
+
+func TestConfigurePoolUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var database db.Database
+	err := database.ConfigurePool(db.PoolConfig{MaxOpenConns: 1})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestConfigurePool(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.ConfigurePool(db.PoolConfig{
+		MaxOpenConns:    3,
+		MaxIdleConns:    1,
+		ConnMaxLifetime: time.Minute,
+	})
+	c.Assert(err, qt.IsNil)
+
+	stats, err := s.Database.Stats()
+	c.Assert(err, qt.IsNil)
+	c.Check(stats.MaxOpenConnections, qt.Equals, 3)
+
+	// Hold the maximum number of connections and then release them, all
+	// but one should be closed rather than returned to the idle pool.
+	sqlDB, err := s.Database.DB.DB()
+	c.Assert(err, qt.IsNil)
+	var conns []*sql.Conn
+	for i := 0; i < 3; i++ {
+		conn, err := sqlDB.Conn(ctx)
+		c.Assert(err, qt.IsNil)
+		conns = append(conns, conn)
+	}
+	stats, err = s.Database.Stats()
+	c.Assert(err, qt.IsNil)
+	c.Check(stats.InUse, qt.Equals, 3)
+
+	for _, conn := range conns {
+		c.Assert(conn.Close(), qt.IsNil)
+	}
+	stats, err = s.Database.Stats()
+	c.Assert(err, qt.IsNil)
+	c.Check(stats.InUse, qt.Equals, 0)
+	c.Check(stats.Idle, qt.Equals, 1)
+	c.Check(stats.MaxIdleClosed >= 2, qt.IsTrue)
+}
@@ -4,6 +4,7 @@ package db
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/uuid"
 
@@ -82,10 +83,12 @@ func (d *Database) GetGroup(ctx context.Context, group *dbmodel.GroupEntry) (err
 	return nil
 }
 
-// ForEachGroup iterates through every group calling the given function
-// for each one. If the given function returns an error the iteration
-// will stop immediately and the error will be returned unmodified.
-func (d *Database) ForEachGroup(ctx context.Context, limit, offset int, f func(*dbmodel.GroupEntry) error) (err error) {
+// ForEachGroup iterates through every group, in ascending order of name,
+// calling the given function for each one. If namePrefixFilter is not
+// empty only groups whose name starts with it are included. If the given
+// function returns an error the iteration will stop immediately and the
+// error will be returned unmodified.
+func (d *Database) ForEachGroup(ctx context.Context, limit, offset int, namePrefixFilter string, f func(*dbmodel.GroupEntry) error) (err error) {
 	const op = errors.Op("db.ForEachGroup")
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -96,6 +99,9 @@ func (d *Database) ForEachGroup(ctx context.Context, limit, offset int, f func(*
 	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
 
 	db := d.DB.WithContext(ctx)
+	if namePrefixFilter != "" {
+		db = db.Where("name LIKE ?", likePrefix(namePrefixFilter))
+	}
 	db = db.Order("name asc")
 	db = db.Limit(limit)
 	db = db.Offset(offset)
@@ -168,3 +174,13 @@ func (d *Database) RemoveGroup(ctx context.Context, group *dbmodel.GroupEntry) (
 	}
 	return nil
 }
+
+// likeEscaper escapes the characters that have a special meaning in a
+// LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// likePrefix returns a LIKE pattern matching strings that start with the
+// given prefix.
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
@@ -195,7 +195,7 @@ func (s *dbSuite) TestForEachGroup(c *qt.C) {
 	}
 	firstGroups := []*dbmodel.GroupEntry{}
 	ctx := context.Background()
-	err = s.Database.ForEachGroup(ctx, 5, 0, func(ge *dbmodel.GroupEntry) error {
+	err = s.Database.ForEachGroup(ctx, 5, 0, "", func(ge *dbmodel.GroupEntry) error {
 		firstGroups = append(firstGroups, ge)
 		return nil
 	})
@@ -204,7 +204,7 @@ func (s *dbSuite) TestForEachGroup(c *qt.C) {
 		c.Assert(firstGroups[i].Name, qt.Equals, fmt.Sprintf("test-group-%d", i))
 	}
 	secondGroups := []*dbmodel.GroupEntry{}
-	err = s.Database.ForEachGroup(ctx, 5, 5, func(ge *dbmodel.GroupEntry) error {
+	err = s.Database.ForEachGroup(ctx, 5, 5, "", func(ge *dbmodel.GroupEntry) error {
 		secondGroups = append(secondGroups, ge)
 		return nil
 	})
@@ -213,3 +213,32 @@ func (s *dbSuite) TestForEachGroup(c *qt.C) {
 		c.Assert(secondGroups[i].Name, qt.Equals, fmt.Sprintf("test-group-%d", i+5))
 	}
 }
+
+func (s *dbSuite) TestForEachGroupNamePrefix(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	for _, name := range []string{"team-b", "team_a", "team-a", "teamster", "other-team"} {
+		_, err := s.Database.AddGroup(ctx, name)
+		c.Assert(err, qt.IsNil)
+	}
+	listGroups := func(limit, offset int, prefix string) []string {
+		var names []string
+		err := s.Database.ForEachGroup(ctx, limit, offset, prefix, func(ge *dbmodel.GroupEntry) error {
+			names = append(names, ge.Name)
+			return nil
+		})
+		c.Assert(err, qt.IsNil)
+		return names
+	}
+
+	c.Check(listGroups(10, 0, "team-"), qt.DeepEquals, []string{"team-a", "team-b"})
+	// Wildcard characters in the prefix are matched literally.
+	c.Check(listGroups(10, 0, "team_"), qt.DeepEquals, []string{"team_a"})
+	c.Check(listGroups(10, 0, "team"), qt.DeepEquals, []string{"team-a", "team-b", "team_a", "teamster"})
+	c.Check(listGroups(2, 0, "team"), qt.DeepEquals, []string{"team-a", "team-b"})
+	c.Check(listGroups(2, 2, "team"), qt.DeepEquals, []string{"team_a", "teamster"})
+	c.Check(listGroups(2, 4, "team"), qt.HasLen, 0)
+	c.Check(listGroups(10, 0, "nope"), qt.HasLen, 0)
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// SetIdentityPreferences stores the given preferences, replacing any
+// preferences already stored for the identity.
+func (d *Database) SetIdentityPreferences(ctx context.Context, prefs *dbmodel.IdentityPreferences) (err error) {
+	const op = errors.Op("db.SetIdentityPreferences")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Omit("Identity").Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "identity_name"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "cloud_name", "cloud_region", "cloud_credential_name"}),
+	}).Create(prefs).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// IdentityPreferences fetches the preferences stored for the identity
+// named in the given preferences. If the identity has no stored
+// preferences an error with a code of CodeNotFound is returned.
+func (d *Database) IdentityPreferences(ctx context.Context, prefs *dbmodel.IdentityPreferences) (err error) {
+	const op = errors.Op("db.IdentityPreferences")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("identity_name = ?", prefs.IdentityName).First(prefs).Error; err != nil {
+		err = dbError(err)
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, errors.CodeNotFound, "identity preferences not found", err)
+		}
+		return errors.E(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// UpsertMachine stores the given machine, replacing any machine already
+// stored with the same model and machine ID.
+func (d *Database) UpsertMachine(ctx context.Context, machine *dbmodel.Machine) (err error) {
+	const op = errors.Op("db.UpsertMachine")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "model_id"},
+			{Name: "machine_id"},
+		},
+		UpdateAll: true,
+	}).Create(machine).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// DeleteMachine removes the machine with the given model and machine ID.
+// Deleting a machine that is not stored is not an error.
+func (d *Database) DeleteMachine(ctx context.Context, machine *dbmodel.Machine) (err error) {
+	const op = errors.Op("db.DeleteMachine")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("model_id = ? AND machine_id = ?", machine.ModelID, machine.MachineID).Delete(&dbmodel.Machine{}).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetModelMachines returns the machines stored for the given model,
+// ordered by machine ID.
+func (d *Database) GetModelMachines(ctx context.Context, model *dbmodel.Model) (_ []dbmodel.Machine, err error) {
+	const op = errors.Op("db.GetModelMachines")
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	var machines []dbmodel.Machine
+	if err := db.Where("model_id = ?", model.ID).Order("machine_id").Find(&machines).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return machines, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestUpsertMachineUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+	var d db.Database
+
+	err := d.UpsertMachine(context.Background(), &dbmodel.Machine{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestMachines(c *qt.C) {
+	ctx := context.Background()
+	env := initTestEnvironment(c, s.Database)
+
+	m1 := dbmodel.Machine{
+		ModelID:    env.model.ID,
+		MachineID:  "1",
+		InstanceID: "inst-1",
+		Hardware: dbmodel.MachineHardware{
+			Arch:     sql.NullString{String: "amd64", Valid: true},
+			CPUCores: sql.NullInt64{Int64: 4, Valid: true},
+		},
+		Life:   "alive",
+		Status: dbmodel.Status{Status: "started"},
+		Base:   "ubuntu@22.04",
+	}
+	err := s.Database.UpsertMachine(ctx, &m1)
+	c.Assert(err, qt.IsNil)
+	m0 := dbmodel.Machine{
+		ModelID:   env.model.ID,
+		MachineID: "0",
+		Life:      "alive",
+		Status:    dbmodel.Status{Status: "pending"},
+	}
+	err = s.Database.UpsertMachine(ctx, &m0)
+	c.Assert(err, qt.IsNil)
+
+	// Upserting a machine with the same ID replaces it.
+	m1Update := dbmodel.Machine{
+		ModelID:    env.model.ID,
+		MachineID:  "1",
+		InstanceID: "inst-1",
+		Hardware: dbmodel.MachineHardware{
+			Arch:     sql.NullString{String: "amd64", Valid: true},
+			Mem:      sql.NullInt64{Int64: 8192, Valid: true},
+			CPUCores: sql.NullInt64{Int64: 8, Valid: true},
+		},
+		Life:   "dying",
+		Status: dbmodel.Status{Status: "stopped"},
+		Base:   "ubuntu@22.04",
+	}
+	err = s.Database.UpsertMachine(ctx, &m1Update)
+	c.Assert(err, qt.IsNil)
+
+	machines, err := s.Database.GetModelMachines(ctx, &env.model)
+	c.Assert(err, qt.IsNil)
+	c.Assert(machines, qt.HasLen, 2)
+	c.Check(machines[0].MachineID, qt.Equals, "0")
+	c.Check(machines[0].Status.Status, qt.Equals, "pending")
+	c.Check(machines[1].ID, qt.Equals, m1.ID)
+	c.Check(machines[1].InstanceID, qt.Equals, "inst-1")
+	c.Check(machines[1].Hardware, qt.DeepEquals, m1Update.Hardware)
+	c.Check(machines[1].Life, qt.Equals, "dying")
+	c.Check(machines[1].Status.Status, qt.Equals, "stopped")
+
+	err = s.Database.DeleteMachine(ctx, &dbmodel.Machine{ModelID: env.model.ID, MachineID: "0"})
+	c.Assert(err, qt.IsNil)
+	// Deleting a machine that has already gone is not an error.
+	err = s.Database.DeleteMachine(ctx, &dbmodel.Machine{ModelID: env.model.ID, MachineID: "0"})
+	c.Assert(err, qt.IsNil)
+
+	machines, err = s.Database.GetModelMachines(ctx, &env.model)
+	c.Assert(err, qt.IsNil)
+	c.Assert(machines, qt.HasLen, 1)
+	c.Check(machines[0].MachineID, qt.Equals, "1")
+
+	// Machines are removed with their model.
+	err = s.Database.DeleteModel(ctx, &env.model)
+	c.Assert(err, qt.IsNil)
+	machines, err = s.Database.GetModelMachines(ctx, &env.model)
+	c.Assert(err, qt.IsNil)
+	c.Check(machines, qt.HasLen, 0)
+}
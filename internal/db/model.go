@@ -4,6 +4,9 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -34,7 +37,9 @@ func (d *Database) AddModel(ctx context.Context, model *dbmodel.Model) (err erro
 }
 
 // GetModel returns model information based on the
-// model UUID.
+// model UUID. If there is a ModelCache, models looked up by UUID alone
+// outside of a transaction may be served from it; lookups by any other
+// means always read the model from the database.
 func (d *Database) GetModel(ctx context.Context, model *dbmodel.Model) (err error) {
 	const op = errors.Op("db.GetModel")
 	if err := d.ready(); err != nil {
@@ -45,6 +50,22 @@ func (d *Database) GetModel(ctx context.Context, model *dbmodel.Model) (err erro
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
 
+	cacheable := d.ModelCache != nil && !d.inTransaction && model.UUID.Valid && model.ControllerID == 0
+	var generation uint64
+	if cacheable {
+		var m dbmodel.Model
+		var ok bool
+		if m, generation, ok = d.ModelCache.get(model.UUID.String); ok {
+			*model = m
+			db := d.DB.WithContext(ctx).Where("model_id = ?", model.ID)
+			db = db.Preload("Connections").Preload("Endpoints")
+			if err := db.Find(&model.Offers).Error; err != nil {
+				return errors.E(op, dbError(err))
+			}
+			return nil
+		}
+	}
+
 	db := d.DB.WithContext(ctx)
 	switch {
 	case model.UUID.Valid:
@@ -54,6 +75,8 @@ func (d *Database) GetModel(ctx context.Context, model *dbmodel.Model) (err erro
 		}
 	case model.ID != 0:
 		db = db.Where("id = ?", model.ID)
+	case model.CreationHandle.Valid:
+		db = db.Where("creation_handle = ?", model.CreationHandle.String)
 	case model.OwnerIdentityName != "" && model.Name != "":
 		db = db.Where("owner_identity_name = ? AND name = ?", model.OwnerIdentityName, model.Name)
 	case model.ControllerID != 0:
@@ -72,9 +95,71 @@ func (d *Database) GetModel(ctx context.Context, model *dbmodel.Model) (err erro
 		}
 		return errors.E(op, dbError(err))
 	}
+	if cacheable {
+		d.ModelCache.put(*model, generation)
+	}
+	return nil
+}
+
+// GetModelSummary fills in the model with the given UUID along with its
+// owner, controller, cloud region, cloud and cloud credential. Unlike
+// GetModel the offers hosted in the model are not loaded, which makes it
+// suitable for requests that only describe the model. If the model does
+// not exist an error with a code of CodeNotFound is returned.
+func (d *Database) GetModelSummary(ctx context.Context, model *dbmodel.Model) (err error) {
+	const op = errors.Op("db.GetModelSummary")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if !model.UUID.Valid {
+		return errors.E(op, "missing uuid", errors.CodeBadRequest)
+	}
+
+	db := d.DB.WithContext(ctx)
+	db = db.Where("uuid = ?", model.UUID.String)
+	db = db.Preload("Owner")
+	db = db.Preload("Controller")
+	db = db.Preload("CloudRegion").Preload("CloudRegion.Cloud")
+	db = db.Preload("CloudCredential")
+	if err := db.First(&model).Error; err != nil {
+		err = dbError(err)
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, err, "model not found")
+		}
+		return errors.E(op, err)
+	}
 	return nil
 }
 
+// InvalidateModel removes the given model from the model cache, if there
+// is one, so that the next lookup reads the model from the database. If
+// the Database is being used within a transaction the model is removed
+// once the transaction has finished.
+func (d *Database) InvalidateModel(model *dbmodel.Model) {
+	d.invalidateModels(matchModel(model))
+}
+
+// invalidateModels removes every model for which the given function
+// returns true from the model cache, if there is one. If the Database is
+// being used within a transaction the models are removed once the
+// transaction has finished, so that the models cannot be cached again
+// before the change is committed.
+func (d *Database) invalidateModels(match func(*dbmodel.Model) bool) {
+	if d.ModelCache == nil {
+		return
+	}
+	if d.invalidations != nil {
+		*d.invalidations = append(*d.invalidations, match)
+		return
+	}
+	d.ModelCache.invalidate(match)
+}
+
 // GetModelsUsingCredential returns all models that use the specified credentials.
 func (d *Database) GetModelsUsingCredential(ctx context.Context, credentialID uint) (_ []dbmodel.Model, err error) {
 	const op = errors.Op("db.GetModelsUsingCredential")
@@ -95,7 +180,13 @@ func (d *Database) GetModelsUsingCredential(ctx context.Context, credentialID ui
 	return models, nil
 }
 
-// UpdateModel updates the model information.
+// UpdateModel updates the model information. Models are updated using
+// optimistic locking: the update only succeeds if the stored version of
+// the model matches the version of the given model, after which the
+// version is incremented. If the model has been updated since it was
+// read an error with a code of CodeConflict is returned. UpdateModel
+// does not retry, callers that can safely reapply their changes should
+// re-read the model and try again.
 func (d *Database) UpdateModel(ctx context.Context, model *dbmodel.Model) (err error) {
 	const op = errors.Op("db.UpdateModel")
 	if err := d.ready(); err != nil {
@@ -106,9 +197,26 @@ func (d *Database) UpdateModel(ctx context.Context, model *dbmodel.Model) (err e
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
 
+	defer d.InvalidateModel(model)
+
 	db := d.DB.WithContext(ctx)
-	if err := db.Save(model).Error; err != nil {
-		return errors.E(op, dbError(err))
+	if model.ID == 0 {
+		if err := db.Save(model).Error; err != nil {
+			return errors.E(op, dbError(err))
+		}
+		return nil
+	}
+
+	version := model.Version
+	model.Version++
+	result := db.Model(model).Where("version = ?", version).Select("*").Updates(model)
+	if result.Error != nil {
+		model.Version = version
+		return errors.E(op, dbError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		model.Version = version
+		return errors.E(op, errors.CodeConflict, fmt.Sprintf("model %q has been modified concurrently", model.Name))
 	}
 	return nil
 }
@@ -124,6 +232,8 @@ func (d *Database) DeleteModel(ctx context.Context, model *dbmodel.Model) (err e
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
 
+	defer d.InvalidateModel(model)
+
 	db := d.DB.WithContext(ctx)
 	if err := db.Delete(model, model.ID).Error; err != nil {
 		return errors.E(op, dbError(err))
@@ -132,8 +242,10 @@ func (d *Database) DeleteModel(ctx context.Context, model *dbmodel.Model) (err e
 }
 
 // ForEachModel iterates through every model calling the given function
-// for each one. If the given function returns an error the iteration
-// will stop immediately and the error will be returned unmodified.
+// for each one. Models that are still being created, and so do not yet
+// have a UUID, are skipped. If the given function returns an error the
+// iteration will stop immediately and the error will be returned
+// unmodified.
 func (d *Database) ForEachModel(ctx context.Context, f func(m *dbmodel.Model) error) (err error) {
 	const op = errors.Op("db.ForEachModel")
 
@@ -147,7 +259,7 @@ func (d *Database) ForEachModel(ctx context.Context, f func(m *dbmodel.Model) er
 
 	db := d.DB.WithContext(ctx)
 	db = preloadModel("", db)
-	rows, err := db.Model(&dbmodel.Model{}).Rows()
+	rows, err := db.Model(&dbmodel.Model{}).Where("uuid IS NOT NULL").Rows()
 	if err != nil {
 		return errors.E(op, err)
 	}
@@ -233,6 +345,170 @@ func (d *Database) GetModelsByController(ctx context.Context, ctl dbmodel.Contro
 	return models, nil
 }
 
+// GetModelsByLife retrieves the models with any of the given life
+// values. The controller hosting each model is preloaded.
+func (d *Database) GetModelsByLife(ctx context.Context, life ...string) (_ []dbmodel.Model, err error) {
+	const op = errors.Op("db.GetModelsByLife")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var models []dbmodel.Model
+	db := d.DB.WithContext(ctx)
+	err = db.Preload("Controller").
+		Where("life IN ?", life).
+		Order("id asc").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return models, nil
+}
+
+// GetExpiredModels returns the models with one of the given life values
+// that have either passed their expiry time, or have had no connections
+// for longer than their idle timeout, at the given time. Models that have
+// never been connected to are considered idle since their creation. The
+// controller of each model is preloaded.
+func (d *Database) GetExpiredModels(ctx context.Context, now time.Time, life ...string) (_ []dbmodel.Model, err error) {
+	const op = errors.Op("db.GetExpiredModels")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var models []dbmodel.Model
+	db := d.DB.WithContext(ctx)
+	err = db.Preload("Controller").
+		Where("life IN ?", life).
+		Where(db.Where("expires_at <= ?", now).
+			// idle_timeout is stored in nanoseconds.
+			Or("idle_timeout > 0 AND COALESCE(last_connection, created_at) + idle_timeout / 1000 * INTERVAL '1 microsecond' <= ?", now)).
+		Order("id asc").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return models, nil
+}
+
+// UpdateModelLastConnection records the given time as the time of the
+// most recent connection to the given model. Unlike UpdateModel the
+// model's version is not checked or changed, as connections are recorded
+// independently of any other changes to the model.
+func (d *Database) UpdateModelLastConnection(ctx context.Context, model *dbmodel.Model, t time.Time) (err error) {
+	const op = errors.Op("db.UpdateModelLastConnection")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	defer d.InvalidateModel(model)
+
+	db := d.DB.WithContext(ctx)
+	err = db.Model(&dbmodel.Model{}).
+		Where("id = ?", model.ID).
+		UpdateColumn("last_connection", t).Error
+	if err != nil {
+		return errors.E(op, dbError(err))
+	}
+	model.LastConnection = sql.NullTime{Time: t, Valid: true}
+	return nil
+}
+
+// ListModelsByController retrieves a page of the models hosted on the
+// specified controller, ordered by UUID. The owner of each model is
+// preloaded.
+func (d *Database) ListModelsByController(ctx context.Context, ctl dbmodel.Controller, limit, offset int) (_ []dbmodel.Model, err error) {
+	const op = errors.Op("db.ListModelsByController")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var models []dbmodel.Model
+	db := d.DB.WithContext(ctx)
+	err = db.Preload("Owner").
+		Where("controller_id = ?", ctl.ID).
+		Order("uuid asc").
+		Limit(limit).
+		Offset(offset).
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return models, nil
+}
+
+// ListModels returns a page of all the models known to JIMM, ordered by
+// UUID.
+func (d *Database) ListModels(ctx context.Context, limit, offset int) (_ []dbmodel.Model, err error) {
+	const op = errors.Op("db.ListModels")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var models []dbmodel.Model
+	db := d.DB.WithContext(ctx)
+	db = preloadModel("", db)
+	err = db.Order("uuid asc").
+		Limit(limit).
+		Offset(offset).
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return models, nil
+}
+
+// ListModelsByUUID returns a page of the models with UUIDs in the given
+// modelUUIDs slice, ordered by UUID.
+func (d *Database) ListModelsByUUID(ctx context.Context, modelUUIDs []string, limit, offset int) (_ []dbmodel.Model, err error) {
+	const op = errors.Op("db.ListModelsByUUID")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var models []dbmodel.Model
+	db := d.DB.WithContext(ctx)
+	db = preloadModel("", db)
+	err = db.Where("uuid IN ?", modelUUIDs).
+		Order("uuid asc").
+		Limit(limit).
+		Offset(offset).
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return models, nil
+}
+
 // CountModelsByController counts the number of models hosted on a controller.
 func (d *Database) CountModelsByController(ctx context.Context, ctl dbmodel.Controller) (int, error) {
 	const op = errors.Op("db.CountModelsByController")
@@ -248,3 +524,123 @@ func (d *Database) CountModelsByController(ctx context.Context, ctl dbmodel.Cont
 	}
 	return int(count), nil
 }
+
+// CountLiveModelsByOwner counts the number of models owned by the
+// identity with the given name that are not dead, and whose creation has
+// not failed.
+func (d *Database) CountLiveModelsByOwner(ctx context.Context, ownerName string) (_ int, err error) {
+	const op = errors.Op("db.CountLiveModelsByOwner")
+
+	if err := d.ready(); err != nil {
+		return 0, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	var count int64
+	err = db.Model(&dbmodel.Model{}).
+		Where("owner_identity_name = ?", ownerName).
+		Where("life NOT IN ?", []string{"dead", "failed"}).
+		Count(&count).Error
+	if err != nil {
+		return 0, errors.E(op, dbError(err))
+	}
+	return int(count), nil
+}
+
+// CloudUsage holds the aggregate usage of the models hosted on a cloud.
+type CloudUsage struct {
+	// CloudName is the name of the cloud.
+	CloudName string
+
+	// Models is the number of models on the cloud.
+	Models int64
+
+	// Machines is the total number of machines in the models.
+	Machines int64
+
+	// Cores is the total number of cores in the models.
+	Cores int64
+
+	// Units is the total number of units in the models.
+	Units int64
+}
+
+// GetCloudUsage returns the aggregate usage of the models on each cloud
+// that hosts at least one model that is not dead, ordered by cloud name.
+// Models that are still being created are not counted. The counts are
+// calculated in a single query from the model counts stored by the
+// watcher.
+func (d *Database) GetCloudUsage(ctx context.Context) (_ []CloudUsage, err error) {
+	const op = errors.Op("db.GetCloudUsage")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var usage []CloudUsage
+	db := d.DB.WithContext(ctx)
+	err = db.Model(&dbmodel.Model{}).
+		Select(`cloud_regions.cloud_name AS cloud_name,
+COUNT(models.id) AS models,
+COALESCE(SUM(models.machines), 0) AS machines,
+COALESCE(SUM(models.cores), 0) AS cores,
+COALESCE(SUM(models.units), 0) AS units`).
+		Joins("JOIN cloud_regions ON models.cloud_region_id = cloud_regions.id").
+		Where("models.life <> ? AND models.uuid IS NOT NULL", "dead").
+		Group("cloud_regions.cloud_name").
+		Order("cloud_regions.cloud_name").
+		Scan(&usage).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return usage, nil
+}
+
+// ModelStatusCount holds the number of models with a particular life and
+// status.
+type ModelStatusCount struct {
+	// Life is the life of the models.
+	Life string
+
+	// Status is the status of the models.
+	Status string
+
+	// Count is the number of models with the life and status.
+	Count int64
+}
+
+// CountModelsByLifeAndStatus returns the number of models hosted on the
+// given controller grouped by life and status, ordered by life and then
+// status. Models that are still being created are not counted.
+func (d *Database) CountModelsByLifeAndStatus(ctx context.Context, ctl dbmodel.Controller) (_ []ModelStatusCount, err error) {
+	const op = errors.Op("db.CountModelsByLifeAndStatus")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var counts []ModelStatusCount
+	db := d.DB.WithContext(ctx)
+	err = db.Model(&dbmodel.Model{}).
+		Select("life, status_status AS status, COUNT(*) AS count").
+		Where("controller_id = ? AND uuid IS NOT NULL", ctl.ID).
+		Group("life, status_status").
+		Order("life, status_status").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return counts, nil
+}
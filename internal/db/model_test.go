@@ -6,6 +6,7 @@ import (
 	"context"
 	"database/sql"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -200,6 +201,227 @@ func (s *dbSuite) TestGetModel(c *qt.C) {
 	c.Assert(dbModel, jimmtest.DBObjectEquals, expectModel)
 }
 
+func (s *dbSuite) TestGetModelSummary(c *qt.C) {
+	ctx := context.Background()
+	env := initTestEnvironment(c, s.Database)
+
+	offer := dbmodel.ApplicationOffer{
+		UUID:            "00000000-0000-0000-0000-000000000001",
+		Name:            "offer1",
+		ModelID:         env.model.ID,
+		ApplicationName: "app-1",
+	}
+	err := s.Database.AddApplicationOffer(ctx, &offer)
+	c.Assert(err, qt.IsNil)
+
+	m := dbmodel.Model{
+		UUID: env.model.UUID,
+	}
+	err = s.Database.GetModelSummary(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	c.Check(m.Name, qt.Equals, env.model.Name)
+	c.Check(m.Owner.Name, qt.Equals, env.u.Name)
+	c.Check(m.Controller.UUID, qt.Equals, env.controller.UUID)
+	c.Check(m.CloudRegion.Cloud.Name, qt.Equals, env.cloud.Name)
+	c.Check(m.CloudCredential.Name, qt.Equals, env.cred.Name)
+	c.Check(m.Offers, qt.HasLen, 0)
+
+	m = dbmodel.Model{
+		UUID: sql.NullString{
+			String: "no such model",
+			Valid:  true,
+		},
+	}
+	err = s.Database.GetModelSummary(ctx, &m)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = s.Database.GetModelSummary(ctx, &dbmodel.Model{Name: env.model.Name})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+}
+
+func (s *dbSuite) TestGetModelCache(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.Equals, nil)
+
+	u, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Database.DB.Create(u).Error, qt.IsNil)
+
+	cloud := dbmodel.Cloud{
+		Name: "test-cloud",
+		Type: "test-provider",
+		Regions: []dbmodel.CloudRegion{{
+			Name: "test-region",
+		}},
+	}
+	c.Assert(s.Database.DB.Create(&cloud).Error, qt.IsNil)
+
+	cred := dbmodel.CloudCredential{
+		Name:     "test-cred",
+		Cloud:    cloud,
+		Owner:    *u,
+		AuthType: "empty",
+	}
+	c.Assert(s.Database.DB.Create(&cred).Error, qt.IsNil)
+
+	controller := dbmodel.Controller{
+		Name:        "test-controller",
+		UUID:        "00000000-0000-0000-0000-0000-0000000000001",
+		CloudName:   "test-cloud",
+		CloudRegion: "test-region",
+	}
+	err = s.Database.AddController(ctx, &controller)
+	c.Assert(err, qt.Equals, nil)
+
+	model := dbmodel.Model{
+		Name: "test-model-1",
+		UUID: sql.NullString{
+			String: "00000001-0000-0000-0000-0000-000000000001",
+			Valid:  true,
+		},
+		OwnerIdentityName: u.Name,
+		ControllerID:      controller.ID,
+		CloudRegionID:     cloud.Regions[0].ID,
+		CloudCredentialID: cred.ID,
+		Type:              "iaas",
+		Life:              state.Alive.String(),
+	}
+	err = s.Database.AddModel(ctx, &model)
+	c.Assert(err, qt.Equals, nil)
+
+	var queries int
+	err = s.Database.DB.Callback().Query().After("gorm:query").Register("test:count_models", func(tx *gorm.DB) {
+		if tx.Statement.Table == "models" {
+			queries++
+		}
+	})
+	c.Assert(err, qt.IsNil)
+	s.Database.ModelCache = db.NewModelCache(time.Minute)
+
+	m1 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m1)
+	c.Assert(err, qt.IsNil)
+	m2 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m2)
+	c.Assert(err, qt.IsNil)
+	c.Check(queries, qt.Equals, 1)
+	c.Check(m2, qt.DeepEquals, m1)
+	c.Check(m2.Controller.Name, qt.Equals, "test-controller")
+
+	// Lookups other than by UUID are not cached.
+	m3 := dbmodel.Model{OwnerIdentityName: u.Name, Name: model.Name}
+	err = s.Database.GetModel(ctx, &m3)
+	c.Assert(err, qt.IsNil)
+	c.Check(queries, qt.Equals, 2)
+
+	// Updating the model removes it from the cache.
+	m1.Life = state.Dying.String()
+	err = s.Database.UpdateModel(ctx, &m1)
+	c.Assert(err, qt.IsNil)
+	m4 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m4)
+	c.Assert(err, qt.IsNil)
+	c.Check(queries, qt.Equals, 3)
+	c.Check(m4.Life, qt.Equals, state.Dying.String())
+
+	// Models read within a transaction are always read from the database.
+	err = s.Database.Transaction(func(d *db.Database) error {
+		m := dbmodel.Model{UUID: model.UUID}
+		return d.GetModel(ctx, &m)
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(queries, qt.Equals, 4)
+
+	s.Database.InvalidateModel(&dbmodel.Model{UUID: model.UUID})
+	m5 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m5)
+	c.Assert(err, qt.IsNil)
+	c.Check(queries, qt.Equals, 5)
+
+	// Models in the cache are not changed by changes to the models
+	// returned from it.
+	m5.Controller.Name = "changed"
+	m5.Offers = append(m5.Offers, dbmodel.ApplicationOffer{Name: "changed"})
+	m7 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m7)
+	c.Assert(err, qt.IsNil)
+	c.Check(queries, qt.Equals, 5)
+	c.Check(m7.Controller.Name, qt.Equals, "test-controller")
+	c.Check(m7.Offers, qt.HasLen, 0)
+	m7.Controller.Name = "changed"
+	m8 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m8)
+	c.Assert(err, qt.IsNil)
+	c.Check(m8.Controller.Name, qt.Equals, "test-controller")
+
+	// Models updated within a transaction are removed from the cache once
+	// the transaction has finished.
+	err = s.Database.Transaction(func(d *db.Database) error {
+		m := dbmodel.Model{UUID: model.UUID}
+		if err := d.GetModel(ctx, &m); err != nil {
+			return err
+		}
+		m.Life = state.Dead.String()
+		if err := d.UpdateModel(ctx, &m); err != nil {
+			return err
+		}
+		m9 := dbmodel.Model{UUID: model.UUID}
+		if err := s.Database.GetModel(ctx, &m9); err != nil {
+			return err
+		}
+		c.Check(m9.Life, qt.Equals, state.Dying.String())
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(queries, qt.Equals, 6)
+	m10 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m10)
+	c.Assert(err, qt.IsNil)
+	c.Check(queries, qt.Equals, 7)
+	c.Check(m10.Life, qt.Equals, state.Dead.String())
+
+	// Offers are not cached, they are always read from the database.
+	offer := dbmodel.ApplicationOffer{
+		UUID:            "00000000-0000-0000-0000-000000000001",
+		Name:            "offer1",
+		ModelID:         model.ID,
+		ApplicationName: "app-1",
+	}
+	err = s.Database.AddApplicationOffer(ctx, &offer)
+	c.Assert(err, qt.IsNil)
+	m11 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m11)
+	c.Assert(err, qt.IsNil)
+	c.Check(queries, qt.Equals, 7)
+	c.Assert(m11.Offers, qt.HasLen, 1)
+	c.Check(m11.Offers[0].Name, qt.Equals, "offer1")
+	err = s.Database.DeleteApplicationOffer(ctx, &offer)
+	c.Assert(err, qt.IsNil)
+	m12 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m12)
+	c.Assert(err, qt.IsNil)
+	c.Check(queries, qt.Equals, 7)
+	c.Check(m12.Offers, qt.HasLen, 0)
+
+	// Updating the controller removes its models from the cache.
+	controller.PublicAddress = "controller.example.com:443"
+	err = s.Database.UpdateController(ctx, &controller)
+	c.Assert(err, qt.IsNil)
+	m13 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m13)
+	c.Assert(err, qt.IsNil)
+	c.Check(queries, qt.Equals, 8)
+	c.Check(m13.Controller.PublicAddress, qt.Equals, "controller.example.com:443")
+
+	// Deleting the model removes it from the cache.
+	err = s.Database.DeleteModel(ctx, &m13)
+	c.Assert(err, qt.IsNil)
+	m6 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m6)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
+
 func (s *dbSuite) TestUpdateModel(c *qt.C) {
 	err := s.Database.Migrate(context.Background(), true)
 	c.Assert(err, qt.Equals, nil)
@@ -267,6 +489,113 @@ func (s *dbSuite) TestUpdateModel(c *qt.C) {
 	c.Assert(dbModel, qt.DeepEquals, model)
 }
 
+func (s *dbSuite) TestUpdateModelConcurrent(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.Equals, nil)
+
+	i, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Database.DB.Create(i).Error, qt.IsNil)
+
+	cloud := dbmodel.Cloud{
+		Name: "test-cloud",
+		Type: "test-provider",
+		Regions: []dbmodel.CloudRegion{{
+			Name: "test-region",
+		}},
+	}
+	c.Assert(s.Database.DB.Create(&cloud).Error, qt.IsNil)
+
+	cred := dbmodel.CloudCredential{
+		Name:     "test-cred",
+		Cloud:    cloud,
+		Owner:    *i,
+		AuthType: "empty",
+	}
+	c.Assert(s.Database.DB.Create(&cred).Error, qt.IsNil)
+
+	controller := dbmodel.Controller{
+		Name:        "test-controller",
+		UUID:        "00000000-0000-0000-0000-0000-0000000000001",
+		CloudName:   "test-cloud",
+		CloudRegion: "test-region",
+	}
+	err = s.Database.AddController(ctx, &controller)
+	c.Assert(err, qt.Equals, nil)
+
+	model := dbmodel.Model{
+		Name: "test-model-1",
+		UUID: sql.NullString{
+			String: "00000001-0000-0000-0000-0000-000000000001",
+			Valid:  true,
+		},
+		OwnerIdentityName: i.Name,
+		ControllerID:      controller.ID,
+		CloudRegionID:     cloud.Regions[0].ID,
+		CloudCredentialID: cred.ID,
+		Type:              "iaas",
+		Life:              state.Alive.String(),
+	}
+	err = s.Database.AddModel(ctx, &model)
+	c.Assert(err, qt.Equals, nil)
+
+	// Read the model twice, as two concurrent writers would.
+	m1 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m1)
+	c.Assert(err, qt.Equals, nil)
+	m2 := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &m2)
+	c.Assert(err, qt.Equals, nil)
+
+	m1.Cores = 4
+	m2.Life = state.Dying.String()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, m := range []*dbmodel.Model{&m1, &m2} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.Database.UpdateModel(ctx, m)
+		}()
+	}
+	wg.Wait()
+
+	// Exactly one of the updates succeeds.
+	var winner, loser *dbmodel.Model
+	switch {
+	case errs[0] == nil && errors.ErrorCode(errs[1]) == errors.CodeConflict:
+		winner, loser = &m1, &m2
+	case errs[1] == nil && errors.ErrorCode(errs[0]) == errors.CodeConflict:
+		winner, loser = &m2, &m1
+	default:
+		c.Fatalf("unexpected update results: %v, %v", errs[0], errs[1])
+	}
+	c.Check(winner.Version, qt.Equals, uint(1))
+	c.Check(loser.Version, qt.Equals, uint(0))
+
+	// The loser re-reads the model and reapplies its change.
+	cores, life := loser.Cores, loser.Life
+	err = s.Database.GetModel(ctx, loser)
+	c.Assert(err, qt.Equals, nil)
+	if loser == &m1 {
+		loser.Cores = cores
+	} else {
+		loser.Life = life
+	}
+	err = s.Database.UpdateModel(ctx, loser)
+	c.Assert(err, qt.Equals, nil)
+
+	// Neither update has been lost.
+	dbModel := dbmodel.Model{UUID: model.UUID}
+	err = s.Database.GetModel(ctx, &dbModel)
+	c.Assert(err, qt.Equals, nil)
+	c.Check(dbModel.Cores, qt.Equals, int64(4))
+	c.Check(dbModel.Life, qt.Equals, state.Dying.String())
+	c.Check(dbModel.Version, qt.Equals, uint(2))
+}
+
 func TestDeleteModelUnconfiguredDatabase(t *testing.T) {
 	c := qt.New(t)
 
@@ -276,6 +605,24 @@ func TestDeleteModelUnconfiguredDatabase(t *testing.T) {
 	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
 }
 
+func TestGetExpiredModelsUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, err := d.GetExpiredModels(context.Background(), time.Now(), state.Alive.String())
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func TestUpdateModelLastConnectionUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.UpdateModelLastConnection(context.Background(), &dbmodel.Model{}, time.Now())
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
 func (s *dbSuite) TestDeleteModel(c *qt.C) {
 	err := s.Database.Migrate(context.Background(), true)
 	c.Assert(err, qt.Equals, nil)
@@ -535,6 +882,22 @@ func (s *dbSuite) TestForEachModel(c *qt.C) {
 	env := jimmtest.ParseEnvironment(c, testForEachModelEnv)
 	env.PopulateDB(c, *s.Database)
 
+	// A model that is still being created is skipped.
+	m := dbmodel.Model{
+		UUID: sql.NullString{String: "00000002-0000-0000-0000-000000000001", Valid: true},
+	}
+	err = s.Database.GetModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.AddModel(ctx, &dbmodel.Model{
+		Name:              "test-4",
+		OwnerIdentityName: "alice@canonical.com",
+		ControllerID:      m.ControllerID,
+		CloudRegionID:     m.CloudRegionID,
+		CloudCredentialID: m.CloudCredentialID,
+		Life:              "creating",
+	})
+	c.Assert(err, qt.IsNil)
+
 	testError := errors.E("test error")
 	err = s.Database.ForEachModel(ctx, func(m *dbmodel.Model) error {
 		return testError
@@ -788,3 +1151,74 @@ func (s *dbSuite) TestCountModelsByController(c *qt.C) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(count, qt.Equals, 3)
 }
+
+const testCountLiveModelsByOwnerEnv = `clouds:
+- name: test
+  type: test
+  regions:
+  - name: test-region
+cloud-credentials:
+- name: test-cred
+  cloud: test
+  owner: alice@canonical.com
+  type: empty
+controllers:
+- name: test
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test
+  region: test-region
+models:
+- name: test-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  owner: alice@canonical.com
+  cloud: test
+  region: test-region
+  cloud-credential: test-cred
+  controller: test
+  life: alive
+- name: test-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  owner: alice@canonical.com
+  cloud: test
+  region: test-region
+  cloud-credential: test-cred
+  controller: test
+  life: dying
+- name: test-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  owner: alice@canonical.com
+  cloud: test
+  region: test-region
+  cloud-credential: test-cred
+  controller: test
+  life: dead
+- name: test-4
+  uuid: 00000002-0000-0000-0000-000000000004
+  owner: bob@canonical.com
+  cloud: test
+  region: test-region
+  cloud-credential: test-cred
+  controller: test
+  life: alive
+`
+
+func (s *dbSuite) TestCountLiveModelsByOwner(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.Equals, nil)
+
+	env := jimmtest.ParseEnvironment(c, testCountLiveModelsByOwnerEnv)
+	env.PopulateDB(c, *s.Database)
+
+	count, err := s.Database.CountLiveModelsByOwner(ctx, "alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 2)
+
+	count, err = s.Database.CountLiveModelsByOwner(ctx, "bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 1)
+
+	count, err = s.Database.CountLiveModelsByOwner(ctx, "charlie@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 0)
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+// A ModelCache holds recently retrieved models, keyed by UUID, so that
+// repeated lookups of the same model within a short period do not each
+// query the database. The application offers hosted in a model are not
+// cached and are always read from the database. Models are removed from
+// the cache once changes to them or their controller made through the
+// Database have been committed, or when Database.InvalidateModel is
+// called. Changes made to a model's other associated objects, such as its
+// cloud credential, are only seen once the cached model expires.
+//
+// The cache is local to the process, changes made by other JIMM
+// processes sharing the database are only seen once the cached model
+// expires. A ModelCache is safe to use from multiple goroutines.
+type ModelCache struct {
+	ttl time.Duration
+
+	mu     sync.Mutex
+	models map[string]cachedModel
+
+	// generation is incremented whenever models are invalidated. A
+	// model read from the database is only cached if no models have
+	// been invalidated since the read started, otherwise the read may
+	// have returned the model as it was before the change.
+	generation uint64
+}
+
+type cachedModel struct {
+	model   dbmodel.Model
+	expires time.Time
+}
+
+// NewModelCache returns a ModelCache that holds each model for the
+// given length of time.
+func NewModelCache(ttl time.Duration) *ModelCache {
+	return &ModelCache{
+		ttl:    ttl,
+		models: make(map[string]cachedModel),
+	}
+}
+
+// get returns a copy of the cached model with the given UUID, if there is
+// one that has not expired. If there is not, the returned generation
+// should be passed to put along with the model read from the database.
+func (c *ModelCache) get(uuid string) (_ dbmodel.Model, generation uint64, _ bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cm, ok := c.models[uuid]
+	if !ok {
+		return dbmodel.Model{}, c.generation, false
+	}
+	if time.Now().After(cm.expires) {
+		delete(c.models, uuid)
+		return dbmodel.Model{}, c.generation, false
+	}
+	return copyModel(cm.model), c.generation, true
+}
+
+// put adds a copy of the given model to the cache, unless models have
+// been invalidated since the given generation was returned by get.
+func (c *ModelCache) put(m dbmodel.Model, generation uint64) {
+	if !m.UUID.Valid {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != generation {
+		return
+	}
+	c.models[m.UUID.String] = cachedModel{
+		model:   copyModel(m),
+		expires: time.Now().Add(c.ttl),
+	}
+}
+
+// invalidate removes every model for which the given function returns
+// true from the cache.
+func (c *ModelCache) invalidate(match func(*dbmodel.Model) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for uuid, cm := range c.models {
+		if match(&cm.model) {
+			delete(c.models, uuid)
+		}
+	}
+}
+
+// matchModel returns a function that matches the given model by UUID, or
+// by ID if it has no UUID. If the model has neither every model matches,
+// as the model cannot otherwise be identified.
+func matchModel(model *dbmodel.Model) func(*dbmodel.Model) bool {
+	switch {
+	case model.UUID.Valid:
+		return func(m *dbmodel.Model) bool { return m.UUID.String == model.UUID.String }
+	case model.ID != 0:
+		id := model.ID
+		return func(m *dbmodel.Model) bool { return m.ID == id }
+	default:
+		return func(*dbmodel.Model) bool { return true }
+	}
+}
+
+// copyModel returns a copy of the given model and the objects loaded with
+// it by GetModel, other than its offers, so that models in the cache never
+// share slices or maps with models held by callers. The values held in
+// maps are not themselves copied.
+func copyModel(m dbmodel.Model) dbmodel.Model {
+	c := m
+	c.Status.Data = maps.Clone(m.Status.Data)
+	c.Controller = copyController(m.Controller)
+	c.CloudRegion = copyCloudRegion(m.CloudRegion)
+	c.CloudCredential = copyCloudCredential(m.CloudCredential)
+	c.Offers = nil
+	return c
+}
+
+func copyController(ctl dbmodel.Controller) dbmodel.Controller {
+	c := ctl
+	if ctl.Addresses != nil {
+		c.Addresses = make(dbmodel.HostPorts, len(ctl.Addresses))
+		for i, hps := range ctl.Addresses {
+			c.Addresses[i] = slices.Clone(hps)
+		}
+	}
+	c.ModelDefaults = maps.Clone(ctl.ModelDefaults)
+	return c
+}
+
+func copyCloudRegion(cr dbmodel.CloudRegion) dbmodel.CloudRegion {
+	c := cr
+	c.Config = maps.Clone(cr.Config)
+	c.Cloud.AuthTypes = slices.Clone(cr.Cloud.AuthTypes)
+	c.Cloud.CACertificates = slices.Clone(cr.Cloud.CACertificates)
+	c.Cloud.Config = maps.Clone(cr.Cloud.Config)
+	return c
+}
+
+func copyCloudCredential(cred dbmodel.CloudCredential) dbmodel.CloudCredential {
+	c := cred
+	c.Attributes = maps.Clone(cred.Attributes)
+	c.Labels = maps.Clone(cred.Labels)
+	return c
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// AddModelCreationFailure stores the given failed model creation. If a
+// failure with the same handle is already stored an error with a code of
+// CodeAlreadyExists is returned.
+func (d *Database) AddModelCreationFailure(ctx context.Context, failure *dbmodel.ModelCreationFailure) (err error) {
+	const op = errors.Op("db.AddModelCreationFailure")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Create(failure).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetModelCreationFailure completes the given failed model creation from
+// the one stored with the same handle. If no failure is stored with the
+// handle an error with a code of CodeNotFound is returned.
+func (d *Database) GetModelCreationFailure(ctx context.Context, failure *dbmodel.ModelCreationFailure) (err error) {
+	const op = errors.Op("db.GetModelCreationFailure")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("handle = ?", failure.Handle).First(failure).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// DeleteModelCreationFailuresBefore deletes the failed model creations
+// recorded before the given time. It returns the number of failures
+// deleted.
+func (d *Database) DeleteModelCreationFailuresBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	const op = errors.Op("db.DeleteModelCreationFailuresBefore")
+	if err := d.ready(); err != nil {
+		return 0, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	tx := d.DB.WithContext(ctx).
+		Where("created_at < ?", before).
+		Delete(&dbmodel.ModelCreationFailure{})
+	if tx.Error != nil {
+		return 0, errors.E(op, dbError(tx.Error))
+	}
+	return tx.RowsAffected, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestAddModelCreationFailureUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+	var d db.Database
+
+	err := d.AddModelCreationFailure(context.Background(), &dbmodel.ModelCreationFailure{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestModelCreationFailures(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	f1 := dbmodel.ModelCreationFailure{
+		Handle:            "handle-1",
+		Requester:         "bob@canonical.com",
+		OwnerIdentityName: "alice@canonical.com",
+		ModelName:         "model-1",
+		Error:             "a silly error",
+	}
+	err = s.Database.AddModelCreationFailure(ctx, &f1)
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.AddModelCreationFailure(ctx, &dbmodel.ModelCreationFailure{Handle: "handle-1"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	f := dbmodel.ModelCreationFailure{Handle: "handle-1"}
+	err = s.Database.GetModelCreationFailure(ctx, &f)
+	c.Assert(err, qt.IsNil)
+	c.Check(f.Requester, qt.Equals, "bob@canonical.com")
+	c.Check(f.OwnerIdentityName, qt.Equals, "alice@canonical.com")
+	c.Check(f.ModelName, qt.Equals, "model-1")
+	c.Check(f.Error, qt.Equals, "a silly error")
+
+	err = s.Database.GetModelCreationFailure(ctx, &dbmodel.ModelCreationFailure{Handle: "handle-2"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	n, err := s.Database.DeleteModelCreationFailuresBefore(ctx, f.CreatedAt)
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, int64(0))
+
+	n, err = s.Database.DeleteModelCreationFailuresBefore(ctx, f.CreatedAt.Add(time.Second))
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, int64(1))
+
+	err = s.Database.GetModelCreationFailure(ctx, &f)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
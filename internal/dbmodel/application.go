@@ -0,0 +1,57 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"time"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+)
+
+// An Application is an application deployed in a model, as last reported
+// by the controller hosting the model.
+type Application struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// ModelID is the ID of the model containing the application.
+	ModelID uint
+
+	// Name is the name of the application.
+	Name string
+
+	// CharmURL is the URL of the charm deployed to the application.
+	CharmURL string `gorm:"column:charm_url"`
+
+	// Life holds the life status of the application.
+	Life string
+
+	// Status holds the status of the application.
+	Status Status `gorm:"embedded;embeddedPrefix:status_"`
+
+	// Exposed holds whether the application is exposed.
+	Exposed bool
+
+	// Subordinate holds whether the application is a subordinate.
+	Subordinate bool
+
+	// WorkloadVersion holds the version of the application's workload.
+	WorkloadVersion string
+
+	// Units is the number of units of the application.
+	Units int64
+}
+
+// FromJujuApplicationInfo updates the application from the given
+// jujuparams.ApplicationInfo. The number of units is not reported in
+// jujuparams.ApplicationInfo and is not changed.
+func (a *Application) FromJujuApplicationInfo(info jujuparams.ApplicationInfo) {
+	a.Name = info.Name
+	a.CharmURL = info.CharmURL
+	a.Life = string(info.Life)
+	a.Status.FromJujuStatusInfo(info.Status)
+	a.Exposed = info.Exposed
+	a.Subordinate = info.Subordinate
+	a.WorkloadVersion = info.WorkloadVersion
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 Canonical.
+
+package dbmodel_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/juju/juju/core/life"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+func TestApplicationFromJujuApplicationInfo(t *testing.T) {
+	c := qt.New(t)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	info := jujuparams.ApplicationInfo{
+		Name:     "app-1",
+		Exposed:  true,
+		CharmURL: "ch:amd64/app-1-3",
+		Life:     life.Alive,
+		Status: jujuparams.StatusInfo{
+			Current: "active",
+			Message: "ready",
+			Since:   &now,
+		},
+		WorkloadVersion: "1.2",
+	}
+
+	a := dbmodel.Application{
+		Units: 2,
+	}
+	a.FromJujuApplicationInfo(info)
+	c.Check(a, qt.DeepEquals, dbmodel.Application{
+		Name:     "app-1",
+		CharmURL: "ch:amd64/app-1-3",
+		Life:     "alive",
+		Status: dbmodel.Status{
+			Status: "active",
+			Info:   "ready",
+			Since:  sql.NullTime{Time: now, Valid: true},
+		},
+		Exposed:         true,
+		WorkloadVersion: "1.2",
+		Units:           2,
+	})
+}
@@ -51,6 +51,10 @@ type AuditLogEntry struct {
 
 	// Errors contains any errors from the controller.
 	Errors JSON
+
+	// PendingExport indicates that the entry has not yet been delivered
+	// to the configured audit sink.
+	PendingExport bool
 }
 
 // TableName overrides the table name gorm will use to find
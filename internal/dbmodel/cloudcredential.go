@@ -5,8 +5,11 @@ package dbmodel
 import (
 	"database/sql"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/juju/names/v5"
+	"go.uber.org/zap/zapcore"
 	"gorm.io/gorm"
 )
 
@@ -38,9 +41,18 @@ type CloudCredential struct {
 	// Attributes contains the attributes of the credential.
 	Attributes StringMap
 
+	// Labels contains free-form metadata describing the credential, for
+	// example its purpose or expiry. Labels are never sent to controllers
+	// and are not considered secret.
+	Labels StringMap
+
 	// Valid stores whether the cloud-credential is known to be valid.
 	Valid sql.NullBool
 
+	// LastCheckedAt stores the time the cloud-credential was last
+	// checked against the controllers using it.
+	LastCheckedAt sql.NullTime
+
 	// Models contains the models using this credential.
 	Models []Model
 }
@@ -69,3 +81,43 @@ func (c *CloudCredential) SetTag(t names.CloudCredentialTag) {
 func (c CloudCredential) Path() string {
 	return fmt.Sprintf("%s/%s/%s", c.CloudName, c.OwnerIdentityName, c.Name)
 }
+
+// MarshalLogObject implements zapcore.ObjectMarshaler. Credentials are
+// always logged through this method, even when logged with zap.Any, so
+// that the values of the credential attributes, which are secret, are
+// never written to the logs. Only the names of the attributes are
+// logged.
+func (c CloudCredential) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("path", c.Path())
+	enc.AddString("auth-type", c.AuthType)
+	enc.AddBool("attributes-in-vault", c.AttributesInVault)
+	keys := make([]string, 0, len(c.Attributes))
+	for k := range c.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return enc.AddArray("attributes", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+		for _, k := range keys {
+			enc.AppendString(k)
+		}
+		return nil
+	}))
+}
+
+// A PendingCredentialUpdate records that the latest update to a cloud
+// credential has not yet been applied on a controller to which the
+// credential is deployed.
+type PendingCredentialUpdate struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// CloudCredential is the credential that has been updated.
+	CloudCredentialID uint
+	CloudCredential   CloudCredential
+
+	// Controller is the controller that has not yet received the
+	// update.
+	ControllerID uint
+	Controller   Controller
+}
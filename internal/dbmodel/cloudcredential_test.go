@@ -7,6 +7,7 @@ import (
 
 	qt "github.com/frankban/quicktest"
 	"github.com/juju/names/v5"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 )
@@ -88,3 +89,28 @@ func TestCloudCredentialsCascadeOnDelete(t *testing.T) {
 	c.Assert(result.Error, qt.IsNil)
 	c.Assert(result.RowsAffected, qt.Equals, int64(0))
 }
+
+func TestCloudCredentialMarshalLogObject(t *testing.T) {
+	c := qt.New(t)
+
+	cred := dbmodel.CloudCredential{
+		Name:              "test-credential",
+		CloudName:         "test-cloud",
+		OwnerIdentityName: "test-user",
+		AuthType:          "userpass",
+		Attributes: dbmodel.StringMap{
+			"username": "test-username",
+			"password": "test-password",
+		},
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	err := cred.MarshalLogObject(enc)
+	c.Assert(err, qt.IsNil)
+	c.Check(enc.Fields, qt.DeepEquals, map[string]interface{}{
+		"path":                "test-cloud/test-user/test-credential",
+		"auth-type":           "userpass",
+		"attributes-in-vault": false,
+		"attributes":          []interface{}{"password", "username"},
+	})
+}
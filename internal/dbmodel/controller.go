@@ -66,6 +66,17 @@ type Controller struct {
 	// therefore no new models or clouds will be added to the controller.
 	Deprecated bool `gorm:"not null;default:FALSE"`
 
+	// Quiesced records whether this controller has been temporarily
+	// removed from model placement, for example during maintenance. No
+	// new models will be added to a quiesced controller, but its existing
+	// models continue to be served.
+	Quiesced bool `gorm:"not null;default:FALSE"`
+
+	// ControllerGroup is the name of the group the controller has been
+	// assigned to, for example the datacenter hosting it. Controllers
+	// that have not been assigned to a group have an empty group.
+	ControllerGroup string `gorm:"not null;default:''"`
+
 	// AgentVersion holds the string representation of the controller's
 	// agent version.
 	AgentVersion string
@@ -74,10 +85,20 @@ type Controller struct {
 	// listening.
 	Addresses HostPorts
 
+	// DialTimeout is the maximum time allowed to establish an API
+	// connection to the controller. If this is zero then the default
+	// timeout is used.
+	DialTimeout time.Duration
+
 	// UnavailableSince records the time that this controller became
 	// unavailable, if it has.
 	UnavailableSince sql.NullTime
 
+	// ModelDefaults holds the model-defaults that new models created in
+	// the controller's cloud region would inherit, as read from the
+	// controller when it was added.
+	ModelDefaults Map
+
 	// CloudRegions is the set of cloud-regions that are available on this
 	// controller.
 	CloudRegions []CloudRegionControllerPriority
@@ -134,6 +155,10 @@ func (c Controller) ToAPIControllerInfo() apiparams.ControllerInfo {
 		ci.Status = jujuparams.EntityStatus{
 			Status: "deprecated",
 		}
+	case c.Quiesced:
+		ci.Status = jujuparams.EntityStatus{
+			Status: "quiesced",
+		}
 	default:
 		ci.Status = jujuparams.EntityStatus{
 			Status: "available",
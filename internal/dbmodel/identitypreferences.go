@@ -0,0 +1,26 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import "time"
+
+// IdentityPreferences holds the cloud, region and credential an identity
+// prefers new models to be created with when they are not specified.
+type IdentityPreferences struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	IdentityName string   `gorm:"uniqueIndex"`
+	Identity     Identity `gorm:"foreignKey:IdentityName;references:Name"`
+
+	// CloudName is the name of the preferred cloud.
+	CloudName string
+
+	// CloudRegion is the name of the preferred region of the cloud.
+	CloudRegion string
+
+	// CloudCredentialName is the name of the identity's preferred
+	// credential for the cloud.
+	CloudCredentialName string
+}
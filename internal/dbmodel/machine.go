@@ -0,0 +1,90 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+)
+
+// A Machine is a machine in a model, as last reported by the controller
+// hosting the model.
+type Machine struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// ModelID is the ID of the model containing the machine.
+	ModelID uint
+
+	// MachineID is the ID of the machine within the model.
+	MachineID string
+
+	// InstanceID is the ID of the instance provisioned for the machine,
+	// this is empty until the machine has been provisioned.
+	InstanceID string
+
+	// Hardware holds the hardware characteristics of the machine's
+	// instance.
+	Hardware MachineHardware `gorm:"embedded;embeddedPrefix:hardware_"`
+
+	// Life holds the life status of the machine.
+	Life string
+
+	// Status holds the status of the machine agent.
+	Status Status `gorm:"embedded;embeddedPrefix:status_"`
+
+	// InstanceStatus holds the status of the machine's instance.
+	InstanceStatus Status `gorm:"embedded;embeddedPrefix:instance_status_"`
+
+	// Base holds the base the machine is running.
+	Base string
+}
+
+// MachineHardware holds the hardware characteristics of a machine. Each
+// characteristic is only valid if it has been reported by the controller.
+type MachineHardware struct {
+	Arch             sql.NullString
+	Mem              sql.NullInt64
+	RootDisk         sql.NullInt64
+	CPUCores         sql.NullInt64 `gorm:"column:cpu_cores"`
+	CPUPower         sql.NullInt64 `gorm:"column:cpu_power"`
+	AvailabilityZone sql.NullString
+}
+
+// FromJujuMachineInfo updates the machine from the given
+// jujuparams.MachineInfo.
+func (m *Machine) FromJujuMachineInfo(info jujuparams.MachineInfo) {
+	m.MachineID = info.Id
+	m.InstanceID = info.InstanceId
+	m.Life = string(info.Life)
+	m.Status.FromJujuStatusInfo(info.AgentStatus)
+	m.InstanceStatus.FromJujuStatusInfo(info.InstanceStatus)
+	m.Base = info.Base
+	m.Hardware = MachineHardware{}
+	if hc := info.HardwareCharacteristics; hc != nil {
+		m.Hardware.Arch = nullString(hc.Arch)
+		m.Hardware.Mem = nullInt64(hc.Mem)
+		m.Hardware.RootDisk = nullInt64(hc.RootDisk)
+		m.Hardware.CPUCores = nullInt64(hc.CpuCores)
+		m.Hardware.CPUPower = nullInt64(hc.CpuPower)
+		m.Hardware.AvailabilityZone = nullString(hc.AvailabilityZone)
+	}
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func nullInt64(n *uint64) sql.NullInt64 {
+	if n == nil {
+		return sql.NullInt64{}
+	}
+	//nolint:gosec // We expect hardware characteristics to fit into int64.
+	return sql.NullInt64{Int64: int64(*n), Valid: true}
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical.
+
+package dbmodel_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/life"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+func TestMachineFromJujuMachineInfo(t *testing.T) {
+	c := qt.New(t)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	arch := "amd64"
+	var mem, cores uint64 = 8192, 4
+	info := jujuparams.MachineInfo{
+		Id:         "0",
+		InstanceId: "inst-0",
+		AgentStatus: jujuparams.StatusInfo{
+			Current: "started",
+			Since:   &now,
+		},
+		InstanceStatus: jujuparams.StatusInfo{
+			Current: "running",
+			Message: "ok",
+		},
+		Life: life.Alive,
+		Base: "ubuntu@22.04",
+		HardwareCharacteristics: &instance.HardwareCharacteristics{
+			Arch:     &arch,
+			Mem:      &mem,
+			CpuCores: &cores,
+		},
+	}
+
+	var m dbmodel.Machine
+	m.FromJujuMachineInfo(info)
+	c.Check(m, qt.DeepEquals, dbmodel.Machine{
+		MachineID:  "0",
+		InstanceID: "inst-0",
+		Hardware: dbmodel.MachineHardware{
+			Arch:     sql.NullString{String: "amd64", Valid: true},
+			Mem:      sql.NullInt64{Int64: 8192, Valid: true},
+			CPUCores: sql.NullInt64{Int64: 4, Valid: true},
+		},
+		Life: "alive",
+		Status: dbmodel.Status{
+			Status: "started",
+			Since:  sql.NullTime{Time: now, Valid: true},
+		},
+		InstanceStatus: dbmodel.Status{
+			Status: "running",
+			Info:   "ok",
+		},
+		Base: "ubuntu@22.04",
+	})
+
+	// Hardware that is no longer reported is cleared.
+	info.HardwareCharacteristics = nil
+	m.FromJujuMachineInfo(info)
+	c.Check(m.Hardware, qt.DeepEquals, dbmodel.MachineHardware{})
+}
+
+func TestMachineUniqueConstraint(t *testing.T) {
+	c := qt.New(t)
+	db := gormDB(c)
+	cl, cred, ctl, u := initModelEnv(c, db)
+
+	m := dbmodel.Model{
+		Name: "test-model",
+		UUID: sql.NullString{
+			String: "00000001-0000-0000-0000-0000-000000000001",
+			Valid:  true,
+		},
+		Owner:           u,
+		Controller:      ctl,
+		CloudRegion:     cl.Regions[0],
+		CloudCredential: cred,
+		Type:            "iaas",
+		IsController:    false,
+		DefaultSeries:   "warty",
+		Life:            state.Alive.String(),
+	}
+	c.Assert(db.Create(&m).Error, qt.IsNil)
+
+	machine := dbmodel.Machine{
+		ModelID:   m.ID,
+		MachineID: "0",
+		Life:      state.Alive.String(),
+	}
+	c.Assert(db.Create(&machine).Error, qt.IsNil)
+	machine.ID = 0
+	c.Assert(db.Create(&machine).Error, qt.ErrorMatches, `ERROR: duplicate key value violates unique constraint "machines_model_id_machine_id_key" .*`)
+}
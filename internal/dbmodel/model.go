@@ -23,6 +23,10 @@ type Model struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
+	// Version is incremented every time the model is updated, it is
+	// used to detect concurrent updates to the model.
+	Version uint `gorm:"not null;default:0"`
+
 	// Name is the name of the model.
 	Name string `gorm:"uniqueIndex:unique_model_names;not null"`
 
@@ -42,6 +46,11 @@ type Model struct {
 	// This is only filled if the new controller is within JIMM.
 	MigrationControllerID sql.NullInt32
 
+	// PinnedControllerID is the controller the model has been pinned to.
+	// A pinned model must not be moved to another controller until it
+	// has been unpinned.
+	PinnedControllerID sql.NullInt32
+
 	// CloudRegion is the cloud-region hosting the model.
 	CloudRegionID uint
 	CloudRegion   CloudRegion
@@ -77,6 +86,26 @@ type Model struct {
 	// Units contains the count of machines in the model.
 	Units int64
 
+	// ExpiresAt, if valid, is the time after which the model is
+	// automatically destroyed.
+	ExpiresAt sql.NullTime
+
+	// IdleTimeout, if non-zero, is the length of time the model may go
+	// without any connections before it is automatically destroyed.
+	IdleTimeout time.Duration
+
+	// LastConnection is the time of the most recent connection proxied
+	// to the model, if there has been one.
+	LastConnection sql.NullTime
+
+	// CreationHandle, if valid, identifies the asynchronous creation
+	// that created the model.
+	CreationHandle sql.NullString
+
+	// CreationRequester holds the name of the identity that started the
+	// asynchronous creation of the model.
+	CreationRequester string
+
 	// Offers are the ApplicationOffers attached to the model.
 	Offers []ApplicationOffer
 }
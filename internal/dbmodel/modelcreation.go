@@ -0,0 +1,30 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import "time"
+
+// A ModelCreationFailure records an asynchronous model creation that
+// failed. The model being created is removed when its creation fails, the
+// failure is kept so that it can be reported to the user that started
+// the creation.
+type ModelCreationFailure struct {
+	// Handle identifies the failed model creation.
+	Handle string `gorm:"primaryKey"`
+
+	CreatedAt time.Time
+
+	// Requester holds the name of the identity that started the model
+	// creation.
+	Requester string
+
+	// OwnerIdentityName holds the name of the identity that would have
+	// owned the model.
+	OwnerIdentityName string
+
+	// ModelName holds the name of the model that could not be created.
+	ModelName string
+
+	// Error holds the reason the model could not be created.
+	Error string
+}
@@ -0,0 +1,4 @@
+-- 1_13.sql is a migration that adds a per-controller dial timeout.
+ALTER TABLE controllers ADD COLUMN dial_timeout BIGINT NOT NULL DEFAULT 0;
+
+UPDATE versions SET major=1, minor=13 WHERE component='jimmdb';
@@ -0,0 +1,4 @@
+-- 1_15.sql is a migration that allows models to be pinned to a controller.
+ALTER TABLE models ADD COLUMN pinned_controller_id INTEGER REFERENCES controllers (id);
+
+UPDATE versions SET major=1, minor=15 WHERE component='jimmdb';
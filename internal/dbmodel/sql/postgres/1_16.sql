@@ -0,0 +1,4 @@
+-- 1_16.sql is a migration that adds a version to models for optimistic locking.
+ALTER TABLE models ADD COLUMN version BIGINT NOT NULL DEFAULT 0;
+
+UPDATE versions SET major=1, minor=16 WHERE component='jimmdb';
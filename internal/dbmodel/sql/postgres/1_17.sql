@@ -0,0 +1,4 @@
+-- 1_17.sql is a migration that records when cloud credentials were last checked.
+ALTER TABLE cloud_credentials ADD COLUMN last_checked_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE versions SET major=1, minor=17 WHERE component='jimmdb';
@@ -0,0 +1,5 @@
+-- 1_18.sql is a migration that tracks audit log entries waiting to be exported.
+ALTER TABLE audit_log ADD COLUMN pending_export BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS idx_audit_log_pending_export ON audit_log (id) WHERE pending_export;
+
+UPDATE versions SET major=1, minor=18 WHERE component='jimmdb';
@@ -0,0 +1,4 @@
+-- 1_19.sql is a migration that allows controllers to be quiesced.
+ALTER TABLE controllers ADD COLUMN quiesced BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE versions SET major=1, minor=19 WHERE component='jimmdb';
@@ -0,0 +1,14 @@
+-- 1_20.sql is a migration that adds a table holding the cloud, region and
+-- credential identities prefer to create models with.
+
+CREATE TABLE IF NOT EXISTS identity_preferences (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	identity_name TEXT NOT NULL UNIQUE REFERENCES identities (name) ON DELETE CASCADE,
+	cloud_name TEXT NOT NULL DEFAULT '',
+	cloud_region TEXT NOT NULL DEFAULT '',
+	cloud_credential_name TEXT NOT NULL DEFAULT ''
+);
+
+UPDATE versions SET major=1, minor=20 WHERE component='jimmdb';
@@ -0,0 +1,5 @@
+-- 1_21.sql is a migration that allows controllers to be organised into
+-- groups, for example by datacenter.
+ALTER TABLE controllers ADD COLUMN controller_group TEXT NOT NULL DEFAULT '';
+
+UPDATE versions SET major=1, minor=21 WHERE component='jimmdb';
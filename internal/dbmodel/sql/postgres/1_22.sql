@@ -0,0 +1,5 @@
+-- 1_22.sql is a migration that stores the model-defaults of each
+-- controller, as read from the controller when it was added.
+ALTER TABLE controllers ADD COLUMN model_defaults BYTEA;
+
+UPDATE versions SET major=1, minor=22 WHERE component='jimmdb';
@@ -0,0 +1,7 @@
+-- 1_23.sql is a migration that allows models to be automatically
+-- destroyed once they expire or have been idle for too long.
+ALTER TABLE models ADD COLUMN expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE models ADD COLUMN idle_timeout BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE models ADD COLUMN last_connection TIMESTAMP WITH TIME ZONE;
+
+UPDATE versions SET major=1, minor=23 WHERE component='jimmdb';
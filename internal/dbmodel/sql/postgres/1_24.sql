@@ -0,0 +1,7 @@
+-- 1_24.sql is a migration that stores the progress of asynchronous
+-- model creations on the models being created.
+ALTER TABLE models ADD COLUMN creation_handle TEXT UNIQUE;
+ALTER TABLE models ADD COLUMN creation_requester TEXT NOT NULL DEFAULT '';
+ALTER TABLE models ADD COLUMN creation_error TEXT NOT NULL DEFAULT '';
+
+UPDATE versions SET major=1, minor=24 WHERE component='jimmdb';
@@ -0,0 +1,32 @@
+-- 1_25.sql is a migration that adds a table holding the machines in each
+-- model, as reported by the controller hosting the model.
+
+CREATE TABLE IF NOT EXISTS machines (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	model_id BIGINT NOT NULL REFERENCES models (id) ON DELETE CASCADE,
+	machine_id TEXT NOT NULL,
+	instance_id TEXT NOT NULL,
+	hardware_arch TEXT,
+	hardware_mem BIGINT,
+	hardware_root_disk BIGINT,
+	hardware_cpu_cores BIGINT,
+	hardware_cpu_power BIGINT,
+	hardware_availability_zone TEXT,
+	life TEXT NOT NULL,
+	status_status TEXT NOT NULL,
+	status_info TEXT NOT NULL,
+	status_data BYTEA,
+	status_since TIMESTAMP WITH TIME ZONE,
+	status_version TEXT NOT NULL,
+	instance_status_status TEXT NOT NULL,
+	instance_status_info TEXT NOT NULL,
+	instance_status_data BYTEA,
+	instance_status_since TIMESTAMP WITH TIME ZONE,
+	instance_status_version TEXT NOT NULL,
+	base TEXT NOT NULL,
+	UNIQUE (model_id, machine_id)
+);
+
+UPDATE versions SET major=1, minor=25 WHERE component='jimmdb';
@@ -0,0 +1,13 @@
+-- 1_26.sql is a migration that adds a table recording the controllers
+-- to which an update to a cloud credential has not yet been applied.
+
+CREATE TABLE IF NOT EXISTS pending_credential_updates (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	cloud_credential_id BIGINT NOT NULL REFERENCES cloud_credentials (id) ON DELETE CASCADE,
+	controller_id INTEGER NOT NULL REFERENCES controllers (id) ON DELETE CASCADE,
+	UNIQUE (cloud_credential_id, controller_id)
+);
+
+UPDATE versions SET major=1, minor=26 WHERE component='jimmdb';
@@ -0,0 +1,24 @@
+-- 1_27.sql is a migration that adds a table holding the applications in
+-- each model, as reported by the controller hosting the model.
+
+CREATE TABLE IF NOT EXISTS applications (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	model_id BIGINT NOT NULL REFERENCES models (id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	charm_url TEXT NOT NULL,
+	life TEXT NOT NULL,
+	status_status TEXT NOT NULL,
+	status_info TEXT NOT NULL,
+	status_data BYTEA,
+	status_since TIMESTAMP WITH TIME ZONE,
+	status_version TEXT NOT NULL,
+	exposed BOOLEAN NOT NULL,
+	subordinate BOOLEAN NOT NULL,
+	workload_version TEXT NOT NULL,
+	units BIGINT NOT NULL DEFAULT 0,
+	UNIQUE (model_id, name)
+);
+
+UPDATE versions SET major=1, minor=27 WHERE component='jimmdb';
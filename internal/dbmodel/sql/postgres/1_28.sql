@@ -0,0 +1,17 @@
+-- 1_28.sql is a migration that records failed asynchronous model
+-- creations in their own table, so that the models whose creation failed
+-- can be removed.
+
+CREATE TABLE IF NOT EXISTS model_creation_failures (
+	handle TEXT PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	requester TEXT NOT NULL,
+	owner_identity_name TEXT NOT NULL,
+	model_name TEXT NOT NULL,
+	error TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_model_creation_failures_created_at ON model_creation_failures (created_at);
+
+ALTER TABLE models DROP COLUMN creation_error;
+
+UPDATE versions SET major=1, minor=28 WHERE component='jimmdb';
@@ -108,8 +108,11 @@ type Code string
 const (
 	CodeAlreadyExists                Code = jujuparams.CodeAlreadyExists
 	CodeBadRequest                   Code = jujuparams.CodeBadRequest
+	CodeCloudNotFound                Code = "cloud not found"
 	CodeCloudRegionRequired          Code = jujuparams.CodeCloudRegionRequired
+	CodeConflict                     Code = "conflict"
 	CodeConnectionFailed             Code = "connection failed"
+	CodeControllerNotFound           Code = "controller not found"
 	CodeDatabaseLocked               Code = "database locked"
 	CodeForbidden                    Code = jujuparams.CodeForbidden
 	CodeIncompatibleClouds           Code = jujuparams.CodeIncompatibleClouds
@@ -117,6 +120,8 @@ const (
 	CodeNotFound                     Code = jujuparams.CodeNotFound
 	CodeNotImplemented               Code = jujuparams.CodeNotImplemented
 	CodeNotSupported                 Code = jujuparams.CodeNotSupported
+	CodeOperationBlocked             Code = jujuparams.CodeOperationBlocked
+	CodeQuotaExceeded                Code = jujuparams.CodeQuotaLimitExceeded
 	CodeRedirect                     Code = jujuparams.CodeRedirect
 	CodeServerConfiguration          Code = "server configuration"
 	CodeStillAlive                   Code = apiparams.CodeStillAlive
@@ -127,6 +132,7 @@ const (
 	CodeFailedToResolveTupleResource Code = "failed resolve resource"
 	CodeOpenFGARequestFailed         Code = "failed request to OpenFGA"
 	CodeJWKSRetrievalFailed          Code = "jwks retrieval failure"
+	CodeLoginLocked                  Code = "login locked"
 )
 
 // ErrorCode returns the error code from the given error.
@@ -137,3 +143,15 @@ func ErrorCode(err error) Code {
 	}
 	return e.Code
 }
+
+// IsNotFound reports whether the given error has a code indicating that
+// something could not be found. This includes the generic CodeNotFound
+// as well as the codes identifying the specific type of entity that was
+// missing.
+func IsNotFound(err error) bool {
+	switch ErrorCode(err) {
+	case CodeNotFound, CodeModelNotFound, CodeControllerNotFound, CodeCloudNotFound:
+		return true
+	}
+	return false
+}
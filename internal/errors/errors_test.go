@@ -35,3 +35,20 @@ func TestE(t *testing.T) {
 	c.Check(err, qt.ErrorMatches, `an error happened`)
 	c.Check(errors.ErrorCode(err), qt.Equals, code)
 }
+
+func TestIsNotFound(t *testing.T) {
+	c := qt.New(t)
+
+	for _, code := range []errors.Code{
+		errors.CodeNotFound,
+		errors.CodeModelNotFound,
+		errors.CodeControllerNotFound,
+		errors.CodeCloudNotFound,
+	} {
+		err := errors.E(errors.Op("test.op"), code, "not here")
+		c.Check(errors.IsNotFound(err), qt.IsTrue, qt.Commentf("code %q", code))
+		c.Check(errors.IsNotFound(errors.E(errors.Op("test.op2"), err)), qt.IsTrue, qt.Commentf("code %q", code))
+	}
+	c.Check(errors.IsNotFound(errors.E(errors.CodeUnauthorized, "unauthorized")), qt.IsFalse)
+	c.Check(errors.IsNotFound(nil), qt.IsFalse)
+}
@@ -117,6 +117,18 @@ func ToCloudRelation(accessLevel string) (openfga.Relation, error) {
 	}
 }
 
+// ToCloudCredentialRelation returns a valid relation for the cloud credential.
+func ToCloudCredentialRelation(accessLevel string) (openfga.Relation, error) {
+	switch accessLevel {
+	case "admin":
+		return ofganames.AdministratorRelation, nil
+	case "read":
+		return ofganames.ReaderRelation, nil
+	default:
+		return ofganames.NoRelation, errors.E("unknown cloud credential access")
+	}
+}
+
 // ToModelRelation returns a valid relation for the model.
 func ToModelRelation(accessLevel string) (openfga.Relation, error) {
 	switch accessLevel {
@@ -167,14 +179,24 @@ type JWTService interface {
 	NewJWT(context.Context, jimmjwx.JWTParams) ([]byte, error)
 }
 
+// A JWTClaimsSource provides additional claims to include in the JWTs
+// issued for a user, for example data held in the metadata of the
+// user's groups.
+type JWTClaimsSource interface {
+	JWTClaims(context.Context, *openfga.User) (map[string]interface{}, error)
+}
+
 // JWTGenerator provides the necessary state and methods to authorize a user and generate JWT tokens.
 type JWTGenerator struct {
 	database      JWTGeneratorDatabase
 	accessChecker JWTGeneratorAccessChecker
 	jwtService    JWTService
+	claimsSources []JWTClaimsSource
+	loginAttempts *LoginAttemptTracker
 
 	mu             sync.Mutex
 	accessMapCache map[string]string
+	claimsCache    map[string]interface{}
 	mt             names.ModelTag
 	ct             names.ControllerTag
 	user           *openfga.User
@@ -190,6 +212,21 @@ func NewJWTGenerator(database JWTGeneratorDatabase, accessChecker JWTGeneratorAc
 	}
 }
 
+// AddClaimsSources adds sources of additional claims to include in the
+// generated JWTs. The claims are collected when the user logs in, if
+// more than one source provides the same claim the last one wins.
+func (auth *JWTGenerator) AddClaimsSources(sources ...JWTClaimsSource) {
+	auth.claimsSources = append(auth.claimsSources, sources...)
+}
+
+// SetLoginAttemptTracker sets the tracker used to count the logins
+// rejected because the user has no access to the model or controller,
+// and to reject logins by users that have failed to log in too many
+// times. If the tracker is nil logins are never rejected.
+func (auth *JWTGenerator) SetLoginAttemptTracker(t *LoginAttemptTracker) {
+	auth.loginAttempts = t
+}
+
 // SetTags implements TokenGenerator
 func (auth *JWTGenerator) SetTags(mt names.ModelTag, ct names.ControllerTag) {
 	auth.mt = mt
@@ -198,6 +235,8 @@ func (auth *JWTGenerator) SetTags(mt names.ModelTag, ct names.ControllerTag) {
 
 // SetTags implements TokenGenerator
 func (auth *JWTGenerator) GetUser() names.UserTag {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
 	if auth.user != nil {
 		return auth.user.ResourceTag()
 	}
@@ -216,6 +255,10 @@ func (auth *JWTGenerator) MakeLoginToken(ctx context.Context, user *openfga.User
 	if user == nil {
 		return nil, errors.E(op, "user not specified")
 	}
+	if err := auth.loginAttempts.Check(user.Name); err != nil {
+		zapctx.Warn(ctx, "login rejected", zap.String("user", user.Name), zap.Error(err))
+		return nil, errors.E(op, err)
+	}
 	auth.user = user
 
 	// Recreate the accessMapCache to prevent leaking permissions across multiple login requests.
@@ -229,6 +272,7 @@ func (auth *JWTGenerator) MakeLoginToken(ctx context.Context, user *openfga.User
 	modelAccess, authErr = auth.accessChecker.GetUserModelAccess(ctx, auth.user, auth.mt)
 	if authErr != nil {
 		zapctx.Error(ctx, "model access check failed", zap.Error(authErr))
+		auth.loginAttempts.Failed(auth.user.Name)
 		return nil, authErr
 	}
 	auth.accessMapCache[auth.mt.String()] = modelAccess
@@ -239,6 +283,7 @@ func (auth *JWTGenerator) MakeLoginToken(ctx context.Context, user *openfga.User
 	var controllerAccess string
 	controllerAccess, authErr = auth.accessChecker.GetUserControllerAccess(ctx, auth.user, auth.ct)
 	if authErr != nil {
+		auth.loginAttempts.Failed(auth.user.Name)
 		return nil, authErr
 	}
 	auth.accessMapCache[auth.ct.String()] = controllerAccess
@@ -263,11 +308,32 @@ func (auth *JWTGenerator) MakeLoginToken(ctx context.Context, user *openfga.User
 		auth.accessMapCache[cloudTag.String()] = accessLevel
 	}
 
-	return auth.jwtService.NewJWT(ctx, jimmjwx.JWTParams{
+	auth.claimsCache = nil
+	for _, source := range auth.claimsSources {
+		claims, err := source.JWTClaims(ctx, auth.user)
+		if err != nil {
+			zapctx.Error(ctx, "failed to get additional claims", zap.Error(err))
+			return nil, errors.E(op, "failed to get additional claims", err)
+		}
+		for k, v := range claims {
+			if auth.claimsCache == nil {
+				auth.claimsCache = make(map[string]interface{})
+			}
+			auth.claimsCache[k] = v
+		}
+	}
+
+	jwt, err := auth.jwtService.NewJWT(ctx, jimmjwx.JWTParams{
 		Controller: auth.ct.Id(),
 		User:       auth.user.Tag().String(),
 		Access:     auth.accessMapCache,
+		Claims:     auth.claimsCache,
 	})
+	if err != nil {
+		return nil, err
+	}
+	auth.loginAttempts.Succeeded(auth.user.Name)
+	return jwt, nil
 }
 
 // MakeToken assumes MakeLoginToken has already been called and checks the permissions
@@ -297,6 +363,7 @@ func (auth *JWTGenerator) MakeToken(ctx context.Context, permissionMap map[strin
 		Controller: auth.ct.Id(),
 		User:       auth.user.Tag().String(),
 		Access:     auth.accessMapCache,
+		Claims:     auth.claimsCache,
 	})
 	if err != nil {
 		return nil, err
@@ -383,6 +450,72 @@ func (j *JIMM) RevokeAuditLogAccess(ctx context.Context, user *openfga.User, tar
 	return nil
 }
 
+// GrantGroupAuditLogAccess grants audit log access to the members of the
+// target group. Members gain access for as long as they remain in the
+// group.
+func (j *JIMM) GrantGroupAuditLogAccess(ctx context.Context, user *openfga.User, targetGroupTag jimmnames.GroupTag) error {
+	const op = errors.Op("jimm.GrantGroupAuditLogAccess")
+
+	access := user.GetControllerAccess(ctx, j.ResourceTag())
+	if access != ofganames.AdministratorRelation {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	group := &dbmodel.GroupEntry{UUID: targetGroupTag.Id()}
+	err := j.Database.GetGroup(ctx, group)
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	err = j.OpenFGAClient.SetGroupAuditLogViewerAccess(ctx, group.ResourceTag(), j.ResourceTag())
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// RevokeGroupAuditLogAccess revokes audit log access from the members of
+// the target group. Members that have been granted access directly keep
+// their access.
+func (j *JIMM) RevokeGroupAuditLogAccess(ctx context.Context, user *openfga.User, targetGroupTag jimmnames.GroupTag) error {
+	const op = errors.Op("jimm.RevokeGroupAuditLogAccess")
+
+	access := user.GetControllerAccess(ctx, j.ResourceTag())
+	if access != ofganames.AdministratorRelation {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	group := &dbmodel.GroupEntry{UUID: targetGroupTag.Id()}
+	err := j.Database.GetGroup(ctx, group)
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	err = j.OpenFGAClient.UnsetGroupAuditLogViewerAccess(ctx, group.ResourceTag(), j.ResourceTag())
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// RevokeAllUserAccess removes all relations in which the target user is
+// the object, revoking any access the user has been granted, including
+// group memberships. Only JIMM administrators may call this method. It
+// returns the number of relations removed.
+func (j *JIMM) RevokeAllUserAccess(ctx context.Context, user *openfga.User, target names.UserTag) (int, error) {
+	const op = errors.Op("jimm.RevokeAllUserAccess")
+
+	if !user.JimmAdmin {
+		return 0, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	removed, err := j.OpenFGAClient.RemoveUserRelations(ctx, target)
+	if err != nil {
+		return removed, errors.E(op, err)
+	}
+	return removed, nil
+}
+
 // ToJAASTag converts a tag used in OpenFGA authorization model to a
 // tag used in JAAS.
 func (j *JIMM) ToJAASTag(ctx context.Context, tag *ofganames.Tag, resolveUUIDs bool) (string, error) {
@@ -563,18 +696,39 @@ func (t *tagResolver) modelTag(ctx context.Context, db *db.Database) (*ofga.Enti
 		return ofganames.ConvertTagWithRelation(names.NewModelTag(t.resourceUUID), t.relation), nil
 	}
 
+	// The model may be qualified by the controller hosting it, either by
+	// name or by UUID, i.e. <controller>:<model-owner>/<model-name>.
+	modelPath := t.trailer
+	var controller *dbmodel.Controller
+	if controllerKey, path, ok := strings.Cut(t.trailer, ":"); ok {
+		controller = &dbmodel.Controller{Name: controllerKey}
+		if _, err := uuid.Parse(controllerKey); err == nil {
+			controller = &dbmodel.Controller{UUID: controllerKey}
+		}
+		modelPath = path
+	}
+
 	model := dbmodel.Model{}
-	matches := modelOwnerAndNameMatcher.FindStringSubmatch(t.trailer)
+	matches := modelOwnerAndNameMatcher.FindStringSubmatch(modelPath)
 	if len(matches) != 3 {
 		return nil, errors.E("model name format incorrect, expected <model-owner>/<model-name>")
 	}
 	model.OwnerIdentityName = matches[1]
 	model.Name = matches[2]
 
+	if controller != nil {
+		if err := db.GetController(ctx, controller); err != nil {
+			return nil, errors.E("model not found")
+		}
+	}
+
 	err := db.GetModel(ctx, &model)
 	if err != nil {
 		return nil, errors.E("model not found")
 	}
+	if controller != nil && model.ControllerID != controller.ID {
+		return nil, errors.E("model not found")
+	}
 
 	return ofganames.ConvertTagWithRelation(model.ResourceTag(), t.relation), nil
 }
@@ -740,7 +894,57 @@ func (j *JIMM) GetGroupByName(ctx context.Context, user *openfga.User, name stri
 	return j.getGroup(ctx, user, &dbmodel.GroupEntry{Name: name})
 }
 
-// RenameGroup renames a group in JIMM's DB.
+// GetGroupByID returns the group identified by the given ID, which is
+// either the ID of the group's tag or the group's name. The user must be
+// a JIMM administrator or a member of the group.
+func (j *JIMM) GetGroupByID(ctx context.Context, user *openfga.User, id string) (*dbmodel.GroupEntry, error) {
+	const op = errors.Op("jimm.GetGroupByID")
+
+	group, err := j.lookupGroup(ctx, id)
+	if err != nil {
+		if !user.JimmAdmin && errors.ErrorCode(err) == errors.CodeNotFound {
+			// Don't reveal which groups exist to non-admin users.
+			return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+		return nil, errors.E(op, err)
+	}
+	if user.JimmAdmin {
+		return group, nil
+	}
+	isMember, err := user.IsGroupMember(ctx, group.ResourceTag())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if !isMember {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	return group, nil
+}
+
+// lookupGroup fetches the group identified by the given ID, which is
+// either the ID of the group's tag or the group's name. Group names may
+// also be valid tag IDs, so a name lookup is made if no group has the
+// given tag ID.
+func (j *JIMM) lookupGroup(ctx context.Context, id string) (*dbmodel.GroupEntry, error) {
+	if jimmnames.IsValidGroupId(id) {
+		group := dbmodel.GroupEntry{UUID: id}
+		err := j.Database.GetGroup(ctx, &group)
+		if err == nil {
+			return &group, nil
+		}
+		if errors.ErrorCode(err) != errors.CodeNotFound {
+			return nil, err
+		}
+	}
+	group := dbmodel.GroupEntry{Name: id}
+	if err := j.Database.GetGroup(ctx, &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// RenameGroup renames a group in JIMM's DB. The group to rename may be
+// identified by either its name or the ID of its tag.
 func (j *JIMM) RenameGroup(ctx context.Context, user *openfga.User, oldName, newName string) error {
 	const op = errors.Op("jimm.RenameGroup")
 
@@ -748,10 +952,7 @@ func (j *JIMM) RenameGroup(ctx context.Context, user *openfga.User, oldName, new
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
-	group := &dbmodel.GroupEntry{
-		Name: oldName,
-	}
-	err := j.Database.GetGroup(ctx, group)
+	group, err := j.lookupGroup(ctx, oldName)
 	if err != nil {
 		return errors.E(op, err)
 	}
@@ -764,6 +965,7 @@ func (j *JIMM) RenameGroup(ctx context.Context, user *openfga.User, oldName, new
 }
 
 // RemoveGroup removes a group within JIMMs DB for reference by OpenFGA.
+// The group may be identified by either its name or the ID of its tag.
 func (j *JIMM) RemoveGroup(ctx context.Context, user *openfga.User, name string) error {
 	const op = errors.Op("jimm.RemoveGroup")
 
@@ -771,10 +973,7 @@ func (j *JIMM) RemoveGroup(ctx context.Context, user *openfga.User, name string)
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
-	group := &dbmodel.GroupEntry{
-		Name: name,
-	}
-	err := j.Database.GetGroup(ctx, group)
+	group, err := j.lookupGroup(ctx, name)
 	if err != nil {
 		return errors.E(op, err)
 	}
@@ -789,8 +988,10 @@ func (j *JIMM) RemoveGroup(ctx context.Context, user *openfga.User, name string)
 	return nil
 }
 
-// ListGroups returns a list of groups known to JIMM.
-func (j *JIMM) ListGroups(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]dbmodel.GroupEntry, error) {
+// ListGroups returns a page of the groups known to JIMM in ascending
+// order of name. If namePrefixFilter is not empty only groups whose name
+// starts with it are returned.
+func (j *JIMM) ListGroups(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination, namePrefixFilter string) ([]dbmodel.GroupEntry, error) {
 	const op = errors.Op("jimm.ListGroups")
 
 	if !user.JimmAdmin {
@@ -798,7 +999,7 @@ func (j *JIMM) ListGroups(ctx context.Context, user *openfga.User, filter pagina
 	}
 
 	var groups []dbmodel.GroupEntry
-	err := j.Database.ForEachGroup(ctx, filter.Limit(), filter.Offset(), func(ge *dbmodel.GroupEntry) error {
+	err := j.Database.ForEachGroup(ctx, filter.Limit(), filter.Offset(), namePrefixFilter, func(ge *dbmodel.GroupEntry) error {
 		groups = append(groups, *ge)
 		return nil
 	})
@@ -172,6 +172,153 @@ func TestAuditLogAccess(t *testing.T) {
 	c.Assert(err, qt.ErrorMatches, "unauthorized")
 }
 
+func TestGroupAuditLogAccess(t *testing.T) {
+	c := qt.New(t)
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	ctx := context.Background()
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	i, err := dbmodel.NewIdentity("alice")
+	c.Assert(err, qt.IsNil)
+	adminUser := openfga.NewUser(i, j.OpenFGAClient)
+	err = adminUser.SetControllerAccess(ctx, j.ResourceTag(), ofganames.AdministratorRelation)
+	c.Assert(err, qt.IsNil)
+
+	i2, err := dbmodel.NewIdentity("bob")
+	c.Assert(err, qt.IsNil)
+	member := openfga.NewUser(i2, j.OpenFGAClient)
+	i3, err := dbmodel.NewIdentity("charlie")
+	c.Assert(err, qt.IsNil)
+	nonMember := openfga.NewUser(i3, j.OpenFGAClient)
+
+	group, err := j.Database.AddGroup(ctx, "auditors")
+	c.Assert(err, qt.IsNil)
+	err = ofgaClient.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(member.ResourceTag()),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group.ResourceTag()),
+	})
+	c.Assert(err, qt.IsNil)
+
+	// non-admin user cannot grant audit log access
+	err = j.GrantGroupAuditLogAccess(ctx, member, group.ResourceTag())
+	c.Assert(err, qt.ErrorMatches, "unauthorized")
+
+	// admin user can grant a group audit log access.
+	err = j.GrantGroupAuditLogAccess(ctx, adminUser, group.ResourceTag())
+	c.Assert(err, qt.IsNil)
+
+	c.Check(member.GetAuditLogViewerAccess(ctx, j.ResourceTag()), qt.Equals, ofganames.AuditLogViewerRelation)
+	c.Check(nonMember.GetAuditLogViewerAccess(ctx, j.ResourceTag()), qt.Equals, ofganames.NoRelation)
+
+	// re-granting access does not result in error.
+	err = j.GrantGroupAuditLogAccess(ctx, adminUser, group.ResourceTag())
+	c.Assert(err, qt.IsNil)
+
+	// non-admin user cannot revoke audit log access
+	err = j.RevokeGroupAuditLogAccess(ctx, member, group.ResourceTag())
+	c.Assert(err, qt.ErrorMatches, "unauthorized")
+
+	// admin user can revoke a group's audit log access.
+	err = j.RevokeGroupAuditLogAccess(ctx, adminUser, group.ResourceTag())
+	c.Assert(err, qt.IsNil)
+	c.Check(member.GetAuditLogViewerAccess(ctx, j.ResourceTag()), qt.Equals, ofganames.NoRelation)
+
+	// re-revoking access does not result in error.
+	err = j.RevokeGroupAuditLogAccess(ctx, adminUser, group.ResourceTag())
+	c.Assert(err, qt.IsNil)
+
+	// the group must exist.
+	err = j.GrantGroupAuditLogAccess(ctx, adminUser, jimmnames.NewGroupTag(uuid.NewString()))
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
+
+func TestRevokeAllUserAccess(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	user, group, controller, model, _, cloud, cred := createTestControllerEnvironment(ctx, c, j.Database)
+	otherUser := names.NewUserTag("bob@canonical.com")
+
+	tuples := []openfga.Tuple{{
+		Object:   ofganames.ConvertTag(user.ResourceTag()),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(model.ResourceTag()),
+	}, {
+		Object:   ofganames.ConvertTag(user.ResourceTag()),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(controller.ResourceTag()),
+	}, {
+		Object:   ofganames.ConvertTag(user.ResourceTag()),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group.ResourceTag()),
+	}, {
+		Object:   ofganames.ConvertTag(user.ResourceTag()),
+		Relation: ofganames.CanAddModelRelation,
+		Target:   ofganames.ConvertTag(cloud.ResourceTag()),
+	}, {
+		Object:   ofganames.ConvertTag(user.ResourceTag()),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(cred.ResourceTag()),
+	}, {
+		// This tuple should remain as it relates to another user.
+		Object:   ofganames.ConvertTag(otherUser),
+		Relation: ofganames.ReaderRelation,
+		Target:   ofganames.ConvertTag(model.ResourceTag()),
+	}}
+	err = ofgaClient.AddRelation(ctx, tuples...)
+	c.Assert(err, qt.IsNil)
+
+	u := openfga.NewUser(&user, ofgaClient)
+
+	// Only JIMM admins may revoke access.
+	_, err = j.RevokeAllUserAccess(ctx, u, user.ResourceTag())
+	c.Assert(err, qt.ErrorMatches, "unauthorized")
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	u.JimmAdmin = true
+	removed, err := j.RevokeAllUserAccess(ctx, u, user.ResourceTag())
+	c.Assert(err, qt.IsNil)
+	c.Assert(removed, qt.Equals, 5)
+
+	remainingTuples, _, err := ofgaClient.ReadRelatedObjects(ctx, ofga.Tuple{}, 0, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(remainingTuples, qt.HasLen, 1)
+	c.Assert(remainingTuples[0].Object.ID, qt.Equals, otherUser.Id())
+
+	// Revoking again is a no-op.
+	removed, err = j.RevokeAllUserAccess(ctx, u, user.ResourceTag())
+	c.Assert(err, qt.IsNil)
+	c.Assert(removed, qt.Equals, 0)
+}
+
 func TestJWTGeneratorMakeLoginToken(t *testing.T) {
 	c := qt.New(t)
 
@@ -429,6 +576,147 @@ func TestJWTGeneratorMakeToken(t *testing.T) {
 	}
 }
 
+type claimsSourceFunc func(context.Context, *openfga.User) (map[string]interface{}, error)
+
+func (f claimsSourceFunc) JWTClaims(ctx context.Context, user *openfga.User) (map[string]interface{}, error) {
+	return f(ctx, user)
+}
+
+func TestJWTGeneratorClaimsSources(t *testing.T) {
+	c := qt.New(t)
+
+	ct := names.NewControllerTag(uuid.New().String())
+	mt := names.NewModelTag(uuid.New().String())
+
+	jwtService := &testJWTService{}
+	generator := jimm.NewJWTGenerator(
+		&testDatabase{},
+		&testAccessChecker{
+			modelAccess: map[string]string{
+				mt.String(): "admin",
+			},
+			controllerAccess: map[string]string{
+				ct.String(): "superuser",
+			},
+		},
+		jwtService,
+	)
+	generator.SetTags(mt, ct)
+	generator.AddClaimsSources(
+		claimsSourceFunc(func(_ context.Context, user *openfga.User) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"tenant":      "tenant-" + user.Name,
+				"cost-center": "1234",
+			}, nil
+		}),
+		claimsSourceFunc(func(context.Context, *openfga.User) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"cost-center": "5678",
+			}, nil
+		}),
+	)
+
+	i, err := dbmodel.NewIdentity("eve@canonical.com")
+	c.Assert(err, qt.IsNil)
+	_, err = generator.MakeLoginToken(context.Background(), &openfga.User{
+		Identity: i,
+	})
+	c.Assert(err, qt.IsNil)
+	expectedJWTParams := jimmjwx.JWTParams{
+		Controller: ct.Id(),
+		User:       names.NewUserTag("eve@canonical.com").String(),
+		Access: map[string]string{
+			ct.String(): "superuser",
+			mt.String(): "admin",
+		},
+		Claims: map[string]interface{}{
+			"tenant":      "tenant-eve@canonical.com",
+			"cost-center": "5678",
+		},
+	}
+	c.Check(jwtService.params, qt.DeepEquals, expectedJWTParams)
+
+	// Tokens issued after login carry the same claims.
+	jwtService.params = jimmjwx.JWTParams{}
+	_, err = generator.MakeToken(context.Background(), nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(jwtService.params, qt.DeepEquals, expectedJWTParams)
+
+	// A failing source fails the login.
+	generator.AddClaimsSources(claimsSourceFunc(func(context.Context, *openfga.User) (map[string]interface{}, error) {
+		return nil, errors.E("a test error")
+	}))
+	_, err = generator.MakeLoginToken(context.Background(), &openfga.User{
+		Identity: i,
+	})
+	c.Check(err, qt.ErrorMatches, "failed to get additional claims")
+}
+
+func TestJWTGeneratorLoginAttempts(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ct := names.NewControllerTag(uuid.New().String())
+	mt := names.NewModelTag(uuid.New().String())
+
+	now := time.Now()
+	tracker := jimm.NewLoginAttemptTracker(2, time.Minute)
+	jimm.SetLoginAttemptTrackerNow(tracker, func() time.Time { return now })
+
+	accessChecker := &testAccessChecker{
+		modelAccess: map[string]string{
+			mt.String(): "admin",
+		},
+		controllerAccess: map[string]string{
+			ct.String(): "superuser",
+		},
+		modelAccessCheckErr: errors.E("a test error"),
+	}
+	generator := jimm.NewJWTGenerator(&testDatabase{}, accessChecker, &testJWTService{})
+	generator.SetTags(mt, ct)
+	generator.SetLoginAttemptTracker(tracker)
+
+	i, err := dbmodel.NewIdentity("eve@canonical.com")
+	c.Assert(err, qt.IsNil)
+	eve := &openfga.User{Identity: i}
+	i, err = dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	alice := &openfga.User{Identity: i}
+
+	// The user is locked out after the configured number of failures.
+	for n := 0; n < 2; n++ {
+		_, err = generator.MakeLoginToken(ctx, eve)
+		c.Assert(err, qt.ErrorMatches, "a test error")
+	}
+	accessChecker.modelAccessCheckErr = nil
+	_, err = generator.MakeLoginToken(ctx, eve)
+	c.Check(err, qt.ErrorMatches, `too many failed logins for "eve@canonical.com", try again in 1m0s`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeLoginLocked)
+
+	// Other users are unaffected.
+	_, err = generator.MakeLoginToken(ctx, alice)
+	c.Check(err, qt.IsNil)
+
+	// The lockout ends once the window has passed.
+	now = now.Add(time.Minute)
+	_, err = generator.MakeLoginToken(ctx, eve)
+	c.Check(err, qt.IsNil)
+
+	// A successful login resets the count of failures.
+	accessChecker.controllerAccessCheckErr = errors.E("a test error")
+	_, err = generator.MakeLoginToken(ctx, eve)
+	c.Assert(err, qt.ErrorMatches, "a test error")
+	accessChecker.controllerAccessCheckErr = nil
+	_, err = generator.MakeLoginToken(ctx, eve)
+	c.Assert(err, qt.IsNil)
+	accessChecker.controllerAccessCheckErr = errors.E("a test error")
+	_, err = generator.MakeLoginToken(ctx, eve)
+	c.Assert(err, qt.ErrorMatches, "a test error")
+	accessChecker.controllerAccessCheckErr = nil
+	_, err = generator.MakeLoginToken(ctx, eve)
+	c.Check(err, qt.IsNil)
+}
+
 func TestParseAndValidateTag(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
@@ -533,6 +821,14 @@ func TestResolveTags(t *testing.T) {
 		desc:     "map model",
 		input:    "model-" + model.OwnerIdentityName + "/" + model.Name + "#administrator",
 		expected: ofganames.ConvertTagWithRelation(names.NewModelTag(model.UUID.String), ofganames.AdministratorRelation),
+	}, {
+		desc:     "map model with controller name",
+		input:    "model-" + controller.Name + ":" + model.OwnerIdentityName + "/" + model.Name + "#administrator",
+		expected: ofganames.ConvertTagWithRelation(names.NewModelTag(model.UUID.String), ofganames.AdministratorRelation),
+	}, {
+		desc:     "map model with controller UUID",
+		input:    "model-" + controller.UUID + ":" + model.OwnerIdentityName + "/" + model.Name + "#administrator",
+		expected: ofganames.ConvertTagWithRelation(names.NewModelTag(model.UUID.String), ofganames.AdministratorRelation),
 	}, {
 		desc:     "map model UUID",
 		input:    "model-" + model.UUID.String,
@@ -621,6 +917,11 @@ func TestResolveTupleObjectHandlesErrors(t *testing.T) {
 			input: "model-test-unknowncontroller-1:alice@canonical.com/test-model-1",
 			want:  "model not found",
 		},
+		// Resolves bad models where the controller UUID is unknown
+		{
+			input: "model-" + uuid.NewString() + ":" + model.OwnerIdentityName + "/" + model.Name,
+			want:  "model not found",
+		},
 	}
 	for i, tc := range tests {
 		t.Run(fmt.Sprintf("test %d", i), func(t *testing.T) {
@@ -849,6 +1150,83 @@ func TestGetGroup(t *testing.T) {
 	c.Assert(err, qt.Not(qt.IsNil))
 }
 
+func TestGetGroupByID(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	adminIdentity, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	admin := openfga.NewUser(adminIdentity, ofgaClient)
+	admin.JimmAdmin = true
+
+	groupEntry, err := j.AddGroup(ctx, admin, "test-group-1")
+	c.Assert(err, qt.IsNil)
+
+	byID, err := j.GetGroupByID(ctx, admin, groupEntry.ResourceTag().Id())
+	c.Assert(err, qt.IsNil)
+	byName, err := j.GetGroupByID(ctx, admin, groupEntry.Name)
+	c.Assert(err, qt.IsNil)
+	c.Check(byID, qt.DeepEquals, groupEntry)
+	c.Check(byName, qt.DeepEquals, byID)
+
+	_, err = j.GetGroupByID(ctx, admin, "unknown-group")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	// Members of the group may fetch it, other users may not.
+	memberIdentity, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	member := openfga.NewUser(memberIdentity, ofgaClient)
+	otherIdentity, err := dbmodel.NewIdentity("eve@canonical.com")
+	c.Assert(err, qt.IsNil)
+	other := openfga.NewUser(otherIdentity, ofgaClient)
+
+	err = ofgaClient.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(member.ResourceTag()),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(groupEntry.ResourceTag()),
+	})
+	c.Assert(err, qt.IsNil)
+
+	got, err := j.GetGroupByID(ctx, member, groupEntry.Name)
+	c.Assert(err, qt.IsNil)
+	c.Check(got, qt.DeepEquals, groupEntry)
+
+	_, err = j.GetGroupByID(ctx, other, groupEntry.UUID)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// Non-admin users cannot discover which groups exist.
+	_, err = j.GetGroupByID(ctx, other, "unknown-group")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// Groups can be renamed and removed by ID as well as by name.
+	err = j.RenameGroup(ctx, admin, groupEntry.UUID, "test-group-2")
+	c.Assert(err, qt.IsNil)
+	got, err = j.GetGroupByID(ctx, admin, groupEntry.UUID)
+	c.Assert(err, qt.IsNil)
+	c.Check(got.Name, qt.Equals, "test-group-2")
+
+	err = j.RemoveGroup(ctx, admin, groupEntry.UUID)
+	c.Assert(err, qt.IsNil)
+	_, err = j.GetGroupByID(ctx, admin, groupEntry.UUID)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
+
 func TestRemoveGroup(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
@@ -1080,7 +1458,7 @@ func TestListGroups(t *testing.T) {
 	u.JimmAdmin = true
 
 	filter := pagination.NewOffsetFilter(10, 0)
-	groups, err := j.ListGroups(ctx, u, filter)
+	groups, err := j.ListGroups(ctx, u, filter, "")
 	c.Assert(err, qt.IsNil)
 	c.Assert(groups, qt.DeepEquals, []dbmodel.GroupEntry{group})
 
@@ -1095,7 +1473,7 @@ func TestListGroups(t *testing.T) {
 		_, err := j.AddGroup(ctx, u, name)
 		c.Assert(err, qt.IsNil)
 	}
-	groups, err = j.ListGroups(ctx, u, filter)
+	groups, err = j.ListGroups(ctx, u, filter, "")
 	c.Assert(err, qt.IsNil)
 	sort.Slice(groups, func(i, j int) bool {
 		return groups[i].Name < groups[j].Name
@@ -1109,4 +1487,28 @@ func TestListGroups(t *testing.T) {
 	c.Assert(groups[2].Name, qt.Equals, "test-group0")
 	c.Assert(groups[3].Name, qt.Equals, "test-group1")
 	c.Assert(groups[4].Name, qt.Equals, "test-group2")
+
+	groupNamesOf := func(groups []dbmodel.GroupEntry) []string {
+		result := make([]string, len(groups))
+		for i, g := range groups {
+			result[i] = g.Name
+		}
+		return result
+	}
+
+	groups, err = j.ListGroups(ctx, u, filter, "test-group")
+	c.Assert(err, qt.IsNil)
+	c.Assert(groupNamesOf(groups), qt.DeepEquals, []string{"test-group", "test-group0", "test-group1", "test-group2"})
+
+	groups, err = j.ListGroups(ctx, u, pagination.NewOffsetFilter(2, 1), "test-group")
+	c.Assert(err, qt.IsNil)
+	c.Assert(groupNamesOf(groups), qt.DeepEquals, []string{"test-group0", "test-group1"})
+
+	groups, err = j.ListGroups(ctx, u, pagination.NewOffsetFilter(2, 4), "test-group")
+	c.Assert(err, qt.IsNil)
+	c.Assert(groups, qt.HasLen, 0)
+
+	groups, err = j.ListGroups(ctx, u, filter, "no-such-group")
+	c.Assert(err, qt.IsNil)
+	c.Assert(groups, qt.HasLen, 0)
 }
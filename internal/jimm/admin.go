@@ -6,6 +6,8 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
 	"golang.org/x/oauth2"
 
 	"github.com/canonical/jimm/v3/internal/errors"
@@ -60,6 +62,10 @@ func (j *JIMM) GetDeviceSessionToken(ctx context.Context, deviceOAuthResponse *o
 }
 
 // LoginClientCredentials verifies a user's client ID and secret before the user is logged in.
+// Invalid credentials are recorded as a failed login for the service account and once the
+// service account is locked out the credentials are not verified. As failures are counted
+// per client ID, anyone that knows a client ID can lock the service account out by
+// repeatedly presenting an invalid secret; the lockout window bounds how long that lasts.
 func (j *JIMM) LoginClientCredentials(ctx context.Context, clientID string, clientSecret string) (*openfga.User, error) {
 	const op = errors.Op("jimm.LoginClientCredentials")
 	// We expect the client to send the service account ID "as-is" and because we know that this is a clientCredentials login,
@@ -68,11 +74,16 @@ func (j *JIMM) LoginClientCredentials(ctx context.Context, clientID string, clie
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
+	if err := j.checkLoginAttempts(ctx, clientIdWithDomain); err != nil {
+		return nil, errors.E(op, err)
+	}
 
 	err = j.OAuthAuthenticator.VerifyClientCredentials(ctx, clientID, clientSecret)
 	if err != nil {
+		j.LoginAttempts.Failed(clientIdWithDomain)
 		return nil, errors.E(op, err)
 	}
+	j.LoginAttempts.Succeeded(clientIdWithDomain)
 
 	return j.UserLogin(ctx, clientIdWithDomain)
 }
@@ -86,9 +97,31 @@ func (j *JIMM) LoginWithSessionToken(ctx context.Context, sessionToken string) (
 	}
 
 	email := jwtToken.Subject()
+	if err := j.checkLoginAttempts(ctx, email); err != nil {
+		return nil, errors.E(op, err)
+	}
 	return j.UserLogin(ctx, email)
 }
 
+// LoginWithIDToken verifies an ID token issued by an external OIDC
+// provider before the user it identifies is logged in. If ID token
+// authentication has not been enabled an error with a code of
+// CodeNotSupported is returned.
+func (j *JIMM) LoginWithIDToken(ctx context.Context, idToken string) (*openfga.User, error) {
+	const op = errors.Op("jimm.LoginWithIDToken")
+	if j.IDTokenAuthenticator == nil {
+		return nil, errors.E(op, errors.CodeNotSupported, "id token login is not enabled")
+	}
+	username, err := j.IDTokenAuthenticator.Authenticate(ctx, idToken)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := j.checkLoginAttempts(ctx, username); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return j.UserLogin(ctx, username)
+}
+
 // LoginWithSessionCookie uses the identity ID expected to have come from a session cookie, to log the user in.
 //
 // The work to parse and store the user's identity from the session cookie takes place in internal/jimmhttp/websocket.go
@@ -100,5 +133,22 @@ func (j *JIMM) LoginWithSessionCookie(ctx context.Context, identityID string) (*
 	if identityID == "" {
 		return nil, errors.E(op, "missing cookie identity")
 	}
+	if err := j.checkLoginAttempts(ctx, identityID); err != nil {
+		return nil, errors.E(op, err)
+	}
 	return j.UserLogin(ctx, identityID)
 }
+
+// checkLoginAttempts returns an error with the code CodeLoginLocked if
+// the given user has been locked out by too many failed logins. Failures
+// are recorded for invalid client credentials and, once a user has been
+// authenticated, for access checks rejected by the JWTGenerator when
+// logging in to a model or controller. A user that is locked out may not
+// log in by any means.
+func (j *JIMM) checkLoginAttempts(ctx context.Context, username string) error {
+	if err := j.LoginAttempts.Check(username); err != nil {
+		zapctx.Warn(ctx, "login rejected", zap.String("user", username), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
 )
@@ -79,6 +80,76 @@ func TestLoginClientCredentials(t *testing.T) {
 	c.Assert(user.Name, qt.Equals, "my-svc-acc@serviceaccount")
 }
 
+// secretAuthenticator accepts only the configured client secret.
+type secretAuthenticator struct {
+	jimm.OAuthAuthenticator
+	secret string
+}
+
+func (a secretAuthenticator) VerifyClientCredentials(_ context.Context, _, clientSecret string) error {
+	if clientSecret != a.secret {
+		return errors.E(errors.CodeUnauthorized, "invalid client credentials")
+	}
+	return nil
+}
+
+func TestLoginClientCredentialsLockout(t *testing.T) {
+	c := qt.New(t)
+	mockAuthenticator := jimmtest.NewMockOAuthAuthenticator(c, nil)
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), t.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now()
+	tracker := jimm.NewLoginAttemptTracker(2, time.Minute)
+	jimm.SetLoginAttemptTrackerNow(tracker, func() time.Time { return now })
+
+	j := jimm.JIMM{
+		UUID: "foo",
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OAuthAuthenticator: secretAuthenticator{
+			OAuthAuthenticator: &mockAuthenticator,
+			secret:             "foo-secret",
+		},
+		OpenFGAClient: client,
+		LoginAttempts: tracker,
+	}
+	ctx := context.Background()
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	// The service account is locked out after the configured number
+	// of invalid secrets, even the valid secret is then rejected.
+	for n := 0; n < 2; n++ {
+		_, err = j.LoginClientCredentials(ctx, "my-svc-acc", "bad-secret")
+		c.Assert(err, qt.ErrorMatches, "invalid client credentials")
+	}
+	_, err = j.LoginClientCredentials(ctx, "my-svc-acc", "foo-secret")
+	c.Check(err, qt.ErrorMatches, `too many failed logins for "my-svc-acc@serviceaccount", try again in 1m0s`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeLoginLocked)
+
+	// Other service accounts are unaffected.
+	user, err := j.LoginClientCredentials(ctx, "other-svc-acc", "foo-secret")
+	c.Assert(err, qt.IsNil)
+	c.Check(user.Name, qt.Equals, "other-svc-acc@serviceaccount")
+
+	// The lockout ends once the window has passed.
+	now = now.Add(time.Minute)
+	_, err = j.LoginClientCredentials(ctx, "my-svc-acc", "foo-secret")
+	c.Assert(err, qt.IsNil)
+
+	// A successful login resets the count of failures.
+	_, err = j.LoginClientCredentials(ctx, "my-svc-acc", "bad-secret")
+	c.Assert(err, qt.ErrorMatches, "invalid client credentials")
+	_, err = j.LoginClientCredentials(ctx, "my-svc-acc", "foo-secret")
+	c.Assert(err, qt.IsNil)
+	_, err = j.LoginClientCredentials(ctx, "my-svc-acc", "bad-secret")
+	c.Assert(err, qt.ErrorMatches, "invalid client credentials")
+	_, err = j.LoginClientCredentials(ctx, "my-svc-acc", "foo-secret")
+	c.Assert(err, qt.IsNil)
+}
+
 func TestLoginWithSessionToken(t *testing.T) {
 	c := qt.New(t)
 	mockAuthenticator := jimmtest.NewMockOAuthAuthenticator(c, nil)
@@ -136,3 +207,43 @@ func TestLoginWithSessionCookie(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(user.Name, qt.Equals, "alice@canonical.com")
 }
+
+type idTokenAuthenticator map[string]string
+
+func (a idTokenAuthenticator) Authenticate(_ context.Context, rawIDToken string) (string, error) {
+	username, ok := a[rawIDToken]
+	if !ok {
+		return "", errors.E(errors.CodeUnauthorized, "invalid id token")
+	}
+	return username, nil
+}
+
+func TestLoginWithIDToken(t *testing.T) {
+	c := qt.New(t)
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), t.Name())
+	c.Assert(err, qt.IsNil)
+	j := jimm.JIMM{
+		UUID: "foo",
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: client,
+	}
+	ctx := context.Background()
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	_, err = j.LoginWithIDToken(ctx, "alice-token")
+	c.Assert(err, qt.ErrorMatches, "id token login is not enabled")
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeNotSupported)
+
+	j.IDTokenAuthenticator = idTokenAuthenticator{"alice-token": "alice@canonical.com"}
+
+	_, err = j.LoginWithIDToken(ctx, "invalid-token")
+	c.Assert(err, qt.ErrorMatches, "invalid id token")
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	user, err := j.LoginWithIDToken(ctx, "alice-token")
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.Name, qt.Equals, "alice@canonical.com")
+}
@@ -11,12 +11,14 @@ import (
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/status"
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
 	"github.com/juju/zaputil"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
 
+	"github.com/canonical/jimm/v3/internal/common/pagination"
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
@@ -358,6 +360,96 @@ func (j *JIMM) GetApplicationOffer(ctx context.Context, user *openfga.User, offe
 	return &offerDetails, nil
 }
 
+// ApplicationOfferInfo holds the details of an application offer stored
+// by JIMM along with the live connection counts reported by the
+// controller hosting the offer.
+type ApplicationOfferInfo struct {
+	jujuparams.ApplicationOfferAdminDetailsV5
+
+	// ActiveConnectedCount is the number of connections to the offer
+	// whose relation has joined.
+	ActiveConnectedCount int
+
+	// TotalConnectedCount is the total number of connections to the
+	// offer.
+	TotalConnectedCount int
+}
+
+// GetApplicationOfferInfo returns the details of the offer with the
+// specified URL. The user must have at least consume access to the
+// offer. The connection counts are fetched from the controller hosting
+// the offer, the remaining details are taken from the database. Only
+// offer administrators are shown the individual connections and all the
+// users with access to the offer, other users only see themselves.
+func (j *JIMM) GetApplicationOfferInfo(ctx context.Context, user *openfga.User, offerURL string) (*ApplicationOfferInfo, error) {
+	const op = errors.Op("jimm.GetApplicationOfferInfo")
+
+	offer := dbmodel.ApplicationOffer{
+		URL: offerURL,
+	}
+	err := j.Database.GetApplicationOffer(ctx, &offer)
+	if err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, err, "application offer not found")
+		}
+		return nil, errors.E(op, err)
+	}
+
+	accessLevel, err := j.getUserOfferAccess(ctx, user, &offer)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	switch accessLevel {
+	case "":
+		// if this user does not have access to this application offer
+		// we return a not found error.
+		return nil, errors.E(op, errors.CodeNotFound, "application offer not found")
+	case string(jujuparams.OfferReadAccess):
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	api, err := j.dial(
+		ctx,
+		&offer.Model.Controller,
+		names.ModelTag{},
+		permission{
+			resource: offer.ResourceTag().String(),
+			relation: accessLevel,
+		},
+	)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	defer api.Close()
+
+	var liveDetails jujuparams.ApplicationOfferAdminDetailsV5
+	liveDetails.OfferURL = offerURL
+	if err := api.GetApplicationOffer(ctx, &liveDetails); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	info := ApplicationOfferInfo{
+		ApplicationOfferAdminDetailsV5: offer.ToJujuApplicationOfferDetailsV5(),
+		TotalConnectedCount:            len(liveDetails.Connections),
+	}
+	for _, conn := range liveDetails.Connections {
+		if conn.Status.Status == status.Joined {
+			info.ActiveConnectedCount++
+		}
+	}
+	if accessLevel == string(jujuparams.OfferAdminAccess) {
+		info.Connections = liveDetails.Connections
+	} else {
+		info.Connections = nil
+	}
+	info.Users, err = j.listApplicationOfferUsers(ctx, offer.ResourceTag(), user.Identity, accessLevel)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	return &info, nil
+}
+
 // GrantOfferAccess grants rights for an application offer.
 func (j *JIMM) GrantOfferAccess(ctx context.Context, user *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) error {
 	const op = errors.Op("jimm.GrantOfferAccess")
@@ -786,6 +878,135 @@ func (j *JIMM) listApplicationOffersForModel(ctx context.Context, user *openfga.
 	return offers, nil
 }
 
+// A ModelOffer is a summary of an application offer made from a model.
+type ModelOffer struct {
+	// Name is the name of the offer.
+	Name string
+
+	// URL is the URL of the offer.
+	URL string
+
+	// ApplicationName is the name of the offered application.
+	ApplicationName string
+
+	// ConnectedCount is the number of connections to the offer known
+	// to JIMM.
+	ConnectedCount int
+
+	// Access is the user's access level on the offer.
+	Access string
+}
+
+// ModelOffers returns summaries of the application offers made from the
+// model with the given tag, ordered by name. JIMM administrators and
+// offer administrators, which includes the administrators of the model,
+// see every offer. Other users only see the offers they can consume. If
+// the model cannot be found an error with the code CodeModelNotFound is
+// returned.
+func (j *JIMM) ModelOffers(ctx context.Context, user *openfga.User, mt names.ModelTag) ([]ModelOffer, error) {
+	const op = errors.Op("jimm.ModelOffers")
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeModelNotFound, err)
+		}
+		return nil, errors.E(op, err)
+	}
+
+	offers, err := j.Database.FindApplicationOffers(ctx, db.ApplicationOfferFilterByModelID(m.ID))
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	var modelOffers []ModelOffer
+	for i := range offers {
+		accessLevel := string(jujuparams.OfferAdminAccess)
+		if !user.JimmAdmin {
+			accessLevel, err = j.getUserOfferAccess(ctx, user, &offers[i])
+			if err != nil {
+				return nil, errors.E(op, err)
+			}
+		}
+		switch accessLevel {
+		case string(jujuparams.OfferAdminAccess), string(jujuparams.OfferConsumeAccess):
+		default:
+			continue
+		}
+		modelOffers = append(modelOffers, ModelOffer{
+			Name:            offers[i].Name,
+			URL:             offers[i].URL,
+			ApplicationName: offers[i].ApplicationName,
+			ConnectedCount:  len(offers[i].Connections),
+			Access:          accessLevel,
+		})
+	}
+	sort.Slice(modelOffers, func(i, j int) bool {
+		return modelOffers[i].Name < modelOffers[j].Name
+	})
+	return modelOffers, nil
+}
+
+// An OfferSummary describes an application offer, along with where it is
+// hosted, as returned by ListAllOffers.
+type OfferSummary struct {
+	// URL is the offer's URL.
+	URL string
+
+	// Name is the name of the offer.
+	Name string
+
+	// ApplicationName is the name of the offered application.
+	ApplicationName string
+
+	// ModelUUID is the UUID of the model the offer is made from.
+	ModelUUID string
+
+	// ModelName is the name of the model the offer is made from.
+	ModelName string
+
+	// ModelOwner is the name of the owner of the model the offer is made
+	// from.
+	ModelOwner string
+
+	// Controller is the name of the controller hosting the offer's model.
+	Controller string
+
+	// ConnectedCount is the number of connections to the offer.
+	ConnectedCount int
+}
+
+// ListAllOffers returns a page of every application offer known to JIMM,
+// ordered by URL. Only JIMM administrators may list all offers.
+func (j *JIMM) ListAllOffers(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]OfferSummary, error) {
+	const op = errors.Op("jimm.ListAllOffers")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	offers, err := j.Database.ListApplicationOffers(ctx, filter.Limit(), filter.Offset())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	summaries := make([]OfferSummary, len(offers))
+	for i, offer := range offers {
+		summaries[i] = OfferSummary{
+			URL:             offer.URL,
+			Name:            offer.Name,
+			ApplicationName: offer.ApplicationName,
+			ModelUUID:       offer.Model.UUID.String,
+			ModelName:       offer.Model.Name,
+			ModelOwner:      offer.Model.OwnerIdentityName,
+			Controller:      offer.Model.Controller.Name,
+			ConnectedCount:  len(offer.Connections),
+		}
+	}
+	return summaries, nil
+}
+
 // doApplicationOfferAdmin performs the given function on an application offer
 // only if the given user has admin access on the model of the offer, or is a
 // controller superuser. Otherwise an unauthorized error is returned.
@@ -5,6 +5,7 @@ package jimm_test
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"sort"
 	"testing"
 	"time"
@@ -15,11 +16,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/juju/charm/v12"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/status"
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
 	"gopkg.in/macaroon.v2"
 	"gorm.io/gorm"
 
+	"github.com/canonical/jimm/v3/internal/common/pagination"
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
@@ -1052,6 +1055,97 @@ func TestGetApplicationOffer(t *testing.T) {
 	}
 }
 
+func TestGetApplicationOfferInfo(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	db := db.Database{
+		DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+	}
+	err := db.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	jimmUUID := uuid.NewString()
+	env := initializeEnvironment(c, ctx, &db, client, jimmUUID)
+
+	connections := []jujuparams.OfferConnection{{
+		SourceModelTag: "test-model-src-1",
+		RelationId:     1,
+		Username:       "bob@canonical.com",
+		Endpoint:       "test-endpoint",
+		Status:         jujuparams.EntityStatus{Status: status.Joined},
+	}, {
+		SourceModelTag: "test-model-src-2",
+		RelationId:     2,
+		Username:       "bob@canonical.com",
+		Endpoint:       "test-endpoint",
+		Status:         jujuparams.EntityStatus{Status: status.Suspended},
+	}}
+	j := &jimm.JIMM{
+		UUID:          jimmUUID,
+		OpenFGAClient: client,
+		Database:      db,
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				GetApplicationOffer_: func(_ context.Context, details *jujuparams.ApplicationOfferAdminDetailsV5) error {
+					details.Connections = connections
+					return nil
+				},
+			},
+		},
+	}
+
+	userNames := func(users []jujuparams.OfferUserDetails) []string {
+		var userNames []string
+		for _, u := range users {
+			userNames = append(userNames, u.UserName)
+		}
+		sort.Strings(userNames)
+		return userNames
+	}
+
+	// eve is an administrator of the offer.
+	info, err := j.GetApplicationOfferInfo(ctx, openfga.NewUser(&env.users[1], client), "test-offer-url")
+	c.Assert(err, qt.IsNil)
+	c.Check(info.OfferUUID, qt.Equals, env.applicationOffers[0].UUID)
+	c.Check(info.OfferName, qt.Equals, "test-offer")
+	c.Check(info.ApplicationName, qt.Equals, "test-app")
+	c.Check(info.TotalConnectedCount, qt.Equals, 2)
+	c.Check(info.ActiveConnectedCount, qt.Equals, 1)
+	c.Check(info.Connections, qt.DeepEquals, connections)
+	users := userNames(info.Users)
+	c.Check(users, qt.Contains, "bob@canonical.com")
+	c.Check(users, qt.Contains, "eve@canonical.com")
+	c.Check(users, qt.Contains, "fred@canonical.com")
+	c.Check(users, qt.Contains, "jane@canonical.com")
+
+	// bob can consume the offer.
+	info, err = j.GetApplicationOfferInfo(ctx, openfga.NewUser(&env.users[2], client), "test-offer-url")
+	c.Assert(err, qt.IsNil)
+	c.Check(info.TotalConnectedCount, qt.Equals, 2)
+	c.Check(info.ActiveConnectedCount, qt.Equals, 1)
+	c.Check(info.Connections, qt.HasLen, 0)
+	c.Check(userNames(info.Users), qt.DeepEquals, []string{"bob@canonical.com"})
+
+	// fred can only read the offer.
+	_, err = j.GetApplicationOfferInfo(ctx, openfga.NewUser(&env.users[3], client), "test-offer-url")
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// grant has no access to the offer.
+	_, err = j.GetApplicationOfferInfo(ctx, openfga.NewUser(&env.users[4], client), "test-offer-url")
+	c.Check(err, qt.ErrorMatches, "application offer not found")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	_, err = j.GetApplicationOfferInfo(ctx, openfga.NewUser(&env.users[1], client), "no-such-offer")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
+
 func TestOffer(t *testing.T) {
 	c := qt.New(t)
 
@@ -2748,3 +2842,197 @@ func TestListApplicationOffers(t *testing.T) {
 		}},
 	}})
 }
+
+func TestModelOffers(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	db := db.Database{
+		DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+	}
+	err := db.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	jimmUUID := uuid.NewString()
+	env := initializeEnvironment(c, ctx, &db, client, jimmUUID)
+
+	offer := dbmodel.ApplicationOffer{
+		UUID:            "00000000-0000-0000-0000-0000-0000000000012",
+		URL:             "test-offer-url-2",
+		Name:            "test-offer-2",
+		ModelID:         env.models[0].ID,
+		Model:           env.models[0],
+		ApplicationName: "test-app-2",
+		CharmURL:        "cs:test-app-2:3",
+		Connections: []dbmodel.ApplicationOfferConnection{{
+			SourceModelTag: "model-00000000-0000-0000-0000-0000-0000000000004",
+			RelationID:     1,
+			IdentityName:   "bob@canonical.com",
+			Endpoint:       "test-endpoint",
+		}},
+	}
+	err = db.AddApplicationOffer(ctx, &offer)
+	c.Assert(err, qt.IsNil)
+	err = client.AddModelApplicationOffer(ctx, env.models[0].ResourceTag(), offer.ResourceTag())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          jimmUUID,
+		OpenFGAClient: client,
+		Database:      db,
+	}
+	mt := env.models[0].ResourceTag()
+
+	// alice is an administrator of the model.
+	offers, err := j.ModelOffers(ctx, openfga.NewUser(&env.users[0], client), mt)
+	c.Assert(err, qt.IsNil)
+	c.Check(offers, qt.DeepEquals, []jimm.ModelOffer{{
+		Name:            "test-offer",
+		URL:             "test-offer-url",
+		ApplicationName: "test-app",
+		Access:          "admin",
+	}, {
+		Name:            "test-offer-2",
+		URL:             "test-offer-url-2",
+		ApplicationName: "test-app-2",
+		ConnectedCount:  1,
+		Access:          "admin",
+	}})
+
+	// joe is a JIMM administrator.
+	joe := openfga.NewUser(&env.users[6], client)
+	joe.JimmAdmin = true
+	offers, err = j.ModelOffers(ctx, joe, mt)
+	c.Assert(err, qt.IsNil)
+	c.Check(offers, qt.HasLen, 2)
+
+	// bob can only consume the first offer.
+	offers, err = j.ModelOffers(ctx, openfga.NewUser(&env.users[2], client), mt)
+	c.Assert(err, qt.IsNil)
+	c.Check(offers, qt.DeepEquals, []jimm.ModelOffer{{
+		Name:            "test-offer",
+		URL:             "test-offer-url",
+		ApplicationName: "test-app",
+		Access:          "consume",
+	}})
+
+	// fred can only read the first offer.
+	offers, err = j.ModelOffers(ctx, openfga.NewUser(&env.users[3], client), mt)
+	c.Assert(err, qt.IsNil)
+	c.Check(offers, qt.HasLen, 0)
+
+	_, err = j.ModelOffers(ctx, openfga.NewUser(&env.users[0], client), names.NewModelTag("00000000-0000-0000-0000-0000-0000000000009"))
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeModelNotFound)
+}
+
+func TestListAllOffers(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	db := db.Database{
+		DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+	}
+	err := db.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	jimmUUID := uuid.NewString()
+	env := initializeEnvironment(c, ctx, &db, client, jimmUUID)
+
+	model := dbmodel.Model{
+		Name: "test-model-2",
+		UUID: sql.NullString{
+			String: "00000000-0000-0000-0000-0000-0000000000004",
+			Valid:  true,
+		},
+		OwnerIdentityName: env.users[1].Name,
+		ControllerID:      env.controllers[0].ID,
+		CloudRegionID:     env.clouds[0].Regions[0].ID,
+		CloudCredentialID: env.credentials[0].ID,
+	}
+	err = db.AddModel(ctx, &model)
+	c.Assert(err, qt.IsNil)
+
+	for i, url := range []string{"test-offer-url-2", "test-offer-url-3"} {
+		offer := dbmodel.ApplicationOffer{
+			UUID:            fmt.Sprintf("00000000-0000-0000-0000-0000-00000000002%d", i),
+			URL:             url,
+			Name:            fmt.Sprintf("test-offer-%d", i+2),
+			ModelID:         model.ID,
+			ApplicationName: "test-app-2",
+			CharmURL:        "cs:test-app-2:3",
+		}
+		if i == 0 {
+			offer.Connections = []dbmodel.ApplicationOfferConnection{{
+				SourceModelTag: "model-00000000-0000-0000-0000-0000-0000000000005",
+				RelationID:     1,
+				IdentityName:   "bob@canonical.com",
+				Endpoint:       "test-endpoint",
+			}, {
+				SourceModelTag: "model-00000000-0000-0000-0000-0000-0000000000006",
+				RelationID:     2,
+				IdentityName:   "fred@canonical.com",
+				Endpoint:       "test-endpoint",
+			}}
+		}
+		err = db.AddApplicationOffer(ctx, &offer)
+		c.Assert(err, qt.IsNil)
+	}
+
+	j := &jimm.JIMM{
+		UUID:          jimmUUID,
+		OpenFGAClient: client,
+		Database:      db,
+	}
+
+	// joe is a JIMM administrator.
+	joe := openfga.NewUser(&env.users[6], client)
+	joe.JimmAdmin = true
+
+	offers, err := j.ListAllOffers(ctx, joe, pagination.NewOffsetFilter(2, 0))
+	c.Assert(err, qt.IsNil)
+	c.Check(offers, qt.DeepEquals, []jimm.OfferSummary{{
+		URL:             "test-offer-url",
+		Name:            "test-offer",
+		ApplicationName: "test-app",
+		ModelUUID:       "00000000-0000-0000-0000-0000-0000000000003",
+		ModelName:       "test-model",
+		ModelOwner:      "alice@canonical.com",
+		Controller:      "test-controller-1",
+	}, {
+		URL:             "test-offer-url-2",
+		Name:            "test-offer-2",
+		ApplicationName: "test-app-2",
+		ModelUUID:       "00000000-0000-0000-0000-0000-0000000000004",
+		ModelName:       "test-model-2",
+		ModelOwner:      "eve@canonical.com",
+		Controller:      "test-controller-1",
+		ConnectedCount:  2,
+	}})
+
+	offers, err = j.ListAllOffers(ctx, joe, pagination.NewOffsetFilter(2, 2))
+	c.Assert(err, qt.IsNil)
+	c.Check(offers, qt.DeepEquals, []jimm.OfferSummary{{
+		URL:             "test-offer-url-3",
+		Name:            "test-offer-3",
+		ApplicationName: "test-app-2",
+		ModelUUID:       "00000000-0000-0000-0000-0000-0000000000004",
+		ModelName:       "test-model-2",
+		ModelOwner:      "eve@canonical.com",
+		Controller:      "test-controller-1",
+	}})
+
+	// alice administers a model, but is not a JIMM administrator.
+	_, err = j.ListAllOffers(ctx, openfga.NewUser(&env.users[0], client), pagination.NewOffsetFilter(2, 0))
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/openfga"
 	"github.com/canonical/jimm/v3/internal/servermon"
 	"github.com/canonical/jimm/v3/internal/utils"
 )
@@ -98,6 +99,80 @@ func (o DbAuditLogger) LogResponse(r rpc.Request, header *rpc.Header, body inter
 	return nil
 }
 
+// addModelAccessAuditLogEntry adds an entry to the audit log recording
+// that the given user changed the access the target user has on a model
+// from oldAccess to newAccess. An empty access level means the target
+// user has no access to the model.
+func (j *JIMM) addModelAccessAuditLogEntry(user *openfga.User, method string, mt names.ModelTag, ut names.UserTag, oldAccess, newAccess string) {
+	details, err := json.Marshal(map[string]string{
+		"model":      mt.String(),
+		"user":       ut.String(),
+		"old-access": oldAccess,
+		"new-access": newAccess,
+	})
+	if err != nil {
+		zapctx.Error(context.Background(), "failed to marshal model access change", zap.Error(err))
+		return
+	}
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		Model:        mt.Id(),
+		FacadeName:   "JIMM",
+		FacadeMethod: method,
+		IdentityTag:  user.Tag().String(),
+		Params:       details,
+	})
+}
+
+// addControllerAuditLogEntry adds an entry to the audit log recording
+// that the given user changed the named controller. The change describes
+// what was done to the controller and details holds any further
+// information about the change.
+func (j *JIMM) addControllerAuditLogEntry(user *openfga.User, method, controllerName, change string, details map[string]interface{}) {
+	params := map[string]interface{}{
+		"controller": controllerName,
+		"change":     change,
+	}
+	for k, v := range details {
+		params[k] = v
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		zapctx.Error(context.Background(), "failed to marshal controller change", zap.Error(err))
+		return
+	}
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "JIMM",
+		FacadeMethod: method,
+		ObjectId:     controllerName,
+		IdentityTag:  user.Tag().String(),
+		Params:       data,
+	})
+}
+
+// addModelExpiryAuditLogEntry adds an entry to the audit log recording
+// that JIMM itself, rather than any user, destroyed the given model for
+// the given reason.
+func (j *JIMM) addModelExpiryAuditLogEntry(mt names.ModelTag, reason string) {
+	details, err := json.Marshal(map[string]string{
+		"model":  mt.String(),
+		"reason": reason,
+	})
+	if err != nil {
+		zapctx.Error(context.Background(), "failed to marshal model expiry", zap.Error(err))
+		return
+	}
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		Model:        mt.Id(),
+		FacadeName:   "JIMM",
+		FacadeMethod: "DestroyExpiredModel",
+		IdentityTag:  j.ResourceTag().String(),
+		Params:       details,
+	})
+}
+
 // recorder implements an rpc.Recorder.
 type recorder struct {
 	start          time.Time
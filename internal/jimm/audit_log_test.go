@@ -4,6 +4,11 @@ package jimm_test
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,7 +18,9 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
 
 func TestAuditLogCleanupServicePurgesLogs(t *testing.T) {
@@ -81,3 +88,204 @@ func TestCalculateNextPollDuration(t *testing.T) {
 	d = jimm.CalculateNextPollDuration(startingTime)
 	c.Assert(d, qt.Equals, time.Hour*2)
 }
+
+func TestAuditSink(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var received []apiparams.AuditEvent
+	fail := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var events []apiparams.AuditEvent
+		if err := json.NewDecoder(req.Body).Decode(&events); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, events...)
+	}))
+	defer srv.Close()
+
+	j := &jimm.JIMM{
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		AuditSink: &jimm.HTTPAuditSink{URL: srv.URL},
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	// waitReceived waits for the sink to have received n events.
+	waitReceived := func(n int) {
+		for i := 0; i < 100; i++ {
+			mu.Lock()
+			got := len(received)
+			mu.Unlock()
+			if got >= n {
+				return
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		c.Fatalf("audit sink did not receive %d events", n)
+	}
+
+	// An entry that cannot be delivered remains pending.
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "ModelManager",
+		FacadeMethod: "CreateModel",
+		IdentityTag:  "user-alice@canonical.com",
+	})
+	pending, err := j.Database.GetAuditLogEntriesPendingExport(ctx, 10)
+	c.Assert(err, qt.IsNil)
+	c.Assert(pending, qt.HasLen, 1)
+
+	mu.Lock()
+	fail = false
+	mu.Unlock()
+
+	// An entry is delivered as soon as it is written.
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "ModelManager",
+		FacadeMethod: "DestroyModels",
+		IdentityTag:  "user-alice@canonical.com",
+	})
+	mu.Lock()
+	c.Assert(received, qt.HasLen, 1)
+	c.Check(received[0].FacadeMethod, qt.Equals, "DestroyModels")
+	c.Check(received[0].UserTag, qt.Equals, "user-alice@canonical.com")
+	mu.Unlock()
+
+	exportCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error)
+	go func() {
+		done <- j.RunAuditExport(exportCtx, time.Hour)
+	}()
+
+	// The failed entry is delivered when the export runs.
+	waitReceived(2)
+	mu.Lock()
+	c.Check(received[1].FacadeMethod, qt.Equals, "CreateModel")
+	mu.Unlock()
+
+	cancel()
+	c.Check(<-done, qt.Equals, context.Canceled)
+
+	pending, err = j.Database.GetAuditLogEntriesPendingExport(ctx, 10)
+	c.Assert(err, qt.IsNil)
+	c.Check(pending, qt.HasLen, 0)
+}
+
+func TestAuditSinkTimeout(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c.Patch(jimm.AuditSendTimeout, 100*time.Millisecond)
+	j := &jimm.JIMM{
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		AuditSink: &jimm.HTTPAuditSink{URL: srv.URL},
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	// Writing an entry does not wait for an unresponsive audit sink, the
+	// entry remains pending.
+	start := time.Now()
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "ModelManager",
+		FacadeMethod: "CreateModel",
+		IdentityTag:  "user-alice@canonical.com",
+	})
+	c.Check(time.Since(start) < 5*time.Second, qt.IsTrue)
+
+	pending, err := j.Database.GetAuditLogEntriesPendingExport(ctx, 10)
+	c.Assert(err, qt.IsNil)
+	c.Check(pending, qt.HasLen, 1)
+}
+
+// testAuditSink is an AuditSink that records the events sent to it.
+type testAuditSink struct {
+	err     error
+	entries []dbmodel.AuditLogEntry
+}
+
+func (s *testAuditSink) SendAuditEvents(_ context.Context, entries []dbmodel.AuditLogEntry) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.entries = append(s.entries, entries...)
+	return nil
+}
+
+func TestPruneAuditEvents(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	sink := &testAuditSink{err: errors.E("sink unavailable")}
+	j := &jimm.JIMM{
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		AuditSink: sink,
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	for i, method := range []string{"Old1", "Old2", "Recent1", "Recent2"} {
+		ale := dbmodel.AuditLogEntry{
+			Time:          now.AddDate(0, 0, -10),
+			FacadeName:    "JIMM",
+			FacadeMethod:  method,
+			PendingExport: i == 0,
+		}
+		if i >= 2 {
+			ale.Time = now.Add(-time.Minute)
+		}
+		c.Assert(j.Database.AddAuditLogEntry(ctx, &ale), qt.IsNil)
+	}
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, nil)
+	admin.JimmAdmin = true
+	cutoff := now.AddDate(0, 0, -1)
+
+	_, err = j.PruneAuditEvents(ctx, openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, nil), cutoff)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// Nothing is pruned while events cannot be exported.
+	_, err = j.PruneAuditEvents(ctx, admin, cutoff)
+	c.Check(err, qt.ErrorMatches, `failed to export audit events`)
+
+	sink.err = nil
+	deleted, err := j.PruneAuditEvents(ctx, admin, cutoff)
+	c.Assert(err, qt.IsNil)
+	c.Check(deleted, qt.Equals, int64(2))
+	c.Assert(sink.entries, qt.HasLen, 1)
+	c.Check(sink.entries[0].FacadeMethod, qt.Equals, "Old1")
+
+	var remaining []string
+	err = j.Database.ForEachAuditLogEntry(ctx, db.AuditLogFilter{}, func(ale *dbmodel.AuditLogEntry) error {
+		remaining = append(remaining, ale.FacadeMethod)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	sort.Strings(remaining)
+	c.Check(remaining, qt.DeepEquals, []string{"Recent1", "Recent2"})
+}
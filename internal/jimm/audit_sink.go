@@ -0,0 +1,126 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// auditExportBatchSize is the maximum number of pending audit log
+// entries sent to the audit sink at once.
+const auditExportBatchSize = 100
+
+// auditSendTimeout is the maximum time AddAuditLogEntry waits for a new
+// entry to be delivered to the audit sink.
+var auditSendTimeout = 2 * time.Second
+
+// An AuditSink receives audit log entries exported from JIMM.
+type AuditSink interface {
+	// SendAuditEvents delivers the given audit log entries to the sink.
+	// Entries may be delivered more than once if an earlier attempt to
+	// deliver them failed.
+	SendAuditEvents(ctx context.Context, entries []dbmodel.AuditLogEntry) error
+}
+
+// An HTTPAuditSink is an AuditSink that POSTs audit events as a JSON
+// array to an HTTP endpoint.
+type HTTPAuditSink struct {
+	// URL is the URL audit events are posted to.
+	URL string
+
+	// Client is the HTTP client used to post audit events. If this is
+	// nil a client with a 10 second timeout is used.
+	Client *http.Client
+}
+
+// SendAuditEvents implements AuditSink. Any response with a status other
+// than 2xx is considered a failure.
+func (s *HTTPAuditSink) SendAuditEvents(ctx context.Context, entries []dbmodel.AuditLogEntry) error {
+	const op = errors.Op("jimm.HTTPAuditSink.SendAuditEvents")
+
+	events := make([]apiparams.AuditEvent, len(entries))
+	for i, e := range entries {
+		events[i] = e.ToAPIAuditEvent()
+	}
+	body, err := json.Marshal(events)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.E(op, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.E(op, fmt.Sprintf("audit sink returned status %q", resp.Status))
+	}
+	return nil
+}
+
+// exportAuditLogEntries sends the given entries to the audit sink and, if
+// they are delivered, marks them as exported.
+func (j *JIMM) exportAuditLogEntries(ctx context.Context, entries []dbmodel.AuditLogEntry) error {
+	if err := j.AuditSink.SendAuditEvents(ctx, entries); err != nil {
+		return err
+	}
+	ids := make([]uint, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ID
+	}
+	return j.Database.ClearAuditLogPendingExport(ctx, ids)
+}
+
+// ExportPendingAuditLogEntries sends all audit log entries that have not
+// yet been delivered to the audit sink, oldest first. If the audit sink
+// is not configured this does nothing.
+func (j *JIMM) ExportPendingAuditLogEntries(ctx context.Context) error {
+	const op = errors.Op("jimm.ExportPendingAuditLogEntries")
+
+	if j.AuditSink == nil {
+		return nil
+	}
+	for {
+		entries, err := j.Database.GetAuditLogEntriesPendingExport(ctx, auditExportBatchSize)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		if err := j.exportAuditLogEntries(ctx, entries); err != nil {
+			return errors.E(op, err)
+		}
+		if len(entries) < auditExportBatchSize {
+			return nil
+		}
+	}
+}
+
+// RunAuditExport retries exporting pending audit log entries, written by
+// any JIMM process, at the given interval. RunAuditExport blocks until
+// the given context is canceled.
+func (j *JIMM) RunAuditExport(ctx context.Context, interval time.Duration) error {
+	return runPeriodically(ctx, interval, "failed to export audit log entries", j.ExportPendingAuditLogEntries)
+}
@@ -21,6 +21,7 @@ func CacheDialer(d Dialer) Dialer {
 	return &cacheDialer{
 		dialer: d,
 		conns:  make(map[string]cachedAPI),
+		gens:   make(map[string]uint64),
 	}
 }
 
@@ -35,6 +36,11 @@ type cacheDialer struct {
 	sfg   singleflight.Group
 	mu    sync.Mutex
 	conns map[string]cachedAPI
+
+	// gens holds the number of times the connection to each controller
+	// has been evicted. It is used to detect evictions that happen
+	// while a connection is being dialed.
+	gens map[string]uint64
 }
 
 // Dial implements Dialer.Dial.
@@ -58,35 +64,60 @@ func (d *cacheDialer) Dial(ctx context.Context, ctl *dbmodel.Controller, mt name
 }
 
 func (d *cacheDialer) dial(ctx context.Context, ctl *dbmodel.Controller, requiredPermissions map[string]string) (interface{}, error) {
-	d.mu.Lock()
-	capi, ok := d.conns[ctl.Name]
-	if ok {
-		if err := capi.Ping(ctx); err == nil {
+	for {
+		d.mu.Lock()
+		capi, ok := d.conns[ctl.Name]
+		if ok {
+			if err := capi.Ping(ctx); err == nil {
+				d.mu.Unlock()
+				return capi, nil
+			} else {
+				zapctx.Warn(ctx, "cached connection failed", zap.Error(err))
+				delete(d.conns, ctl.Name)
+				capi.Close()
+			}
+		}
+		gen := d.gens[ctl.Name]
+		d.mu.Unlock()
+
+		// We don't have a working connection to the controller, so dial one.
+		api, err := d.dialer.Dial(ctx, ctl, names.ModelTag{}, requiredPermissions)
+		if err != nil {
+			return nil, err
+		}
+		d.mu.Lock()
+		if d.gens[ctl.Name] != gen {
+			// The connection was evicted while we were dialing,
+			// so it may have been made with stale credentials.
+			// Discard it and try again.
 			d.mu.Unlock()
-			return capi, nil
-		} else {
-			zapctx.Warn(ctx, "cached connection failed", zap.Error(err))
-			delete(d.conns, ctl.Name)
-			capi.Close()
+			api.Close()
+			continue
 		}
+		capi = cachedAPI{
+			API:      api,
+			refCount: new(int64),
+			closed:   new(uint32),
+		}
+		atomic.StoreInt64(capi.refCount, 1)
+		d.conns[ctl.Name] = capi
+		d.mu.Unlock()
+		return capi, nil
 	}
-	d.mu.Unlock()
+}
 
-	// We don't have a working connection to the controller, so dial one.
-	api, err := d.dialer.Dial(ctx, ctl, names.ModelTag{}, requiredPermissions)
-	if err != nil {
-		return nil, err
-	}
-	capi = cachedAPI{
-		API:      api,
-		refCount: new(int64),
-		closed:   new(uint32),
-	}
-	atomic.StoreInt64(capi.refCount, 1)
+// Evict removes any cached connection to the controller with the given
+// name, so that the next Dial establishes a new connection. Connections
+// already handed out remain usable until they are closed. A connection
+// being dialed when Evict is called is discarded once it completes.
+func (d *cacheDialer) Evict(controllerName string) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	d.conns[ctl.Name] = capi
-	return capi, nil
+	d.gens[controllerName]++
+	if capi, ok := d.conns[controllerName]; ok {
+		delete(d.conns, controllerName)
+		capi.Close()
+	}
 }
 
 // Close implements io.Closer.
@@ -196,3 +196,85 @@ type dialerFunc func(context.Context, *dbmodel.Controller, names.ModelTag, map[s
 func (f dialerFunc) Dial(ctx context.Context, ctl *dbmodel.Controller, mt names.ModelTag, requiredPermissions map[string]string) (jimm.API, error) {
 	return f(ctx, ctl, mt, requiredPermissions)
 }
+
+func TestCacheDialerEvict(t *testing.T) {
+	c := qt.New(t)
+
+	apis := map[string]*closeCountingAPI{
+		"controller-1": {API: &jimmtest.API{}},
+		"controller-2": {API: &jimmtest.API{}},
+	}
+	var mu sync.Mutex
+	dials := make(map[string]int)
+	dialer := jimm.CacheDialer(dialerFunc(func(_ context.Context, ctl *dbmodel.Controller, _ names.ModelTag, _ map[string]string) (jimm.API, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		dials[ctl.Name]++
+		return apis[ctl.Name], nil
+	}))
+	ctl1 := dbmodel.Controller{Name: "controller-1"}
+	ctl2 := dbmodel.Controller{Name: "controller-2"}
+
+	for _, ctl := range []*dbmodel.Controller{&ctl1, &ctl2} {
+		api, err := dialer.Dial(context.Background(), ctl, names.ModelTag{}, nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(api.Close(), qt.IsNil)
+	}
+
+	dialer.(interface{ Evict(string) }).Evict("controller-1")
+	c.Check(atomic.LoadInt64(&apis["controller-1"].count), qt.Equals, int64(1))
+	c.Check(atomic.LoadInt64(&apis["controller-2"].count), qt.Equals, int64(0))
+
+	for _, ctl := range []*dbmodel.Controller{&ctl1, &ctl2} {
+		api, err := dialer.Dial(context.Background(), ctl, names.ModelTag{}, nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(api.Close(), qt.IsNil)
+	}
+	c.Check(dials, qt.DeepEquals, map[string]int{
+		"controller-1": 2,
+		"controller-2": 1,
+	})
+}
+
+func TestCacheDialerEvictDuringDial(t *testing.T) {
+	c := qt.New(t)
+
+	staleAPI := &closeCountingAPI{API: &jimmtest.API{}}
+	freshAPI := &closeCountingAPI{API: &jimmtest.API{}}
+	dialingC := make(chan struct{})
+	evictedC := make(chan struct{})
+	var dials int64
+	dialer := jimm.CacheDialer(dialerFunc(func(context.Context, *dbmodel.Controller, names.ModelTag, map[string]string) (jimm.API, error) {
+		if atomic.AddInt64(&dials, 1) == 1 {
+			close(dialingC)
+			<-evictedC
+			return staleAPI, nil
+		}
+		return freshAPI, nil
+	}))
+	ctl := dbmodel.Controller{Name: "test-controller"}
+
+	errC := make(chan error, 1)
+	go func() {
+		api, err := dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+		if err == nil {
+			err = api.Close()
+		}
+		errC <- err
+	}()
+	<-dialingC
+	dialer.(interface{ Evict(string) }).Evict(ctl.Name)
+	close(evictedC)
+	c.Assert(<-errC, qt.IsNil)
+
+	// The connection dialed before the eviction is discarded and a
+	// new one is cached in its place.
+	c.Check(atomic.LoadInt64(&dials), qt.Equals, int64(2))
+	c.Check(atomic.LoadInt64(&staleAPI.count), qt.Equals, int64(1))
+	c.Check(atomic.LoadInt64(&freshAPI.count), qt.Equals, int64(0))
+
+	api, err := dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(api.Close(), qt.IsNil)
+	c.Check(atomic.LoadInt64(&dials), qt.Equals, int64(2))
+}
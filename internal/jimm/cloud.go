@@ -27,7 +27,7 @@ func (j *JIMM) GetUserCloudAccess(ctx context.Context, user *openfga.User, cloud
 }
 
 // GetCloud retrieves the cloud for the given cloud tag. If the cloud
-// cannot be found then an error with the code CodeNotFound is
+// cannot be found then an error with the code CodeCloudNotFound is
 // returned. If the user does not have permission to view the cloud then an
 // error with a code of CodeUnauthorized is returned. If the user only has
 // add-model access to the cloud then the returned Users field will only
@@ -39,6 +39,9 @@ func (j *JIMM) GetCloud(ctx context.Context, user *openfga.User, tag names.Cloud
 	cl.SetTag(tag)
 
 	if err := j.Database.GetCloud(ctx, &cl); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return cl, errors.E(op, errors.CodeCloudNotFound, err)
+		}
 		return cl, errors.E(op, err)
 	}
 
@@ -87,6 +90,24 @@ func (j *JIMM) ForEachUserCloud(ctx context.Context, user *openfga.User, f func(
 	return nil
 }
 
+// ListClouds returns the clouds the given user may add models to, that is
+// the clouds on which the user, or everyone, has add-model or admin
+// access. The returned clouds include their regions, supported auth types
+// and endpoints.
+func (j *JIMM) ListClouds(ctx context.Context, user *openfga.User) ([]dbmodel.Cloud, error) {
+	const op = errors.Op("jimm.ListClouds")
+
+	var clouds []dbmodel.Cloud
+	err := j.ForEachUserCloud(ctx, user, func(c *dbmodel.Cloud) error {
+		clouds = append(clouds, *c)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return clouds, nil
+}
+
 // ForEachCloud iterates through each cloud known to JIMM calling the given
 // function. If f returns an error then iteration stops immediately and the
 // error is returned unmodified. If the given user is not a controller
@@ -349,6 +370,9 @@ func (j *JIMM) doCloudAdmin(ctx context.Context, user *openfga.User, ct names.Cl
 	c.SetTag(ct)
 
 	if err := j.Database.GetCloud(ctx, &c); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, errors.CodeCloudNotFound, err)
+		}
 		return errors.E(op, err)
 	}
 
@@ -565,6 +589,9 @@ func (j *JIMM) UpdateCloud(ctx context.Context, user *openfga.User, ct names.Clo
 	c.SetTag(ct)
 
 	if err := j.Database.GetCloud(ctx, &c); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, errors.CodeCloudNotFound, err)
+		}
 		return errors.E(op, err)
 	}
 	cloudAccess, err := j.GetUserCloudAccess(ctx, user, c.ResourceTag())
@@ -638,6 +665,9 @@ func (j *JIMM) RemoveCloudFromController(ctx context.Context, user *openfga.User
 	cloud.SetTag(ct)
 
 	if err := j.Database.GetCloud(ctx, &cloud); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, errors.CodeCloudNotFound, err)
+		}
 		return errors.E(op, err)
 	}
 
@@ -806,3 +836,37 @@ func (j *JIMM) addCloudToDatabase(ctx context.Context, controller *dbmodel.Contr
 
 	return dbCloud, nil
 }
+
+// CloudUsageSummary returns the number of models, machines, cores and
+// units on each cloud known to JIMM. Only JIMM administrators can
+// perform this operation.
+func (j *JIMM) CloudUsageSummary(ctx context.Context, user *openfga.User) ([]db.CloudUsage, error) {
+	const op = errors.Op("jimm.CloudUsageSummary")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	usage, err := j.Database.GetCloudUsage(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return usage, nil
+}
+
+// UncoveredCloudRegions returns the cloud regions known to JIMM that have
+// no controller able to host models, so adding a model to them would
+// fail. Only JIMM administrators can perform this operation.
+func (j *JIMM) UncoveredCloudRegions(ctx context.Context, user *openfga.User) ([]db.CloudRegionName, error) {
+	const op = errors.Op("jimm.UncoveredCloudRegions")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	regions, err := j.Database.GetUncoveredCloudRegions(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return regions, nil
+}
@@ -4,6 +4,7 @@ package jimm_test
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
@@ -103,7 +104,7 @@ func TestGetCloud(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 
 	_, err = j.GetCloud(ctx, alice, names.NewCloudTag("test-cloud-0"))
-	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeCloudNotFound)
 
 	_, err = j.GetCloud(ctx, charlie, names.NewCloudTag("test-cloud-1"))
 	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
@@ -327,6 +328,95 @@ func TestForEachCloud(t *testing.T) {
 	}})
 }
 
+const listCloudsTestEnv = `clouds:
+- name: public-cloud
+  type: test-provider
+  auth-types:
+  - empty
+  - userpass
+  endpoint: https://public.example.com
+  regions:
+  - name: public-region-1
+  - name: public-region-2
+  users:
+  - user: everyone@external
+    access: add-model
+- name: alice-cloud
+  type: test-provider
+  auth-types:
+  - userpass
+  endpoint: https://alice.example.com
+  regions:
+  - name: alice-region
+  users:
+  - user: alice@canonical.com
+    access: add-model
+- name: bob-cloud
+  type: test-provider
+  regions:
+  - name: bob-region
+  users:
+  - user: bob@canonical.com
+    access: admin
+`
+
+func TestListClouds(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, listCloudsTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	cloudNames := func(clouds []dbmodel.Cloud) []string {
+		var ns []string
+		for _, cl := range clouds {
+			ns = append(ns, cl.Name)
+		}
+		sort.Strings(ns)
+		return ns
+	}
+
+	alice, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	clouds, err := j.ListClouds(ctx, openfga.NewUser(alice, client))
+	c.Assert(err, qt.IsNil)
+	c.Check(cloudNames(clouds), qt.DeepEquals, []string{"alice-cloud", "public-cloud"})
+	for _, cl := range clouds {
+		if cl.Name != "public-cloud" {
+			continue
+		}
+		c.Check([]string(cl.AuthTypes), qt.DeepEquals, []string{"empty", "userpass"})
+		c.Check(cl.Endpoint, qt.Equals, "https://public.example.com")
+		c.Check(cl.Regions, qt.HasLen, 2)
+	}
+
+	bob, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	clouds, err = j.ListClouds(ctx, openfga.NewUser(bob, client))
+	c.Assert(err, qt.IsNil)
+	c.Check(cloudNames(clouds), qt.DeepEquals, []string{"bob-cloud", "public-cloud"})
+
+	// Users without any explicit access only see the public clouds.
+	charlie, err := dbmodel.NewIdentity("charlie@canonical.com")
+	c.Assert(err, qt.IsNil)
+	clouds, err = j.ListClouds(ctx, openfga.NewUser(charlie, client))
+	c.Assert(err, qt.IsNil)
+	c.Check(cloudNames(clouds), qt.DeepEquals, []string{"public-cloud"})
+}
+
 const addHostedCloudTestEnv = `clouds:
 - name: test-cloud
   type: test-provider
@@ -747,7 +837,7 @@ var addHostedCloudToControllerTests = []struct {
 		StorageEndpoint:  "https://example.com/storage",
 	},
 	expectError:     `controller not found`,
-	expectErrorCode: errors.CodeNotFound,
+	expectErrorCode: errors.CodeControllerNotFound,
 }, {
 	name:           "CloudWithReservedName",
 	username:       "alice@canonical.com",
@@ -969,7 +1059,7 @@ var grantCloudAccessTests = []struct {
 	targetUsername:  "bob@canonical.com",
 	access:          "add-model",
 	expectError:     `cloud "test2" not found`,
-	expectErrorCode: errors.CodeNotFound,
+	expectErrorCode: errors.CodeCloudNotFound,
 }, {
 	name:           "Admin grants admin access",
 	env:            grantCloudAccessTestEnv,
@@ -1134,7 +1224,7 @@ var revokeCloudAccessTests = []struct {
 	targetUsername:  "bob@canonical.com",
 	access:          "admin",
 	expectError:     `cloud "test2" not found`,
-	expectErrorCode: errors.CodeNotFound,
+	expectErrorCode: errors.CodeCloudNotFound,
 }, {
 	name:           "Admin revokes 'admin' from another admin",
 	env:            revokeCloudAccessTestEnv,
@@ -1447,7 +1537,7 @@ var removeCloudTests = []struct {
 	username:        "alice@canonical.com",
 	cloud:           "test2",
 	expectError:     `cloud "test2" not found`,
-	expectErrorCode: errors.CodeNotFound,
+	expectErrorCode: errors.CodeCloudNotFound,
 }, {
 	name: "Success",
 	env:  removeCloudTestEnv,
@@ -1583,7 +1673,7 @@ var updateCloudTests = []struct {
 	username:        "alice@canonical.com",
 	cloud:           "test2",
 	expectError:     `cloud "test2" not found`,
-	expectErrorCode: errors.CodeNotFound,
+	expectErrorCode: errors.CodeCloudNotFound,
 }, /* NOTE (alesstimec) Need to figure out what makes test-cloud
 	                        a public cloud giving alice@canonical.com the right
 							to update it.
@@ -1846,7 +1936,7 @@ var removeCloudFromControllerTests = []struct {
 	cloud:           "test2",
 	controllerName:  "controller-2",
 	expectError:     `cloud "test2" not found`,
-	expectErrorCode: errors.CodeNotFound,
+	expectErrorCode: errors.CodeCloudNotFound,
 }, {
 	name: "Success - with other controllers for the cloud",
 	env:  removeCloudFromControllerTestEnv,
@@ -1964,3 +2054,185 @@ func TestRemoveFromControllerCloud(t *testing.T) {
 		})
 	}
 }
+
+const cloudUsageSummaryTestEnv = `clouds:
+- name: cloud-1
+  type: test-provider
+  regions:
+  - name: cloud-1-region
+- name: cloud-2
+  type: test-provider
+  regions:
+  - name: cloud-2-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: cloud-1
+- owner: alice@canonical.com
+  name: cred-2
+  cloud: cloud-2
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: cloud-1
+  region: cloud-1-region
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: cloud-2
+  region: cloud-2-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: cloud-1
+  region: cloud-1-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+  machines: 2
+  cores: 4
+  units: 3
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: cloud-1
+  region: cloud-1-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: dying
+  machines: 1
+  cores: 2
+  units: 1
+- name: model-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  cloud: cloud-1
+  region: cloud-1-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: dead
+  machines: 5
+  cores: 5
+  units: 5
+- name: model-4
+  uuid: 00000002-0000-0000-0000-000000000004
+  controller: controller-2
+  cloud: cloud-2
+  region: cloud-2-region
+  cloud-credential: cred-2
+  owner: alice@canonical.com
+  life: alive
+  machines: 3
+  cores: 6
+  units: 2
+users:
+- username: diane@canonical.com
+  controller-access: superuser
+`
+
+func TestCloudUsageSummary(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, cloudUsageSummaryTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	_, err = j.CloudUsageSummary(ctx, openfga.NewUser(&alice, client))
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	diane := env.User("diane@canonical.com").DBObject(c, j.Database)
+	u := openfga.NewUser(&diane, client)
+	u.JimmAdmin = true
+	usage, err := j.CloudUsageSummary(ctx, u)
+	c.Assert(err, qt.IsNil)
+	c.Check(usage, qt.DeepEquals, []db.CloudUsage{{
+		CloudName: "cloud-1",
+		Models:    2,
+		Machines:  3,
+		Cores:     6,
+		Units:     4,
+	}, {
+		CloudName: "cloud-2",
+		Models:    1,
+		Machines:  3,
+		Cores:     6,
+		Units:     2,
+	}})
+}
+
+const uncoveredCloudRegionsTestEnv = `clouds:
+- name: cloud-1
+  type: test-provider
+  regions:
+  - name: cloud-1-region-1
+  - name: cloud-1-region-2
+- name: cloud-2
+  type: test-provider
+  regions:
+  - name: cloud-2-region-1
+users:
+- username: alice@canonical.com
+  controller-access: login
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: cloud-1
+  region: cloud-1-region-1
+  cloud-regions:
+  - cloud: cloud-1
+    region: cloud-1-region-1
+    priority: 1
+`
+
+func TestUncoveredCloudRegions(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, uncoveredCloudRegionsTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	u := openfga.NewUser(&alice, client)
+	_, err = j.UncoveredCloudRegions(ctx, u)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	u.JimmAdmin = true
+	regions, err := j.UncoveredCloudRegions(ctx, u)
+	c.Assert(err, qt.IsNil)
+	c.Check(regions, qt.DeepEquals, []db.CloudRegionName{{
+		CloudName:  "cloud-1",
+		RegionName: "cloud-1-region-2",
+	}, {
+		CloudName:  "cloud-2",
+		RegionName: "cloud-2-region-1",
+	}})
+}
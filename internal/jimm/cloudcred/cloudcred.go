@@ -4,10 +4,73 @@
 
 package cloudcred
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // IsVisibleAttribute returns whether a cloud-credential attribute is known
 // not to be hidden and can therefore does not need to be redacted.
 func IsVisibleAttribute(provider, authtype, attribute string) bool {
 	return attr[fmt.Sprintf("%s\x1e%s\x1e%s", provider, authtype, attribute)]
 }
+
+// optional holds the credential schema attributes that the pinned juju
+// version marks as optional. The generated attribute table does not
+// record whether an attribute is optional, so they are listed here.
+var optional = map[string]bool{
+	"azure\x1eservice-principal-secret\x1eapplication-object-id":   true,
+	"azure\x1eservice-principal-secret\x1emanaged-subscription-id": true,
+	"kubernetes\x1ecertificate\x1erbac-id":                         true,
+	"kubernetes\x1eclientcertificate\x1erbac-id":                   true,
+	"kubernetes\x1eoauth2\x1erbac-id":                              true,
+	"openstack\x1eaccess-key\x1etenant-id":                         true,
+	"openstack\x1eaccess-key\x1etenant-name":                       true,
+	"openstack\x1eaccess-key\x1eversion":                           true,
+	"openstack\x1euserpass\x1edomain-name":                         true,
+	"openstack\x1euserpass\x1eproject-domain-name":                 true,
+	"openstack\x1euserpass\x1etenant-id":                           true,
+	"openstack\x1euserpass\x1etenant-name":                         true,
+	"openstack\x1euserpass\x1euser-domain-name":                    true,
+	"openstack\x1euserpass\x1eversion":                             true,
+	"vsphere\x1euserpass\x1evmfolder":                              true,
+}
+
+// unchecked holds the providers whose credentials are not checked against
+// their schema. The dummy provider is only used for testing and accepts
+// credentials with any attributes.
+var unchecked = map[string]bool{
+	"dummy": true,
+}
+
+// schemas holds the attribute names of each known provider and auth-type
+// combination, keyed by "<provider>\x1e<auth-type>".
+var schemas = func() map[string][]string {
+	m := make(map[string][]string)
+	for k := range attr {
+		i := strings.LastIndex(k, "\x1e")
+		m[k[:i]] = append(m[k[:i]], k[i+1:])
+	}
+	return m
+}()
+
+// MissingAttributes returns the sorted names of the required attributes in
+// the credential schema of the provider's auth-type that are not in the
+// given credential attributes. Attributes that are not part of the schema
+// are ignored. If the schema of the auth-type is not known no attributes
+// are reported missing.
+func MissingAttributes(provider, authtype string, attributes map[string]string) []string {
+	if unchecked[provider] {
+		return nil
+	}
+	key := fmt.Sprintf("%s\x1e%s", provider, authtype)
+	var missing []string
+	for _, name := range schemas[key] {
+		if _, ok := attributes[name]; !ok && !optional[key+"\x1e"+name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
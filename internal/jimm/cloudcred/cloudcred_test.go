@@ -15,3 +15,39 @@ func TestIsVisibleAttribute(t *testing.T) {
 	qt.Check(t, cloudcred.IsVisibleAttribute("ec2", "access-key", "secret-key"), qt.Equals, false)
 	qt.Check(t, cloudcred.IsVisibleAttribute("ec2", "unknown-auth", "access-key"), qt.Equals, false)
 }
+
+func TestMissingAttributes(t *testing.T) {
+	c := qt.New(t)
+
+	missing := cloudcred.MissingAttributes("ec2", "access-key", map[string]string{
+		"access-key": "key",
+		"secret-key": "secret",
+	})
+	c.Check(missing, qt.IsNil)
+
+	// Attributes that are not in the schema are ignored.
+	missing = cloudcred.MissingAttributes("ec2", "access-key", map[string]string{
+		"access-key": "key",
+		"region":     "eu-west-1",
+	})
+	c.Check(missing, qt.DeepEquals, []string{"secret-key"})
+
+	// Optional attributes may be omitted.
+	missing = cloudcred.MissingAttributes("openstack", "access-key", map[string]string{
+		"access-key": "key",
+		"secret-key": "secret",
+	})
+	c.Check(missing, qt.IsNil)
+
+	// Auth types without a known schema are not checked.
+	missing = cloudcred.MissingAttributes("ec2", "unknown-auth", map[string]string{
+		"anything": "goes",
+	})
+	c.Check(missing, qt.IsNil)
+
+	// The dummy provider accepts any attributes.
+	missing = cloudcred.MissingAttributes("dummy", "userpass", map[string]string{
+		"attr1": "val1",
+	})
+	c.Check(missing, qt.IsNil)
+}
@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
 	"github.com/juju/names/v5"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
@@ -18,11 +20,12 @@ import (
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm/cloudcred"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 )
 
 // GetCloudCredential retrieves the given credential from the database. The
-// returned credential will never contain any attributes, see
-// GetCloudCredentialAttributes to retrieve those. If credentials
+// returned credential will include any labels but will never contain any
+// attributes, see GetCloudCredentialAttributes to retrieve those. If credentials
 // identified by the given tag cannot be found then an errror with a code
 // of CodeNotFound will be returned. If the given user is not a controller
 // superuser or the owner of the credentials then an error with a code of
@@ -46,6 +49,29 @@ func (j *JIMM) GetCloudCredential(ctx context.Context, user *openfga.User, tag n
 	return &credential, nil
 }
 
+// SetCloudCredentialLabels replaces the labels stored with the given
+// credential. Labels are only stored in JIMM's database, so the controllers
+// using the credential are not updated. Only the owner of the credential or
+// a JIMM administrator may set its labels.
+func (j *JIMM) SetCloudCredentialLabels(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, labels map[string]string) error {
+	const op = errors.Op("jimm.SetCloudCredentialLabels")
+
+	if !user.JimmAdmin && user.Name != tag.Owner().Id() {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var credential dbmodel.CloudCredential
+	credential.SetTag(tag)
+	if err := j.Database.GetCloudCredential(ctx, &credential); err != nil {
+		return errors.E(op, err)
+	}
+	credential.Labels = labels
+	if err := j.Database.SetCloudCredential(ctx, &credential); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
 // RevokeCloudCredential checks that the credential with the given path
 // can be revoked  and revokes the credential.
 func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity, tag names.CloudCredentialTag, force bool) error {
@@ -55,6 +81,9 @@ func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
+	ctx, cancel := j.credentialContext(ctx)
+	defer cancel()
+
 	var credential dbmodel.CloudCredential
 	credential.SetTag(tag)
 
@@ -105,6 +134,9 @@ func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity
 		if errors.ErrorCode(err) == errors.CodeNotFound {
 			err = nil
 		}
+		if err != nil {
+			zapctx.Warn(ctx, "failed to revoke credential on controller", zap.String("controller", ctl.Name), zap.Object("credential", credential), zap.Error(err))
+		}
 		return err
 	})
 
@@ -116,9 +148,136 @@ func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity
 	if err != nil {
 		return errors.E(op, err, "failed to revoke credential in local database")
 	}
+	if err := j.OpenFGAClient.RemoveCloudCredential(ctx, tag); err != nil {
+		zapctx.Error(ctx, "failed to remove cloud credential from openfga", zap.String("credential", tag.Id()), zap.Error(err))
+	}
+	return nil
+}
+
+const (
+	// DefaultMaxCredentialAttributesSize is the default maximum total
+	// size, in bytes, of a cloud credential's attributes.
+	DefaultMaxCredentialAttributesSize = 64 * 1024
+
+	// DefaultMaxCredentialAttributes is the default maximum number of
+	// attributes a cloud credential may have.
+	DefaultMaxCredentialAttributes = 64
+
+	// CredentialTimeoutConfigKey is the controller config key holding the
+	// maximum time, as a duration string, that updating or revoking a
+	// cloud credential on the controllers may take.
+	CredentialTimeoutConfigKey = "credential-timeout"
+
+	// DefaultCredentialTimeout is the maximum time updating or revoking a
+	// cloud credential on the controllers may take when no timeout has
+	// been configured.
+	DefaultCredentialTimeout = 5 * time.Minute
+)
+
+// credentialContext returns a context to use when updating or revoking a
+// credential on the controllers. If ctx already has a deadline it is
+// returned unchanged, otherwise the configured credential timeout is
+// applied.
+func (j *JIMM) credentialContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	timeout, err := j.credentialTimeout(ctx)
+	if err != nil {
+		zapctx.Warn(ctx, "invalid credential timeout, using default", zap.Error(err))
+		timeout = DefaultCredentialTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// credentialTimeout returns the configured credential timeout.
+func (j *JIMM) credentialTimeout(ctx context.Context) (time.Duration, error) {
+	config := dbmodel.ControllerConfig{
+		Name: "jimm",
+	}
+	err := j.Database.GetControllerConfig(ctx, &config)
+	if err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return DefaultCredentialTimeout, nil
+		}
+		return 0, err
+	}
+	v, ok := config.Config[CredentialTimeoutConfigKey]
+	if !ok {
+		return DefaultCredentialTimeout, nil
+	}
+	return parseCredentialTimeout(v)
+}
+
+// parseCredentialTimeout parses the given credential timeout config
+// value.
+func parseCredentialTimeout(v interface{}) (time.Duration, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, errors.E(errors.CodeServerConfiguration, fmt.Sprintf("invalid %s value %v", CredentialTimeoutConfigKey, v))
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, errors.E(errors.CodeServerConfiguration, fmt.Sprintf("invalid %s value %q", CredentialTimeoutConfigKey, s))
+	}
+	return d, nil
+}
+
+// checkCredentialAttributes checks that the given credential attributes
+// are within the configured limits. If they are not an error with a code
+// of CodeBadRequest is returned.
+func (j *JIMM) checkCredentialAttributes(attrs map[string]string) error {
+	maxAttributes := j.MaxCredentialAttributes
+	if maxAttributes == 0 {
+		maxAttributes = DefaultMaxCredentialAttributes
+	}
+	if len(attrs) > maxAttributes {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("credential has %d attributes, the maximum is %d", len(attrs), maxAttributes))
+	}
+
+	maxSize := j.MaxCredentialAttributesSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxCredentialAttributesSize
+	}
+	var size int
+	for k, v := range attrs {
+		size += len(k) + len(v)
+	}
+	if size > maxSize {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("credential attributes are %d bytes, the maximum is %d", size, maxSize))
+	}
 	return nil
 }
 
+// checkCredentialAuthType checks that the given auth type is supported by
+// the cloud. If it is not an error with a code of CodeBadRequest is
+// returned. Clouds that do not record any supported auth types accept
+// all auth types.
+func checkCredentialAuthType(cloud dbmodel.Cloud, authType string) error {
+	if len(cloud.AuthTypes) == 0 {
+		return nil
+	}
+	for _, t := range cloud.AuthTypes {
+		if t == authType {
+			return nil
+		}
+	}
+	return errors.E(errors.CodeBadRequest, fmt.Sprintf("auth type %q not supported by cloud %q, supported auth types %q", authType, cloud.Name, []string(cloud.AuthTypes)))
+}
+
+// checkCredentialSchema checks that the given attributes include every
+// required attribute in the credential schema of the auth type for the
+// cloud's provider. If any are missing an error with a code of
+// CodeBadRequest is returned. Attributes that are not part of the schema
+// are allowed.
+func checkCredentialSchema(cloud dbmodel.Cloud, authType string, attrs map[string]string) error {
+	missing := cloudcred.MissingAttributes(cloud.Type, authType, attrs)
+	if len(missing) == 0 {
+		return nil
+	}
+	return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid %q credential for cloud %q: missing attributes %q", authType, cloud.Name, missing))
+}
+
 // UpdateCloudCredentialArgs holds arguments for the cloud credential update
 type UpdateCloudCredentialArgs struct {
 	CredentialTag names.CloudCredentialTag
@@ -129,7 +288,11 @@ type UpdateCloudCredentialArgs struct {
 
 // UpdateCloudCredential checks that the credential can be updated
 // and updates it in the local database and all controllers
-// to which it is deployed.
+// to which it is deployed. Any labels stored with the credential are
+// kept, they are never sent to the controllers or the credential store.
+// If the credential attributes exceed the
+// configured size limits then an error with a code of CodeBadRequest is
+// returned before any changes are made.
 func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, args UpdateCloudCredentialArgs) ([]jujuparams.UpdateCredentialModelResult, error) {
 	const op = errors.Op("jimm.UpdateCloudCredential")
 
@@ -147,6 +310,13 @@ func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, ar
 		}
 	}
 
+	if err := j.checkCredentialAttributes(args.Credential.Attributes); err != nil {
+		return result, errors.E(op, err)
+	}
+
+	ctx, cancel := j.credentialContext(ctx)
+	defer cancel()
+
 	var credential dbmodel.CloudCredential
 	credential.SetTag(args.CredentialTag)
 
@@ -161,6 +331,12 @@ func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, ar
 	if err = j.Database.GetCloud(ctx, &cloud); err != nil {
 		return result, errors.E(op, err)
 	}
+	if err := checkCredentialAuthType(cloud, args.Credential.AuthType); err != nil {
+		return result, errors.E(op, err)
+	}
+	if err := checkCredentialSchema(cloud, args.Credential.AuthType, args.Credential.Attributes); err != nil {
+		return result, errors.E(op, err)
+	}
 
 	models, err := j.Database.GetModelsUsingCredential(ctx, credential.ID)
 	if err != nil {
@@ -203,16 +379,28 @@ func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, ar
 	if args.SkipUpdate {
 		return result, nil
 	}
+	if !args.SkipCheck {
+		credential.LastCheckedAt = sql.NullTime{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		}
+	}
 
 	if err := j.updateCredential(ctx, &credential); err != nil {
 		return result, errors.E(op, err)
 	}
+	if err := j.Database.AddPendingCredentialUpdates(ctx, &credential, controllers); err != nil {
+		return result, errors.E(op, err)
+	}
 
 	err = j.forEachController(ctx, controllers, func(ctl *dbmodel.Controller, api API) error {
 		models, err := j.updateControllerCloudCredential(ctx, &credential, api.UpdateCredential)
 		if err != nil {
 			return err
 		}
+		if err := j.Database.DeletePendingCredentialUpdate(ctx, &credential, ctl); err != nil {
+			zapctx.Error(ctx, "failed to clear pending credential update", zap.String("controller", ctl.Name), zap.Object("credential", credential), zap.Error(err))
+		}
 		if args.SkipCheck {
 			resultMu.Lock()
 			defer resultMu.Unlock()
@@ -226,6 +414,311 @@ func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, ar
 	return result, nil
 }
 
+// StaleCredentials returns the cloud credentials that have not been
+// checked against the controllers using them in the given duration,
+// including any credentials that have never been checked. The returned
+// credentials will not contain any attributes. Only JIMM administrators
+// may list stale credentials.
+func (j *JIMM) StaleCredentials(ctx context.Context, user *openfga.User, olderThan time.Duration) ([]dbmodel.CloudCredential, error) {
+	const op = errors.Op("jimm.StaleCredentials")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	creds, err := j.Database.GetCloudCredentialsCheckedBefore(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	for i := range creds {
+		creds[i].Attributes = nil
+	}
+	return creds, nil
+}
+
+// OrphanedCredentials returns the cloud credentials that are no longer
+// used by any live model, for example because every model using them has
+// been destroyed, and so could be revoked. The returned credentials will
+// not contain any attributes. Only JIMM administrators may list orphaned
+// credentials.
+func (j *JIMM) OrphanedCredentials(ctx context.Context, user *openfga.User) ([]dbmodel.CloudCredential, error) {
+	const op = errors.Op("jimm.OrphanedCredentials")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	creds, err := j.Database.GetUnusedCloudCredentials(ctx, state.Dying.String(), state.Dead.String())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	for i := range creds {
+		creds[i].Attributes = nil
+	}
+	return creds, nil
+}
+
+// ModelsUsingCredential returns the models that use the given cloud
+// credential, sorted by name. Only the models the given user has at least
+// read access to are returned, unless the user is a JIMM administrator in
+// which case all the models are returned. If the credential cannot be
+// found an error with a code of CodeNotFound is returned.
+func (j *JIMM) ModelsUsingCredential(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) ([]dbmodel.Model, error) {
+	const op = errors.Op("jimm.ModelsUsingCredential")
+
+	var credential dbmodel.CloudCredential
+	credential.SetTag(tag)
+	if err := j.Database.GetCloudCredential(ctx, &credential); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	models, err := j.Database.GetModelsUsingCredential(ctx, credential.ID)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if !user.JimmAdmin {
+		visible := models[:0]
+		for _, m := range models {
+			access, err := j.GetUserModelAccess(ctx, user, m.ResourceTag())
+			if err != nil {
+				return nil, errors.E(op, err)
+			}
+			if allowedModelAccess["read"][access] {
+				visible = append(visible, m)
+			}
+		}
+		models = visible
+	}
+	sort.Slice(models, func(i, k int) bool {
+		return models[i].Name < models[k].Name
+	})
+	return models, nil
+}
+
+// A CredentialDeployment describes a controller to which a cloud
+// credential has been deployed.
+type CredentialDeployment struct {
+	// Controller is the controller the credential is deployed to.
+	Controller dbmodel.Controller
+
+	// Models contains the models on the controller that use the
+	// credential, sorted by name.
+	Models []dbmodel.Model
+}
+
+// CredentialDeployments returns the controllers to which the given cloud
+// credential is currently deployed, along with the models using the
+// credential on each, sorted by controller name. A credential is
+// deployed to every controller hosting a model that uses it. Only the
+// owner of the credential or a JIMM administrator may list its
+// deployments. If the credential cannot be found an error with a code of
+// CodeNotFound is returned.
+func (j *JIMM) CredentialDeployments(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) ([]CredentialDeployment, error) {
+	const op = errors.Op("jimm.CredentialDeployments")
+
+	if !user.JimmAdmin && user.Tag() != tag.Owner() {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var credential dbmodel.CloudCredential
+	credential.SetTag(tag)
+	if err := j.Database.GetCloudCredential(ctx, &credential); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	models, err := j.Database.GetModelsUsingCredential(ctx, credential.ID)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	sort.Slice(models, func(i, k int) bool {
+		return models[i].Name < models[k].Name
+	})
+
+	var deployments []CredentialDeployment
+	index := make(map[uint]int)
+	for _, m := range models {
+		i, ok := index[m.ControllerID]
+		if !ok {
+			i = len(deployments)
+			index[m.ControllerID] = i
+			deployments = append(deployments, CredentialDeployment{Controller: m.Controller})
+		}
+		deployments[i].Models = append(deployments[i].Models, m)
+	}
+	sort.Slice(deployments, func(i, k int) bool {
+		return deployments[i].Controller.Name < deployments[k].Controller.Name
+	})
+	return deployments, nil
+}
+
+// PendingCredentialUpdates returns the names of the controllers on which
+// the latest update to the given cloud credential has not yet been
+// applied, sorted by name. An update is pending on a controller until it
+// has been successfully sent to that controller, updates that failed are
+// sent again the next time the credential is updated. Only the owner of
+// the credential or a JIMM administrator may list its pending updates. If
+// the credential cannot be found an error with a code of CodeNotFound is
+// returned.
+func (j *JIMM) PendingCredentialUpdates(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) ([]string, error) {
+	const op = errors.Op("jimm.PendingCredentialUpdates")
+
+	if !user.JimmAdmin && user.Tag() != tag.Owner() {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var credential dbmodel.CloudCredential
+	credential.SetTag(tag)
+	if err := j.Database.GetCloudCredential(ctx, &credential); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	pending, err := j.Database.GetPendingCredentialUpdates(ctx, credential.ID)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	var controllers []string
+	for _, p := range pending {
+		controllers = append(controllers, p.ControllerName)
+	}
+	return controllers, nil
+}
+
+// CredentialPendingUpdates holds the controllers on which the latest
+// update to a cloud credential has not yet been applied.
+type CredentialPendingUpdates struct {
+	// Credential is the path of the credential, in the form
+	// cloud/owner/name.
+	Credential string
+
+	// Controllers contains the names of the controllers on which the
+	// update is pending, sorted by name.
+	Controllers []string
+}
+
+// AllPendingCredentialUpdates returns every cloud credential that has an
+// update that has not yet been applied on all the controllers to which
+// it is deployed, sorted by credential path. Only JIMM administrators may
+// list all pending credential updates.
+func (j *JIMM) AllPendingCredentialUpdates(ctx context.Context, user *openfga.User) ([]CredentialPendingUpdates, error) {
+	const op = errors.Op("jimm.AllPendingCredentialUpdates")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	pending, err := j.Database.GetPendingCredentialUpdates(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	var updates []CredentialPendingUpdates
+	for _, p := range pending {
+		path := dbmodel.CloudCredential{
+			CloudName:         p.CloudName,
+			OwnerIdentityName: p.OwnerIdentityName,
+			Name:              p.CredentialName,
+		}.Path()
+		if len(updates) == 0 || updates[len(updates)-1].Credential != path {
+			updates = append(updates, CredentialPendingUpdates{Credential: path})
+		}
+		u := &updates[len(updates)-1]
+		u.Controllers = append(u.Controllers, p.ControllerName)
+	}
+	return updates, nil
+}
+
+// GrantCredentialAccess grants the given access level on the given cloud
+// credential to the given user, allowing them to create models using the
+// credential. The access level is either "read", which allows the
+// credential to be used, or "admin", which also allows access to the
+// credential to be shared with others. Only the owner of the credential,
+// a user with admin access to the credential or a JIMM administrator may
+// grant access. If the credential is not found then an error with the
+// code CodeNotFound is returned.
+func (j *JIMM) GrantCredentialAccess(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, ut names.UserTag, access string) error {
+	const op = errors.Op("jimm.GrantCredentialAccess")
+
+	targetRelation, err := ToCloudCredentialRelation(access)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("failed to recognize given access: %q", access), err)
+	}
+	targetUser, err := j.credentialAccessTarget(ctx, user, tag, ut)
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	currentRelation := targetUser.GetCloudCredentialAccess(ctx, tag)
+	switch currentRelation {
+	case ofganames.AdministratorRelation:
+		return nil
+	case ofganames.ReaderRelation:
+		if targetRelation == ofganames.ReaderRelation {
+			return nil
+		}
+	}
+	if err := targetUser.SetCloudCredentialAccess(ctx, tag, targetRelation); err != nil {
+		return errors.E(op, err, "failed to set cloud credential access")
+	}
+	return nil
+}
+
+// RevokeCredentialAccess revokes the given access level on the given
+// cloud credential from the given user. Revoking "read" access removes
+// all access to the credential. Only the owner of the credential, a user
+// with admin access to the credential or a JIMM administrator may revoke
+// access. Models already using the credential are not affected. If the
+// credential is not found then an error with the code CodeNotFound is
+// returned.
+func (j *JIMM) RevokeCredentialAccess(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, ut names.UserTag, access string) error {
+	const op = errors.Op("jimm.RevokeCredentialAccess")
+
+	targetRelation, err := ToCloudCredentialRelation(access)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("failed to recognize given access: %q", access), err)
+	}
+	targetUser, err := j.credentialAccessTarget(ctx, user, tag, ut)
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	// Having admin access to a credential indirectly grants read
+	// access, so revoking read access must remove both relations.
+	relations := []openfga.Relation{ofganames.AdministratorRelation}
+	if targetRelation == ofganames.ReaderRelation {
+		relations = append(relations, ofganames.ReaderRelation)
+	}
+	if err := targetUser.UnsetCloudCredentialAccess(ctx, tag, relations...); err != nil {
+		return errors.E(op, err, "failed to unset cloud credential access")
+	}
+	return nil
+}
+
+// credentialAccessTarget checks that the given user may change access to
+// the given credential and returns the user whose access is to be
+// changed.
+func (j *JIMM) credentialAccessTarget(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, ut names.UserTag) (*openfga.User, error) {
+	if ut.Id() == tag.Owner().Id() {
+		return nil, errors.E(errors.CodeBadRequest, "cannot change the access of the credential owner")
+	}
+	if !user.JimmAdmin && user.Name != tag.Owner().Id() {
+		if user.GetCloudCredentialAccess(ctx, tag) != ofganames.AdministratorRelation {
+			return nil, errors.E(errors.CodeUnauthorized, "unauthorized")
+		}
+	}
+
+	var credential dbmodel.CloudCredential
+	credential.SetTag(tag)
+	if err := j.Database.GetCloudCredential(ctx, &credential); err != nil {
+		return nil, err
+	}
+
+	targetUser := &dbmodel.Identity{}
+	targetUser.SetTag(ut)
+	if err := j.Database.GetIdentity(ctx, targetUser); err != nil {
+		return nil, err
+	}
+	return openfga.NewUser(targetUser, j.OpenFGAClient), nil
+}
+
 // updateCredential updates the credential stored in JIMM's database.
 func (j *JIMM) updateCredential(ctx context.Context, credential *dbmodel.CloudCredential) error {
 	const op = errors.Op("jimm.updateCredential")
@@ -243,15 +736,15 @@ func (j *JIMM) updateCredential(ctx context.Context, credential *dbmodel.CloudCr
 	credential1.Attributes = nil
 	credential1.AttributesInVault = true
 	if err := j.Database.SetCloudCredential(ctx, &credential1); err != nil {
-		zapctx.Error(ctx, "failed to store credential id", zap.Error(err))
+		zapctx.Error(ctx, "failed to store credential id", zap.Object("credential", credential), zap.Error(err))
 		return errors.E(op, err)
 	}
 	if err := j.CredentialStore.Put(ctx, credential.ResourceTag(), credential.Attributes); err != nil {
-		zapctx.Error(ctx, "failed to store credentials", zap.Error(err))
+		zapctx.Error(ctx, "failed to store credentials", zap.Object("credential", credential), zap.Error(err))
 		return errors.E(op, err)
 	}
 
-	zapctx.Info(ctx, "credential store location", zap.Bool("vault", credential.AttributesInVault))
+	zapctx.Info(ctx, "credential store location", zap.Object("credential", credential), zap.Bool("vault", credential.AttributesInVault))
 
 	return nil
 }
@@ -280,6 +773,7 @@ func (j *JIMM) updateControllerCloudCredential(
 		},
 	})
 	if err != nil {
+		zapctx.Warn(ctx, "failed to update credential on controller", zap.Object("credential", cred), zap.Error(err))
 		return models, errors.E(op, err)
 	}
 	return models, nil
@@ -3,9 +3,11 @@
 package jimm_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -16,7 +18,10 @@ import (
 	"github.com/juju/juju/core/status"
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
 	"github.com/lestrrat-go/jwx/v2/jwk"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
@@ -1743,6 +1748,446 @@ func TestCloudCredentialAttributeStore(t *testing.T) {
 	c.Check(attr, qt.DeepEquals, args.Credential.Attributes)
 }
 
+func TestUpdateCloudCredentialAttributeLimits(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	attrStore := testCloudCredentialAttributeStore{
+		attrs: make(map[string]map[string]string),
+	}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{},
+		},
+		CredentialStore:             attrStore,
+		MaxCredentialAttributesSize: 32,
+		MaxCredentialAttributes:     2,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, `clouds:
+- name: test
+  type: test-provider
+  regions:
+  - name: test-region
+`)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&u, client)
+	tag := names.NewCloudCredentialTag("test/alice@canonical.com/cred-1")
+
+	// The attributes are too large.
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "test-user",
+				"password": "a-password-that-is-far-too-long",
+			},
+		},
+	})
+	c.Check(err, qt.ErrorMatches, `credential attributes are 56 bytes, the maximum is 32`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+	c.Check(attrStore.attrs, qt.HasLen, 0)
+
+	// There are too many attributes.
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"a": "1",
+				"b": "2",
+				"c": "3",
+			},
+		},
+	})
+	c.Check(err, qt.ErrorMatches, `credential has 3 attributes, the maximum is 2`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+	c.Check(attrStore.attrs, qt.HasLen, 0)
+
+	// Attributes exactly at the limits are accepted.
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "test-user",
+				"password": "test-pw",
+			},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(attrStore.attrs[tag.String()], qt.DeepEquals, map[string]string{
+		"username": "test-user",
+		"password": "test-pw",
+	})
+}
+
+func TestUpdateCloudCredentialUnsupportedAuthType(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{},
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, `clouds:
+- name: test
+  type: test-provider
+  auth-types:
+  - empty
+  - access-key
+  regions:
+  - name: test-region
+`)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&u, client)
+	tag := names.NewCloudCredentialTag("test/alice@canonical.com/cred-1")
+
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "test-user",
+				"password": "test-pw",
+			},
+		},
+	})
+	c.Check(err, qt.ErrorMatches, `auth type "userpass" not supported by cloud "test", supported auth types \["empty" "access-key"\]`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	cred := dbmodel.CloudCredential{
+		CloudName:         "test",
+		OwnerIdentityName: "alice@canonical.com",
+		Name:              "cred-1",
+	}
+	err = j.Database.GetCloudCredential(ctx, &cred)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "access-key",
+			Attributes: map[string]string{
+				"access-key": "key",
+				"secret-key": "secret",
+			},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+}
+
+func TestUpdateCloudCredentialSchema(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	attrStore := testCloudCredentialAttributeStore{
+		attrs: make(map[string]map[string]string),
+	}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{},
+		},
+		CredentialStore: attrStore,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, `clouds:
+- name: aws
+  type: ec2
+  auth-types:
+  - access-key
+  regions:
+  - name: eu-west-1
+`)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&u, client)
+	tag := names.NewCloudCredentialTag("aws/alice@canonical.com/cred-1")
+
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "access-key",
+			Attributes: map[string]string{
+				"access-key": "key",
+				"region":     "eu-west-1",
+			},
+		},
+	})
+	c.Check(err, qt.ErrorMatches, `invalid "access-key" credential for cloud "aws": missing attributes \["secret-key"\]`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	// Nothing is stored for a rejected credential.
+	cred := dbmodel.CloudCredential{
+		CloudName:         "aws",
+		OwnerIdentityName: "alice@canonical.com",
+		Name:              "cred-1",
+	}
+	err = j.Database.GetCloudCredential(ctx, &cred)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+	c.Check(attrStore.attrs, qt.HasLen, 0)
+
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "access-key",
+			Attributes: map[string]string{
+				"access-key": "key",
+				"secret-key": "secret",
+				"region":     "eu-west-1",
+			},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(attrStore.attrs[tag.String()], qt.DeepEquals, map[string]string{
+		"access-key": "key",
+		"secret-key": "secret",
+		"region":     "eu-west-1",
+	})
+}
+
+const credentialLogRedactionTestEnv = `clouds:
+- name: test
+  type: test-provider
+  regions:
+  - name: test-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test
+  region: test-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test
+  region: test-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+`
+
+func TestUpdateCloudCredentialLogRedaction(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zap.DebugLevel))
+	ctx := zapctx.WithLogger(context.Background(), logger)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				CheckCredentialModels_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+				UpdateCredential_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, errors.E("test error")
+				},
+			},
+		},
+		CredentialStore: testCloudCredentialAttributeStore{
+			attrs: make(map[string]map[string]string),
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, credentialLogRedactionTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&u, client)
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: names.NewCloudCredentialTag("test/alice@canonical.com/cred-1"),
+		Credential: jujuparams.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "secret-username",
+				"password": "secret-password",
+			},
+		},
+	})
+	c.Assert(err, qt.ErrorMatches, `test error`)
+
+	logs := buf.String()
+	c.Check(strings.Contains(logs, "failed to update credential on controller"), qt.IsTrue)
+	c.Check(strings.Contains(logs, `"path":"test/alice@canonical.com/cred-1"`), qt.IsTrue)
+	c.Check(strings.Contains(logs, `"attributes":["password","username"]`), qt.IsTrue)
+	c.Check(strings.Contains(logs, "secret-username"), qt.IsFalse)
+	c.Check(strings.Contains(logs, "secret-password"), qt.IsFalse)
+}
+
+func TestCloudCredentialLabels(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	var sentCreds []jujuparams.TaggedCredential
+	var mu sync.Mutex
+	recordCredential := func(_ context.Context, cred jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		sentCreds = append(sentCreds, cred)
+		return nil, nil
+	}
+	attrStore := testCloudCredentialAttributeStore{
+		attrs: make(map[string]map[string]string),
+	}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				CheckCredentialModels_: recordCredential,
+				UpdateCredential_:      recordCredential,
+			},
+		},
+		CredentialStore: attrStore,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+users:
+- username: alice@canonical.com
+- username: bob@canonical.com
+`)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&alice, client)
+	tag := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1")
+	labels := map[string]string{
+		"purpose":     "testing",
+		"owner-email": "alice@example.com",
+	}
+
+	// Setting the labels does not contact the controller.
+	err = j.SetCloudCredentialLabels(ctx, user, tag, labels)
+	c.Assert(err, qt.IsNil)
+	c.Check(sentCreds, qt.HasLen, 0)
+
+	// Updating the credential keeps the existing labels.
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "alice",
+				"password": "secret",
+			},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+
+	cred, err := j.GetCloudCredential(ctx, user, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(cred.Labels, qt.DeepEquals, dbmodel.StringMap(labels))
+	c.Check(cred.Attributes, qt.IsNil)
+
+	// The labels are never sent to the controller or the credential store.
+	c.Assert(sentCreds, qt.HasLen, 2)
+	for _, sent := range sentCreds {
+		c.Check(sent.Credential.Attributes, qt.DeepEquals, map[string]string{
+			"username": "alice",
+			"password": "secret",
+		})
+	}
+	c.Check(attrStore.attrs[tag.String()], qt.DeepEquals, map[string]string{
+		"username": "alice",
+		"password": "secret",
+	})
+
+	// Setting the labels replaces them without contacting the controller.
+	sentCreds = nil
+	err = j.SetCloudCredentialLabels(ctx, user, tag, map[string]string{"purpose": "production"})
+	c.Assert(err, qt.IsNil)
+	c.Check(sentCreds, qt.HasLen, 0)
+	cred, err = j.GetCloudCredential(ctx, user, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(cred.Labels, qt.DeepEquals, dbmodel.StringMap{"purpose": "production"})
+	c.Check(attrStore.attrs[tag.String()]["password"], qt.Equals, "secret")
+
+	// Other users cannot set the labels.
+	bob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	err = j.SetCloudCredentialLabels(ctx, openfga.NewUser(&bob, client), tag, nil)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+}
+
 type testCloudCredentialAttributeStore struct {
 	attrs map[string]map[string]string
 }
@@ -1751,54 +2196,714 @@ func (s testCloudCredentialAttributeStore) Get(_ context.Context, tag names.Clou
 	return s.attrs[tag.String()], nil
 }
 
-func (s testCloudCredentialAttributeStore) Put(_ context.Context, tag names.CloudCredentialTag, attr map[string]string) error {
-	s.attrs[tag.String()] = attr
-	return nil
+func (s testCloudCredentialAttributeStore) Put(_ context.Context, tag names.CloudCredentialTag, attr map[string]string) error {
+	s.attrs[tag.String()] = attr
+	return nil
+}
+
+func (s testCloudCredentialAttributeStore) GetControllerCredentials(ctx context.Context, controllerName string) (string, string, error) {
+	return "", "", errors.E(errors.CodeNotImplemented)
+}
+
+func (s testCloudCredentialAttributeStore) PutControllerCredentials(ctx context.Context, controllerName string, username string, password string) error {
+	return errors.E(errors.CodeNotImplemented)
+}
+
+func (s testCloudCredentialAttributeStore) GetJWKS(ctx context.Context) (jwk.Set, error) {
+	return nil, errors.E(errors.CodeNotImplemented)
+}
+
+func (s testCloudCredentialAttributeStore) GetJWKSPrivateKey(ctx context.Context) ([]byte, error) {
+	return nil, errors.E(errors.CodeNotImplemented)
+}
+
+func (s testCloudCredentialAttributeStore) GetJWKSExpiry(ctx context.Context) (time.Time, error) {
+	return time.Now(), errors.E(errors.CodeNotImplemented)
+}
+
+func (s testCloudCredentialAttributeStore) PutJWKS(ctx context.Context, jwks jwk.Set) error {
+	return errors.E(errors.CodeNotImplemented)
+}
+func (s testCloudCredentialAttributeStore) PutJWKSPrivateKey(ctx context.Context, pem []byte) error {
+	return errors.E(errors.CodeNotImplemented)
+}
+
+func (s testCloudCredentialAttributeStore) PutJWKSExpiry(ctx context.Context, expiry time.Time) error {
+	return errors.E(errors.CodeNotImplemented)
+}
+
+func (s testCloudCredentialAttributeStore) CleanupJWKS(ctx context.Context) error {
+	return errors.E(errors.CodeNotImplemented)
+}
+
+func (s testCloudCredentialAttributeStore) CleanupOAuthSecrets(ctx context.Context) error {
+	return errors.E(errors.CodeNotImplemented)
+}
+
+func (s testCloudCredentialAttributeStore) GetOAuthSecret(ctx context.Context) ([]byte, error) {
+	return nil, errors.E(errors.CodeNotImplemented)
+}
+
+func (s testCloudCredentialAttributeStore) PutOAuthSecret(ctx context.Context, raw []byte) error {
+	return errors.E(errors.CodeNotImplemented)
+}
+
+func TestStaleCredentials(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				CheckCredentialModels_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+				UpdateCredential_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+			},
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+  auth-type: empty
+- owner: alice@canonical.com
+  name: cred-2
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+users:
+- username: alice@canonical.com
+- username: bob@canonical.com
+  controller-access: superuser
+`)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	aliceUser := openfga.NewUser(&alice, client)
+	bob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bobUser := openfga.NewUser(&bob, client)
+	bobUser.JimmAdmin = true
+
+	// Neither credential has been checked.
+	creds, err := j.StaleCredentials(ctx, bobUser, time.Hour)
+	c.Assert(err, qt.IsNil)
+	c.Check(credentialNames(creds), qt.DeepEquals, []string{"cred-1", "cred-2"})
+
+	_, err = j.UpdateCloudCredential(ctx, aliceUser, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1"),
+		Credential: jujuparams.CloudCredential{
+			AuthType: "empty",
+		},
+	})
+	c.Assert(err, qt.IsNil)
+
+	// The recently checked credential is no longer stale.
+	creds, err = j.StaleCredentials(ctx, bobUser, time.Hour)
+	c.Assert(err, qt.IsNil)
+	c.Check(credentialNames(creds), qt.DeepEquals, []string{"cred-2"})
+	c.Check(creds[0].Attributes, qt.IsNil)
+
+	// Until the threshold has passed.
+	creds, err = j.StaleCredentials(ctx, bobUser, 0)
+	c.Assert(err, qt.IsNil)
+	c.Check(credentialNames(creds), qt.DeepEquals, []string{"cred-1", "cred-2"})
+
+	_, err = j.StaleCredentials(ctx, aliceUser, time.Hour)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
 }
 
-func (s testCloudCredentialAttributeStore) GetControllerCredentials(ctx context.Context, controllerName string) (string, string, error) {
-	return "", "", errors.E(errors.CodeNotImplemented)
-}
+func TestOrphanedCredentials(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
 
-func (s testCloudCredentialAttributeStore) PutControllerCredentials(ctx context.Context, controllerName string, username string, password string) error {
-	return errors.E(errors.CodeNotImplemented)
-}
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
 
-func (s testCloudCredentialAttributeStore) GetJWKS(ctx context.Context) (jwk.Set, error) {
-	return nil, errors.E(errors.CodeNotImplemented)
-}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
 
-func (s testCloudCredentialAttributeStore) GetJWKSPrivateKey(ctx context.Context) ([]byte, error) {
-	return nil, errors.E(errors.CodeNotImplemented)
-}
+	env := jimmtest.ParseEnvironment(c, `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+  auth-type: empty
+- owner: alice@canonical.com
+  name: cred-2
+  cloud: test-cloud
+  auth-type: empty
+- owner: alice@canonical.com
+  name: cred-3
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-3
+  owner: alice@canonical.com
+  life: dying
+users:
+- username: alice@canonical.com
+- username: bob@canonical.com
+  controller-access: superuser
+`)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
 
-func (s testCloudCredentialAttributeStore) GetJWKSExpiry(ctx context.Context) (time.Time, error) {
-	return time.Now(), errors.E(errors.CodeNotImplemented)
-}
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	aliceUser := openfga.NewUser(&alice, client)
+	bob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bobUser := openfga.NewUser(&bob, client)
+	bobUser.JimmAdmin = true
 
-func (s testCloudCredentialAttributeStore) PutJWKS(ctx context.Context, jwks jwk.Set) error {
-	return errors.E(errors.CodeNotImplemented)
-}
-func (s testCloudCredentialAttributeStore) PutJWKSPrivateKey(ctx context.Context, pem []byte) error {
-	return errors.E(errors.CodeNotImplemented)
+	// cred-2 is unused and cred-3 is only used by a dying model.
+	creds, err := j.OrphanedCredentials(ctx, bobUser)
+	c.Assert(err, qt.IsNil)
+	c.Check(credentialNames(creds), qt.DeepEquals, []string{"cred-2", "cred-3"})
+	c.Check(creds[0].Attributes, qt.IsNil)
+
+	// Destroying the only model using cred-1 orphans it.
+	m := env.Model("alice@canonical.com", "model-1").DBObject(c, j.Database)
+	err = j.Database.DeleteModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+
+	creds, err = j.OrphanedCredentials(ctx, bobUser)
+	c.Assert(err, qt.IsNil)
+	c.Check(credentialNames(creds), qt.DeepEquals, []string{"cred-1", "cred-2", "cred-3"})
+
+	_, err = j.OrphanedCredentials(ctx, aliceUser)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
 }
 
-func (s testCloudCredentialAttributeStore) PutJWKSExpiry(ctx context.Context, expiry time.Time) error {
-	return errors.E(errors.CodeNotImplemented)
+func credentialNames(creds []dbmodel.CloudCredential) []string {
+	var result []string
+	for _, cred := range creds {
+		result = append(result, cred.Name)
+	}
+	return result
 }
 
-func (s testCloudCredentialAttributeStore) CleanupJWKS(ctx context.Context) error {
-	return errors.E(errors.CodeNotImplemented)
+func TestModelsUsingCredential(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+  auth-type: empty
+- owner: alice@canonical.com
+  name: cred-2
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+  users:
+  - user: bob@canonical.com
+    access: read
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-2
+  owner: alice@canonical.com
+  life: alive
+users:
+- username: bob@canonical.com
+- username: charlie@canonical.com
+`)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	tag := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1")
+	modelNames := func(models []dbmodel.Model) []string {
+		var result []string
+		for _, m := range models {
+			result = append(result, m.Name)
+		}
+		return result
+	}
+
+	charlie := env.User("charlie@canonical.com").DBObject(c, j.Database)
+	admin := openfga.NewUser(&charlie, client)
+	admin.JimmAdmin = true
+	models, err := j.ModelsUsingCredential(ctx, admin, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(modelNames(models), qt.DeepEquals, []string{"model-1", "model-2"})
+
+	// Other users only see the models they have access to.
+	bob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	models, err = j.ModelsUsingCredential(ctx, openfga.NewUser(&bob, client), tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(modelNames(models), qt.DeepEquals, []string{"model-2"})
+
+	_, err = j.ModelsUsingCredential(ctx, admin, names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-3"))
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
 }
 
-func (s testCloudCredentialAttributeStore) CleanupOAuthSecrets(ctx context.Context) error {
-	return errors.E(errors.CodeNotImplemented)
+func TestCredentialDeployments(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+  auth-type: empty
+- owner: alice@canonical.com
+  name: cred-2
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-2
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-4
+  uuid: 00000002-0000-0000-0000-000000000004
+  controller: controller-2
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-2
+  owner: alice@canonical.com
+  life: alive
+users:
+- username: bob@canonical.com
+`)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	tag := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1")
+	type deployment struct {
+		Controller string
+		Models     []string
+	}
+	summarize := func(deployments []jimm.CredentialDeployment) []deployment {
+		var result []deployment
+		for _, d := range deployments {
+			var models []string
+			for _, m := range d.Models {
+				models = append(models, m.Name)
+			}
+			result = append(result, deployment{Controller: d.Controller.Name, Models: models})
+		}
+		return result
+	}
+
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	deployments, err := j.CredentialDeployments(ctx, openfga.NewUser(&alice, client), tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(summarize(deployments), qt.DeepEquals, []deployment{{
+		Controller: "controller-1",
+		Models:     []string{"model-1", "model-2"},
+	}, {
+		Controller: "controller-2",
+		Models:     []string{"model-3"},
+	}})
+
+	bob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	_, err = j.CredentialDeployments(ctx, openfga.NewUser(&bob, client), tag)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	admin := openfga.NewUser(&bob, client)
+	admin.JimmAdmin = true
+	deployments, err = j.CredentialDeployments(ctx, admin, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(deployments, qt.HasLen, 2)
+
+	_, err = j.CredentialDeployments(ctx, admin, names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-3"))
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
 }
 
-func (s testCloudCredentialAttributeStore) GetOAuthSecret(ctx context.Context) ([]byte, error) {
-	return nil, errors.E(errors.CodeNotImplemented)
+const pendingCredentialUpdatesTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+  auth-type: empty
+- owner: alice@canonical.com
+  name: cred-2
+  cloud: test-cloud
+  auth-type: empty
+- owner: bob@canonical.com
+  name: cred-3
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-cloud-region
+- name: controller-3
+  uuid: 00000001-0000-0000-0000-000000000003
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-2
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-3
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-4
+  uuid: 00000002-0000-0000-0000-000000000004
+  controller: controller-2
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-2
+  owner: alice@canonical.com
+  life: alive
+- name: model-5
+  uuid: 00000002-0000-0000-0000-000000000005
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-3
+  owner: bob@canonical.com
+  life: alive
+`
+
+func TestPendingCredentialUpdates(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	// Updates to controller-2 and controller-3 fail until they are
+	// marked as available.
+	var available sync.Map
+	updateCredential := func(name string) func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+		return func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+			if _, ok := available.Load(name); !ok && name != "controller-1" {
+				return nil, errors.E("controller unavailable")
+			}
+			return nil, nil
+		}
+	}
+	dialers := make(jimmtest.DialerMap)
+	for _, name := range []string{"controller-1", "controller-2", "controller-3"} {
+		dialers[name] = &jimmtest.Dialer{
+			API: &jimmtest.API{
+				UpdateCredential_: updateCredential(name),
+			},
+		}
+	}
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer:        dialers,
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, pendingCredentialUpdatesTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	aliceUser := openfga.NewUser(&alice, client)
+	bob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bobUser := openfga.NewUser(&bob, client)
+	admin := openfga.NewUser(&bob, client)
+	admin.JimmAdmin = true
+
+	update := func(u *openfga.User, path string) error {
+		_, err := j.UpdateCloudCredential(ctx, u, jimm.UpdateCloudCredentialArgs{
+			CredentialTag: names.NewCloudCredentialTag(path),
+			Credential: jujuparams.CloudCredential{
+				AuthType: "empty",
+			},
+			SkipCheck: true,
+		})
+		return err
+	}
+	err = update(aliceUser, "test-cloud/alice@canonical.com/cred-1")
+	c.Check(err, qt.ErrorMatches, `controller unavailable`)
+	err = update(aliceUser, "test-cloud/alice@canonical.com/cred-2")
+	c.Check(err, qt.ErrorMatches, `controller unavailable`)
+	err = update(bobUser, "test-cloud/bob@canonical.com/cred-3")
+	c.Check(err, qt.IsNil)
+
+	cred1 := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1")
+	controllers, err := j.PendingCredentialUpdates(ctx, aliceUser, cred1)
+	c.Assert(err, qt.IsNil)
+	c.Check(controllers, qt.DeepEquals, []string{"controller-2", "controller-3"})
+
+	_, err = j.PendingCredentialUpdates(ctx, bobUser, cred1)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	_, err = j.AllPendingCredentialUpdates(ctx, aliceUser)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	updates, err := j.AllPendingCredentialUpdates(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	c.Check(updates, qt.DeepEquals, []jimm.CredentialPendingUpdates{{
+		Credential:  "test-cloud/alice@canonical.com/cred-1",
+		Controllers: []string{"controller-2", "controller-3"},
+	}, {
+		Credential:  "test-cloud/alice@canonical.com/cred-2",
+		Controllers: []string{"controller-2"},
+	}})
+
+	// Once controller-2 is available, updating the credential again
+	// clears the updates that have been applied.
+	available.Store("controller-2", true)
+	err = update(aliceUser, "test-cloud/alice@canonical.com/cred-1")
+	c.Check(err, qt.ErrorMatches, `controller unavailable`)
+
+	updates, err = j.AllPendingCredentialUpdates(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	c.Check(updates, qt.DeepEquals, []jimm.CredentialPendingUpdates{{
+		Credential:  "test-cloud/alice@canonical.com/cred-1",
+		Controllers: []string{"controller-3"},
+	}, {
+		Credential:  "test-cloud/alice@canonical.com/cred-2",
+		Controllers: []string{"controller-2"},
+	}})
 }
 
-func (s testCloudCredentialAttributeStore) PutOAuthSecret(ctx context.Context, raw []byte) error {
-	return errors.E(errors.CodeNotImplemented)
+func TestCloudCredentialTimeout(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	// The controllers never respond, the requests only finish when their
+	// context is done.
+	block := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				SupportsCheckCredentialModels_: true,
+				CheckCredentialModels_: func(ctx context.Context, _ jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, block(ctx)
+				},
+				RevokeCredential_: func(ctx context.Context, _ names.CloudCredentialTag) error {
+					return block(ctx)
+				},
+			},
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, deprecatedControllerTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "charlie@canonical.com"}, client)
+	admin.JimmAdmin = true
+	err = j.SetControllerConfig(ctx, admin, jujuparams.ControllerConfigSet{
+		Config: map[string]interface{}{
+			jimm.CredentialTimeoutConfigKey: "not-a-duration",
+		},
+	})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	err = j.SetControllerConfig(ctx, admin, jujuparams.ControllerConfigSet{
+		Config: map[string]interface{}{
+			jimm.CredentialTimeoutConfigKey: "100ms",
+		},
+	})
+	c.Assert(err, qt.IsNil)
+
+	dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbUser, client)
+	tag := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1")
+
+	start := time.Now()
+	_, err = j.UpdateCloudCredential(ctx, alice, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "empty",
+		},
+	})
+	c.Check(err, qt.ErrorMatches, `.*context deadline exceeded`)
+	c.Check(time.Since(start) < 5*time.Second, qt.IsTrue)
+
+	start = time.Now()
+	err = j.RevokeCloudCredential(ctx, &dbUser, tag, true)
+	c.Check(err, qt.ErrorMatches, `.*context deadline exceeded`)
+	c.Check(time.Since(start) < 5*time.Second, qt.IsTrue)
 }
@@ -7,6 +7,10 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller/controller"
@@ -18,6 +22,7 @@ import (
 	"go.uber.org/zap"
 	"gopkg.in/macaroon.v2"
 
+	"github.com/canonical/jimm/v3/internal/common/pagination"
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
@@ -203,21 +208,42 @@ func (act *addControllerTransactor) Run(ctx context.Context) error {
 }
 
 // addControllerTx stores the clouds, regions, cloud region priorities and the controller itself in the database determined
-// from the incoming Juju API.Clouds() call.
-func addControllerTx(ctx context.Context, j *JIMM, jujuClouds []dbmodel.Cloud, ctl *dbmodel.Controller) error {
-	return j.Database.Transaction(func(tx *db.Database) error {
+// from the incoming Juju API.Clouds() call. The given relations are written
+// to OpenFGA once the transaction has been committed, so that the
+// transaction is not held open while OpenFGA is contacted. If the
+// relations cannot be written the controller is removed from the
+// database again, the clouds and regions are left in place.
+func addControllerTx(ctx context.Context, j *JIMM, jujuClouds []dbmodel.Cloud, ctl *dbmodel.Controller, tuples []openfga.Tuple) error {
+	err := j.Database.Transaction(func(tx *db.Database) error {
 		return newAddControllerTransactor(j, jujuClouds, ctl, tx).Run(ctx)
 	})
+	if err != nil {
+		return err
+	}
+	if err := j.OpenFGAClient.AddRelationsAtomic(ctx, tuples...); err != nil {
+		zapctx.Error(
+			ctx,
+			"failed to add controller relations",
+			zap.String("controller", ctl.ResourceTag().Id()),
+			zap.Error(err),
+		)
+		if derr := j.Database.DeleteController(ctx, ctl); derr != nil {
+			zapctx.Error(ctx, "failed to remove controller", zap.String("controller", ctl.Name), zap.Error(derr))
+		}
+		return errors.E(err, "failed to add controller relations")
+	}
+	return nil
 }
 
 // AddController adds the specified controller to JIMM. Only
 // controller-admin level users may add new controllers. If the user adding
 // the controller is not authorized then an error with a code of
 // CodeUnauthorized will be returned. If there already exists a controller
-// with the same name as the controller being added then an error with a
-// code of CodeAlreadyExists will be returned. If the controller cannot be
-// contacted then an error with a code of CodeConnectionFailed will be
-// returned.
+// with the same name, or the same UUID, as the controller being added then
+// an error with a code of CodeAlreadyExists will be returned. If the
+// controller cannot be contacted then an error with a code of
+// CodeConnectionFailed will be returned. If the controller's relations
+// cannot be written to OpenFGA the controller is not added.
 func (j *JIMM) AddController(ctx context.Context, user *openfga.User, ctl *dbmodel.Controller) error {
 	const op = errors.Op("jimm.AddController")
 
@@ -225,12 +251,29 @@ func (j *JIMM) AddController(ctx context.Context, user *openfga.User, ctl *dbmod
 		return err
 	}
 
+	// The controller is dialed with the given admin credentials, make
+	// sure we don't reuse a connection made with different ones.
+	j.evictControllerConnection(ctl.Name)
 	api, err := j.dialController(ctx, ctl)
 	if err != nil {
 		return errors.E(op, "failed to dial the controller", err)
 	}
 	defer api.Close()
 
+	// Connections to controllers are cached by UUID, so the same
+	// controller must not be added more than once under different
+	// names.
+	if ctl.UUID != "" {
+		existing := dbmodel.Controller{UUID: ctl.UUID}
+		err := j.Database.GetController(ctx, &existing)
+		switch {
+		case err == nil && existing.Name != ctl.Name:
+			return errors.E(op, errors.CodeAlreadyExists, fmt.Sprintf("controller %s already exists as %q", ctl.UUID, existing.Name))
+		case err != nil && errors.ErrorCode(err) != errors.CodeNotFound:
+			return errors.E(op, err)
+		}
+	}
+
 	modelSummary, err := getControllerModelSummary(ctx, api)
 	if err != nil {
 		return errors.E(op, err, "failed to get model summary")
@@ -252,6 +295,12 @@ func (j *JIMM) AddController(ctx context.Context, user *openfga.User, ctl *dbmod
 
 	dbClouds := convertJujuCloudsToDbClouds(clouds)
 
+	defaults, err := api.ModelDefaultsForCloud(ctx, names.NewCloudTag(cloudName))
+	if err != nil {
+		return errors.E(op, err, "failed to fetch controller model defaults")
+	}
+	ctl.ModelDefaults = inheritedModelDefaults(defaults, ctl.CloudRegion)
+
 	// TODO(ale8k): This shouldn't be necessary to check, but tests need updating
 	// to set insecure credential store explicitly.
 	if j.CredentialStore != nil {
@@ -266,7 +315,36 @@ func (j *JIMM) AddController(ctx context.Context, user *openfga.User, ctl *dbmod
 	ctl.AdminIdentityName = ""
 	ctl.AdminPassword = ""
 
-	if err := addControllerTx(ctx, j, dbClouds, ctl); err != nil {
+	// All the relations for the new controller are written in a single
+	// request so that a failure doesn't leave partial relations.
+	var tuples []openfga.Tuple
+	for _, cloud := range dbClouds {
+		// If this cloud is the one used by the controller model then
+		// it is available to all users. Other clouds require `juju grant-cloud` to add permissions.
+		if cloud.ResourceTag().String() == modelSummary.CloudTag {
+			tuples = append(tuples, openfga.Tuple{
+				Object:   ofganames.ConvertTag(j.everyoneUser().ResourceTag()),
+				Relation: ofganames.CanAddModelRelation,
+				Target:   ofganames.ConvertTag(cloud.ResourceTag()),
+			})
+		}
+
+		// Add controller relation between the cloud and the added controller.
+		tuples = append(tuples, openfga.Tuple{
+			Object:   ofganames.ConvertTag(ctl.ResourceTag()),
+			Relation: ofganames.ControllerRelation,
+			Target:   ofganames.ConvertTag(cloud.ResourceTag()),
+		})
+	}
+
+	// Finally add a controller relation between JIMM and the added controller.
+	tuples = append(tuples, openfga.Tuple{
+		Object:   ofganames.ConvertTag(j.ResourceTag()),
+		Relation: ofganames.ControllerRelation,
+		Target:   ofganames.ConvertTag(ctl.ResourceTag()),
+	})
+
+	if err := addControllerTx(ctx, j, dbClouds, ctl, tuples); err != nil {
 		zapctx.Error(ctx, "failed to add controller", zaputil.Error(err))
 		if errors.ErrorCode(err) == errors.CodeAlreadyExists {
 			return errors.E(op, err, fmt.Sprintf("controller %q already exists", ctl.Name))
@@ -275,40 +353,53 @@ func (j *JIMM) AddController(ctx context.Context, user *openfga.User, ctl *dbmod
 		return errors.E(op, err)
 	}
 
-	for _, cloud := range dbClouds {
-		// If this cloud is the one used by the controller model then
-		// it is available to all users. Other clouds require `juju grant-cloud` to add permissions.
-		if cloud.ResourceTag().String() == modelSummary.CloudTag {
-			if err := j.everyoneUser().SetCloudAccess(ctx, cloud.ResourceTag(), ofganames.CanAddModelRelation); err != nil {
-				zapctx.Error(ctx, "failed to grant everyone add-model access", zap.Error(err))
+	j.addControllerAuditLogEntry(user, "AddController", ctl.Name, "added", map[string]interface{}{
+		"uuid": ctl.UUID,
+	})
+	return nil
+}
+
+// inheritedModelDefaults returns the value of each of the given
+// model-defaults that a new model in the given region would inherit.
+// A value set for the region takes precedence over one set for the
+// controller, which takes precedence over the default value.
+func inheritedModelDefaults(defaults map[string]jujuparams.ModelDefaults, region string) dbmodel.Map {
+	m := make(dbmodel.Map, len(defaults))
+	for k, d := range defaults {
+		v := d.Default
+		if d.Controller != nil {
+			v = d.Controller
+		}
+		for _, r := range d.Regions {
+			if r.RegionName == region {
+				v = r.Value
 			}
 		}
-
-		// Add controller relation between the cloud and the added controller.
-		err = j.OpenFGAClient.AddCloudController(ctx, cloud.ResourceTag(), ctl.ResourceTag())
-		if err != nil {
-			zapctx.Error(
-				ctx,
-				"failed to add controller relation between controller and cloud",
-				zap.String("controller", ctl.ResourceTag().Id()),
-				zap.String("cloud", cloud.ResourceTag().Id()),
-				zap.Error(err),
-			)
+		if v != nil {
+			m[k] = v
 		}
 	}
+	return m
+}
 
-	// Finally add a controller relation between JIMM and the added controller.
-	err = j.OpenFGAClient.AddController(ctx, j.ResourceTag(), ctl.ResourceTag())
-	if err != nil {
-		zapctx.Error(
-			ctx,
-			"failed to add controller relation between JIMM and controller",
-			zap.String("controller", ctl.ResourceTag().Id()),
-			zap.Error(err),
-		)
+// ControllerModelDefaults returns the model-defaults that new models
+// created on the named controller would inherit, as captured when the
+// controller was added. Only JIMM administrators may read a controller's
+// model-defaults.
+func (j *JIMM) ControllerModelDefaults(ctx context.Context, user *openfga.User, controllerName string) (map[string]interface{}, error) {
+	const op = errors.Op("jimm.ControllerModelDefaults")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
-	return nil
+	ctl := dbmodel.Controller{
+		Name: controllerName,
+	}
+	if err := j.Database.GetController(ctx, &ctl); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return ctl.ModelDefaults, nil
 }
 
 // EarliestControllerVersion returns the earliest agent version
@@ -348,6 +439,43 @@ func (j *JIMM) EarliestControllerVersion(ctx context.Context) (version.Number, e
 	return *v, nil
 }
 
+// RefreshControllerVersion connects to the named controller, stores the
+// agent version it reports and returns it. This allows the stored version
+// to be brought up to date after a controller has been upgraded. Only
+// JIMM administrators may refresh a controller's version. If the
+// controller cannot be found an error with a code of
+// CodeControllerNotFound is returned.
+func (j *JIMM) RefreshControllerVersion(ctx context.Context, user *openfga.User, controllerName string) (version.Number, error) {
+	const op = errors.Op("jimm.RefreshControllerVersion")
+
+	if !user.JimmAdmin {
+		return version.Number{}, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	ctl, err := j.getControllerByName(ctx, controllerName)
+	if err != nil {
+		return version.Number{}, errors.E(op, err)
+	}
+
+	// A cached connection reports the version the controller was
+	// running when the connection was made, so always make a new one.
+	j.evictControllerConnection(ctl.Name)
+	api, err := j.dialController(ctx, ctl)
+	if err != nil {
+		return version.Number{}, errors.E(op, err)
+	}
+	api.Close()
+
+	v, err := version.Parse(ctl.AgentVersion)
+	if err != nil {
+		return version.Number{}, errors.E(op, err, fmt.Sprintf("cannot parse controller agent version %q", ctl.AgentVersion))
+	}
+	if err := j.Database.UpdateController(ctx, ctl); err != nil {
+		return version.Number{}, errors.E(op, err)
+	}
+	return v, nil
+}
+
 // GetJimmControllerAccess returns the JIMM controller access level for the
 // requested user.
 func (j *JIMM) GetJimmControllerAccess(ctx context.Context, user *openfga.User, tag names.UserTag) (string, error) {
@@ -394,12 +522,19 @@ func (j *JIMM) GetUserControllerAccess(ctx context.Context, user *openfga.User,
 }
 
 // ImportModel imports model with the specified UUID from the controller.
-func (j *JIMM) ImportModel(ctx context.Context, user *openfga.User, controllerName string, modelTag names.ModelTag, newOwner string) error {
+// If uuidOverride is not empty it must match the UUID of the model
+// reported by the controller, otherwise an error with a code of
+// CodeBadRequest is returned and nothing is imported. This guards against
+// importing the wrong model.
+func (j *JIMM) ImportModel(ctx context.Context, user *openfga.User, controllerName string, modelTag names.ModelTag, newOwner, uuidOverride string) error {
 	const op = errors.Op("jimm.ImportModel")
 
 	if err := j.checkJimmAdmin(user); err != nil {
 		return err
 	}
+	if uuidOverride != "" && !names.IsValidModel(uuidOverride) {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid model uuid %q", uuidOverride))
+	}
 
 	controller, err := j.getControllerByName(ctx, controllerName)
 	if err != nil {
@@ -419,6 +554,9 @@ func (j *JIMM) ImportModel(ctx context.Context, user *openfga.User, controllerNa
 	if err != nil {
 		return errors.E(op, err)
 	}
+	if uuidOverride != "" && uuidOverride != modelInfo.UUID {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("model uuid %q does not match the uuid of the model on the controller %q", uuidOverride, modelInfo.UUID))
+	}
 	model := dbmodel.Model{}
 	// fill in data from model info
 	err = model.FromJujuModelInfo(modelInfo)
@@ -516,6 +654,77 @@ func (j *JIMM) ImportModel(ctx context.Context, user *openfga.User, controllerNa
 	return j.handleModelDeltas(ctx, controller, modelTag, model)
 }
 
+// ReclaimModel recovers a model that exists on the named controller but
+// is unknown to JIMM, such as a model leaked when AddModel failed after
+// the controller created it. JIMM's administrator access to the model is
+// re-granted and then, if keep is true, the model is imported into JIMM
+// with its original owner, otherwise the model is destroyed on the
+// controller. Only JIMM administrators may reclaim models. If JIMM
+// already knows the model an error with a code of CodeAlreadyExists is
+// returned.
+func (j *JIMM) ReclaimModel(ctx context.Context, user *openfga.User, controllerName, modelUUID string, keep bool) error {
+	const op = errors.Op("jimm.ReclaimModel")
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return errors.E(op, err)
+	}
+	if !names.IsValidModel(modelUUID) {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid model uuid %q", modelUUID))
+	}
+	mt := names.NewModelTag(modelUUID)
+
+	existing := dbmodel.Model{
+		UUID: sql.NullString{String: modelUUID, Valid: true},
+	}
+	err := j.Database.GetModel(ctx, &existing)
+	if err == nil {
+		return errors.E(op, errors.CodeAlreadyExists, fmt.Sprintf("model %s is already known to JIMM", modelUUID))
+	}
+	if errors.ErrorCode(err) != errors.CodeNotFound {
+		return errors.E(op, err)
+	}
+
+	controller, err := j.getControllerByName(ctx, controllerName)
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	api, err := j.dialController(ctx, controller)
+	if err != nil {
+		return errors.E(op, "failed to dial the controller", err)
+	}
+	defer api.Close()
+
+	err = retryGrantModelAccess(ctx, func() error {
+		return api.GrantJIMMModelAdmin(ctx, mt)
+	})
+	if err != nil {
+		return errors.E(op, err, "failed to grant JIMM access to the model")
+	}
+
+	if keep {
+		if err := j.ImportModel(ctx, user, controllerName, mt, "", modelUUID); err != nil {
+			return errors.E(op, err)
+		}
+		j.addControllerAuditLogEntry(user, "ReclaimModel", controllerName, "model-imported", map[string]interface{}{
+			"model": mt.String(),
+		})
+		return nil
+	}
+
+	if err := api.DestroyModel(ctx, mt, nil, nil, nil, nil); err != nil {
+		return errors.E(op, err)
+	}
+	// Remove any relations that were written before the model leaked.
+	if err := j.OpenFGAClient.RemoveModel(ctx, mt); err != nil {
+		zapctx.Error(ctx, "failed to remove model relations", zap.String("model", mt.Id()), zaputil.Error(err))
+	}
+	j.addControllerAuditLogEntry(user, "ReclaimModel", controllerName, "model-destroyed", map[string]interface{}{
+		"model": mt.String(),
+	})
+	return nil
+}
+
 func (j *JIMM) handleModelDeltas(ctx context.Context, controller *dbmodel.Controller, modelTag names.ModelTag, model dbmodel.Model) error {
 	const op = errors.Op("jimm.getModelDeltas")
 
@@ -543,9 +752,10 @@ func (j *JIMM) handleModelDeltas(ctx context.Context, controller *dbmodel.Contro
 	modelIDf := func(uuid string) *modelState {
 		if uuid == model.UUID.String {
 			return &modelState{
-				id:       model.ID,
-				machines: make(map[string]int64),
-				units:    make(map[string]bool),
+				id:           model.ID,
+				machines:     make(map[string]int64),
+				applications: make(map[string]bool),
+				units:        make(map[string]bool),
 			}
 		}
 		return nil
@@ -583,6 +793,29 @@ func (j *JIMM) SetControllerConfig(ctx context.Context, user *openfga.User, args
 			config.Config = make(map[string]interface{})
 		}
 		for key, value := range args.Config {
+			if err := checkDefaultRegionConfig(ctx, tx, key, value); err != nil {
+				return err
+			}
+			if key == ModelNamePatternConfigKey {
+				if _, err := modelNamePattern(value); err != nil {
+					return errors.E(errors.CodeBadRequest, err)
+				}
+			}
+			if key == CredentialTimeoutConfigKey {
+				if _, err := parseCredentialTimeout(value); err != nil {
+					return errors.E(errors.CodeBadRequest, err)
+				}
+			}
+			if key == ModelQuotaConfigKey || strings.HasPrefix(key, UserModelQuotaConfigKeyPrefix) {
+				if _, err := quotaFromConfig(value); err != nil {
+					return errors.E(errors.CodeBadRequest, err)
+				}
+			}
+			if key == ModelLoggingDefaultConfigKey {
+				if _, err := loggingDefaultFromConfig(value); err != nil {
+					return errors.E(errors.CodeBadRequest, err)
+				}
+			}
 			config.Config[key] = value
 		}
 		return tx.UpsertControllerConfig(ctx, &config)
@@ -590,6 +823,43 @@ func (j *JIMM) SetControllerConfig(ctx context.Context, user *openfga.User, args
 	if err != nil {
 		return errors.E(op, err)
 	}
+
+	// Only the keys are recorded as config values may be sensitive.
+	keys := make([]string, 0, len(args.Config))
+	for key := range args.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	j.addControllerAuditLogEntry(user, "SetControllerConfig", "jimm", "config-changed", map[string]interface{}{
+		"keys": keys,
+	})
+	return nil
+}
+
+// checkDefaultRegionConfig returns an error with a code of CodeBadRequest
+// if the given controller config setting is a cloud's default region and
+// the region does not exist on the cloud.
+func checkDefaultRegionConfig(ctx context.Context, tx *db.Database, key string, value interface{}) error {
+	cloudName, ok := strings.CutPrefix(key, DefaultRegionConfigKeyPrefix)
+	if !ok {
+		return nil
+	}
+	region, ok := value.(string)
+	if !ok {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid %s value %v", key, value))
+	}
+	cloud := dbmodel.Cloud{
+		Name: cloudName,
+	}
+	if err := tx.GetCloud(ctx, &cloud); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("cloud %q not found", cloudName))
+		}
+		return err
+	}
+	if cloud.Region(region).Name == "" {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("cloud %q does not have region %q", cloudName, region))
+	}
 	return nil
 }
 
@@ -610,6 +880,201 @@ func (j *JIMM) GetControllerConfig(ctx context.Context, u *dbmodel.Identity) (*d
 	return &config, nil
 }
 
+// ControllerCloudRegion identifies a cloud region available on a
+// controller.
+type ControllerCloudRegion struct {
+	Cloud    string
+	Region   string
+	Priority uint
+}
+
+// ControllerMetadata holds JIMM's view of a controller.
+type ControllerMetadata struct {
+	Name string
+	UUID string
+
+	// CloudName and CloudRegion identify where the controller itself
+	// is hosted.
+	CloudName   string
+	CloudRegion string
+
+	// CloudRegions holds the cloud regions available on the controller.
+	CloudRegions []ControllerCloudRegion
+
+	// AgentVersion holds the controller's agent version. If the
+	// controller is reachable this is the version it currently reports,
+	// otherwise it is the last version JIMM recorded.
+	AgentVersion string
+
+	// Reachable records whether JIMM could connect to the controller.
+	Reachable bool
+
+	// UnavailableSince records the time JIMM recorded the controller
+	// becoming unavailable, if it has.
+	UnavailableSince *time.Time
+
+	// ModelCount holds the number of models JIMM knows to be hosted on
+	// the controller.
+	ModelCount int
+}
+
+// GetControllerInfo returns the metadata JIMM holds about the controller
+// with the given name, having checked the controller's current agent
+// version. Only JIMM administrators may call this method.
+func (j *JIMM) GetControllerInfo(ctx context.Context, user *openfga.User, name string) (*ControllerMetadata, error) {
+	const op = errors.Op("jimm.GetControllerInfo")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	ctl := dbmodel.Controller{Name: name}
+	if err := j.Database.GetController(ctx, &ctl); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeControllerNotFound, err)
+		}
+		return nil, errors.E(op, err)
+	}
+	modelCount, err := j.Database.CountModelsByController(ctx, ctl)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	info := ControllerMetadata{
+		Name:        ctl.Name,
+		UUID:        ctl.UUID,
+		CloudName:   ctl.CloudName,
+		CloudRegion: ctl.CloudRegion,
+		ModelCount:  modelCount,
+	}
+	for _, cr := range ctl.CloudRegions {
+		info.CloudRegions = append(info.CloudRegions, ControllerCloudRegion{
+			Cloud:    cr.CloudRegion.Cloud.Name,
+			Region:   cr.CloudRegion.Name,
+			Priority: cr.Priority,
+		})
+	}
+	if ctl.UnavailableSince.Valid {
+		info.UnavailableSince = &ctl.UnavailableSince.Time
+	}
+
+	// Dialing the controller updates the agent version to the one the
+	// controller currently reports.
+	api, err := j.dial(ctx, &ctl, names.ModelTag{})
+	if err != nil {
+		zapctx.Warn(ctx, "cannot connect to controller", zap.String("controller", ctl.Name), zap.Error(err))
+	} else {
+		api.Close()
+		info.Reachable = true
+	}
+	info.AgentVersion = ctl.AgentVersion
+
+	return &info, nil
+}
+
+// CloudController holds a controller on which a cloud is configured
+// along with the regions of that cloud the controller serves.
+type CloudController struct {
+	Name string
+	UUID string
+
+	// Regions holds the regions of the cloud available on the
+	// controller.
+	Regions []ControllerCloudRegion
+}
+
+// ControllersForCloud returns the controllers, visible to the given user,
+// that have the given cloud configured. Controllers are returned in name
+// order.
+func (j *JIMM) ControllersForCloud(ctx context.Context, user *openfga.User, cloud names.CloudTag) ([]CloudController, error) {
+	const op = errors.Op("jimm.ControllersForCloud")
+
+	var controllers []CloudController
+	err := j.ForEachControllerForUser(ctx, user, func(ctl *dbmodel.Controller) error {
+		var regions []ControllerCloudRegion
+		for _, cr := range ctl.CloudRegions {
+			if cr.CloudRegion.Cloud.Name != cloud.Id() {
+				continue
+			}
+			regions = append(regions, ControllerCloudRegion{
+				Cloud:    cr.CloudRegion.Cloud.Name,
+				Region:   cr.CloudRegion.Name,
+				Priority: cr.Priority,
+			})
+		}
+		if len(regions) == 0 {
+			return nil
+		}
+		controllers = append(controllers, CloudController{
+			Name:    ctl.Name,
+			UUID:    ctl.UUID,
+			Regions: regions,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return controllers, nil
+}
+
+// ControllerModels returns a page of the models hosted on the named
+// controller, including each model's owner, life and status. Only JIMM
+// administrators and administrators of the controller may list its
+// models, other users receive an error with a code of CodeUnauthorized.
+// If the controller cannot be found an error with a code of
+// CodeControllerNotFound is returned.
+func (j *JIMM) ControllerModels(ctx context.Context, user *openfga.User, controllerName string, filter pagination.LimitOffsetPagination) ([]dbmodel.Model, error) {
+	const op = errors.Op("jimm.ControllerModels")
+
+	ctl := dbmodel.Controller{Name: controllerName}
+	if err := j.Database.GetController(ctx, &ctl); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeControllerNotFound, err)
+		}
+		return nil, errors.E(op, err)
+	}
+
+	if !user.JimmAdmin {
+		isAdmin, err := openfga.IsAdministrator(ctx, user, ctl.ResourceTag())
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		if !isAdmin {
+			return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+	}
+
+	models, err := j.Database.ListModelsByController(ctx, ctl, filter.Limit(), filter.Offset())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return models, nil
+}
+
+// ControllerModelStatusSummary returns the number of models hosted on the
+// named controller grouped by life and status. Only JIMM administrators
+// can perform this operation. If the controller cannot be found an error
+// with a code of CodeControllerNotFound is returned.
+func (j *JIMM) ControllerModelStatusSummary(ctx context.Context, user *openfga.User, controllerName string) ([]db.ModelStatusCount, error) {
+	const op = errors.Op("jimm.ControllerModelStatusSummary")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	ctl, err := j.getControllerByName(ctx, controllerName)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	counts, err := j.Database.CountModelsByLifeAndStatus(ctx, *ctl)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return counts, nil
+}
+
 // UpdateMigratedModel asserts that the model has been migrated to the
 // specified controller and updates the internal model representation.
 func (j *JIMM) UpdateMigratedModel(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetControllerName string) error {
@@ -639,11 +1104,16 @@ func (j *JIMM) UpdateMigratedModel(ctx context.Context, user *openfga.User, mode
 	err = j.Database.GetController(ctx, &targetController)
 	if err != nil {
 		if errors.ErrorCode(err) == errors.CodeNotFound {
-			return errors.E(op, "controller not found", errors.CodeNotFound)
+			return errors.E(op, "controller not found", errors.CodeControllerNotFound)
 		}
 		return errors.E(op, err)
 	}
 
+	// A pinned model must stay on its controller until it is unpinned.
+	if model.PinnedControllerID.Valid && uint(model.PinnedControllerID.Int32) != targetController.ID {
+		return errors.E(op, errors.CodeForbidden, fmt.Sprintf("model %s is pinned to its controller, unpin it before migrating", modelTag.Id()))
+	}
+
 	// check the model is known to the controller
 	api, err := j.dial(ctx, &targetController, names.ModelTag{})
 	if err != nil {
@@ -658,14 +1128,19 @@ func (j *JIMM) UpdateMigratedModel(ctx context.Context, user *openfga.User, mode
 		return errors.E(op, err)
 	}
 
-	model.Controller = targetController
-	model.ControllerID = targetController.ID
-	err = j.Database.UpdateModel(ctx, &model)
+	err = j.updateModel(ctx, &model, func(m *dbmodel.Model) error {
+		m.Controller = targetController
+		m.ControllerID = targetController.ID
+		return nil
+	})
 	if err != nil {
 		zapctx.Error(ctx, "failed to update model", zap.String("model", model.UUID.String), zaputil.Error(err))
 		return errors.E(op, err)
 	}
 
+	j.addControllerAuditLogEntry(user, "UpdateMigratedModel", targetController.Name, "model-migrated", map[string]interface{}{
+		"model": modelTag.String(),
+	})
 	return nil
 }
 
@@ -714,6 +1189,9 @@ func (j *JIMM) InitiateMigration(ctx context.Context, user *openfga.User, spec j
 	if err != nil {
 		return result, errors.E(op, "failed to retrieve the model from the database", err)
 	}
+	if model.PinnedControllerID.Valid {
+		return result, errors.E(op, errors.CodeForbidden, fmt.Sprintf("model %s is pinned to its controller, unpin it before migrating", mt.Id()))
+	}
 
 	api, err := j.dial(ctx, &model.Controller, names.ModelTag{})
 	if err != nil {
@@ -738,3 +1216,95 @@ func (j *JIMM) InitiateMigration(ctx context.Context, user *openfga.User, spec j
 	}
 	return result, nil
 }
+
+// A ModelMigration describes a model migration that is in progress on
+// one of JIMM's controllers.
+type ModelMigration struct {
+	// Model is the tag of the model being migrated.
+	Model names.ModelTag
+
+	// ModelName is the name of the model being migrated.
+	ModelName string
+
+	// Owner is the name of the owner of the model being migrated.
+	Owner string
+
+	// Controller is the name of the controller the model is being
+	// migrated from.
+	Controller string
+
+	// Status is the status of the migration as reported by the
+	// controller.
+	Status string
+
+	// Start is the time the migration started.
+	Start time.Time
+}
+
+// ListMigrations returns the model migrations in progress on JIMM's
+// available controllers, as reported by the controllers, ordered by
+// controller and model name. Juju does not report the target of a
+// migration, nor allow a migration to be aborted, through its client
+// API, so neither is available here. Only JIMM administrators may list
+// migrations.
+func (j *JIMM) ListMigrations(ctx context.Context, user *openfga.User) ([]ModelMigration, error) {
+	const op = errors.Op("jimm.ListMigrations")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var controllers []dbmodel.Controller
+	err := j.Database.ForEachController(ctx, func(ctl *dbmodel.Controller) error {
+		if !ctl.UnavailableSince.Valid {
+			controllers = append(controllers, *ctl)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	var mu sync.Mutex
+	var migrations []ModelMigration
+	err = j.forEachController(ctx, controllers, func(ctl *dbmodel.Controller, api API) error {
+		models, err := j.Database.GetModelsByController(ctx, *ctl)
+		if err != nil {
+			return err
+		}
+		for _, m := range models {
+			mi := jujuparams.ModelInfo{UUID: m.UUID.String}
+			if err := api.ModelInfo(ctx, &mi); err != nil {
+				zapctx.Warn(ctx, "failed to get model info", zap.String("controller", ctl.Name), zap.String("model", m.UUID.String), zap.Error(err))
+				continue
+			}
+			if mi.Migration == nil || mi.Migration.End != nil {
+				continue
+			}
+			migration := ModelMigration{
+				Model:      names.NewModelTag(m.UUID.String),
+				ModelName:  m.Name,
+				Owner:      m.OwnerIdentityName,
+				Controller: ctl.Name,
+				Status:     mi.Migration.Status,
+			}
+			if mi.Migration.Start != nil {
+				migration.Start = *mi.Migration.Start
+			}
+			mu.Lock()
+			migrations = append(migrations, migration)
+			mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	sort.Slice(migrations, func(i, k int) bool {
+		if migrations[i].Controller != migrations[k].Controller {
+			return migrations[i].Controller < migrations[k].Controller
+		}
+		return migrations[i].ModelName < migrations[k].ModelName
+	})
+	return migrations, nil
+}
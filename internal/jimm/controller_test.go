@@ -6,6 +6,8 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -24,6 +26,7 @@ import (
 	semversion "github.com/juju/version"
 	"gopkg.in/macaroon.v2"
 
+	"github.com/canonical/jimm/v3/internal/common/pagination"
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
@@ -128,6 +131,31 @@ func TestAddController(t *testing.T) {
 			ms.AgentVersion = newVersion("1.2.3")
 			return nil
 		},
+		ModelDefaultsForCloud_: func(_ context.Context, tag names.CloudTag) (map[string]jujuparams.ModelDefaults, error) {
+			if tag.Id() != "aws" {
+				c.Errorf("ModelDefaultsForCloud called for unexpected cloud %q", tag)
+				return nil, errors.E("unexpected cloud")
+			}
+			return map[string]jujuparams.ModelDefaults{
+				"default-series": {
+					Default: "jammy",
+				},
+				"logging-config": {
+					Default:    "<root>=INFO",
+					Controller: "<root>=DEBUG",
+				},
+				"http-proxy": {
+					Regions: []jujuparams.RegionDefaults{{
+						RegionName: "eu-west-1",
+						Value:      "http://proxy.example.com",
+					}, {
+						RegionName: "eu-west-2",
+						Value:      "http://proxy2.example.com",
+					}},
+				},
+				"no-proxy": {},
+			}, nil
+		},
 	}
 
 	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
@@ -172,7 +200,27 @@ func TestAddController(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 	c.Check(ctl2, qt.CmpEquals(cmpopts.EquateEmpty(), cmpopts.IgnoreTypes(dbmodel.CloudRegion{})), ctl1)
 
+	// The model-defaults new models would inherit are captured when the
+	// controller is added.
+	defaults, err := j.ControllerModelDefaults(ctx, alice, "test-controller")
+	c.Assert(err, qt.IsNil)
+	c.Check(defaults, qt.DeepEquals, map[string]interface{}{
+		"default-series": "jammy",
+		"logging-config": "<root>=DEBUG",
+		"http-proxy":     "http://proxy.example.com",
+	})
+
+	bob, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	_, err = j.ControllerModelDefaults(ctx, openfga.NewUser(bob, client), "test-controller")
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	_, err = j.ControllerModelDefaults(ctx, alice, "no-such-controller")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
 	ctl3 := dbmodel.Controller{
+		UUID:              "00000001-0000-0000-0000-000000000002",
 		Name:              "test-controller-2",
 		AdminIdentityName: "admin",
 		AdminPassword:     "5ecret",
@@ -187,6 +235,139 @@ func TestAddController(t *testing.T) {
 	err = j.Database.GetController(ctx, &ctl4)
 	c.Assert(err, qt.IsNil)
 	c.Check(ctl4, qt.CmpEquals(cmpopts.EquateEmpty(), cmpopts.IgnoreTypes(dbmodel.CloudRegion{})), ctl3)
+
+	// The same controller cannot be added again under a different name.
+	ctl5 := dbmodel.Controller{
+		UUID:              ctl1.UUID,
+		Name:              "test-controller-3",
+		AdminIdentityName: "admin",
+		AdminPassword:     "5ecret",
+		PublicAddress:     "example.com:443",
+	}
+	err = j.AddController(context.Background(), alice, &ctl5)
+	c.Check(err, qt.ErrorMatches, `controller `+ctl1.UUID+` already exists as "test-controller"`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+	err = j.Database.GetController(ctx, &dbmodel.Controller{Name: "test-controller-3"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	c.Check(controllerAuditLogEntries(c, j, "AddController"), qt.DeepEquals, []controllerAuditLogEntry{{
+		Actor: "user-alice@canonical.com",
+		Details: map[string]interface{}{
+			"controller": "test-controller",
+			"change":     "added",
+			"uuid":       ctl1.UUID,
+		},
+	}, {
+		Actor: "user-alice@canonical.com",
+		Details: map[string]interface{}{
+			"controller": "test-controller-2",
+			"change":     "added",
+			"uuid":       ctl3.UUID,
+		},
+	}})
+}
+
+type controllerAuditLogEntry struct {
+	Actor   string
+	Details map[string]interface{}
+}
+
+// controllerAuditLogEntries returns the controller changes recorded in
+// the audit log by the given method, in the order they were made.
+func controllerAuditLogEntries(c *qt.C, j *jimm.JIMM, method string) []controllerAuditLogEntry {
+	var entries []controllerAuditLogEntry
+	err := j.Database.ForEachAuditLogEntry(context.Background(), db.AuditLogFilter{Method: method}, func(ale *dbmodel.AuditLogEntry) error {
+		entry := controllerAuditLogEntry{
+			Actor: ale.IdentityTag,
+		}
+		if err := json.Unmarshal(ale.Params, &entry.Details); err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	return entries
+}
+
+func TestAddControllerRelationsFailure(t *testing.T) {
+	c := qt.New(t)
+
+	// A controller with this many clouds needs more relations than can
+	// be written in a single OpenFGA request.
+	api := &jimmtest.API{
+		Clouds_: func(context.Context) (map[names.CloudTag]jujuparams.Cloud, error) {
+			clouds := make(map[names.CloudTag]jujuparams.Cloud)
+			for i := 0; i < 100; i++ {
+				clouds[names.NewCloudTag(fmt.Sprintf("cloud-%d", i))] = jujuparams.Cloud{
+					Type:      "ec2",
+					AuthTypes: []string{"userpass"},
+					Regions: []jujuparams.CloudRegion{{
+						Name: "default",
+					}},
+				}
+			}
+			return clouds, nil
+		},
+		ControllerModelSummary_: func(_ context.Context, ms *jujuparams.ModelSummary) error {
+			ms.Name = "controller"
+			ms.UUID = "5fddf0ed-83d5-47e8-ae7b-a4b27fc04a9f"
+			ms.Type = "iaas"
+			ms.ControllerUUID = jimmtest.DefaultControllerUUID
+			ms.IsController = true
+			ms.ProviderType = "ec2"
+			ms.CloudTag = "cloud-cloud-0"
+			ms.CloudRegion = "default"
+			ms.OwnerTag = "user-admin"
+			ms.Life = life.Value(state.Alive.String())
+			ms.UserAccess = "admin"
+			ms.AgentVersion = newVersion("1.2.3")
+			return nil
+		},
+		ModelDefaultsForCloud_: func(context.Context, names.CloudTag) (map[string]jujuparams.ModelDefaults, error) {
+			return nil, nil
+		},
+	}
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: api,
+		},
+		OpenFGAClient: client,
+	}
+
+	ctx := context.Background()
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	u, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	alice := openfga.NewUser(u, client)
+	alice.JimmAdmin = true
+
+	ctl := dbmodel.Controller{
+		Name:              "test-controller",
+		AdminIdentityName: "admin",
+		AdminPassword:     "5ecret",
+		PublicAddress:     "example.com:443",
+	}
+	err = j.AddController(ctx, alice, &ctl)
+	c.Check(err, qt.ErrorMatches, `failed to add controller relations`)
+
+	// The controller is removed again when its relations cannot be
+	// written, the clouds it added are left in place.
+	err = j.Database.GetController(ctx, &dbmodel.Controller{Name: "test-controller"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+	err = j.Database.GetCloud(ctx, &dbmodel.Cloud{Name: "cloud-0"})
+	c.Check(err, qt.IsNil)
+	c.Check(controllerAuditLogEntries(c, j, "AddController"), qt.HasLen, 0)
 }
 
 func TestAddControllerWithVault(t *testing.T) {
@@ -294,6 +475,31 @@ func TestAddControllerWithVault(t *testing.T) {
 			ms.AgentVersion = newVersion("1.2.3")
 			return nil
 		},
+		ModelDefaultsForCloud_: func(_ context.Context, tag names.CloudTag) (map[string]jujuparams.ModelDefaults, error) {
+			if tag.Id() != "aws" {
+				c.Errorf("ModelDefaultsForCloud called for unexpected cloud %q", tag)
+				return nil, errors.E("unexpected cloud")
+			}
+			return map[string]jujuparams.ModelDefaults{
+				"default-series": {
+					Default: "jammy",
+				},
+				"logging-config": {
+					Default:    "<root>=INFO",
+					Controller: "<root>=DEBUG",
+				},
+				"http-proxy": {
+					Regions: []jujuparams.RegionDefaults{{
+						RegionName: "eu-west-1",
+						Value:      "http://proxy.example.com",
+					}, {
+						RegionName: "eu-west-2",
+						Value:      "http://proxy2.example.com",
+					}},
+				},
+				"no-proxy": {},
+			}, nil
+		},
 	}
 
 	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
@@ -348,6 +554,7 @@ func TestAddControllerWithVault(t *testing.T) {
 	c.Assert(password, qt.Equals, "5ecret")
 
 	ctl3 := dbmodel.Controller{
+		UUID:              "00000001-0000-0000-0000-000000000002",
 		Name:              "test-controller-2",
 		AdminIdentityName: "admin",
 		AdminPassword:     "5ecretToo",
@@ -427,6 +634,56 @@ func TestEarliestControllerVersion(t *testing.T) {
 	c.Assert(v, qt.DeepEquals, semversion.MustParse("2.1.0"))
 }
 
+func TestRefreshControllerVersion(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+		Dialer: &jimmtest.Dialer{
+			API:          &jimmtest.API{},
+			AgentVersion: "3.5.1",
+		},
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testEarliestControllerVersionEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	bob := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, client)
+	_, err = j.RefreshControllerVersion(ctx, bob, "test3")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	alice := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, client)
+	alice.JimmAdmin = true
+
+	_, err = j.RefreshControllerVersion(ctx, alice, "no-such-controller")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeControllerNotFound)
+
+	v, err := j.RefreshControllerVersion(ctx, alice, "test3")
+	c.Assert(err, qt.IsNil)
+	c.Check(v, qt.DeepEquals, semversion.MustParse("3.5.1"))
+
+	ctl := dbmodel.Controller{Name: "test3"}
+	err = j.Database.GetController(ctx, &ctl)
+	c.Assert(err, qt.IsNil)
+	c.Check(ctl.AgentVersion, qt.Equals, "3.5.1")
+
+	v, err = j.EarliestControllerVersion(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(v, qt.DeepEquals, semversion.MustParse("3.2.0"))
+}
+
 const testImportModelEnv = `
 users:
 - username: alice@canonical.com
@@ -937,7 +1194,7 @@ func TestImportModel(t *testing.T) {
 			user := openfga.NewUser(&dbUser, client)
 			user.JimmAdmin = test.jimmAdmin
 
-			err = j.ImportModel(ctx, user, test.controllerName, names.NewModelTag(test.modelUUID), test.newOwner)
+			err = j.ImportModel(ctx, user, test.controllerName, names.NewModelTag(test.modelUUID), test.newOwner, "")
 			if test.expectedError == "" {
 				c.Assert(err, qt.IsNil)
 
@@ -963,6 +1220,219 @@ func TestImportModel(t *testing.T) {
 	}
 }
 
+func TestImportModelUUIDOverride(t *testing.T) {
+	c := qt.New(t)
+
+	trueValue := true
+	modelInfo := func(_ context.Context, info *jujuparams.ModelInfo) error {
+		info.Name = "test-model"
+		info.Type = "test-type"
+		info.UUID = "00000002-0000-0000-0000-000000000001"
+		info.ControllerUUID = "00000001-0000-0000-0000-000000000001"
+		info.DefaultSeries = "test-series"
+		info.CloudTag = names.NewCloudTag("test-cloud").String()
+		info.CloudRegion = "test-region"
+		info.CloudCredentialTag = names.NewCloudCredentialTag("test-cloud/alice@canonical.com/test-credential").String()
+		info.CloudCredentialValidity = &trueValue
+		info.OwnerTag = names.NewUserTag("alice@canonical.com").String()
+		info.Life = life.Alive
+		info.AgentVersion = newVersion("2.1.0")
+		return nil
+	}
+
+	tests := []struct {
+		about           string
+		uuidOverride    string
+		expectedError   string
+		expectedErrCode errors.Code
+	}{{
+		about:        "matching uuid override",
+		uuidOverride: "00000002-0000-0000-0000-000000000001",
+	}, {
+		about:           "mismatched uuid override",
+		uuidOverride:    "00000002-0000-0000-0000-000000000002",
+		expectedError:   `model uuid "00000002-0000-0000-0000-000000000002" does not match the uuid of the model on the controller "00000002-0000-0000-0000-000000000001"`,
+		expectedErrCode: errors.CodeBadRequest,
+	}, {
+		about:           "invalid uuid override",
+		uuidOverride:    "not-a-uuid",
+		expectedError:   `invalid model uuid "not-a-uuid"`,
+		expectedErrCode: errors.CodeBadRequest,
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			api := &jimmtest.API{
+				ModelInfo_: modelInfo,
+				ModelWatcherNext_: func(context.Context, string) ([]jujuparams.Delta, error) {
+					return nil, nil
+				},
+				ModelWatcherStop_: func(context.Context, string) error {
+					return nil
+				},
+				WatchAll_: func(context.Context) (string, error) {
+					return "1", nil
+				},
+			}
+
+			client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), test.about)
+			c.Assert(err, qt.IsNil)
+
+			j := &jimm.JIMM{
+				UUID: uuid.NewString(),
+				Database: db.Database{
+					DB: jimmtest.PostgresDB(c, nil),
+				},
+				Dialer: &jimmtest.Dialer{
+					API:  api,
+					UUID: "00000001-0000-0000-0000-000000000001",
+				},
+				OpenFGAClient: client,
+			}
+			ctx := context.Background()
+			err = j.Database.Migrate(ctx, false)
+			c.Assert(err, qt.IsNil)
+
+			env := jimmtest.ParseEnvironment(c, testImportModelEnv)
+			env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+			dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+			user := openfga.NewUser(&dbUser, client)
+			user.JimmAdmin = true
+
+			mt := names.NewModelTag("00000002-0000-0000-0000-000000000001")
+			err = j.ImportModel(ctx, user, "test-controller", mt, "", test.uuidOverride)
+			m := dbmodel.Model{
+				UUID: sql.NullString{String: mt.Id(), Valid: true},
+			}
+			if test.expectedError == "" {
+				c.Assert(err, qt.IsNil)
+				err = j.Database.GetModel(ctx, &m)
+				c.Assert(err, qt.IsNil)
+				c.Check(m.Name, qt.Equals, "test-model")
+				return
+			}
+			c.Check(err, qt.ErrorMatches, test.expectedError)
+			c.Check(errors.ErrorCode(err), qt.Equals, test.expectedErrCode)
+			// Nothing is imported.
+			err = j.Database.GetModel(ctx, &m)
+			c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+		})
+	}
+}
+
+func TestReclaimModel(t *testing.T) {
+	c := qt.New(t)
+
+	const leakedModelUUID = "00000002-0000-0000-0000-000000000001"
+	trueValue := true
+	modelInfo := func(_ context.Context, info *jujuparams.ModelInfo) error {
+		info.Name = "leaked-model"
+		info.Type = "iaas"
+		info.UUID = leakedModelUUID
+		info.ControllerUUID = "00000001-0000-0000-0000-000000000001"
+		info.DefaultSeries = "test-series"
+		info.CloudTag = names.NewCloudTag("test-cloud").String()
+		info.CloudRegion = "test-region"
+		info.CloudCredentialTag = names.NewCloudCredentialTag("test-cloud/alice@canonical.com/test-credential").String()
+		info.CloudCredentialValidity = &trueValue
+		info.OwnerTag = names.NewUserTag("alice@canonical.com").String()
+		info.Life = life.Alive
+		info.AgentVersion = newVersion("2.1.0")
+		return nil
+	}
+
+	tests := []struct {
+		about string
+		keep  bool
+	}{{
+		about: "orphaned model kept",
+		keep:  true,
+	}, {
+		about: "orphaned model destroyed",
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			var granted, destroyed []string
+			api := &jimmtest.API{
+				ModelInfo_: modelInfo,
+				GrantJIMMModelAdmin_: func(_ context.Context, mt names.ModelTag) error {
+					granted = append(granted, mt.Id())
+					return nil
+				},
+				DestroyModel_: func(_ context.Context, mt names.ModelTag, _, _ *bool, _, _ *time.Duration) error {
+					destroyed = append(destroyed, mt.Id())
+					return nil
+				},
+				ModelWatcherNext_: func(context.Context, string) ([]jujuparams.Delta, error) {
+					return nil, nil
+				},
+				ModelWatcherStop_: func(context.Context, string) error {
+					return nil
+				},
+				WatchAll_: func(context.Context) (string, error) {
+					return "1", nil
+				},
+			}
+
+			client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), test.about)
+			c.Assert(err, qt.IsNil)
+
+			j := &jimm.JIMM{
+				UUID: uuid.NewString(),
+				Database: db.Database{
+					DB: jimmtest.PostgresDB(c, nil),
+				},
+				Dialer: &jimmtest.Dialer{
+					API:  api,
+					UUID: "00000001-0000-0000-0000-000000000001",
+				},
+				OpenFGAClient: client,
+			}
+			ctx := context.Background()
+			err = j.Database.Migrate(ctx, false)
+			c.Assert(err, qt.IsNil)
+
+			env := jimmtest.ParseEnvironment(c, testImportModelEnv)
+			env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+			dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+			user := openfga.NewUser(&dbUser, client)
+
+			err = j.ReclaimModel(ctx, user, "test-controller", leakedModelUUID, test.keep)
+			c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+			user.JimmAdmin = true
+
+			// Models known to JIMM cannot be reclaimed.
+			err = j.ReclaimModel(ctx, user, "test-controller", "00000002-0000-0000-0000-000000000002", test.keep)
+			c.Check(err, qt.ErrorMatches, `model 00000002-0000-0000-0000-000000000002 is already known to JIMM`)
+			c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+			c.Check(granted, qt.HasLen, 0)
+
+			err = j.ReclaimModel(ctx, user, "test-controller", leakedModelUUID, test.keep)
+			c.Assert(err, qt.IsNil)
+			c.Check(granted, qt.DeepEquals, []string{leakedModelUUID})
+
+			m := dbmodel.Model{
+				UUID: sql.NullString{String: leakedModelUUID, Valid: true},
+			}
+			err = j.Database.GetModel(ctx, &m)
+			if test.keep {
+				c.Assert(err, qt.IsNil)
+				c.Check(m.Name, qt.Equals, "leaked-model")
+				c.Check(m.Owner.Name, qt.Equals, "alice@canonical.com")
+				c.Check(destroyed, qt.HasLen, 0)
+				c.Check(user.GetModelAccess(ctx, names.NewModelTag(leakedModelUUID)), qt.Equals, ofganames.AdministratorRelation)
+			} else {
+				c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+				c.Check(destroyed, qt.DeepEquals, []string{leakedModelUUID})
+			}
+		})
+	}
+}
+
 const testControllerConfigEnv = `
 users:
 - username: alice@canonical.com
@@ -1021,27 +1491,86 @@ func TestSetControllerConfig(t *testing.T) {
 			},
 		},
 		expectedError: "unauthorized",
-	}}
-
-	for _, test := range tests {
-		c.Run(test.about, func(c *qt.C) {
-			j := &jimm.JIMM{
-				UUID: uuid.NewString(),
-				Database: db.Database{
-					DB: jimmtest.PostgresDB(c, nil),
-				},
-			}
-			ctx := context.Background()
-			err := j.Database.Migrate(ctx, false)
-			c.Assert(err, qt.IsNil)
-
-			env := jimmtest.ParseEnvironment(c, testControllerConfigEnv)
-			env.PopulateDB(c, j.Database)
-
-			dbUser := env.User(test.user).DBObject(c, j.Database)
-			user := openfga.NewUser(&dbUser, nil)
-			user.JimmAdmin = test.jimmAdmin
-
+	}, {
+		about: "model quotas",
+		user:  "alice@canonical.com",
+		args: jujuparams.ControllerConfigSet{
+			Config: map[string]interface{}{
+				"model-quota":                     "10",
+				"model-quota/bob@canonical.com":   float64(20),
+				"model-quota/carol@canonical.com": 0,
+			},
+		},
+		jimmAdmin: true,
+		expectedConfig: dbmodel.ControllerConfig{
+			Name: "jimm",
+			Config: map[string]interface{}{
+				"model-quota":                     "10",
+				"model-quota/bob@canonical.com":   float64(20),
+				"model-quota/carol@canonical.com": float64(0),
+			},
+		},
+	}, {
+		about: "invalid model quota",
+		user:  "alice@canonical.com",
+		args: jujuparams.ControllerConfigSet{
+			Config: map[string]interface{}{
+				"model-quota": "lots",
+			},
+		},
+		jimmAdmin:     true,
+		expectedError: `invalid model quota "lots"`,
+	}, {
+		about: "invalid user model quota",
+		user:  "alice@canonical.com",
+		args: jujuparams.ControllerConfigSet{
+			Config: map[string]interface{}{
+				"model-quota/bob@canonical.com": true,
+			},
+		},
+		jimmAdmin:     true,
+		expectedError: `invalid model quota true`,
+	}, {
+		about: "invalid model logging default",
+		user:  "alice@canonical.com",
+		args: jujuparams.ControllerConfigSet{
+			Config: map[string]interface{}{
+				"model-logging-default": "<root>=LOUD",
+			},
+		},
+		jimmAdmin:     true,
+		expectedError: `invalid model-logging-default value "<root>=LOUD": .*`,
+	}, {
+		about: "model logging default not a string",
+		user:  "alice@canonical.com",
+		args: jujuparams.ControllerConfigSet{
+			Config: map[string]interface{}{
+				"model-logging-default": 1,
+			},
+		},
+		jimmAdmin:     true,
+		expectedError: `invalid model-logging-default value 1`,
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			j := &jimm.JIMM{
+				UUID: uuid.NewString(),
+				Database: db.Database{
+					DB: jimmtest.PostgresDB(c, nil),
+				},
+			}
+			ctx := context.Background()
+			err := j.Database.Migrate(ctx, false)
+			c.Assert(err, qt.IsNil)
+
+			env := jimmtest.ParseEnvironment(c, testControllerConfigEnv)
+			env.PopulateDB(c, j.Database)
+
+			dbUser := env.User(test.user).DBObject(c, j.Database)
+			user := openfga.NewUser(&dbUser, nil)
+			user.JimmAdmin = test.jimmAdmin
+
 			err = j.SetControllerConfig(ctx, user, test.args)
 			if test.expectedError == "" {
 				c.Assert(err, qt.IsNil)
@@ -1052,8 +1581,25 @@ func TestSetControllerConfig(t *testing.T) {
 				err = j.Database.GetControllerConfig(ctx, &cfg)
 				c.Assert(err, qt.IsNil)
 				c.Assert(cfg, jimmtest.DBObjectEquals, test.expectedConfig)
+
+				keys := make([]interface{}, 0, len(test.args.Config))
+				for key := range test.args.Config {
+					keys = append(keys, key)
+				}
+				sort.Slice(keys, func(i, j int) bool {
+					return keys[i].(string) < keys[j].(string)
+				})
+				c.Check(controllerAuditLogEntries(c, j, "SetControllerConfig"), qt.DeepEquals, []controllerAuditLogEntry{{
+					Actor: "user-" + test.user,
+					Details: map[string]interface{}{
+						"controller": "jimm",
+						"change":     "config-changed",
+						"keys":       keys,
+					},
+				}})
 			} else {
 				c.Assert(err, qt.ErrorMatches, test.expectedError)
+				c.Check(controllerAuditLogEntries(c, j, "SetControllerConfig"), qt.HasLen, 0)
 			}
 		})
 	}
@@ -1141,6 +1687,525 @@ func TestGetControllerConfig(t *testing.T) {
 	}
 }
 
+const testControllerInfoEnv = `
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+clouds:
+- name: test-cloud
+  type: test
+  regions:
+  - name: test-region-1
+  - name: test-region-2
+cloud-credentials:
+- name: test-credential
+  cloud: test-cloud
+  owner: alice@canonical.com
+  type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+  agent-version: 3.2.1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-1
+    priority: 10
+  - cloud: test-cloud
+    region: test-region-2
+    priority: 1
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential
+  owner: alice@canonical.com
+  life: alive
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-2
+  cloud-credential: test-credential
+  owner: alice@canonical.com
+  life: alive
+`
+
+func TestGetControllerInfo(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		about        string
+		user         string
+		jimmAdmin    bool
+		dialError    error
+		expectedInfo *jimm.ControllerMetadata
+		expectError  string
+	}{{
+		about:     "reachable controller",
+		user:      "alice@canonical.com",
+		jimmAdmin: true,
+		expectedInfo: &jimm.ControllerMetadata{
+			Name:        "controller-1",
+			UUID:        "00000001-0000-0000-0000-000000000001",
+			CloudName:   "test-cloud",
+			CloudRegion: "test-region-1",
+			CloudRegions: []jimm.ControllerCloudRegion{{
+				Cloud:    "test-cloud",
+				Region:   "test-region-1",
+				Priority: 10,
+			}, {
+				Cloud:    "test-cloud",
+				Region:   "test-region-2",
+				Priority: 1,
+			}},
+			AgentVersion: "3.3.0",
+			Reachable:    true,
+			ModelCount:   2,
+		},
+	}, {
+		about:     "unreachable controller",
+		user:      "alice@canonical.com",
+		jimmAdmin: true,
+		dialError: errors.E("test error"),
+		expectedInfo: &jimm.ControllerMetadata{
+			Name:        "controller-1",
+			UUID:        "00000001-0000-0000-0000-000000000001",
+			CloudName:   "test-cloud",
+			CloudRegion: "test-region-1",
+			CloudRegions: []jimm.ControllerCloudRegion{{
+				Cloud:    "test-cloud",
+				Region:   "test-region-1",
+				Priority: 10,
+			}, {
+				Cloud:    "test-cloud",
+				Region:   "test-region-2",
+				Priority: 1,
+			}},
+			AgentVersion: "3.2.1",
+			ModelCount:   2,
+		},
+	}, {
+		about:       "non-admin user - unauthorized",
+		user:        "bob@canonical.com",
+		expectError: "unauthorized",
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			j := &jimm.JIMM{
+				UUID: uuid.NewString(),
+				Database: db.Database{
+					DB: jimmtest.PostgresDB(c, nil),
+				},
+				Dialer: &jimmtest.Dialer{
+					API:          &jimmtest.API{},
+					Err:          test.dialError,
+					AgentVersion: "3.3.0",
+				},
+			}
+			ctx := context.Background()
+			err := j.Database.Migrate(ctx, false)
+			c.Assert(err, qt.IsNil)
+
+			env := jimmtest.ParseEnvironment(c, testControllerInfoEnv)
+			env.PopulateDB(c, j.Database)
+
+			dbUser := env.User(test.user).DBObject(c, j.Database)
+			user := openfga.NewUser(&dbUser, nil)
+			user.JimmAdmin = test.jimmAdmin
+
+			info, err := j.GetControllerInfo(ctx, user, "controller-1")
+			if test.expectError != "" {
+				c.Assert(err, qt.ErrorMatches, test.expectError)
+				c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(info, qt.DeepEquals, test.expectedInfo)
+		})
+	}
+}
+
+func TestGetControllerInfoNotFound(t *testing.T) {
+	c := qt.New(t)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+	}
+	ctx := context.Background()
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testControllerInfoEnv)
+	env.PopulateDB(c, j.Database)
+
+	dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&dbUser, nil)
+	user.JimmAdmin = true
+
+	_, err = j.GetControllerInfo(ctx, user, "no-such-controller")
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeControllerNotFound)
+}
+
+const testControllersForCloudEnv = `
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+clouds:
+- name: test-cloud
+  type: test
+  regions:
+  - name: test-region-1
+  - name: test-region-2
+- name: other-cloud
+  type: test
+  regions:
+  - name: other-region
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-1
+    priority: 10
+  - cloud: test-cloud
+    region: test-region-2
+    priority: 1
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-region-2
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-2
+    priority: 10
+- name: controller-3
+  uuid: 00000001-0000-0000-0000-000000000003
+  cloud: other-cloud
+  region: other-region
+  cloud-regions:
+  - cloud: other-cloud
+    region: other-region
+    priority: 10
+`
+
+func TestControllersForCloud(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testControllersForCloudEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+
+	controllers, err := j.ControllersForCloud(ctx, alice, names.NewCloudTag("test-cloud"))
+	c.Assert(err, qt.IsNil)
+	c.Check(controllers, qt.DeepEquals, []jimm.CloudController{{
+		Name: "controller-1",
+		UUID: "00000001-0000-0000-0000-000000000001",
+		Regions: []jimm.ControllerCloudRegion{{
+			Cloud:    "test-cloud",
+			Region:   "test-region-1",
+			Priority: 10,
+		}, {
+			Cloud:    "test-cloud",
+			Region:   "test-region-2",
+			Priority: 1,
+		}},
+	}, {
+		Name: "controller-2",
+		UUID: "00000001-0000-0000-0000-000000000002",
+		Regions: []jimm.ControllerCloudRegion{{
+			Cloud:    "test-cloud",
+			Region:   "test-region-2",
+			Priority: 10,
+		}},
+	}})
+
+	// bob can only see the controllers they administer.
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+	err = bob.SetControllerAccess(ctx, env.Controller("controller-2").DBObject(c, j.Database).ResourceTag(), ofganames.AdministratorRelation)
+	c.Assert(err, qt.IsNil)
+
+	controllers, err = j.ControllersForCloud(ctx, bob, names.NewCloudTag("test-cloud"))
+	c.Assert(err, qt.IsNil)
+	c.Check(controllers, qt.HasLen, 1)
+	c.Check(controllers[0].Name, qt.Equals, "controller-2")
+}
+
+const testControllerModelsEnv = `
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+clouds:
+- name: test-cloud
+  type: test
+  regions:
+  - name: test-region
+cloud-credentials:
+- name: test-cred
+  cloud: test-cloud
+  owner: alice@canonical.com
+  type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  region: test-region
+  cloud-credential: test-cred
+  life: alive
+  status:
+    status: available
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-2
+  owner: alice@canonical.com
+  cloud: test-cloud
+  region: test-region
+  cloud-credential: test-cred
+  life: alive
+  status:
+    status: available
+- name: model-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  owner: bob@canonical.com
+  cloud: test-cloud
+  region: test-region
+  cloud-credential: test-cred
+  life: dying
+  status:
+    status: destroying
+`
+
+func TestControllerModels(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testControllerModelsEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+
+	models, err := j.ControllerModels(ctx, alice, "controller-1", pagination.NewOffsetFilter(10, 0))
+	c.Assert(err, qt.IsNil)
+	c.Assert(models, qt.HasLen, 2)
+	c.Check(models[0].UUID.String, qt.Equals, "00000002-0000-0000-0000-000000000001")
+	c.Check(models[0].Owner.Name, qt.Equals, "alice@canonical.com")
+	c.Check(models[0].Life, qt.Equals, "alive")
+	c.Check(models[0].Status.Status, qt.Equals, "available")
+	c.Check(models[1].UUID.String, qt.Equals, "00000002-0000-0000-0000-000000000003")
+	c.Check(models[1].Owner.Name, qt.Equals, "bob@canonical.com")
+	c.Check(models[1].Life, qt.Equals, "dying")
+	c.Check(models[1].Status.Status, qt.Equals, "destroying")
+
+	models, err = j.ControllerModels(ctx, alice, "controller-1", pagination.NewOffsetFilter(1, 1))
+	c.Assert(err, qt.IsNil)
+	c.Assert(models, qt.HasLen, 1)
+	c.Check(models[0].UUID.String, qt.Equals, "00000002-0000-0000-0000-000000000003")
+
+	_, err = j.ControllerModels(ctx, alice, "no-such-controller", pagination.NewOffsetFilter(10, 0))
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeControllerNotFound)
+
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+	_, err = j.ControllerModels(ctx, bob, "controller-1", pagination.NewOffsetFilter(10, 0))
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// Controller administrators may list the models on their controller.
+	err = bob.SetControllerAccess(ctx, env.Controller("controller-2").DBObject(c, j.Database).ResourceTag(), ofganames.AdministratorRelation)
+	c.Assert(err, qt.IsNil)
+	models, err = j.ControllerModels(ctx, bob, "controller-2", pagination.NewOffsetFilter(10, 0))
+	c.Assert(err, qt.IsNil)
+	c.Assert(models, qt.HasLen, 1)
+	c.Check(models[0].Name, qt.Equals, "model-2")
+}
+
+const testControllerModelStatusSummaryEnv = `
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+clouds:
+- name: test-cloud
+  type: test
+  regions:
+  - name: test-region
+cloud-credentials:
+- name: test-cred
+  cloud: test-cloud
+  owner: alice@canonical.com
+  type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  region: test-region
+  cloud-credential: test-cred
+  life: alive
+  status:
+    status: available
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  region: test-region
+  cloud-credential: test-cred
+  life: alive
+  status:
+    status: error
+- name: model-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  region: test-region
+  cloud-credential: test-cred
+  life: alive
+  status:
+    status: error
+- name: model-4
+  uuid: 00000002-0000-0000-0000-000000000004
+  controller: controller-1
+  owner: bob@canonical.com
+  cloud: test-cloud
+  region: test-region
+  cloud-credential: test-cred
+  life: dying
+  status:
+    status: destroying
+- name: model-5
+  uuid: 00000002-0000-0000-0000-000000000005
+  controller: controller-2
+  owner: alice@canonical.com
+  cloud: test-cloud
+  region: test-region
+  cloud-credential: test-cred
+  life: alive
+  status:
+    status: error
+`
+
+func TestControllerModelStatusSummary(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testControllerModelStatusSummaryEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+	_, err = j.ControllerModelStatusSummary(ctx, bob, "controller-1")
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+
+	summary, err := j.ControllerModelStatusSummary(ctx, alice, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(summary, qt.DeepEquals, []db.ModelStatusCount{{
+		Life:   "alive",
+		Status: "available",
+		Count:  1,
+	}, {
+		Life:   "alive",
+		Status: "error",
+		Count:  2,
+	}, {
+		Life:   "dying",
+		Status: "destroying",
+		Count:  1,
+	}})
+
+	_, err = j.ControllerModelStatusSummary(ctx, alice, "no-such-controller")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeControllerNotFound)
+}
+
 const testUpdateMigratedModelEnv = `
 users:
 - username: alice@canonical.com
@@ -1292,6 +2357,65 @@ func TestUpdateMigratedModel(t *testing.T) {
 	}
 }
 
+func TestUpdateMigratedModelPinned(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				ModelInfo_: func(context.Context, *jujuparams.ModelInfo) error {
+					return nil
+				},
+			},
+		},
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testUpdateMigratedModelEnv)
+	env.PopulateDB(c, j.Database)
+
+	dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&dbUser, nil)
+	mt := names.NewModelTag("00000002-0000-0000-0000-000000000002")
+
+	// Only JIMM admins may pin models.
+	err = j.PinModel(ctx, user, mt)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	user.JimmAdmin = true
+	err = j.PinModel(ctx, user, mt)
+	c.Assert(err, qt.IsNil)
+
+	err = j.UpdateMigratedModel(ctx, user, mt, "controller-2")
+	c.Assert(err, qt.ErrorMatches, `model 00000002-0000-0000-0000-000000000002 is pinned to its controller, unpin it before migrating`)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeForbidden)
+
+	model := dbmodel.Model{}
+	model.SetTag(mt)
+	err = j.Database.GetModel(ctx, &model)
+	c.Assert(err, qt.IsNil)
+	c.Assert(model.Controller.Name, qt.Equals, "controller-1")
+
+	err = j.UnpinModel(ctx, user, mt)
+	c.Assert(err, qt.IsNil)
+
+	err = j.UpdateMigratedModel(ctx, user, mt, "controller-2")
+	c.Assert(err, qt.IsNil)
+
+	model = dbmodel.Model{}
+	model.SetTag(mt)
+	err = j.Database.GetModel(ctx, &model)
+	c.Assert(err, qt.IsNil)
+	c.Assert(model.Controller.Name, qt.Equals, "controller-2")
+	c.Assert(model.PinnedControllerID.Valid, qt.IsFalse)
+}
+
 const testGetControllerAccessEnv = `
 users:
 - username: alice@canonical.com
@@ -1678,3 +2802,76 @@ func (c *testControllerClient) InitiateMigration(spec controller.MigrationSpec)
 func (c *testControllerClient) Close() error {
 	return nil
 }
+
+func TestListMigrations(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(time.Hour)
+	api := &jimmtest.API{
+		ModelInfo_: func(_ context.Context, mi *jujuparams.ModelInfo) error {
+			switch mi.UUID {
+			case "00000002-0000-0000-0000-000000000001":
+				mi.Migration = &jujuparams.ModelMigrationStatus{
+					Status: "importing model into target controller",
+					Start:  &start,
+				}
+			case "00000002-0000-0000-0000-000000000002":
+				// A finished migration.
+				mi.Migration = &jujuparams.ModelMigrationStatus{
+					Status: "aborted",
+					Start:  &start,
+					End:    &end,
+				}
+			case "00000002-0000-0000-0000-000000000003":
+				mi.Migration = &jujuparams.ModelMigrationStatus{
+					Status: "performing source prechecks",
+					Start:  &start,
+				}
+			}
+			return nil
+		},
+	}
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer:        &jimmtest.Dialer{API: api},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, administeredModelsTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, client)
+	admin.JimmAdmin = true
+	migrations, err := j.ListMigrations(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	c.Check(migrations, qt.DeepEquals, []jimm.ModelMigration{{
+		Model:      names.NewModelTag("00000002-0000-0000-0000-000000000001"),
+		ModelName:  "model-1",
+		Owner:      "bob@canonical.com",
+		Controller: "controller-1",
+		Status:     "importing model into target controller",
+		Start:      start,
+	}, {
+		Model:      names.NewModelTag("00000002-0000-0000-0000-000000000003"),
+		ModelName:  "model-3",
+		Owner:      "bob@canonical.com",
+		Controller: "controller-2",
+		Status:     "performing source prechecks",
+		Start:      start,
+	}})
+
+	alice := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, client)
+	_, err = j.ListMigrations(ctx, alice)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+}
@@ -4,6 +4,7 @@ package jimm
 
 import (
 	"context"
+	"time"
 
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
@@ -22,8 +23,19 @@ var (
 	FillMigrationTarget            = fillMigrationTarget
 	InitiateMigration              = &initiateMigration
 	ResolveTag                     = resolveTag
+	SetModelOwnerAccess            = &setModelOwnerAccess
+	GrantModelAccessDelay          = &grantModelAccessDelay
+	ModelSummaryWatcherDelay       = &modelSummaryWatcherDelay
+	CheckResourceTags              = checkResourceTags
+	SetModelLife                   = setModelLife
+	ModelCreationRetention         = &modelCreationRetention
+	AuditSendTimeout               = &auditSendTimeout
 )
 
+func SetLoginAttemptTrackerNow(t *LoginAttemptTracker, now func() time.Time) {
+	t.now = now
+}
+
 func WatchController(w *Watcher, ctx context.Context, ctl *dbmodel.Controller) error {
 	return w.watchController(ctx, ctl)
 }
@@ -65,3 +77,11 @@ func (j *JIMM) UpdateUserLastLogin(ctx context.Context, identifier string) error
 func (j *JIMM) EveryoneUser() *openfga.User {
 	return j.everyoneUser()
 }
+
+func (j *JIMM) WaitModelCreations() {
+	j.modelCreations.wait()
+}
+
+func (j *JIMM) UpdateModel(ctx context.Context, m *dbmodel.Model, apply func(*dbmodel.Model) error) error {
+	return j.updateModel(ctx, m, apply)
+}
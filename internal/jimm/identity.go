@@ -4,11 +4,15 @@ package jimm
 
 import (
 	"context"
+	"sort"
+
+	"github.com/juju/names/v5"
 
 	"github.com/canonical/jimm/v3/internal/common/pagination"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 )
 
 // FetchIdentity fetches the user specified by the username and returns the user if it is found.
@@ -63,3 +67,110 @@ func (j *JIMM) CountIdentities(ctx context.Context, user *openfga.User) (int, er
 	}
 	return count, nil
 }
+
+// An AccessProfile describes the access an identity has to the resources
+// managed by JIMM.
+type AccessProfile struct {
+	// Identity is the name of the identity.
+	Identity string
+
+	// ControllerAccess is the identity's access level to JIMM, either
+	// "superuser" or "login".
+	ControllerAccess string
+
+	// Groups holds the names of the groups the identity is a member of,
+	// sorted by name.
+	Groups []string
+
+	// Models holds the number of models the identity can access at each
+	// access level.
+	Models map[string]int
+
+	// ApplicationOffers holds the number of application offers the
+	// identity can access at each access level.
+	ApplicationOffers map[string]int
+
+	// Clouds holds the number of clouds the identity can access at each
+	// access level.
+	Clouds map[string]int
+}
+
+// UserAccessProfile returns the access profile of the target identity.
+// Resources are only counted at the highest access level the target has
+// to them, whether that access is granted directly or through a group.
+// Only JIMM administrators and the target identity may see the profile,
+// other users will receive an error with a code of CodeUnauthorized.
+func (j *JIMM) UserAccessProfile(ctx context.Context, requester *openfga.User, target names.UserTag) (*AccessProfile, error) {
+	const op = errors.Op("jimm.UserAccessProfile")
+
+	if !requester.JimmAdmin && requester.Name != target.Id() {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	identity, err := dbmodel.NewIdentity(target.Id())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := j.Database.FetchIdentity(ctx, identity); err != nil {
+		return nil, errors.E(op, err)
+	}
+	user := openfga.NewUser(identity, j.OpenFGAClient)
+
+	profile := AccessProfile{
+		Identity:         identity.Name,
+		ControllerAccess: ToControllerAccessString(user.GetControllerAccess(ctx, j.ResourceTag())),
+	}
+
+	groups, err := j.OpenFGAClient.ListObjects(ctx, ofganames.ConvertTag(user.ResourceTag()), ofganames.MemberRelation, openfga.GroupType, nil)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	for _, t := range groups {
+		group := dbmodel.GroupEntry{UUID: t.ID}
+		if err := j.Database.GetGroup(ctx, &group); err != nil {
+			return nil, errors.E(op, err)
+		}
+		profile.Groups = append(profile.Groups, group.Name)
+	}
+	sort.Strings(profile.Groups)
+
+	profile.Models, err = j.countAccessLevels(ctx, user, openfga.ModelType, ToModelAccessString,
+		ofganames.AdministratorRelation, ofganames.WriterRelation, ofganames.ReaderRelation)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	profile.ApplicationOffers, err = j.countAccessLevels(ctx, user, openfga.ApplicationOfferType, ToOfferAccessString,
+		ofganames.AdministratorRelation, ofganames.ConsumerRelation, ofganames.ReaderRelation)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	profile.Clouds, err = j.countAccessLevels(ctx, user, openfga.CloudType, ToCloudAccessString,
+		ofganames.AdministratorRelation, ofganames.CanAddModelRelation)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &profile, nil
+}
+
+// countAccessLevels returns the number of objects of the given kind the
+// user has each of the given relations to, keyed by access level. The
+// relations must be ordered from the highest access level to the lowest,
+// each object is only counted at the highest level.
+func (j *JIMM) countAccessLevels(ctx context.Context, user *openfga.User, kind openfga.Kind, toAccess func(openfga.Relation) string, relations ...openfga.Relation) (map[string]int, error) {
+	seen := make(map[string]bool)
+	counts := make(map[string]int)
+	for _, relation := range relations {
+		tags, err := j.OpenFGAClient.ListObjects(ctx, ofganames.ConvertTag(user.ResourceTag()), relation, kind, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tags {
+			if seen[t.ID] {
+				continue
+			}
+			seen[t.ID] = true
+			counts[toAccess(relation)]++
+		}
+	}
+	return counts, nil
+}
@@ -9,12 +9,15 @@ import (
 
 	qt "github.com/frankban/quicktest"
 	"github.com/google/uuid"
+	"github.com/juju/names/v5"
 
 	"github.com/canonical/jimm/v3/internal/common/pagination"
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
 )
 
@@ -160,3 +163,132 @@ func TestCountIdentities(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(count, qt.Equals, 4)
 }
+
+const userAccessProfileTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+  users:
+  - user: alice@canonical.com
+    access: add-model
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+  auth-type: empty
+- owner: bob@canonical.com
+  name: cred-2
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-2
+  owner: bob@canonical.com
+  life: alive
+  users:
+  - user: alice@canonical.com
+    access: write
+- name: model-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-2
+  owner: bob@canonical.com
+  life: alive
+users:
+- username: charlie@canonical.com
+`
+
+func TestUserAccessProfile(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, userAccessProfileTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, ofgaClient)
+
+	charlie := env.User("charlie@canonical.com").DBObject(c, j.Database)
+	admin := openfga.NewUser(&charlie, ofgaClient)
+	admin.JimmAdmin = true
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+
+	// alice can read model-3 through her membership of group-b.
+	for _, name := range []string{"group-b", "group-a"} {
+		group, err := j.AddGroup(ctx, admin, name)
+		c.Assert(err, qt.IsNil)
+		err = ofgaClient.AddRelation(ctx, openfga.Tuple{
+			Object:   ofganames.ConvertTag(alice.ResourceTag()),
+			Relation: ofganames.MemberRelation,
+			Target:   ofganames.ConvertTag(group.ResourceTag()),
+		})
+		c.Assert(err, qt.IsNil)
+		if name == "group-b" {
+			err = ofgaClient.AddRelation(ctx, openfga.Tuple{
+				Object:   ofganames.ConvertTagWithRelation(group.ResourceTag(), ofganames.MemberRelation),
+				Relation: ofganames.ReaderRelation,
+				Target:   ofganames.ConvertTag(names.NewModelTag("00000002-0000-0000-0000-000000000003")),
+			})
+			c.Assert(err, qt.IsNil)
+		}
+	}
+
+	expectProfile := &jimm.AccessProfile{
+		Identity:         "alice@canonical.com",
+		ControllerAccess: "login",
+		Groups:           []string{"group-a", "group-b"},
+		Models: map[string]int{
+			"admin": 1,
+			"write": 1,
+			"read":  1,
+		},
+		ApplicationOffers: map[string]int{},
+		Clouds: map[string]int{
+			"add-model": 1,
+		},
+	}
+
+	profile, err := j.UserAccessProfile(ctx, admin, names.NewUserTag("alice@canonical.com"))
+	c.Assert(err, qt.IsNil)
+	c.Check(profile, qt.DeepEquals, expectProfile)
+
+	// Users can see their own profile.
+	profile, err = j.UserAccessProfile(ctx, openfga.NewUser(&alice, ofgaClient), names.NewUserTag("alice@canonical.com"))
+	c.Assert(err, qt.IsNil)
+	c.Check(profile, qt.DeepEquals, expectProfile)
+
+	// But not the profile of other users.
+	_, err = j.UserAccessProfile(ctx, openfga.NewUser(&alice, ofgaClient), names.NewUserTag("bob@canonical.com"))
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// SetIdentityPreferences stores the cloud, region and credential the
+// identity prefers new models to be created with, replacing any previous
+// preferences. An empty value clears the corresponding preference. A
+// region or credential can only be preferred along with a cloud.
+func (j *JIMM) SetIdentityPreferences(ctx context.Context, identity *dbmodel.Identity, prefs dbmodel.IdentityPreferences) error {
+	const op = errors.Op("jimm.SetIdentityPreferences")
+
+	if prefs.CloudName == "" && (prefs.CloudRegion != "" || prefs.CloudCredentialName != "") {
+		return errors.E(op, errors.CodeBadRequest, "a preferred cloud region or credential requires a preferred cloud")
+	}
+	if prefs.CloudName != "" && !names.IsValidCloud(prefs.CloudName) {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid cloud %q", prefs.CloudName))
+	}
+	if prefs.CloudCredentialName != "" && !names.IsValidCloudCredentialName(prefs.CloudCredentialName) {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid cloud credential name %q", prefs.CloudCredentialName))
+	}
+
+	err := j.Database.SetIdentityPreferences(ctx, &dbmodel.IdentityPreferences{
+		IdentityName:        identity.Name,
+		CloudName:           prefs.CloudName,
+		CloudRegion:         prefs.CloudRegion,
+		CloudCredentialName: prefs.CloudCredentialName,
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// IdentityPreferences returns the model creation preferences stored for
+// the identity.
+func (j *JIMM) IdentityPreferences(ctx context.Context, identity *dbmodel.Identity) (*dbmodel.IdentityPreferences, error) {
+	const op = errors.Op("jimm.IdentityPreferences")
+
+	prefs := dbmodel.IdentityPreferences{
+		IdentityName: identity.Name,
+	}
+	if err := j.Database.IdentityPreferences(ctx, &prefs); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &prefs, nil
+}
+
+// withIdentityPreferences returns a copy of the given model creation
+// arguments with the cloud, region and credential filled in from the
+// owner's preferences where they have not been specified. The preferred
+// region and credential are only used when the model is being created
+// on the preferred cloud.
+func (j *JIMM) withIdentityPreferences(ctx context.Context, owner *dbmodel.Identity, args *ModelCreateArgs) (*ModelCreateArgs, error) {
+	prefs := dbmodel.IdentityPreferences{
+		IdentityName: owner.Name,
+	}
+	if err := j.Database.IdentityPreferences(ctx, &prefs); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return args, nil
+		}
+		return nil, err
+	}
+	if prefs.CloudName == "" {
+		return args, nil
+	}
+
+	args1 := *args
+	if args1.Cloud == (names.CloudTag{}) {
+		args1.Cloud = names.NewCloudTag(prefs.CloudName)
+	}
+	if args1.Cloud.Id() != prefs.CloudName {
+		return &args1, nil
+	}
+	if args1.CloudRegion == "" {
+		args1.CloudRegion = prefs.CloudRegion
+	}
+	if args1.CloudCredential == (names.CloudCredentialTag{}) && args1.CloudCredentialName == "" {
+		args1.CloudCredentialName = prefs.CloudCredentialName
+	}
+	return &args1, nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+func TestIdentityPreferences(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j := &jimm.JIMM{
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	identity, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(j.Database.DB.Create(identity).Error, qt.IsNil)
+
+	_, err = j.IdentityPreferences(ctx, identity)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = j.SetIdentityPreferences(ctx, identity, dbmodel.IdentityPreferences{
+		CloudName:           "aws",
+		CloudRegion:         "eu-west-1",
+		CloudCredentialName: "cred-1",
+	})
+	c.Assert(err, qt.IsNil)
+
+	prefs, err := j.IdentityPreferences(ctx, identity)
+	c.Assert(err, qt.IsNil)
+	c.Check(prefs.CloudName, qt.Equals, "aws")
+	c.Check(prefs.CloudRegion, qt.Equals, "eu-west-1")
+	c.Check(prefs.CloudCredentialName, qt.Equals, "cred-1")
+
+	// Setting the preferences replaces all of them.
+	err = j.SetIdentityPreferences(ctx, identity, dbmodel.IdentityPreferences{
+		CloudName: "gce",
+	})
+	c.Assert(err, qt.IsNil)
+
+	prefs, err = j.IdentityPreferences(ctx, identity)
+	c.Assert(err, qt.IsNil)
+	c.Check(prefs.CloudName, qt.Equals, "gce")
+	c.Check(prefs.CloudRegion, qt.Equals, "")
+	c.Check(prefs.CloudCredentialName, qt.Equals, "")
+
+	err = j.SetIdentityPreferences(ctx, identity, dbmodel.IdentityPreferences{
+		CloudRegion: "eu-west-1",
+	})
+	c.Check(err, qt.ErrorMatches, `a preferred cloud region or credential requires a preferred cloud`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+}
+
+const identityPreferencesTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+  - name: test-region-2
+  users:
+  - user: alice@canonical.com
+    access: add-model
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+  auth-type: empty
+- owner: alice@canonical.com
+  name: cred-2
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-1
+    priority: 1
+  - cloud: test-cloud
+    region: test-region-2
+    priority: 1
+`
+
+func TestAddModelIdentityPreferences(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	create := createModel(`
+status:
+  status: started
+  info: running a test
+life: alive
+users:
+- user: alice@canonical.com
+  access: admin
+`[1:])
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				UpdateCredential_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+				GrantJIMMModelAdmin_: func(context.Context, names.ModelTag) error {
+					return nil
+				},
+				CreateModel_: func(ctx context.Context, args *jujuparams.ModelCreateArgs, mi *jujuparams.ModelInfo) error {
+					if err := create(ctx, args, mi); err != nil {
+						return err
+					}
+					mi.UUID = uuid.NewString()
+					return nil
+				},
+			},
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, identityPreferencesTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&dbUser, client)
+
+	err = j.SetIdentityPreferences(ctx, &dbUser, dbmodel.IdentityPreferences{
+		CloudName:           "test-cloud",
+		CloudRegion:         "test-region-2",
+		CloudCredentialName: "cred-2",
+	})
+	c.Assert(err, qt.IsNil)
+
+	addModel := func(args jimm.ModelCreateArgs) dbmodel.Model {
+		args.Owner = names.NewUserTag("alice@canonical.com")
+		mi, err := j.AddModel(ctx, user, &args)
+		c.Assert(err, qt.IsNil)
+		m := dbmodel.Model{
+			UUID: sql.NullString{String: mi.UUID, Valid: true},
+		}
+		err = j.Database.GetModel(ctx, &m)
+		c.Assert(err, qt.IsNil)
+		return m
+	}
+
+	// With nothing specified the preferences are used.
+	m := addModel(jimm.ModelCreateArgs{
+		Name: "model-1",
+	})
+	c.Check(m.CloudRegion.Cloud.Name, qt.Equals, "test-cloud")
+	c.Check(m.CloudRegion.Name, qt.Equals, "test-region-2")
+	c.Check(m.CloudCredential.Name, qt.Equals, "cred-2")
+
+	// Explicit arguments override the preferences.
+	m = addModel(jimm.ModelCreateArgs{
+		Name:            "model-2",
+		Cloud:           names.NewCloudTag("test-cloud"),
+		CloudRegion:     "test-region-1",
+		CloudCredential: names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1"),
+	})
+	c.Check(m.CloudRegion.Name, qt.Equals, "test-region-1")
+	c.Check(m.CloudCredential.Name, qt.Equals, "cred-1")
+
+	// Preferences fill in whatever is not specified.
+	m = addModel(jimm.ModelCreateArgs{
+		Name:        "model-3",
+		CloudRegion: "test-region-1",
+	})
+	c.Check(m.CloudRegion.Name, qt.Equals, "test-region-1")
+	c.Check(m.CloudCredential.Name, qt.Equals, "cred-2")
+}
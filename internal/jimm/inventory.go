@@ -0,0 +1,181 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// inventoryRelationsPageSize is the number of relations read from
+// OpenFGA at a time when exporting the inventory.
+const inventoryRelationsPageSize = 100
+
+// ExportInventory writes a JSON document describing every controller,
+// cloud, model and group known to JIMM, along with the access relations
+// stored in OpenFGA, to the given writer. The document has the format of
+// apiparams.Inventory and never contains any secrets, such as controller
+// passwords or cloud credential attributes. The document is written as
+// the entities are read so that the whole inventory is never held in
+// memory. Only JIMM administrators may export the inventory.
+func (j *JIMM) ExportInventory(ctx context.Context, user *openfga.User, w io.Writer) error {
+	const op = errors.Op("jimm.ExportInventory")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	iw := &inventoryWriter{w: w}
+	iw.writeRaw(fmt.Sprintf(`{"version":%d`, apiparams.InventoryVersion))
+
+	iw.section("controllers", func() error {
+		return j.Database.ForEachController(ctx, func(ctl *dbmodel.Controller) error {
+			return iw.write(apiparams.InventoryController{
+				Name:          ctl.Name,
+				UUID:          ctl.UUID,
+				Cloud:         ctl.CloudName,
+				CloudRegion:   ctl.CloudRegion,
+				PublicAddress: ctl.PublicAddress,
+				AgentVersion:  ctl.AgentVersion,
+				Deprecated:    ctl.Deprecated,
+			})
+		})
+	})
+	iw.section("clouds", func() error {
+		clouds, err := j.Database.GetClouds(ctx)
+		if err != nil {
+			return err
+		}
+		for _, cloud := range clouds {
+			ic := apiparams.InventoryCloud{
+				Name:            cloud.Name,
+				Type:            cloud.Type,
+				HostCloudRegion: cloud.HostCloudRegion,
+			}
+			for _, r := range cloud.Regions {
+				ic.Regions = append(ic.Regions, r.Name)
+			}
+			if err := iw.write(ic); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	iw.section("models", func() error {
+		return j.Database.ForEachModel(ctx, func(m *dbmodel.Model) error {
+			im := apiparams.InventoryModel{
+				UUID:        m.UUID.String,
+				Name:        m.Name,
+				Owner:       m.OwnerIdentityName,
+				Type:        m.Type,
+				Controller:  m.Controller.Name,
+				Cloud:       m.CloudRegion.Cloud.Name,
+				CloudRegion: m.CloudRegion.Name,
+				Life:        m.Life,
+				Status:      m.Status.Status,
+			}
+			if m.CloudCredentialID != 0 {
+				im.CloudCredential = m.CloudCredential.Path()
+			}
+			return iw.write(im)
+		})
+	})
+	iw.section("groups", func() error {
+		return j.Database.ForEachGroup(ctx, 0, 0, "", func(g *dbmodel.GroupEntry) error {
+			return iw.write(apiparams.InventoryGroup{
+				UUID: g.UUID,
+				Name: g.Name,
+			})
+		})
+	})
+	iw.section("relations", func() error {
+		var token string
+		for {
+			tuples, next, err := j.OpenFGAClient.ReadRelatedObjects(ctx, openfga.Tuple{}, inventoryRelationsPageSize, token)
+			if err != nil {
+				return err
+			}
+			for _, t := range tuples {
+				rt := apiparams.RelationshipTuple{
+					Relation: string(t.Relation),
+				}
+				if t.Object != nil {
+					rt.Object = t.Object.String()
+				}
+				if t.Target != nil {
+					rt.TargetObject = t.Target.String()
+				}
+				if err := iw.write(rt); err != nil {
+					return err
+				}
+			}
+			if next == "" || next == token {
+				return nil
+			}
+			token = next
+		}
+	})
+	iw.writeRaw("}\n")
+
+	if iw.err != nil {
+		return errors.E(op, iw.err)
+	}
+	return nil
+}
+
+// An inventoryWriter writes the inventory document one value at a time.
+// Once an error has occurred nothing further is written and the error is
+// held in err.
+type inventoryWriter struct {
+	w     io.Writer
+	err   error
+	first bool
+}
+
+// section writes an array field with the given name, f is called to
+// write the elements of the array.
+func (iw *inventoryWriter) section(name string, f func() error) {
+	iw.writeRaw(`,"` + name + `":[`)
+	if iw.err != nil {
+		return
+	}
+	iw.first = true
+	if err := f(); err != nil && iw.err == nil {
+		iw.err = err
+	}
+	iw.writeRaw("]")
+}
+
+// write writes the JSON encoding of v. Within a section the values are
+// separated by commas.
+func (iw *inventoryWriter) write(v interface{}) error {
+	if iw.err != nil {
+		return iw.err
+	}
+	buf, err := json.Marshal(v)
+	if err != nil {
+		iw.err = err
+		return err
+	}
+	if !iw.first {
+		buf = append([]byte(","), buf...)
+	}
+	iw.first = false
+	_, iw.err = iw.w.Write(buf)
+	return iw.err
+}
+
+// writeRaw writes s unchanged.
+func (iw *inventoryWriter) writeRaw(s string) {
+	if iw.err != nil {
+		return
+	}
+	_, iw.err = io.WriteString(iw.w, s)
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+const exportInventoryTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+  users:
+  - user: alice@canonical.com
+    access: add-model
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+  auth-type: userpass
+  attributes:
+    username: alice
+    password: credential-secret
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+  agent-version: 3.5.0
+  admin-password: controller-secret
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  type: iaas
+  life: alive
+  status:
+    status: available
+  users:
+  - user: alice@canonical.com
+    access: admin
+`
+
+func TestExportInventory(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, exportInventoryTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	group, err := j.Database.AddGroup(ctx, "group-1")
+	c.Assert(err, qt.IsNil)
+
+	alice := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, client)
+	var buf bytes.Buffer
+	err = j.ExportInventory(ctx, alice, &buf)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+	c.Check(buf.Len(), qt.Equals, 0)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, client)
+	admin.JimmAdmin = true
+	err = j.ExportInventory(ctx, admin, &buf)
+	c.Assert(err, qt.IsNil)
+
+	// No secrets are included.
+	c.Check(buf.String(), qt.Not(qt.Contains), "controller-secret")
+	c.Check(buf.String(), qt.Not(qt.Contains), "credential-secret")
+
+	var inv apiparams.Inventory
+	err = json.Unmarshal(buf.Bytes(), &inv)
+	c.Assert(err, qt.IsNil)
+	c.Check(inv.Version, qt.Equals, apiparams.InventoryVersion)
+	c.Check(inv.Controllers, qt.DeepEquals, []apiparams.InventoryController{{
+		Name:         "controller-1",
+		UUID:         "00000001-0000-0000-0000-000000000001",
+		Cloud:        "test-cloud",
+		CloudRegion:  "test-cloud-region",
+		AgentVersion: "3.5.0",
+	}})
+	c.Check(inv.Clouds, qt.DeepEquals, []apiparams.InventoryCloud{{
+		Name:    "test-cloud",
+		Type:    "test-provider",
+		Regions: []string{"test-cloud-region"},
+	}})
+	c.Check(inv.Models, qt.DeepEquals, []apiparams.InventoryModel{{
+		UUID:            "00000002-0000-0000-0000-000000000001",
+		Name:            "model-1",
+		Owner:           "alice@canonical.com",
+		Type:            "iaas",
+		Controller:      "controller-1",
+		Cloud:           "test-cloud",
+		CloudRegion:     "test-cloud-region",
+		CloudCredential: "test-cloud/alice@canonical.com/cred-1",
+		Life:            "alive",
+		Status:          "available",
+	}})
+	c.Check(inv.Groups, qt.DeepEquals, []apiparams.InventoryGroup{{
+		UUID: group.UUID,
+		Name: "group-1",
+	}})
+	c.Check(inv.Relations, qt.Contains, apiparams.RelationshipTuple{
+		Object:       "user:alice@canonical.com",
+		Relation:     "administrator",
+		TargetObject: "model:00000002-0000-0000-0000-000000000001",
+	})
+}
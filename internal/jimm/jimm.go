@@ -7,17 +7,21 @@ package jimm
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
 	"github.com/juju/zaputil/zapctx"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"go.uber.org/zap"
@@ -72,6 +76,16 @@ type JIMM struct {
 	// UUID holds the UUID of the JIMM controller.
 	UUID string
 
+	// MaxCredentialAttributesSize is the maximum total size, in bytes,
+	// of the attribute names and values of a cloud credential. If this
+	// is zero then DefaultMaxCredentialAttributesSize is used.
+	MaxCredentialAttributesSize int
+
+	// MaxCredentialAttributes is the maximum number of attributes a
+	// cloud credential may have. If this is zero then
+	// DefaultMaxCredentialAttributes is used.
+	MaxCredentialAttributes int
+
 	// OpenFGAClient holds the client used to interact
 	// with the OpenFGA ReBAC system.
 	OpenFGAClient *openfga.OFGAClient
@@ -83,9 +97,35 @@ type JIMM struct {
 	// JWTService is responsible for minting JWTs to access controllers.
 	JWTService *jimmjwx.JWTService
 
+	// JWTClaimsSources holds the sources of any additional claims to
+	// include in the JWTs used to access controllers.
+	JWTClaimsSources []JWTClaimsSource
+
+	// LoginAttempts, if set, counts the logins rejected because of
+	// invalid client credentials, or because the user may not access
+	// the model or controller, and is used to reject logins by users
+	// that have failed to log in too many times.
+	LoginAttempts *LoginAttemptTracker
+
 	// OAuthAuthenticator is responsible for handling authentication
 	// via OAuth2.0 AND JWT access tokens to JIMM.
 	OAuthAuthenticator OAuthAuthenticator
+
+	// IDTokenAuthenticator, if set, enables authentication using ID
+	// tokens issued by an external OIDC provider.
+	IDTokenAuthenticator IDTokenAuthenticator
+
+	// AuditSink, if set, receives every audit log entry after it has
+	// been stored. Entries that cannot be sent when they are stored are
+	// sent later by RunAuditExport.
+	AuditSink AuditSink
+
+	// sessions holds the model sessions currently being proxied.
+	sessions sessionTracker
+
+	// modelCreations runs the asynchronous model creations started by
+	// AddModelAsync.
+	modelCreations modelCreationTracker
 }
 
 // ResourceTag returns JIMM's controller tag stating its UUID.
@@ -166,6 +206,14 @@ type OAuthAuthenticator interface {
 	AuthenticateBrowserSession(ctx context.Context, w http.ResponseWriter, req *http.Request) (context.Context, error)
 }
 
+// IDTokenAuthenticator authenticates users with an ID token issued by an
+// external OIDC provider.
+type IDTokenAuthenticator interface {
+	// Authenticate validates the given raw ID token and returns the
+	// name of the user it identifies.
+	Authenticate(ctx context.Context, rawIDToken string) (string, error)
+}
+
 // GetCredentialStore returns the credential store used by JIMM.
 func (j *JIMM) GetCredentialStore() credentials.CredentialStore {
 	return j.CredentialStore
@@ -194,6 +242,15 @@ func (j *JIMM) dial(ctx context.Context, ctl *dbmodel.Controller, modelTag names
 	return j.Dialer.Dial(ctx, ctl, modelTag, permissionMap)
 }
 
+// evictControllerConnection drops any connection to the given controller
+// cached by the configured Dialer. It should be called whenever the
+// credentials JIMM uses to connect to the controller change.
+func (j *JIMM) evictControllerConnection(controllerName string) {
+	if e, ok := j.Dialer.(interface{ Evict(string) }); ok {
+		e.Evict(controllerName)
+	}
+}
+
 // A Dialer provides a connection to a controller.
 type Dialer interface {
 	// Dial creates an API connection to a controller. If the given
@@ -275,6 +332,10 @@ type API interface {
 	// consume an application offer
 	GetApplicationOfferConsumeDetails(context.Context, names.UserTag, *jujuparams.ConsumeOfferDetails, bakery.Version) error
 
+	// GetModelConstraints returns the constraints of the model the API
+	// is connected to.
+	GetModelConstraints(context.Context) (constraints.Value, error)
+
 	// GrantApplicationOfferAccess grants access to an application offer to
 	// a user.
 	GrantApplicationOfferAccess(context.Context, string, names.UserTag, jujuparams.OfferAccessPermission) error
@@ -295,6 +356,30 @@ type API interface {
 	// filter.
 	ListApplicationOffers(context.Context, []jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
 
+	// ListBlocks returns the blocks switched on for the model the API is
+	// connected to.
+	ListBlocks(context.Context) ([]jujuparams.Block, error)
+
+	// ListModels lists the models the given user has access to on the
+	// controller.
+	ListModels(context.Context, names.UserTag) ([]jujuparams.UserModel, error)
+
+	// ModelGet returns the configuration of the model the API is
+	// connected to.
+	ModelGet(context.Context) (map[string]jujuparams.ConfigValue, error)
+
+	// ModelSet sets configuration values on the model the API is
+	// connected to.
+	ModelSet(context.Context, map[string]interface{}) error
+
+	// ModelUnset removes configuration values from the model the API
+	// is connected to.
+	ModelUnset(context.Context, []string) error
+
+	// ModelDefaultsForCloud returns the model-defaults configured on the
+	// controller for the given cloud.
+	ModelDefaultsForCloud(context.Context, names.CloudTag) (map[string]jujuparams.ModelDefaults, error)
+
 	// ModelInfo fetches a model's ModelInfo.
 	ModelInfo(context.Context, *jujuparams.ModelInfo) error
 
@@ -337,6 +422,18 @@ type API interface {
 	// RevokeModelAccess revokes model access from a user.
 	RevokeModelAccess(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error
 
+	// SetSLALevel sets the SLA level and owner of the model the API is
+	// connected to.
+	SetSLALevel(ctx context.Context, level, owner string) error
+
+	// SwitchBlockOff switches off a block on the model the API is
+	// connected to.
+	SwitchBlockOff(ctx context.Context, blockType string) error
+
+	// SwitchBlockOn switches on a block, with the given message, on the
+	// model the API is connected to.
+	SwitchBlockOn(ctx context.Context, blockType, message string) error
+
 	// SupportsCheckCredentialModels returns true if the
 	// CheckCredentialModels method can be used.
 	SupportsCheckCredentialModels() bool
@@ -354,6 +451,10 @@ type API interface {
 	// UpdateCredential updates a credential.
 	UpdateCredential(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error)
 
+	// UpgradeModel starts the upgrade of a model to the given agent
+	// version, returning the version chosen by the controller.
+	UpgradeModel(context.Context, names.ModelTag, version.Number) (version.Number, error)
+
 	// ValidateModelUpgrade validates that a model can be upgraded.
 	ValidateModelUpgrade(context.Context, names.ModelTag, bool) error
 
@@ -402,12 +503,68 @@ func (j *JIMM) forEachController(ctx context.Context, controllers []dbmodel.Cont
 	return eg.Wait()
 }
 
+// WarmControllerConnections dials every available controller so that,
+// when the configured Dialer caches connections, the first requests to
+// each controller don't pay the cost of establishing a connection. At
+// most concurrency controllers are dialed at once and each dial is
+// abandoned after the given timeout. Failures are logged, but otherwise
+// ignored.
+func (j *JIMM) WarmControllerConnections(ctx context.Context, concurrency int, timeout time.Duration) {
+	var controllers []dbmodel.Controller
+	err := j.Database.ForEachController(ctx, func(ctl *dbmodel.Controller) error {
+		if !ctl.UnavailableSince.Valid {
+			controllers = append(controllers, *ctl)
+		}
+		return nil
+	})
+	if err != nil {
+		zapctx.Error(ctx, "failed to list controllers to warm connections", zap.Error(err))
+		return
+	}
+
+	eg := new(errgroup.Group)
+	if concurrency > 0 {
+		eg.SetLimit(concurrency)
+	}
+	for i := range controllers {
+		ctl := &controllers[i]
+		eg.Go(func() error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			api, err := j.dial(ctx, ctl, names.ModelTag{})
+			if err != nil {
+				zapctx.Warn(ctx, "failed to warm controller connection", zap.String("controller", ctl.Name), zap.Error(err))
+				return nil
+			}
+			// Closing the connection leaves it in the cache.
+			api.Close()
+			return nil
+		})
+	}
+	eg.Wait()
+	zapctx.Info(ctx, "warmed controller connections", zap.Int("controllers", len(controllers)))
+}
+
 // addAuditLogEntry causes an entry to be added the the audit log.
 func (j *JIMM) AddAuditLogEntry(ale *dbmodel.AuditLogEntry) {
 	ctx := context.Background()
 	redactSensitiveParams(ale)
+	ale.PendingExport = j.AuditSink != nil
 	if err := j.Database.AddAuditLogEntry(ctx, ale); err != nil {
 		zapctx.Error(ctx, "cannot store audit log entry", zap.Error(err), zap.Any("entry", *ale))
+		return
+	}
+	if j.Pubsub != nil {
+		j.Pubsub.Publish(AuditLogTopic, *ale)
+	}
+	if j.AuditSink != nil {
+		// The entry remains pending if it cannot be delivered in time,
+		// it will be retried by RunAuditExport.
+		ctx, cancel := context.WithTimeout(ctx, auditSendTimeout)
+		defer cancel()
+		if err := j.exportAuditLogEntries(ctx, []dbmodel.AuditLogEntry{*ale}); err != nil {
+			zapctx.Warn(ctx, "cannot export audit log entry", zap.Error(err))
+		}
 	}
 }
 
@@ -453,6 +610,62 @@ func (j *JIMM) FindAuditEvents(ctx context.Context, user *openfga.User, filter d
 	return entries, nil
 }
 
+// AuditLogTopic is the pub-sub topic on which new audit log entries are
+// published.
+const AuditLogTopic = "audit-log"
+
+// WatchAuditEvents subscribes to new audit log entries. It returns up to
+// backlog of the most recent existing entries, oldest first, and calls f
+// with every entry written after those until the returned stop function
+// is called. The function f may be called concurrently. Only JIMM
+// administrators and audit log viewers may watch audit events.
+func (j *JIMM) WatchAuditEvents(ctx context.Context, user *openfga.User, backlog int, f func(dbmodel.AuditLogEntry)) (_ []dbmodel.AuditLogEntry, stop func(), err error) {
+	const op = errors.Op("jimm.WatchAuditEvents")
+
+	if !user.JimmAdmin && user.GetAuditLogViewerAccess(ctx, j.ResourceTag()) != ofganames.AuditLogViewerRelation {
+		return nil, nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if j.Pubsub == nil {
+		return nil, nil, errors.E(op, errors.CodeNotSupported, "audit event streaming not available")
+	}
+
+	// Always find the most recent entry so that entries already in the
+	// database, including the last entry retained by the pub-sub hub,
+	// are not delivered as new events.
+	limit := backlog
+	if limit < 1 {
+		limit = 1
+	}
+	var entries []dbmodel.AuditLogEntry
+	err = j.Database.ForEachAuditLogEntry(ctx, db.AuditLogFilter{Limit: limit, SortTime: true}, func(entry *dbmodel.AuditLogEntry) error {
+		entries = append(entries, *entry)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, errors.E(op, err)
+	}
+	var lastID uint
+	for _, entry := range entries {
+		lastID = max(lastID, entry.ID)
+	}
+	if backlog < len(entries) {
+		entries = entries[:backlog]
+	}
+	slices.Reverse(entries)
+
+	stop, err = j.Pubsub.Subscribe(AuditLogTopic, func(_ string, v interface{}) {
+		entry, ok := v.(dbmodel.AuditLogEntry)
+		if !ok || entry.ID <= lastID {
+			return
+		}
+		f(entry)
+	})
+	if err != nil {
+		return nil, nil, errors.E(op, err)
+	}
+	return entries, stop, nil
+}
+
 // ControllerInfo returns info about a controller connected to JIMM.
 func (j *JIMM) ControllerInfo(ctx context.Context, name string) (*dbmodel.Controller, error) {
 	const op = errors.Op("jimm.ListControllers")
@@ -460,6 +673,9 @@ func (j *JIMM) ControllerInfo(ctx context.Context, name string) (*dbmodel.Contro
 		Name: name,
 	}
 	if err := j.Database.GetController(ctx, &ctl); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeControllerNotFound, err)
+		}
 		return nil, errors.E(op, err)
 	}
 	return &ctl, nil
@@ -485,6 +701,43 @@ func (j *JIMM) ListControllers(ctx context.Context, user *openfga.User) ([]dbmod
 	return controllers, nil
 }
 
+// ForEachControllerForUser iterates through every controller the given
+// user has access to, calling the given function for each one. JIMM
+// administrators have access to every controller, other users only have
+// access to controllers they are an administrator of, which is the only
+// relation a user can have with a controller. If f returns an error then
+// iteration stops immediately and the error is returned unmodified.
+func (j *JIMM) ForEachControllerForUser(ctx context.Context, user *openfga.User, f func(*dbmodel.Controller) error) error {
+	const op = errors.Op("jimm.ForEachControllerForUser")
+
+	// Load the controllers before checking access so that the database
+	// query is not held open while waiting for OpenFGA.
+	var controllers []dbmodel.Controller
+	err := j.Database.ForEachController(ctx, func(c *dbmodel.Controller) error {
+		controllers = append(controllers, *c)
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	for i := range controllers {
+		if !user.JimmAdmin {
+			isAdmin, err := openfga.IsAdministrator(ctx, user, controllers[i].ResourceTag())
+			if err != nil {
+				return errors.E(op, "failed administrator check", err)
+			}
+			if !isAdmin {
+				continue
+			}
+		}
+		if err := f(&controllers[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SetControllerDeprecated records if the controller is to be deprecated.
 // No new models or clouds can be added to a deprecated controller.
 func (j *JIMM) SetControllerDeprecated(ctx context.Context, user *openfga.User, controllerName string, deprecated bool) error {
@@ -514,6 +767,124 @@ func (j *JIMM) SetControllerDeprecated(ctx context.Context, user *openfga.User,
 	return nil
 }
 
+// SetControllerQuiesced records if the controller is to be quiesced. No
+// new models are placed on a quiesced controller, even if the model is
+// pinned to it, but existing models and connections are unaffected.
+func (j *JIMM) SetControllerQuiesced(ctx context.Context, user *openfga.User, controllerName string, quiesced bool) error {
+	const op = errors.Op("jimm.SetControllerQuiesced")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	err := j.Database.Transaction(func(db *db.Database) error {
+		c := dbmodel.Controller{
+			Name: controllerName,
+		}
+		if err := db.GetController(ctx, &c); err != nil {
+			return err
+		}
+		c.Quiesced = quiesced
+		return db.UpdateController(ctx, &c)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	j.addControllerAuditLogEntry(user, "SetControllerQuiesced", controllerName, "quiesced-changed", map[string]interface{}{
+		"quiesced": quiesced,
+	})
+	return nil
+}
+
+// AuthorizationModelInfo holds information about the OpenFGA
+// authorization model used by JIMM.
+type AuthorizationModelInfo struct {
+	// AuthModelID holds the ID of the authorization model JIMM is
+	// configured to use.
+	AuthModelID string
+
+	// AvailableAuthModelIDs holds the IDs of the authorization models
+	// in the OpenFGA store, most recent first.
+	AvailableAuthModelIDs []string
+}
+
+// AuthorizationModelInfo returns the ID of the OpenFGA authorization
+// model JIMM is using along with the IDs of the models available in the
+// OpenFGA store, so that a mismatch between the two can be diagnosed.
+// Only JIMM administrators may fetch the authorization model info.
+func (j *JIMM) AuthorizationModelInfo(ctx context.Context, user *openfga.User) (*AuthorizationModelInfo, error) {
+	const op = errors.Op("jimm.AuthorizationModelInfo")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	ids, err := j.OpenFGAClient.ListAuthModelIDs(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &AuthorizationModelInfo{
+		AuthModelID:           j.OpenFGAClient.AuthModelID(),
+		AvailableAuthModelIDs: ids,
+	}, nil
+}
+
+// SetControllerGroup assigns the controller to the given controller
+// group. An empty group removes the controller from any group. Only JIMM
+// administrators may assign controllers to groups.
+func (j *JIMM) SetControllerGroup(ctx context.Context, user *openfga.User, controllerName string, group string) error {
+	const op = errors.Op("jimm.SetControllerGroup")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if strings.TrimSpace(group) != group {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid controller group %q", group))
+	}
+
+	err := j.Database.Transaction(func(db *db.Database) error {
+		c := dbmodel.Controller{
+			Name: controllerName,
+		}
+		if err := db.GetController(ctx, &c); err != nil {
+			return err
+		}
+		c.ControllerGroup = group
+		return db.UpdateController(ctx, &c)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	return nil
+}
+
+// ListControllersInGroup returns the controllers assigned to the given
+// controller group, sorted by name. An empty group lists the controllers
+// that have not been assigned to a group. Only JIMM administrators may
+// list controllers.
+func (j *JIMM) ListControllersInGroup(ctx context.Context, user *openfga.User, group string) ([]dbmodel.Controller, error) {
+	const op = errors.Op("jimm.ListControllersInGroup")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var controllers []dbmodel.Controller
+	err := j.Database.ForEachController(ctx, func(c *dbmodel.Controller) error {
+		if c.ControllerGroup == group {
+			controllers = append(controllers, *c)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	return controllers, nil
+}
+
 // RemoveController removes a controller.
 func (j *JIMM) RemoveController(ctx context.Context, user *openfga.User, controllerName string, force bool) error {
 	const op = errors.Op("jimm.RemoveController")
@@ -558,7 +929,11 @@ func (j *JIMM) RemoveController(ctx context.Context, user *openfga.User, control
 	if err != nil {
 		return errors.E(op, err)
 	}
+	j.evictControllerConnection(controllerName)
 
+	j.addControllerAuditLogEntry(user, "RemoveController", controllerName, "removed", map[string]interface{}{
+		"force": force,
+	})
 	return nil
 }
 
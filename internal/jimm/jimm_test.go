@@ -6,6 +6,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/pubsub"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
 	"github.com/canonical/jimm/v3/pkg/api/params"
 )
@@ -207,6 +209,71 @@ users:
   controller-access: "no-access"
 `
 
+func TestWatchAuditEvents(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+		Pubsub:        &pubsub.Hub{},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	alice, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	viewer := openfga.NewUser(alice, client)
+	err = viewer.SetControllerAccess(ctx, j.ResourceTag(), ofganames.AuditLogViewerRelation)
+	c.Assert(err, qt.IsNil)
+
+	eve, err := dbmodel.NewIdentity("eve@canonical.com")
+	c.Assert(err, qt.IsNil)
+
+	for _, method := range []string{"Login", "AddModel", "DestroyModel"} {
+		j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+			Time:         time.Now().UTC(),
+			IdentityTag:  alice.Tag().String(),
+			FacadeMethod: method,
+		})
+	}
+
+	_, _, err = j.WatchAuditEvents(ctx, openfga.NewUser(eve, client), 0, func(dbmodel.AuditLogEntry) {})
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+
+	received := make(chan dbmodel.AuditLogEntry, 10)
+	backlog, stop, err := j.WatchAuditEvents(ctx, viewer, 2, func(e dbmodel.AuditLogEntry) {
+		received <- e
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(backlog, qt.HasLen, 2)
+	c.Check(backlog[0].FacadeMethod, qt.Equals, "AddModel")
+	c.Check(backlog[1].FacadeMethod, qt.Equals, "DestroyModel")
+
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC(),
+		IdentityTag:  alice.Tag().String(),
+		FacadeMethod: "Deploy",
+	})
+	select {
+	case e := <-received:
+		c.Check(e.FacadeMethod, qt.Equals, "Deploy")
+	case <-time.After(5 * time.Second):
+		c.Fatal("timed out waiting for audit event")
+	}
+
+	// No events are received once the watcher is stopped.
+	stop()
+	<-j.Pubsub.Publish(jimm.AuditLogTopic, dbmodel.AuditLogEntry{ID: 1000, FacadeMethod: "Deploy"})
+	c.Check(received, qt.HasLen, 0)
+}
+
 func TestControllerInfo(t *testing.T) {
 	c := qt.New(t)
 
@@ -295,6 +362,68 @@ func TestListControllers(t *testing.T) {
 	}
 }
 
+func TestForEachControllerForUser(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: client,
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testControllersEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	// bob is an administrator of controller test2 only.
+	bob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	err = openfga.NewUser(&bob, client).SetControllerAccess(ctx, env.Controller("test2").DBObject(c, j.Database).ResourceTag(), ofganames.AdministratorRelation)
+	c.Assert(err, qt.IsNil)
+
+	tests := []struct {
+		about               string
+		user                dbmodel.Identity
+		jimmAdmin           bool
+		expectedControllers []string
+	}{{
+		about:               "superuser sees all controllers",
+		user:                env.User("alice@canonical.com").DBObject(c, j.Database),
+		jimmAdmin:           true,
+		expectedControllers: []string{"test1", "test2", "test3"},
+	}, {
+		about:               "user sees only the controllers they have access to",
+		user:                bob,
+		expectedControllers: []string{"test2"},
+	}, {
+		about: "user without access sees no controllers",
+		user:  env.User("eve@canonical.com").DBObject(c, j.Database),
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			user := openfga.NewUser(&test.user, client)
+			user.JimmAdmin = test.jimmAdmin
+			var controllers []string
+			err := j.ForEachControllerForUser(ctx, user, func(ctl *dbmodel.Controller) error {
+				controllers = append(controllers, ctl.Name)
+				return nil
+			})
+			c.Assert(err, qt.IsNil)
+			c.Check(controllers, qt.DeepEquals, test.expectedControllers)
+		})
+	}
+}
+
 const testSetControllerDeprecatedEnv = `clouds:
 - name: test
   type: test
@@ -386,6 +515,103 @@ func TestSetControllerDeprecated(t *testing.T) {
 	}
 }
 
+func TestControllerGroups(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: eve@canonical.com
+  controller-access: login
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-cloud-region
+- name: controller-3
+  uuid: 00000001-0000-0000-0000-000000000003
+  cloud: test-cloud
+  region: test-cloud-region
+`)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	admin := openfga.NewUser(&alice, client)
+	admin.JimmAdmin = true
+	eve := env.User("eve@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&eve, client)
+
+	controllerNames := func(controllers []dbmodel.Controller) []string {
+		var names []string
+		for _, ctl := range controllers {
+			names = append(names, ctl.Name)
+		}
+		return names
+	}
+
+	err = j.SetControllerGroup(ctx, admin, "controller-1", "dc-1")
+	c.Assert(err, qt.IsNil)
+	err = j.SetControllerGroup(ctx, admin, "controller-3", "dc-1")
+	c.Assert(err, qt.IsNil)
+	err = j.SetControllerGroup(ctx, admin, "controller-2", "dc-2")
+	c.Assert(err, qt.IsNil)
+
+	controllers, err := j.ListControllersInGroup(ctx, admin, "dc-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(controllerNames(controllers), qt.DeepEquals, []string{"controller-1", "controller-3"})
+
+	controllers, err = j.ListControllersInGroup(ctx, admin, "dc-2")
+	c.Assert(err, qt.IsNil)
+	c.Check(controllerNames(controllers), qt.DeepEquals, []string{"controller-2"})
+
+	// Removing a controller from its group.
+	err = j.SetControllerGroup(ctx, admin, "controller-3", "")
+	c.Assert(err, qt.IsNil)
+	controllers, err = j.ListControllersInGroup(ctx, admin, "dc-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(controllerNames(controllers), qt.DeepEquals, []string{"controller-1"})
+	controllers, err = j.ListControllersInGroup(ctx, admin, "")
+	c.Assert(err, qt.IsNil)
+	c.Check(controllerNames(controllers), qt.DeepEquals, []string{"controller-3"})
+
+	err = j.SetControllerGroup(ctx, admin, "controller-1", " dc-1")
+	c.Check(err, qt.ErrorMatches, `invalid controller group " dc-1"`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	err = j.SetControllerGroup(ctx, admin, "controller-4", "dc-1")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = j.SetControllerGroup(ctx, user, "controller-1", "dc-2")
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	_, err = j.ListControllersInGroup(ctx, user, "dc-1")
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+}
+
 const removeControllerTestEnv = `clouds:
 - name: test-cloud
   type: test-provider
@@ -925,3 +1151,102 @@ func TestInitiateInternalMigration(t *testing.T) {
 		})
 	}
 }
+
+const warmControllerConnectionsTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-cloud-region
+- name: controller-3
+  uuid: 00000001-0000-0000-0000-000000000003
+  cloud: test-cloud
+  region: test-cloud-region
+`
+
+func TestWarmControllerConnections(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	dials := make(map[string]int)
+	dialer := dialerFunc(func(_ context.Context, ctl *dbmodel.Controller, _ names.ModelTag, _ map[string]string) (jimm.API, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		dials[ctl.Name]++
+		if ctl.Name == "controller-2" {
+			return nil, errors.New("test error")
+		}
+		return &jimmtest.API{
+			Ping_: func(context.Context) error { return nil },
+		}, nil
+	})
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+		Dialer:        jimm.CacheDialer(dialer),
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, warmControllerConnectionsTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	ctl3 := env.Controller("controller-3").DBObject(c, j.Database)
+	ctl3.UnavailableSince = sql.NullTime{Time: time.Now(), Valid: true}
+	err = j.Database.UpdateController(ctx, &ctl3)
+	c.Assert(err, qt.IsNil)
+
+	// A failure to connect to a controller doesn't stop the other
+	// connections being warmed, unavailable controllers are skipped.
+	j.WarmControllerConnections(ctx, 2, time.Second)
+	c.Check(dials, qt.DeepEquals, map[string]int{
+		"controller-1": 1,
+		"controller-2": 1,
+	})
+
+	// The warmed connection is served from the cache.
+	ctl1 := env.Controller("controller-1").DBObject(c, j.Database)
+	api, err := j.Dialer.Dial(ctx, &ctl1, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	defer api.Close()
+	c.Check(dials["controller-1"], qt.Equals, 1)
+}
+
+func TestAuthorizationModelInfo(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, params, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+	}
+
+	alice := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, client)
+	_, err = j.AuthorizationModelInfo(ctx, alice)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+
+	alice.JimmAdmin = true
+	info, err := j.AuthorizationModelInfo(ctx, alice)
+	c.Assert(err, qt.IsNil)
+	c.Check(info.AuthModelID, qt.Equals, params.AuthModelID)
+	c.Check(info.AvailableAuthModelIDs, qt.Contains, params.AuthModelID)
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// A LoginAttemptTracker counts the consecutive failed logins made by
+// each user. Once a user has failed to log in the configured number of
+// times further logins by that user are rejected until the window has
+// passed since the last failure. A successful login resets the count. A
+// LoginAttemptTracker is safe to use from multiple goroutines. A nil
+// LoginAttemptTracker never rejects a login.
+type LoginAttemptTracker struct {
+	maxFailures int
+	window      time.Duration
+	now         func() time.Time
+
+	mu       sync.Mutex
+	failures map[string]loginFailures
+}
+
+type loginFailures struct {
+	count int
+	last  time.Time
+}
+
+// NewLoginAttemptTracker returns a LoginAttemptTracker that locks out a
+// user after maxFailures consecutive failed logins, for the given window.
+func NewLoginAttemptTracker(maxFailures int, window time.Duration) *LoginAttemptTracker {
+	return &LoginAttemptTracker{
+		maxFailures: maxFailures,
+		window:      window,
+		now:         time.Now,
+		failures:    make(map[string]loginFailures),
+	}
+}
+
+// Check returns an error with the code CodeLoginLocked if the given
+// user has failed to log in too many times and must wait before trying
+// again.
+func (t *LoginAttemptTracker) Check(username string) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f, ok := t.get(username)
+	if !ok || f.count < t.maxFailures {
+		return nil
+	}
+	wait := f.last.Add(t.window).Sub(t.now()).Round(time.Second)
+	return errors.E(errors.CodeLoginLocked, fmt.Sprintf("too many failed logins for %q, try again in %s", username, wait))
+}
+
+// Failed records a failed login by the given user.
+func (t *LoginAttemptTracker) Failed(username string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f, _ := t.get(username)
+	f.count++
+	f.last = t.now()
+	t.failures[username] = f
+}
+
+// Succeeded records a successful login by the given user, resetting
+// their count of failed logins.
+func (t *LoginAttemptTracker) Succeeded(username string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.failures, username)
+}
+
+// get returns the failed logins recorded for the given user, if the
+// last of them is within the window. t.mu must be held.
+func (t *LoginAttemptTracker) get(username string) (loginFailures, bool) {
+	f, ok := t.failures[username]
+	if !ok {
+		return loginFailures{}, false
+	}
+	if !t.now().Before(f.last.Add(t.window)) {
+		delete(t.failures, username)
+		return loginFailures{}, false
+	}
+	return f, true
+}
@@ -7,22 +7,29 @@ import (
 	"database/sql"
 	"fmt"
 	"math/rand"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	jujupermission "github.com/juju/juju/core/permission"
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
+	"github.com/juju/loggo"
 	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
 	"github.com/juju/zaputil"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
 
+	"github.com/canonical/jimm/v3/internal/common/pagination"
+	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
 
 // shuffle is used to randomize the order in which possible controllers
@@ -46,16 +53,87 @@ type ModelCreateArgs struct {
 	Cloud           names.CloudTag
 	CloudRegion     string
 	CloudCredential names.CloudCredentialTag
+	// CloudCredentialName is the name of the cloud credential to use
+	// when a full cloud credential tag was not specified. The tag is
+	// inferred from the model owner and the selected cloud.
+	CloudCredentialName string
+	// PinnedController is the name of the controller the model must be
+	// placed on. The model is pinned to the controller and will not be
+	// moved to another controller until it is unpinned.
+	PinnedController string
+	// TemplateModelUUID is the UUID of a model whose configuration is
+	// inherited by the new model. The template's configuration takes
+	// precedence over any model defaults, but not over Config.
+	TemplateModelUUID string
+	// ModelTags holds tags to apply to the cloud resources of the
+	// model, for example a cost-center. The tags are merged into the
+	// model's resource-tags config, taking precedence over any tags with
+	// the same key already in the config.
+	ModelTags map[string]string
+	// ExpiresAt, if set, is the time after which the model is
+	// automatically destroyed.
+	ExpiresAt time.Time
+	// IdleTimeout, if set, is the length of time the model may go
+	// without any connections before it is automatically destroyed.
+	IdleTimeout time.Duration
 }
 
+const (
+	// ModelQuotaConfigKey is the controller config key holding the
+	// default maximum number of live models a user may own. A value of
+	// zero, or no value, means there is no limit.
+	ModelQuotaConfigKey = "model-quota"
+
+	// UserModelQuotaConfigKeyPrefix is the prefix of controller config
+	// keys holding the maximum number of live models a specific user may
+	// own, i.e. "model-quota/alice@canonical.com". A user's quota takes
+	// precedence over the default.
+	UserModelQuotaConfigKeyPrefix = ModelQuotaConfigKey + "/"
+
+	// ModelLoggingDefaultConfigKey is the controller config key holding
+	// the logging-config new models are created with, unless the user
+	// specifies one, i.e. "<root>=INFO;unit=DEBUG".
+	ModelLoggingDefaultConfigKey = "model-logging-default"
+
+	// DefaultRegionConfigKeyPrefix is the prefix of controller config
+	// keys holding the region models are created in when the user does
+	// not specify one, i.e. "default-region/aws" = "eu-west-1".
+	DefaultRegionConfigKeyPrefix = "default-region/"
+
+	// ModelNamePatternConfigKey is the controller config key holding a
+	// regular expression the names of new models must match, i.e.
+	// "^team-[a-z]+-". When it is not set only juju's model name rules
+	// apply.
+	ModelNamePatternConfigKey = "model-name-pattern"
+
+	// ModelExpiresAtConfigKey is the model config key that may be
+	// specified when creating a model to set the time, in RFC 3339
+	// format, after which the model is automatically destroyed. It is
+	// removed from the config before the model is created.
+	ModelExpiresAtConfigKey = "jimm-expires-at"
+
+	// ModelIdleTimeoutConfigKey is the model config key that may be
+	// specified when creating a model to set the length of time, i.e.
+	// "24h", the model may go without any connections before it is
+	// automatically destroyed. It is removed from the config before the
+	// model is created.
+	ModelIdleTimeoutConfigKey = "jimm-idle-timeout"
+
+	// loggingConfigKey is the model config key holding a model's
+	// logging configuration.
+	loggingConfigKey = "logging-config"
+)
+
 // FromJujuModelCreateArgs converts jujuparams.ModelCreateArgs into AddModelArgs.
 func (a *ModelCreateArgs) FromJujuModelCreateArgs(args *jujuparams.ModelCreateArgs) error {
 	if args.Name == "" {
 		return errors.E("name not specified")
 	}
 	a.Name = args.Name
-	a.Config = args.Config
 	a.CloudRegion = args.CloudRegion
+	if err := a.setConfig(args.Config); err != nil {
+		return err
+	}
 	if args.CloudTag != "" {
 		ct, err := names.ParseCloudTag(args.CloudTag)
 		if err != nil {
@@ -76,6 +154,10 @@ func (a *ModelCreateArgs) FromJujuModelCreateArgs(args *jujuparams.ModelCreateAr
 	if args.CloudCredentialTag != "" {
 		ct, err := names.ParseCloudCredentialTag(args.CloudCredentialTag)
 		if err != nil {
+			if names.IsValidCloudCredentialName(args.CloudCredentialTag) {
+				a.CloudCredentialName = args.CloudCredentialTag
+				return nil
+			}
 			return errors.E(err, "invalid cloud credential tag")
 		}
 		if a.Cloud.Id() != "" && ct.Cloud().Id() != a.Cloud.Id() {
@@ -87,6 +169,41 @@ func (a *ModelCreateArgs) FromJujuModelCreateArgs(args *jujuparams.ModelCreateAr
 	return nil
 }
 
+// setConfig sets the model config to the given config, without the keys
+// that set when the model expires, which are instead used to set
+// ExpiresAt and IdleTimeout.
+func (a *ModelCreateArgs) setConfig(config map[string]interface{}) error {
+	_, hasExpiresAt := config[ModelExpiresAtConfigKey]
+	_, hasIdleTimeout := config[ModelIdleTimeoutConfigKey]
+	if !hasExpiresAt && !hasIdleTimeout {
+		a.Config = config
+		return nil
+	}
+	a.Config = make(map[string]interface{}, len(config))
+	for k, v := range config {
+		a.Config[k] = v
+	}
+	if hasExpiresAt {
+		s, _ := a.Config[ModelExpiresAtConfigKey].(string)
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid %s %v, expected an RFC 3339 time", ModelExpiresAtConfigKey, a.Config[ModelExpiresAtConfigKey]))
+		}
+		a.ExpiresAt = t
+		delete(a.Config, ModelExpiresAtConfigKey)
+	}
+	if hasIdleTimeout {
+		s, _ := a.Config[ModelIdleTimeoutConfigKey].(string)
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid %s %v, expected a duration", ModelIdleTimeoutConfigKey, a.Config[ModelIdleTimeoutConfigKey]))
+		}
+		a.IdleTimeout = d
+		delete(a.Config, ModelIdleTimeoutConfigKey)
+	}
+	return nil
+}
+
 func newModelBuilder(ctx context.Context, j *JIMM) *modelBuilder {
 	return &modelBuilder{
 		ctx:  ctx,
@@ -110,6 +227,21 @@ type modelBuilder struct {
 	cloudRegionID uint
 	model         *dbmodel.Model
 	modelInfo     *jujuparams.ModelInfo
+
+	pinnedController string
+
+	expiresAt   sql.NullTime
+	idleTimeout time.Duration
+
+	// life, if set, is the life status recorded for the model when it
+	// is first stored in the database.
+	life string
+
+	// creationHandle and creationRequester, if set, identify the
+	// asynchronous creation of the model and the identity that started
+	// it.
+	creationHandle    string
+	creationRequester string
 }
 
 // Error returns the error that occurred in the process
@@ -174,6 +306,83 @@ func (b *modelBuilder) WithConfig(cfg map[string]interface{}) *modelBuilder {
 	return b
 }
 
+// WithModelTags returns a builder with the given tags merged into the
+// resource-tags model config. The merged tags are checked against the
+// constraints of the model's cloud provider, so the cloud must have been
+// selected before calling WithModelTags.
+func (b *modelBuilder) WithModelTags(tags map[string]string) *modelBuilder {
+	if b.err != nil {
+		return b
+	}
+	if b.cloud == nil {
+		b.err = errors.E("cloud not specified")
+		return b
+	}
+	merged, err := parseResourceTags(b.config[resourceTagsConfigKey])
+	if err != nil {
+		b.err = err
+		return b
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	if err := checkResourceTags(b.cloud.Type, merged); err != nil {
+		b.err = err
+		return b
+	}
+	return b.WithConfig(map[string]interface{}{resourceTagsConfigKey: formatResourceTags(merged)})
+}
+
+// WithTemplateModel returns a builder with the config explicitly set on
+// the template model with the given UUID. The user must have read access
+// to the template model.
+func (b *modelBuilder) WithTemplateModel(user *openfga.User, uuid string) *modelBuilder {
+	if b.err != nil {
+		return b
+	}
+	if !names.IsValidModel(uuid) {
+		b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid template model uuid %q", uuid))
+		return b
+	}
+
+	mt := names.NewModelTag(uuid)
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := b.jimm.Database.GetModel(b.ctx, &m); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			b.err = errors.E(errors.CodeModelNotFound, fmt.Sprintf("template model %s not found", uuid))
+		} else {
+			b.err = err
+		}
+		return b
+	}
+	if !user.JimmAdmin {
+		accessLevel, err := b.jimm.GetUserModelAccess(b.ctx, user, mt)
+		if err != nil {
+			b.err = err
+			return b
+		}
+		if !allowedModelAccess["read"][accessLevel] {
+			b.err = errors.E(errors.CodeUnauthorized, "unauthorized")
+			return b
+		}
+	}
+
+	api, err := b.jimm.dial(b.ctx, &m.Controller, mt)
+	if err != nil {
+		b.err = errors.E(err, "failed to connect to the template model")
+		return b
+	}
+	defer api.Close()
+
+	config, err := api.ModelGet(b.ctx)
+	if err != nil {
+		b.err = errors.E(err, "failed to fetch the template model config")
+		return b
+	}
+	return b.WithConfig(inheritableConfig(config))
+}
+
 // WithCloud returns a builder with the specified cloud.
 func (b *modelBuilder) WithCloud(user *openfga.User, cloud names.CloudTag) *modelBuilder {
 	if b.err != nil {
@@ -227,6 +436,68 @@ func (b *modelBuilder) withImplicitCloud(user *openfga.User) *modelBuilder {
 	return b
 }
 
+// WithPinnedController returns a builder that only places the model on the
+// controller with the specified name and pins the model to it.
+func (b *modelBuilder) WithPinnedController(name string) *modelBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.pinnedController = name
+	return b
+}
+
+// WithExpiry returns a builder that creates a model which is automatically
+// destroyed after the given time, if it is not zero, or once it has had
+// no connections for the given idle timeout, if it is not zero.
+func (b *modelBuilder) WithExpiry(expiresAt time.Time, idleTimeout time.Duration) *modelBuilder {
+	if b.err != nil {
+		return b
+	}
+	if idleTimeout < 0 {
+		b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid idle timeout %s", idleTimeout))
+		return b
+	}
+	if !expiresAt.IsZero() {
+		b.expiresAt = sql.NullTime{Time: expiresAt, Valid: true}
+	}
+	b.idleTimeout = idleTimeout
+	return b
+}
+
+// placementControllers returns the controllers the model may be placed on
+// from the given cloud region controllers. Deprecated controllers are
+// only used when the model is explicitly pinned to them, quiesced
+// controllers are never used.
+func (b *modelBuilder) placementControllers(controllers []dbmodel.CloudRegionControllerPriority) []dbmodel.CloudRegionControllerPriority {
+	var candidates []dbmodel.CloudRegionControllerPriority
+	for _, c := range controllers {
+		if c.Controller.Quiesced {
+			continue
+		}
+		if b.pinnedController != "" {
+			if c.Controller.Name == b.pinnedController {
+				candidates = append(candidates, c)
+			}
+			continue
+		}
+		if !c.Controller.Deprecated {
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates
+}
+
+// pinnedControllerQuiesced reports whether the named controller is one
+// of the given cloud region controllers and has been quiesced.
+func pinnedControllerQuiesced(controllers []dbmodel.CloudRegionControllerPriority, name string) bool {
+	for _, c := range controllers {
+		if c.Controller.Name == name {
+			return c.Controller.Quiesced
+		}
+	}
+	return false
+}
+
 // WithCloudRegion returns a builder with the specified cloud region.
 func (b *modelBuilder) WithCloudRegion(region string) *modelBuilder {
 	if b.err != nil {
@@ -236,11 +507,19 @@ func (b *modelBuilder) WithCloudRegion(region string) *modelBuilder {
 		b.err = errors.E("cloud not specified")
 		return b
 	}
-	// if the region is not specified, we pick the first cloud region
-	// with any associated controllers
+	// if the region is not specified, we use the cloud's configured
+	// default region
+	if region == "" {
+		region, b.err = b.defaultCloudRegion()
+		if b.err != nil {
+			return b
+		}
+	}
+	// if there is no usable default region, we pick the first cloud
+	// region with any associated controllers
 	if region == "" {
 		for _, r := range b.cloud.Regions {
-			regionControllers := r.Controllers
+			regionControllers := b.placementControllers(r.Controllers)
 			if len(regionControllers) == 0 {
 				continue
 			}
@@ -254,8 +533,16 @@ func (b *modelBuilder) WithCloudRegion(region string) *modelBuilder {
 			continue
 		}
 		// consider all possible controllers for that region
-		regionControllers := r.Controllers
+		regionControllers := b.placementControllers(r.Controllers)
 		if len(regionControllers) == 0 {
+			if b.pinnedController != "" {
+				if pinnedControllerQuiesced(r.Controllers, b.pinnedController) {
+					b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("controller %s is quiesced", b.pinnedController))
+					return b
+				}
+				b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("controller %s does not support cloud region %s/%s", b.pinnedController, b.cloud.Name, region))
+				return b
+			}
 			b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("unsupported cloud region %s/%s", b.cloud.Name, region))
 			return b
 		}
@@ -276,7 +563,41 @@ func (b *modelBuilder) WithCloudRegion(region string) *modelBuilder {
 	return b
 }
 
-// WithCloudCredential returns a builder with the specified cloud credentials.
+// defaultCloudRegion returns the configured default region of the
+// builder's cloud. If no default region is configured, or the default
+// region has no controllers that can host the model, an empty string is
+// returned.
+func (b *modelBuilder) defaultCloudRegion() (string, error) {
+	config := dbmodel.ControllerConfig{
+		Name: "jimm",
+	}
+	err := b.jimm.Database.GetControllerConfig(b.ctx, &config)
+	if err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	v, ok := config.Config[DefaultRegionConfigKeyPrefix+b.cloud.Name]
+	if !ok {
+		return "", nil
+	}
+	region, ok := v.(string)
+	if !ok {
+		return "", errors.E(errors.CodeServerConfiguration, fmt.Sprintf("invalid %s%s value %v", DefaultRegionConfigKeyPrefix, b.cloud.Name, v))
+	}
+	for _, r := range b.cloud.Regions {
+		if r.Name == region && len(b.placementControllers(r.Controllers)) > 0 {
+			return region, nil
+		}
+	}
+	zapctx.Warn(b.ctx, "default region cannot host models", zap.String("cloud", b.cloud.Name), zap.String("region", region))
+	return "", nil
+}
+
+// WithCloudCredential returns a builder with the specified cloud
+// credentials. If the model owner has been set the credential must belong
+// to the owner.
 func (b *modelBuilder) WithCloudCredential(credentialTag names.CloudCredentialTag) *modelBuilder {
 	if b.err != nil {
 		return b
@@ -286,6 +607,16 @@ func (b *modelBuilder) WithCloudCredential(credentialTag names.CloudCredentialTa
 		CloudName:         credentialTag.Cloud().Id(),
 		OwnerIdentityName: credentialTag.Owner().Id(),
 	}
+	// A model may only use credentials belonging to its owner, or
+	// shared with its owner, even when it is being created on the
+	// owner's behalf by somebody else.
+	if b.owner != nil && credential.OwnerIdentityName != b.owner.Name {
+		owner := openfga.NewUser(b.owner, b.jimm.OpenFGAClient)
+		if owner.GetCloudCredentialAccess(b.ctx, credentialTag) == ofganames.NoRelation {
+			b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("cloud credential %s is not owned by %s", credential.Path(), b.owner.Name))
+			return b
+		}
+	}
 	err := b.jimm.Database.GetCloudCredential(b.ctx, &credential)
 	if err != nil {
 		b.err = errors.E(err, fmt.Sprintf("failed to fetch cloud credentials %s", credential.Path()))
@@ -295,6 +626,28 @@ func (b *modelBuilder) WithCloudCredential(credentialTag names.CloudCredentialTa
 	return b
 }
 
+// WithCloudCredentialName returns a builder with the cloud credential of
+// the given name, owned by the model owner, on the selected cloud.
+func (b *modelBuilder) WithCloudCredentialName(name string) *modelBuilder {
+	if b.err != nil {
+		return b
+	}
+	if b.owner == nil {
+		b.err = errors.E("model owner not specified")
+		return b
+	}
+	if b.cloud == nil {
+		b.err = errors.E("cloud not specified")
+		return b
+	}
+	id := fmt.Sprintf("%s/%s/%s", b.cloud.Name, b.owner.Name, name)
+	if !names.IsValidCloudCredential(id) {
+		b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid cloud credential %q", id))
+		return b
+	}
+	return b.WithCloudCredential(names.NewCloudCredentialTag(id))
+}
+
 // CreateDatabaseModel stores temporary model information.
 func (b *modelBuilder) CreateDatabaseModel() *modelBuilder {
 	if b.err != nil {
@@ -338,6 +691,12 @@ func (b *modelBuilder) CreateDatabaseModel() *modelBuilder {
 			return b
 		}
 	}
+	if b.cloud != nil {
+		if err := checkCredentialAuthType(*b.cloud, b.credential.AuthType); err != nil {
+			b.err = err
+			return b
+		}
+	}
 
 	b.model = &dbmodel.Model{
 		Name:              b.name,
@@ -345,6 +704,17 @@ func (b *modelBuilder) CreateDatabaseModel() *modelBuilder {
 		Owner:             *b.owner,
 		CloudCredentialID: b.credential.ID,
 		CloudRegionID:     b.cloudRegionID,
+		Life:              b.life,
+		ExpiresAt:         b.expiresAt,
+		IdleTimeout:       b.idleTimeout,
+		CreationRequester: b.creationRequester,
+	}
+	if b.creationHandle != "" {
+		b.model.CreationHandle = sql.NullString{String: b.creationHandle, Valid: true}
+	}
+	if b.pinnedController != "" {
+		//nolint:gosec // Database IDs will not exceed int32.
+		b.model.PinnedControllerID = sql.NullInt32{Int32: int32(b.controller.ID), Valid: true}
 	}
 
 	err := b.jimm.Database.AddModel(b.ctx, b.model)
@@ -362,7 +732,10 @@ func (b *modelBuilder) CreateDatabaseModel() *modelBuilder {
 }
 
 // Cleanup deletes temporary model information if there was an
-// error in the process of creating model.
+// error in the process of creating model. If the model had already been
+// created on the controller an attempt is made to destroy it there too,
+// so that retrying the model creation does not fail because the model
+// already exists on the controller.
 func (b *modelBuilder) Cleanup() {
 	if b.err == nil {
 		return
@@ -376,27 +749,53 @@ func (b *modelBuilder) Cleanup() {
 	if derr := b.jimm.Database.DeleteModel(ctx, b.model); derr != nil {
 		zapctx.Error(ctx, "failed to delete model", zap.String("model", b.model.Name), zap.String("owner", b.model.Owner.Name), zaputil.Error(derr))
 	}
+	if b.modelInfo == nil {
+		return
+	}
+	mt := names.NewModelTag(b.modelInfo.UUID)
+	if b.jimm.OpenFGAClient != nil {
+		if derr := b.jimm.OpenFGAClient.RemoveModel(ctx, mt); derr != nil {
+			zapctx.Error(ctx, "failed to remove model relations", zap.String("model", mt.Id()), zaputil.Error(derr))
+		}
+	}
+	api, derr := b.jimm.dial(ctx, b.controller, names.ModelTag{})
+	if derr != nil {
+		zapctx.Error(ctx, "leaked model", zap.String("model", mt.Id()), zaputil.Error(derr))
+		return
+	}
+	defer api.Close()
+	if derr := api.DestroyModel(ctx, mt, nil, nil, nil, nil); derr != nil {
+		zapctx.Error(ctx, "leaked model", zap.String("model", mt.Id()), zaputil.Error(derr))
+	}
 }
 
 func (b *modelBuilder) UpdateDatabaseModel() *modelBuilder {
 	if b.err != nil {
 		return b
 	}
-	err := b.model.FromJujuModelInfo(*b.modelInfo)
-	if err != nil {
+	var mi dbmodel.Model
+	if err := mi.FromJujuModelInfo(*b.modelInfo); err != nil {
 		b.err = errors.E(err, "failed to convert model info")
 		return b
 	}
-	b.model.ControllerID = b.controller.ID
-	// we know which credentials and cloud region was used
-	// - ignore this information returned by the controller
-	//   because we need IDs to properly update the model
-	b.model.CloudCredentialID = b.credential.ID
-	b.model.CloudRegionID = b.cloudRegionID
-	b.model.CloudCredential = dbmodel.CloudCredential{}
-	b.model.CloudRegion = dbmodel.CloudRegion{}
-
-	err = b.jimm.Database.UpdateModel(b.ctx, b.model)
+	err := b.jimm.updateModel(b.ctx, b.model, func(m *dbmodel.Model) error {
+		life := m.Life
+		if err := m.FromJujuModelInfo(*b.modelInfo); err != nil {
+			return err
+		}
+		newLife := m.Life
+		m.Life = life
+		setModelLife(m, newLife)
+		m.ControllerID = b.controller.ID
+		// we know which credentials and cloud region was used
+		// - ignore this information returned by the controller
+		//   because we need IDs to properly update the model
+		m.CloudCredentialID = b.credential.ID
+		m.CloudRegionID = b.cloudRegionID
+		m.CloudCredential = dbmodel.CloudCredential{}
+		m.CloudRegion = dbmodel.CloudRegion{}
+		return nil
+	})
 	if err != nil {
 		b.err = errors.E(err, "failed to store model information")
 		return b
@@ -414,7 +813,7 @@ func (b *modelBuilder) selectCloudRegion() error {
 
 	var regionControllers []dbmodel.CloudRegionControllerPriority
 	for _, r := range b.cloud.Regions {
-		regionControllers = append(regionControllers, r.Controllers...)
+		regionControllers = append(regionControllers, b.placementControllers(r.Controllers)...)
 	}
 
 	// if no controllers are found, we return an error
@@ -442,17 +841,57 @@ func (b *modelBuilder) selectCloudCredentials() error {
 	if err != nil {
 		return errors.E(err, "failed to fetch user cloud credentials")
 	}
+	// The owner's own credentials are preferred to those shared with
+	// them by other users.
+	shared, err := b.sharedCloudCredentials()
+	if err != nil {
+		return errors.E(err, "failed to fetch shared cloud credentials")
+	}
+	credentials = append(credentials, shared...)
 	for _, credential := range credentials {
 		// skip any credentials known to be invalid.
 		if credential.Valid.Valid && !credential.Valid.Bool {
 			continue
 		}
+		// skip any credentials the cloud does not support.
+		if checkCredentialAuthType(*b.cloud, credential.AuthType) != nil {
+			continue
+		}
 		b.credential = &credential
 		return nil
 	}
 	return errors.E("valid cloud credentials not found")
 }
 
+// sharedCloudCredentials returns the credentials for the selected cloud
+// that other users have shared with the model owner, ordered by path.
+func (b *modelBuilder) sharedCloudCredentials() ([]dbmodel.CloudCredential, error) {
+	owner := openfga.NewUser(b.owner, b.jimm.OpenFGAClient)
+	tags, err := owner.ListCloudCredentials(b.ctx, ofganames.ReaderRelation)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Id() < tags[j].Id()
+	})
+	var credentials []dbmodel.CloudCredential
+	for _, tag := range tags {
+		if tag.Cloud().Id() != b.cloud.Name || tag.Owner().Id() == b.owner.Name {
+			continue
+		}
+		var credential dbmodel.CloudCredential
+		credential.SetTag(tag)
+		if err := b.jimm.Database.GetCloudCredential(b.ctx, &credential); err != nil {
+			if errors.ErrorCode(err) == errors.CodeNotFound {
+				continue
+			}
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+	return credentials, nil
+}
+
 // CreateControllerModel uses provided information to create a new
 // model on the selected controller.
 func (b *modelBuilder) CreateControllerModel() *modelBuilder {
@@ -475,7 +914,10 @@ func (b *modelBuilder) CreateControllerModel() *modelBuilder {
 		},
 	)
 	if err != nil {
-		b.err = errors.E(err)
+		// Whatever the reason the controller couldn't be reached,
+		// report it as a connection failure so that callers can
+		// distinguish an unavailable controller from a bad request.
+		b.err = errors.E(err, errors.CodeConnectionFailed, fmt.Sprintf("cannot connect to controller %q", b.controller.Name))
 		return b
 	}
 	defer api.Close()
@@ -518,18 +960,35 @@ func (b *modelBuilder) CreateControllerModel() *modelBuilder {
 		return b
 	}
 
-	// Grant JIMM admin access to the model. Note that if this fails,
-	// the local database entry will be deleted but the model
-	// will remain on the controller and will trigger the "already exists
-	// in the backend controller" message above when the user
-	// attempts to create a model with the same name again.
-	if err := api.GrantJIMMModelAdmin(b.ctx, names.NewModelTag(info.UUID)); err != nil {
-		zapctx.Error(b.ctx, "leaked model", zap.String("model", info.UUID), zaputil.Error(err))
+	// Record the model info before granting access so that, should the
+	// grant fail, Cleanup will also remove the model from the controller.
+	b.modelInfo = &info
+
+	// Grant JIMM admin access to the model.
+	err = retryGrantModelAccess(b.ctx, func() error {
+		return api.GrantJIMMModelAdmin(b.ctx, names.NewModelTag(info.UUID))
+	})
+	if err != nil {
 		b.err = errors.E(err)
 		return b
 	}
+	return b
+}
 
-	b.modelInfo = &info
+// AddModelPermissions relates the model to its controller and grants the
+// owner administrator access to the model in OpenFGA.
+func (b *modelBuilder) AddModelPermissions() *modelBuilder {
+	if b.err != nil {
+		return b
+	}
+	owner := openfga.NewUser(b.owner, b.jimm.OpenFGAClient)
+	mt := names.NewModelTag(b.modelInfo.UUID)
+	err := retryGrantModelAccess(b.ctx, func() error {
+		return b.jimm.addModelPermissions(b.ctx, owner, mt, b.controller.ResourceTag())
+	})
+	if err != nil {
+		b.err = errors.E(err, "failed to grant model access")
+	}
 	return b
 }
 
@@ -550,65 +1009,228 @@ func (b *modelBuilder) JujuModelInfo() *jujuparams.ModelInfo {
 	return b.modelInfo
 }
 
-// AddModel adds the specified model to JIMM.
-func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCreateArgs) (_ *jujuparams.ModelInfo, err error) {
-	const op = errors.Op("jimm.AddModel")
+// ValidateModelName checks whether a model with the given name could be
+// created for the given owner. If the name does not conform to juju's
+// model naming rules an error with a code of CodeBadRequest is returned.
+// If the owner already has a model with the given name an error with a
+// code of CodeAlreadyExists is returned. Only JIMM admins may validate
+// model names on behalf of other users.
+func (j *JIMM) ValidateModelName(ctx context.Context, user *openfga.User, owner names.UserTag, name string) error {
+	const op = errors.Op("jimm.ValidateModelName")
 
-	owner, err := dbmodel.NewIdentity(args.Owner.Id())
+	ownerIdentity, err := dbmodel.NewIdentity(owner.Id())
 	if err != nil {
-		return nil, errors.E(op, err)
+		return errors.E(op, errors.CodeBadRequest, err)
 	}
+	if ownerIdentity.Name != user.Name && !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if !names.IsValidModelName(name) {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid model name %q", name))
+	}
+	if err := j.checkModelNamePattern(ctx, name); err != nil {
+		return errors.E(op, err)
+	}
+	if err := j.checkModelNameAvailable(ctx, ownerIdentity.Name, name); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
 
-	err = j.Database.GetIdentity(ctx, owner)
+// checkModelNamePattern returns an error with a code of CodeBadRequest if
+// the given model name does not match the pattern configured with the
+// model-name-pattern controller config setting.
+func (j *JIMM) checkModelNamePattern(ctx context.Context, name string) error {
+	config := dbmodel.ControllerConfig{
+		Name: "jimm",
+	}
+	err := j.Database.GetControllerConfig(ctx, &config)
 	if err != nil {
-		return nil, errors.E(op, err)
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil
+		}
+		return err
+	}
+	v, ok := config.Config[ModelNamePatternConfigKey]
+	if !ok {
+		return nil
+	}
+	re, err := modelNamePattern(v)
+	if err != nil {
+		return errors.E(errors.CodeServerConfiguration, err)
+	}
+	if re == nil || re.MatchString(name) {
+		return nil
 	}
+	return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid model name %q: model names must match %q", name, re.String()))
+}
 
-	// Only JIMM admins are able to add models on behalf of other users.
-	if owner.Name != user.Name && !user.JimmAdmin {
-		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+// modelNamePattern parses a model-name-pattern controller config value.
+// An empty pattern results in a nil regular expression.
+func modelNamePattern(v interface{}) (*regexp.Regexp, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, errors.E(fmt.Sprintf("invalid %s value %v", ModelNamePatternConfigKey, v))
+	}
+	if s == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, errors.E(fmt.Sprintf("invalid %s value %q: %s", ModelNamePatternConfigKey, s, err))
 	}
+	return re, nil
+}
 
-	builder := newModelBuilder(ctx, j)
-	builder = builder.WithOwner(owner)
-	builder = builder.WithName(args.Name)
-	if err := builder.Error(); err != nil {
-		return nil, errors.E(op, err)
+// checkModelNameAvailable returns an error with a code of
+// CodeAlreadyExists if the given owner already has a model with the given
+// name.
+func (j *JIMM) checkModelNameAvailable(ctx context.Context, ownerName, name string) error {
+	existing := dbmodel.Model{
+		Name:              name,
+		OwnerIdentityName: ownerName,
+	}
+	err := j.Database.GetModel(ctx, &existing)
+	if err == nil {
+		return errors.E(errors.CodeAlreadyExists, fmt.Sprintf("model %s/%s already exists", ownerName, name))
+	}
+	if errors.ErrorCode(err) != errors.CodeNotFound {
+		return err
 	}
+	return nil
+}
 
-	// fetch user model defaults
-	userConfig, err := j.IdentityModelDefaults(ctx, user.Identity)
-	if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
-		return nil, errors.E(op, "failed to fetch cloud defaults")
+// AddModel adds the specified model to JIMM.
+func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCreateArgs) (_ *jujuparams.ModelInfo, err error) {
+	const op = errors.Op("jimm.AddModel")
+
+	builder, err := j.prepareModel(ctx, user, args)
+	if err != nil {
+		return nil, errors.E(op, err)
 	}
-	builder = builder.WithConfig(userConfig)
 
-	// fetch cloud defaults
-	if args.Cloud != (names.CloudTag{}) {
-		cloudDefaults := dbmodel.CloudDefaults{
-			IdentityName: user.Name,
-			Cloud: dbmodel.Cloud{
-				Name: args.Cloud.Id(),
-			},
-		}
-		err = j.Database.CloudDefaults(ctx, &cloudDefaults)
-		if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
-			return nil, errors.E(op, "failed to fetch cloud defaults")
-		}
-		builder = builder.WithConfig(cloudDefaults.Defaults)
+	builder = builder.CreateDatabaseModel()
+	if err := builder.Error(); err != nil {
+		return nil, errors.E(op, err)
 	}
+	defer builder.Cleanup()
 
-	builder = builder.WithCloud(user, args.Cloud)
+	builder = builder.CreateControllerModel()
 	if err := builder.Error(); err != nil {
 		return nil, errors.E(op, err)
 	}
 
-	builder = builder.WithCloudRegion(args.CloudRegion)
+	builder = builder.UpdateDatabaseModel()
 	if err := builder.Error(); err != nil {
 		return nil, errors.E(op, err)
 	}
 
-	// at this point we know which cloud will host the model and
+	builder = builder.AddModelPermissions()
+	if err := builder.Error(); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return builder.JujuModelInfo(), nil
+}
+
+// prepareModel checks that the given user may create the requested model
+// and returns a modelBuilder holding the resolved owner, cloud, region,
+// credential and config for the new model.
+func (j *JIMM) prepareModel(ctx context.Context, user *openfga.User, args *ModelCreateArgs) (*modelBuilder, error) {
+	const op = errors.Op("jimm.prepareModel")
+
+	owner, err := dbmodel.NewIdentity(args.Owner.Id())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	err = j.Database.GetIdentity(ctx, owner)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	// Only JIMM admins are able to add models on behalf of other users.
+	if owner.Name != user.Name && !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	// The owner's preferences take precedence over the system-wide
+	// defaults for anything not specified.
+	args, err = j.withIdentityPreferences(ctx, owner, args)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	// Model names are unique per owner, fail before doing any work on
+	// the controllers if the name is already in use.
+	if args.Name != "" {
+		if err := j.checkModelNamePattern(ctx, args.Name); err != nil {
+			return nil, errors.E(op, err)
+		}
+		if err := j.checkModelNameAvailable(ctx, owner.Name, args.Name); err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
+
+	// JIMM admins are not subject to model quotas.
+	if !user.JimmAdmin {
+		if err := j.checkModelQuota(ctx, owner.Name); err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
+
+	builder := newModelBuilder(ctx, j)
+	builder = builder.WithOwner(owner)
+	builder = builder.WithName(args.Name)
+	builder = builder.WithExpiry(args.ExpiresAt, args.IdleTimeout)
+	if err := builder.Error(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	// the configured logging default has the lowest precedence
+	loggingConfig, err := j.modelLoggingDefault(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if loggingConfig != "" {
+		builder = builder.WithConfig(map[string]interface{}{loggingConfigKey: loggingConfig})
+	}
+
+	// fetch user model defaults
+	userConfig, err := j.IdentityModelDefaults(ctx, user.Identity)
+	if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
+		return nil, errors.E(op, "failed to fetch cloud defaults")
+	}
+	builder = builder.WithConfig(userConfig)
+
+	// fetch cloud defaults
+	if args.Cloud != (names.CloudTag{}) {
+		cloudDefaults := dbmodel.CloudDefaults{
+			IdentityName: user.Name,
+			Cloud: dbmodel.Cloud{
+				Name: args.Cloud.Id(),
+			},
+		}
+		err = j.Database.CloudDefaults(ctx, &cloudDefaults)
+		if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
+			return nil, errors.E(op, "failed to fetch cloud defaults")
+		}
+		builder = builder.WithConfig(cloudDefaults.Defaults)
+	}
+
+	builder = builder.WithCloud(user, args.Cloud)
+	if err := builder.Error(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	if args.PinnedController != "" {
+		builder = builder.WithPinnedController(args.PinnedController)
+	}
+	builder = builder.WithCloudRegion(args.CloudRegion)
+	if err := builder.Error(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	// at this point we know which cloud will host the model and
 	// we must check the user has add-model permission on the cloud
 	canAddModel, err := openfga.NewUser(owner, j.OpenFGAClient).IsAllowedAddModel(ctx, builder.cloud.ResourceTag())
 	if err != nil {
@@ -634,42 +1256,67 @@ func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCrea
 		builder = builder.WithConfig(cloudRegionDefaults.Defaults)
 	}
 
+	// the template model's config overrides all defaults
+	if args.TemplateModelUUID != "" {
+		builder = builder.WithTemplateModel(user, args.TemplateModelUUID)
+		if err := builder.Error(); err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
+
 	// last but not least, use the provided config values
 	// overriding all defaults
 	builder = builder.WithConfig(args.Config)
+	if err := checkLoggingConfig(builder.config); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if len(args.ModelTags) > 0 {
+		builder = builder.WithModelTags(args.ModelTags)
+		if err := builder.Error(); err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
 
 	if args.CloudCredential != (names.CloudCredentialTag{}) {
 		builder = builder.WithCloudCredential(args.CloudCredential)
 		if err := builder.Error(); err != nil {
 			return nil, errors.E(op, err)
 		}
+	} else if args.CloudCredentialName != "" {
+		builder = builder.WithCloudCredentialName(args.CloudCredentialName)
+		if err := builder.Error(); err != nil {
+			return nil, errors.E(op, err)
+		}
 	}
-	builder = builder.CreateDatabaseModel()
-	if err := builder.Error(); err != nil {
-		return nil, errors.E(op, err)
-	}
-	defer builder.Cleanup()
+	return builder, nil
+}
 
-	builder = builder.CreateControllerModel()
-	if err := builder.Error(); err != nil {
-		return nil, errors.E(op, err)
+// AdministeredModels returns a page of the models, across all
+// controllers, on which the given user has administrator access. JIMM
+// administrators administer every model. Models are ordered by UUID.
+func (j *JIMM) AdministeredModels(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]dbmodel.Model, error) {
+	const op = errors.Op("jimm.AdministeredModels")
+
+	if user.JimmAdmin {
+		models, err := j.Database.ListModels(ctx, filter.Limit(), filter.Offset())
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		return models, nil
 	}
 
-	builder = builder.UpdateDatabaseModel()
-	if err := builder.Error(); err != nil {
+	uuids, err := user.ListModels(ctx, ofganames.AdministratorRelation)
+	if err != nil {
 		return nil, errors.E(op, err)
 	}
-
-	mi := builder.JujuModelInfo()
-
-	ownerUser := openfga.NewUser(owner, j.OpenFGAClient)
-	modelTag := names.NewModelTag(mi.UUID)
-	controllerTag := builder.controller.ResourceTag()
-
-	if err := j.addModelPermissions(ctx, ownerUser, modelTag, controllerTag); err != nil {
+	if len(uuids) == 0 {
+		return nil, nil
+	}
+	models, err := j.Database.ListModelsByUUID(ctx, uuids, filter.Limit(), filter.Offset())
+	if err != nil {
 		return nil, errors.E(op, err)
 	}
-	return mi, nil
+	return models, nil
 }
 
 // GetModel retrieves a model object by the model UUID.
@@ -687,6 +1334,43 @@ func (j *JIMM) GetModel(ctx context.Context, uuid string) (dbmodel.Model, error)
 	return model, nil
 }
 
+// setModelOwnerAccess gives the owner of a model administrator access
+// to it. It is a variable so that tests can simulate failures.
+var setModelOwnerAccess = func(ctx context.Context, owner *openfga.User, mt names.ModelTag) error {
+	return owner.SetModelAccess(ctx, mt, ofganames.AdministratorRelation)
+}
+
+// grantModelAccessAttempts is the number of times access to a newly
+// created model is attempted to be granted before giving up.
+const grantModelAccessAttempts = 3
+
+// grantModelAccessDelay is the delay before the first retry of a failed
+// grant, the delay doubles after every subsequent failure.
+var grantModelAccessDelay = 100 * time.Millisecond
+
+// retryGrantModelAccess calls f until it succeeds, grantModelAccessAttempts
+// attempts have been made or the context is done. The access grants made
+// by f must be idempotent.
+func retryGrantModelAccess(ctx context.Context, f func() error) error {
+	delay := grantModelAccessDelay
+	var err error
+	for i := 0; i < grantModelAccessAttempts; i++ {
+		if i > 0 {
+			zapctx.Warn(ctx, "retrying model access grant", zap.Int("attempt", i+1), zap.Error(err))
+			select {
+			case <-ctx.Done():
+				return errors.E(ctx.Err())
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+		if err = f(); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
 // addModelPermissions grants a user access to a model and sets the relation between the controller and model.
 // Call this when adding/importing a model to set the necessary permissions.
 func (j *JIMM) addModelPermissions(ctx context.Context, owner *openfga.User, mt names.ModelTag, ct names.ControllerTag) error {
@@ -699,7 +1383,7 @@ func (j *JIMM) addModelPermissions(ctx context.Context, owner *openfga.User, mt
 		)
 		return err
 	}
-	if err := owner.SetModelAccess(ctx, mt, ofganames.AdministratorRelation); err != nil {
+	if err := setModelOwnerAccess(ctx, owner, mt); err != nil {
 		zapctx.Error(
 			ctx,
 			"failed to add user->model administrator relation",
@@ -723,6 +1407,9 @@ func (j *JIMM) ModelInfo(ctx context.Context, user *openfga.User, mt names.Model
 	var m dbmodel.Model
 	m.SetTag(mt)
 	if err := j.Database.GetModel(ctx, &m); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeModelNotFound, err)
+		}
 		return nil, errors.E(op, err)
 	}
 
@@ -743,12 +1430,86 @@ func (j *JIMM) ModelInfo(ctx context.Context, user *openfga.User, mt names.Model
 		return nil, errors.E(op, err)
 	}
 
-	return j.mergeModelInfo(ctx, user, mi, m)
+	return j.mergeModelInfo(ctx, user, mi, m, ModelInfoFields{Users: true, Machines: true})
+}
+
+// ModelInfoFields selects the optional sections of the model info
+// returned by LightModelInfo.
+type ModelInfoFields struct {
+	// Users includes the users with access to the model.
+	Users bool
+
+	// Machines includes the machines in the model. Machine information
+	// is only held by the controller, so requesting the machines
+	// requires a call to the controller hosting the model.
+	Machines bool
+}
+
+// LightModelInfo returns the model info for the model with the given
+// ModelTag including only the optional sections selected by fields. The
+// model is read without the offers it hosts, and if the machines are not
+// requested the model info is built from the information held by JIMM
+// without contacting the controller. Access to
+// the model and to the selected sections is restricted in the same way
+// as ModelInfo. If the model does not exist then the returned error
+// will have the code CodeModelNotFound. If the given user does not have
+// access to the model then the returned error will have the code
+// CodeUnauthorized.
+func (j *JIMM) LightModelInfo(ctx context.Context, user *openfga.User, mt names.ModelTag, fields ModelInfoFields) (*jujuparams.ModelInfo, error) {
+	const op = errors.Op("jimm.LightModelInfo")
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModelSummary(ctx, &m); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeModelNotFound, err)
+		}
+		return nil, errors.E(op, err)
+	}
+
+	if ok, err := user.IsModelReader(ctx, mt); !ok || err != nil {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var mi *jujuparams.ModelInfo
+	if fields.Machines {
+		api, err := j.dial(ctx, &m.Controller, names.ModelTag{})
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		defer api.Close()
+
+		mi = &jujuparams.ModelInfo{
+			UUID: mt.Id(),
+		}
+		if err := api.ModelInfo(ctx, mi); err != nil {
+			return nil, errors.E(op, err)
+		}
+	} else {
+		ms := m.ToJujuModelSummary()
+		mi = &jujuparams.ModelInfo{
+			Name:          ms.Name,
+			Type:          ms.Type,
+			UUID:          ms.UUID,
+			IsController:  ms.IsController,
+			ProviderType:  ms.ProviderType,
+			DefaultSeries: ms.DefaultSeries,
+			CloudTag:      ms.CloudTag,
+			CloudRegion:   ms.CloudRegion,
+			Life:          ms.Life,
+			Status:        ms.Status,
+			SLA:           ms.SLA,
+			AgentVersion:  ms.AgentVersion,
+		}
+	}
+
+	return j.mergeModelInfo(ctx, user, mi, m, fields)
 }
 
 // mergeModelInfo replaces fields on the juju model info object with
 // information from JIMM where JIMM specific information should be used.
-func (j *JIMM) mergeModelInfo(ctx context.Context, user *openfga.User, modelInfo *jujuparams.ModelInfo, jimmModel dbmodel.Model) (*jujuparams.ModelInfo, error) {
+// Only the optional sections selected by fields are included.
+func (j *JIMM) mergeModelInfo(ctx context.Context, user *openfga.User, modelInfo *jujuparams.ModelInfo, jimmModel dbmodel.Model, fields ModelInfoFields) (*jujuparams.ModelInfo, error) {
 	const op = errors.Op("jimm.mergeModelInfo")
 
 	jimmSummary := jimmModel.ToJujuModelSummary()
@@ -756,6 +1517,30 @@ func (j *JIMM) mergeModelInfo(ctx context.Context, user *openfga.User, modelInfo
 	modelInfo.ControllerUUID = jimmSummary.ControllerUUID
 	modelInfo.OwnerTag = jimmSummary.OwnerTag
 
+	if !fields.Machines {
+		modelInfo.Machines = nil
+	}
+	if !fields.Users && !fields.Machines {
+		modelInfo.Users = nil
+		return modelInfo, nil
+	}
+
+	modelAccess, err := j.GetUserModelAccess(ctx, user, jimmModel.ResourceTag())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	if modelAccess != "admin" && modelAccess != "write" {
+		// Users need "write" level access (or above) to see machine
+		// information.
+		modelInfo.Machines = nil
+	}
+
+	if !fields.Users {
+		modelInfo.Users = nil
+		return modelInfo, nil
+	}
+
 	userAccess := make(map[string]string)
 
 	for _, relation := range []openfga.Relation{
@@ -779,11 +1564,6 @@ func (j *JIMM) mergeModelInfo(ctx context.Context, user *openfga.User, modelInfo
 		}
 	}
 
-	modelAccess, err := j.GetUserModelAccess(ctx, user, jimmModel.ResourceTag())
-	if err != nil {
-		return nil, errors.E(op, err)
-	}
-
 	users := make([]jujuparams.ModelUserInfo, 0, len(userAccess))
 	for username, access := range userAccess {
 		// If the user does not contain an "@" sign (no domain), it means
@@ -801,12 +1581,6 @@ func (j *JIMM) mergeModelInfo(ctx context.Context, user *openfga.User, modelInfo
 	}
 	modelInfo.Users = users
 
-	if modelAccess != "admin" && modelAccess != "write" {
-		// Users need "write" level access (or above) to see machine
-		// information.
-		modelInfo.Machines = nil
-	}
-
 	return modelInfo, nil
 }
 
@@ -828,6 +1602,74 @@ func (j *JIMM) ModelStatus(ctx context.Context, user *openfga.User, mt names.Mod
 	return &ms, nil
 }
 
+// ListModelMachines returns the machines in the given model as last
+// reported to JIMM by the model's controller, the controller is not
+// contacted. Only users with write access to the model may list its
+// machines, if the given user does not have write access an error with a
+// code of CodeUnauthorized is returned.
+func (j *JIMM) ListModelMachines(ctx context.Context, user *openfga.User, mt names.ModelTag) ([]dbmodel.Machine, error) {
+	const op = errors.Op("jimm.ListModelMachines")
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeModelNotFound, err)
+		}
+		return nil, errors.E(op, err)
+	}
+
+	if !user.JimmAdmin {
+		accessLevel, err := j.GetUserModelAccess(ctx, user, mt)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		if !allowedModelAccess["write"][accessLevel] {
+			return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+	}
+
+	machines, err := j.Database.GetModelMachines(ctx, &m)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return machines, nil
+}
+
+// ModelApplications returns the applications in the given model as last
+// reported to JIMM by the model's controller, the controller is not
+// contacted. Only users with read access to the model may list its
+// applications, if the given user does not have read access an error
+// with a code of CodeUnauthorized is returned.
+func (j *JIMM) ModelApplications(ctx context.Context, user *openfga.User, mt names.ModelTag) ([]dbmodel.Application, error) {
+	const op = errors.Op("jimm.ModelApplications")
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeModelNotFound, err)
+		}
+		return nil, errors.E(op, err)
+	}
+
+	if !user.JimmAdmin {
+		accessLevel, err := j.GetUserModelAccess(ctx, user, mt)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		if !allowedModelAccess["read"][accessLevel] {
+			return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+	}
+
+	apps, err := j.Database.GetModelApplications(ctx, &m)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return apps, nil
+}
+
 // ForEachUserModel calls the given function once for each model that the
 // given user has been granted explicit access to. The UserModelAccess
 // object passed to f will always include the Model_, Access, and
@@ -920,7 +1762,7 @@ func (j *JIMM) GrantModelAccess(ctx context.Context, user *openfga.User, mt name
 		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("failed to recognize given access: %q", access), err)
 	}
 
-	err = j.doModelAdmin(ctx, user, mt, func(_ *dbmodel.Model, _ API) error {
+	err = j.doModelAdmin(ctx, user, mt, func(m *dbmodel.Model, _ API) error {
 		targetUser := &dbmodel.Identity{}
 		targetUser.SetTag(ut)
 		if err := j.Database.GetIdentity(ctx, targetUser); err != nil {
@@ -956,6 +1798,10 @@ func (j *JIMM) GrantModelAccess(ctx context.Context, user *openfga.User, mt name
 		if err := targetOfgaUser.SetModelAccess(ctx, mt, targetRelation); err != nil {
 			return errors.E(err, op, "failed to set model access")
 		}
+		// Cached copies of the model must not outlive a change to its
+		// access.
+		j.Database.InvalidateModel(m)
+		j.addModelAccessAuditLogEntry(user, "GrantModelAccess", mt, ut, ToModelAccessString(currentRelation), ToModelAccessString(targetRelation))
 		return nil
 	})
 
@@ -1004,61 +1850,143 @@ func (j *JIMM) RevokeModelAccess(ctx context.Context, user *openfga.User, mt nam
 		if err := j.Database.GetIdentity(ctx, targetUser); err != nil {
 			return err
 		}
-		targetOfgaUser := openfga.NewUser(targetUser, j.OpenFGAClient)
+		return j.revokeModelAccess(ctx, user, mt, targetUser, targetRelation, "RevokeModelAccess")
+	})
 
-		currentRelation := targetOfgaUser.GetModelAccess(ctx, mt)
+	if err != nil {
+		zapctx.Error(
+			ctx,
+			"failed to revoke model access",
+			zaputil.Error(err),
+			zap.String("targetUser", string(ut.Id())),
+			zap.String("model", string(mt.Id())),
+			zap.String("access", string(access)),
+		)
+		return errors.E(op, err)
+	}
+	return nil
+}
 
-		var relationsToRevoke []openfga.Relation
-		switch targetRelation {
-		case ofganames.ReaderRelation:
-			switch currentRelation {
-			case ofganames.NoRelation:
-				return nil
-			default:
-				relationsToRevoke = []openfga.Relation{
-					ofganames.ReaderRelation,
-					ofganames.WriterRelation,
-					ofganames.AdministratorRelation,
-				}
-			}
-		case ofganames.WriterRelation:
-			switch currentRelation {
-			case ofganames.NoRelation, ofganames.ReaderRelation:
-				return nil
-			default:
-				relationsToRevoke = []openfga.Relation{
-					ofganames.WriterRelation,
-					ofganames.AdministratorRelation,
-				}
-			}
-		case ofganames.AdministratorRelation:
-			switch currentRelation {
-			case ofganames.NoRelation, ofganames.ReaderRelation, ofganames.WriterRelation:
-				return nil
-			default:
-				relationsToRevoke = []openfga.Relation{
-					ofganames.AdministratorRelation,
+// RevokeModelAccessBulk revokes the given access level on the given model
+// from each of the given users using a single connection to the model's
+// controller. The returned slice holds the error, if any, encountered
+// revoking access from the user at the same index. If the access level
+// is not valid, the model is not found or the authenticated user is not
+// allowed to revoke access from all the given users then no access is
+// revoked and an error is returned instead. Revoking an access level a
+// user does not hold is not an error.
+func (j *JIMM) RevokeModelAccessBulk(ctx context.Context, user *openfga.User, mt names.ModelTag, users []names.UserTag, access jujuparams.UserAccessPermission) ([]error, error) {
+	const op = errors.Op("jimm.RevokeModelAccessBulk")
+
+	targetRelation, err := ToModelRelation(string(access))
+	if err != nil {
+		return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("failed to recognize given access: %q", access), err)
+	}
+
+	// Users may revoke their own access without being a model admin.
+	requiredAccess := "read"
+	for _, ut := range users {
+		if user.Tag() != ut {
+			requiredAccess = "admin"
+			break
+		}
+	}
+
+	results := make([]error, len(users))
+	err = j.doModel(ctx, user, mt, requiredAccess, func(_ *dbmodel.Model, _ API) error {
+		targetUsers := make([]*dbmodel.Identity, len(users))
+		err := j.Database.Transaction(func(tx *db.Database) error {
+			for i, ut := range users {
+				targetUser := &dbmodel.Identity{}
+				targetUser.SetTag(ut)
+				if err := tx.GetIdentity(ctx, targetUser); err != nil {
+					if errors.ErrorCode(err) != errors.CodeNotFound {
+						return err
+					}
+					results[i] = err
+					continue
 				}
+				targetUsers[i] = targetUser
 			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
-
-		if err := targetOfgaUser.UnsetModelAccess(ctx, mt, relationsToRevoke...); err != nil {
-			return errors.E(err, op, "failed to unset model access")
+		for i, targetUser := range targetUsers {
+			if targetUser == nil {
+				continue
+			}
+			if err := j.revokeModelAccess(ctx, user, mt, targetUser, targetRelation, "RevokeModelAccessBulk"); err != nil {
+				zapctx.Error(
+					ctx,
+					"failed to revoke model access",
+					zaputil.Error(err),
+					zap.String("targetUser", targetUser.Name),
+					zap.String("model", mt.Id()),
+					zap.String("access", string(access)),
+				)
+				results[i] = err
+			}
 		}
 		return nil
 	})
-
 	if err != nil {
-		zapctx.Error(
-			ctx,
-			"failed to revoke model access",
-			zaputil.Error(err),
-			zap.String("targetUser", string(ut.Id())),
-			zap.String("model", string(mt.Id())),
-			zap.String("access", string(access)),
-		)
-		return errors.E(op, err)
+		return nil, errors.E(op, err)
+	}
+	return results, nil
+}
+
+// revokeModelAccess removes the given relation, and any higher relations,
+// that the target user has to the given model. Nothing is done if the
+// target user does not currently hold the given relation.
+func (j *JIMM) revokeModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, targetUser *dbmodel.Identity, targetRelation openfga.Relation, method string) error {
+	targetOfgaUser := openfga.NewUser(targetUser, j.OpenFGAClient)
+
+	currentRelation := targetOfgaUser.GetModelAccess(ctx, mt)
+
+	var relationsToRevoke []openfga.Relation
+	switch targetRelation {
+	case ofganames.ReaderRelation:
+		switch currentRelation {
+		case ofganames.NoRelation:
+			return nil
+		default:
+			relationsToRevoke = []openfga.Relation{
+				ofganames.ReaderRelation,
+				ofganames.WriterRelation,
+				ofganames.AdministratorRelation,
+			}
+		}
+	case ofganames.WriterRelation:
+		switch currentRelation {
+		case ofganames.NoRelation, ofganames.ReaderRelation:
+			return nil
+		default:
+			relationsToRevoke = []openfga.Relation{
+				ofganames.WriterRelation,
+				ofganames.AdministratorRelation,
+			}
+		}
+	case ofganames.AdministratorRelation:
+		switch currentRelation {
+		case ofganames.NoRelation, ofganames.ReaderRelation, ofganames.WriterRelation:
+			return nil
+		default:
+			relationsToRevoke = []openfga.Relation{
+				ofganames.AdministratorRelation,
+			}
+		}
+	}
+
+	if err := targetOfgaUser.UnsetModelAccess(ctx, mt, relationsToRevoke...); err != nil {
+		return errors.E(err, "failed to unset model access")
 	}
+	// Cached copies of the model must not outlive a change to its
+	// access.
+	j.Database.InvalidateModel(&dbmodel.Model{UUID: sql.NullString{String: mt.Id(), Valid: true}})
+	newRelation := targetOfgaUser.GetModelAccess(ctx, mt)
+	j.addModelAccessAuditLogEntry(user, method, mt, targetUser.ResourceTag(), ToModelAccessString(currentRelation), ToModelAccessString(newRelation))
 	return nil
 }
 
@@ -1070,16 +1998,7 @@ func (j *JIMM) DestroyModel(ctx context.Context, user *openfga.User, mt names.Mo
 	const op = errors.Op("jimm.DestroyModel")
 
 	err := j.doModelAdmin(ctx, user, mt, func(m *dbmodel.Model, api API) error {
-		if err := api.DestroyModel(ctx, mt, destroyStorage, force, maxWait, timeout); err != nil {
-			return err
-		}
-		m.Life = state.Dying.String()
-		if err := j.Database.UpdateModel(ctx, m); err != nil {
-			// If the database fails to update don't worry too much the
-			// monitor should catch it.
-			zapctx.Error(ctx, "failed to store model change", zaputil.Error(err))
-		}
-		return nil
+		return j.destroyModel(ctx, m, api, destroyStorage, force, maxWait, timeout)
 	})
 	if err != nil {
 		return errors.E(op, err)
@@ -1093,6 +2012,57 @@ func (j *JIMM) DestroyModel(ctx context.Context, user *openfga.User, mt names.Mo
 	return nil
 }
 
+// destroyModel destroys the given model using the given API connection to
+// its controller, and marks the model as dying. The caller is
+// responsible for checking that the model may be destroyed.
+func (j *JIMM) destroyModel(ctx context.Context, m *dbmodel.Model, api API, destroyStorage, force *bool, maxWait, timeout *time.Duration) error {
+	if err := api.DestroyModel(ctx, m.ResourceTag(), destroyStorage, force, maxWait, timeout); err != nil {
+		return err
+	}
+	if !setModelLife(m, state.Dying.String()) {
+		return nil
+	}
+	err := j.Database.UpdateModel(ctx, m)
+	if err != nil {
+		// If the database fails to update don't worry too much the
+		// monitor should catch it.
+		zapctx.Error(ctx, "failed to store model change", zaputil.Error(err))
+	}
+	return nil
+}
+
+// updateModel applies the given change to the model and stores it in the
+// database. If the model has been changed since it was read, an error
+// with the code CodeConflict is returned and the change is not stored.
+// The change is not reapplied, as it may have been decided from the
+// model as it was read.
+func (j *JIMM) updateModel(ctx context.Context, m *dbmodel.Model, apply func(*dbmodel.Model) error) error {
+	if err := apply(m); err != nil {
+		return err
+	}
+	return j.Database.UpdateModel(ctx, m)
+}
+
+// modelLifeOrder holds the order in which a model moves through its life
+// values. A model's life never moves to an earlier value.
+var modelLifeOrder = map[string]int{
+	ModelCreating:        0,
+	state.Alive.String(): 1,
+	state.Dying.String(): 2,
+	state.Dead.String():  3,
+}
+
+// setModelLife sets the life of the given model to the given value,
+// unless that would move the model's life backwards, for example from
+// dead to dying. It returns whether the life was set.
+func setModelLife(m *dbmodel.Model, life string) bool {
+	if modelLifeOrder[life] < modelLifeOrder[m.Life] {
+		return false
+	}
+	m.Life = life
+	return true
+}
+
 // DumpModel retrieves a database-agnostic dump of the given model from its
 // juju controller. If simplified is true a simpllified dump is requested.
 // If the given user is not a controller superuser or a model admin an
@@ -1148,6 +2118,56 @@ func (j *JIMM) ValidateModelUpgrade(ctx context.Context, user *openfga.User, mt
 	return nil
 }
 
+// UpgradeModel upgrades the given model to the given agent version on
+// its controller and records the new version in JIMM's database. If the
+// given user is not a controller superuser or a model admin then an error
+// with the code CodeUnauthorized is returned. If the target version is
+// older than the model's current version, or newer than the version of the
+// controller hosting the model, then an error with the code CodeBadRequest
+// is returned. If the model is changed in JIMM's database while it is
+// being upgraded then an error with the code CodeConflict is returned. The
+// version chosen by the controller is returned.
+func (j *JIMM) UpgradeModel(ctx context.Context, user *openfga.User, mt names.ModelTag, targetVersion version.Number) (version.Number, error) {
+	const op = errors.Op("jimm.UpgradeModel")
+
+	var chosen version.Number
+	err := j.doModelAdmin(ctx, user, mt, func(m *dbmodel.Model, api API) error {
+		if m.Status.Version != "" {
+			current, err := version.Parse(m.Status.Version)
+			if err != nil {
+				return errors.E(err, fmt.Sprintf("cannot parse model agent version %q", m.Status.Version))
+			}
+			if targetVersion.Compare(current) < 0 {
+				return errors.E(errors.CodeBadRequest, fmt.Sprintf("cannot downgrade model from %s to %s", current, targetVersion))
+			}
+		}
+		// The controller's agent version is updated when it is dialed.
+		if m.Controller.AgentVersion != "" {
+			controllerVersion, err := version.Parse(m.Controller.AgentVersion)
+			if err != nil {
+				return errors.E(err, fmt.Sprintf("cannot parse controller agent version %q", m.Controller.AgentVersion))
+			}
+			if targetVersion.Compare(controllerVersion) > 0 {
+				return errors.E(errors.CodeBadRequest, fmt.Sprintf("controller %s does not support agent version %s, the controller is running %s", m.Controller.Name, targetVersion, controllerVersion))
+			}
+		}
+
+		var err error
+		chosen, err = api.UpgradeModel(ctx, mt, targetVersion)
+		if err != nil {
+			return err
+		}
+		return j.updateModel(ctx, m, func(m *dbmodel.Model) error {
+			m.Status.Version = chosen.String()
+			return nil
+		})
+	})
+	if err != nil {
+		return version.Number{}, errors.E(op, err)
+	}
+	return chosen, nil
+}
+
 // doModelAdmin is a simple wrapper that provides the common parts of model
 // administration commands. doModelAdmin finds the model with the given tag
 // and validates that the given user has admin access to the model.
@@ -1170,6 +2190,195 @@ func (j *JIMM) GetUserModelAccess(ctx context.Context, user *openfga.User, model
 	return ToModelAccessString(accessLevel), nil
 }
 
+// The sources from which a user's access to a model may be derived.
+const (
+	// ModelAccessDirect is the source of access granted directly to the
+	// user.
+	ModelAccessDirect = "direct"
+
+	// ModelAccessEveryone is the source of access granted to every user.
+	ModelAccessEveryone = "everyone"
+
+	// ModelAccessGroup is the source of access granted to a group the
+	// user is a member of.
+	ModelAccessGroup = "group"
+
+	// ModelAccessController is the source of access derived from being an
+	// administrator of the model's controller, or of JIMM itself.
+	ModelAccessController = "controller"
+)
+
+// A ModelAccessSource describes one way in which a user has access to a
+// model.
+type ModelAccessSource struct {
+	// Source is where the access comes from, one of ModelAccessDirect,
+	// ModelAccessEveryone, ModelAccessGroup or ModelAccessController.
+	Source string
+
+	// Access is the access level granted by this source.
+	Access string
+
+	// Group holds the name of the group through which the access is
+	// granted when the Source is ModelAccessGroup. The user may be a
+	// member of the group through membership of another group.
+	Group string
+
+	// Controller holds the name of the controller the user administers
+	// when the Source is ModelAccessController. Administrators of JIMM
+	// itself are reported with a controller name of "jimm".
+	Controller string
+}
+
+// A ModelAccessExplanation describes the access a user has to a model
+// and why.
+type ModelAccessExplanation struct {
+	// User is the name of the user whose access is explained.
+	User string
+
+	// Access is the effective access level the user has to the model.
+	Access string
+
+	// Sources holds every source the user's access is derived from.
+	Sources []ModelAccessSource
+}
+
+// ExplainModelAccess returns the access the target user has to the
+// given model along with the sources of that access. Only model
+// administrators and JIMM administrators may explain a user's access.
+func (j *JIMM) ExplainModelAccess(ctx context.Context, user *openfga.User, target names.UserTag, mt names.ModelTag) (*ModelAccessExplanation, error) {
+	const op = errors.Op("jimm.ExplainModelAccess")
+
+	if !user.JimmAdmin && user.GetModelAccess(ctx, mt) != ofganames.AdministratorRelation {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	targetUser := openfga.NewUser(&dbmodel.Identity{Name: target.Id()}, j.OpenFGAClient)
+	relation := targetUser.GetModelAccess(ctx, mt)
+	explanation := ModelAccessExplanation{
+		User:   target.Id(),
+		Access: ToModelAccessString(relation),
+	}
+	if relation == ofganames.NoRelation {
+		return &explanation, nil
+	}
+
+	modelTag := ofganames.ConvertTag(mt)
+	targetTag := ofganames.ConvertTag(target)
+	relations, err := j.directModelRelations(ctx, targetTag, modelTag)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	for _, r := range relations {
+		explanation.Sources = append(explanation.Sources, ModelAccessSource{
+			Source: ModelAccessDirect,
+			Access: ToModelAccessString(r),
+		})
+	}
+	if target.Id() != ofganames.EveryoneUser {
+		relations, err := j.directModelRelations(ctx, ofganames.ConvertTag(names.NewUserTag(ofganames.EveryoneUser)), modelTag)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		for _, r := range relations {
+			explanation.Sources = append(explanation.Sources, ModelAccessSource{
+				Source: ModelAccessEveryone,
+				Access: ToModelAccessString(r),
+			})
+		}
+	}
+
+	// ListObjects resolves membership of nested groups, so this lists
+	// every group the user is a member of directly or indirectly.
+	groups, err := j.OpenFGAClient.ListObjects(ctx, targetTag, ofganames.MemberRelation, openfga.GroupType, nil)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	var groupSources []ModelAccessSource
+	for _, t := range groups {
+		group := dbmodel.GroupEntry{UUID: t.ID}
+		if err := j.Database.GetGroup(ctx, &group); err != nil {
+			return nil, errors.E(op, err)
+		}
+		relations, err := j.directModelRelations(ctx, ofganames.ConvertTagWithRelation(group.ResourceTag(), ofganames.MemberRelation), modelTag)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		for _, r := range relations {
+			groupSources = append(groupSources, ModelAccessSource{
+				Source: ModelAccessGroup,
+				Access: ToModelAccessString(r),
+				Group:  group.Name,
+			})
+		}
+	}
+	sort.Slice(groupSources, func(i, k int) bool {
+		return groupSources[i].Group < groupSources[k].Group
+	})
+	explanation.Sources = append(explanation.Sources, groupSources...)
+
+	isJIMMAdmin, err := openfga.IsAdministrator(ctx, targetUser, j.ResourceTag())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if isJIMMAdmin {
+		explanation.Sources = append(explanation.Sources, ModelAccessSource{
+			Source:     ModelAccessController,
+			Access:     ToModelAccessString(ofganames.AdministratorRelation),
+			Controller: "jimm",
+		})
+	} else {
+		isControllerAdmin, err := openfga.IsAdministrator(ctx, targetUser, m.Controller.ResourceTag())
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		if isControllerAdmin {
+			explanation.Sources = append(explanation.Sources, ModelAccessSource{
+				Source:     ModelAccessController,
+				Access:     ToModelAccessString(ofganames.AdministratorRelation),
+				Controller: m.Controller.Name,
+			})
+		}
+	}
+	return &explanation, nil
+}
+
+// directModelRelations returns the model access relations stored in
+// OpenFGA between the given object and model, ordered from the highest
+// access level to the lowest.
+func (j *JIMM) directModelRelations(ctx context.Context, object, model *ofganames.Tag) ([]openfga.Relation, error) {
+	found := make(map[openfga.Relation]bool)
+	var token string
+	for {
+		tuples, ct, err := j.OpenFGAClient.ReadRelatedObjects(ctx, openfga.Tuple{
+			Object: object,
+			Target: model,
+		}, 0, token)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tuples {
+			found[t.Relation] = true
+		}
+		if ct == "" {
+			break
+		}
+		token = ct
+	}
+	var relations []openfga.Relation
+	for _, r := range []openfga.Relation{ofganames.AdministratorRelation, ofganames.WriterRelation, ofganames.ReaderRelation} {
+		if found[r] {
+			relations = append(relations, r)
+		}
+	}
+	return relations, nil
+}
+
 func (j *JIMM) doModel(ctx context.Context, user *openfga.User, mt names.ModelTag, access string, f func(*dbmodel.Model, API) error) error {
 	const op = errors.Op("jimm.doModel")
 
@@ -1177,6 +2386,9 @@ func (j *JIMM) doModel(ctx context.Context, user *openfga.User, mt names.ModelTa
 	m.SetTag(mt)
 
 	if err := j.Database.GetModel(ctx, &m); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, errors.CodeModelNotFound, err)
+		}
 		return errors.E(op, err)
 	}
 
@@ -1217,7 +2429,10 @@ var allowedModelAccess = map[string]map[string]bool{
 }
 
 // ChangeModelCredential changes the credential used with a model on both
-// the controller and the local database.
+// the controller and the local database. The credential must belong to the
+// model's owner and be for the model's cloud. Only model administrators
+// may change the credential. If the model is changed concurrently then an
+// error with the code CodeConflict is returned.
 func (j *JIMM) ChangeModelCredential(ctx context.Context, user *openfga.User, modelTag names.ModelTag, cloudCredentialTag names.CloudCredentialTag) error {
 	const op = errors.Op("jimm.ChangeModelCredential")
 
@@ -1235,6 +2450,13 @@ func (j *JIMM) ChangeModelCredential(ctx context.Context, user *openfga.User, mo
 
 	var m *dbmodel.Model
 	err = j.doModelAdmin(ctx, user, modelTag, func(model *dbmodel.Model, api API) error {
+		if credential.OwnerIdentityName != model.OwnerIdentityName {
+			return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("cloud credential %q is not owned by the model owner", credential.Path()))
+		}
+		if credential.CloudName != model.CloudRegion.Cloud.Name {
+			return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("cloud credential %q is not for cloud %q", credential.Path(), model.CloudRegion.Cloud.Name))
+		}
+
 		_, err = j.updateControllerCloudCredential(ctx, &credential, api.UpdateCredential)
 		if err != nil {
 			return errors.E(op, err)
@@ -1251,12 +2473,547 @@ func (j *JIMM) ChangeModelCredential(ctx context.Context, user *openfga.User, mo
 		return errors.E(op, err)
 	}
 
-	m.CloudCredential = credential
-	m.CloudCredentialID = credential.ID
-	err = j.Database.UpdateModel(ctx, m)
+	err = j.updateModel(ctx, m, func(m *dbmodel.Model) error {
+		m.CloudCredential = credential
+		m.CloudCredentialID = credential.ID
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	return nil
+}
+
+// ChangeModelOwner changes the owner of the given model to the given user.
+// The new owner is made an administrator of the model before the model
+// is updated and the previous owner's administrator relation is removed
+// afterwards, so that the owner recorded in the database is always an
+// administrator. If the model cannot be updated the new owner's
+// administrator relation is removed again, unless they already had it.
+// Failing to remove the previous owner's relation is only logged, as the
+// change of owner has already been made. Only a JIMM administrator or the
+// current owner of the model may change its owner. If the model is
+// changed concurrently then an error with the code CodeConflict is
+// returned.
+func (j *JIMM) ChangeModelOwner(ctx context.Context, user *openfga.User, mt names.ModelTag, newOwner names.UserTag) error {
+	const op = errors.Op("jimm.ChangeModelOwner")
+
+	if newOwner.IsLocal() {
+		return errors.E(op, errors.CodeBadRequest, "cannot change model owner to a local user")
+	}
+
+	var model dbmodel.Model
+	model.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &model); err != nil {
+		return errors.E(op, err)
+	}
+	if !user.JimmAdmin && user.Name != model.OwnerIdentityName {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var owner dbmodel.Identity
+	owner.SetTag(newOwner)
+	if err := j.Database.GetIdentity(ctx, &owner); err != nil {
+		return errors.E(op, err)
+	}
+	if owner.Name == model.OwnerIdentityName {
+		return nil
+	}
+
+	ownerUser := openfga.NewUser(&owner, j.OpenFGAClient)
+	wasAdmin, err := openfga.IsAdministrator(ctx, ownerUser, mt)
+	if err != nil {
+		return errors.E(op, "failed administrator check", err)
+	}
+	if !wasAdmin {
+		if err := ownerUser.SetModelAccess(ctx, mt, ofganames.AdministratorRelation); err != nil {
+			return errors.E(op, err, "failed to grant the new owner administrator access")
+		}
+	}
+
+	previousOwner := model.Owner
+	err = j.updateModel(ctx, &model, func(m *dbmodel.Model) error {
+		m.SwitchOwner(&owner)
+		return nil
+	})
+	if err != nil {
+		if !wasAdmin {
+			if err := ownerUser.UnsetModelAccess(ctx, mt, ofganames.AdministratorRelation); err != nil {
+				zapctx.Error(ctx, "failed to revoke the new owner's administrator access", zap.String("model", mt.Id()), zap.String("user", owner.Name), zaputil.Error(err))
+			}
+		}
+		return errors.E(op, err)
+	}
+
+	if err := openfga.NewUser(&previousOwner, j.OpenFGAClient).UnsetModelAccess(ctx, mt, ofganames.AdministratorRelation); err != nil {
+		zapctx.Error(ctx, "failed to revoke the previous owner's administrator access", zap.String("model", mt.Id()), zap.String("user", previousOwner.Name), zaputil.Error(err))
+	}
+	return nil
+}
+
+// checkModelQuota returns an error with code errors.CodeQuotaExceeded if
+// the identity with the given name already owns as many live models as
+// the configured model quota allows.
+func (j *JIMM) checkModelQuota(ctx context.Context, ownerName string) error {
+	config := dbmodel.ControllerConfig{
+		Name: "jimm",
+	}
+	err := j.Database.GetControllerConfig(ctx, &config)
+	if err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil
+		}
+		return err
+	}
+	quotaValue, ok := config.Config[UserModelQuotaConfigKeyPrefix+ownerName]
+	if !ok {
+		quotaValue, ok = config.Config[ModelQuotaConfigKey]
+	}
+	if !ok {
+		return nil
+	}
+	quota, err := quotaFromConfig(quotaValue)
+	if err != nil {
+		return errors.E(errors.CodeServerConfiguration, err)
+	}
+	if quota <= 0 {
+		return nil
+	}
+	count, err := j.Database.CountLiveModelsByOwner(ctx, ownerName)
+	if err != nil {
+		return err
+	}
+	if count >= quota {
+		return errors.E(errors.CodeQuotaExceeded, fmt.Sprintf("model quota exceeded: %s already owns %d of %d models", ownerName, count, quota))
+	}
+	return nil
+}
+
+// modelLoggingDefault returns the logging-config that new models are
+// created with, if one has been configured.
+func (j *JIMM) modelLoggingDefault(ctx context.Context) (string, error) {
+	config := dbmodel.ControllerConfig{
+		Name: "jimm",
+	}
+	err := j.Database.GetControllerConfig(ctx, &config)
+	if err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	v, ok := config.Config[ModelLoggingDefaultConfigKey]
+	if !ok {
+		return "", nil
+	}
+	s, err := loggingDefaultFromConfig(v)
+	if err != nil {
+		return "", errors.E(errors.CodeServerConfiguration, err)
+	}
+	return s, nil
+}
+
+// loggingDefaultFromConfig returns the logging-config held in the given
+// model-logging-default controller config value.
+func loggingDefaultFromConfig(v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", errors.E(fmt.Sprintf("invalid %s value %v", ModelLoggingDefaultConfigKey, v))
+	}
+	if _, err := loggo.ParseConfigString(s); err != nil {
+		return "", errors.E(fmt.Sprintf("invalid %s value %q: %s", ModelLoggingDefaultConfigKey, s, err))
+	}
+	return s, nil
+}
+
+// checkLoggingConfig returns an error with code errors.CodeBadRequest if
+// the given model config contains a logging-config that is not valid.
+func checkLoggingConfig(config map[string]interface{}) error {
+	v, ok := config[loggingConfigKey]
+	if !ok {
+		return nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid logging-config %v", v))
+	}
+	if _, err := loggo.ParseConfigString(s); err != nil {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid logging-config %q: %s", s, err))
+	}
+	return nil
+}
+
+// quotaFromConfig converts a quota value stored in the controller config
+// into an int. Values set through the API are decoded from JSON, so are
+// most likely float64.
+func quotaFromConfig(v interface{}) (int, error) {
+	switch q := v.(type) {
+	case int:
+		return q, nil
+	case int64:
+		return int(q), nil
+	case float64:
+		return int(q), nil
+	case string:
+		n, err := strconv.Atoi(q)
+		if err != nil {
+			return 0, errors.E(fmt.Sprintf("invalid model quota %q", q))
+		}
+		return n, nil
+	}
+	return 0, errors.E(fmt.Sprintf("invalid model quota %v", v))
+}
+
+// PinModel pins the model to the controller currently hosting it. A
+// pinned model will not be moved to another controller until it is
+// unpinned. Only JIMM administrators may pin models.
+func (j *JIMM) PinModel(ctx context.Context, user *openfga.User, mt names.ModelTag) error {
+	const op = errors.Op("jimm.PinModel")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	err := j.Database.Transaction(func(tx *db.Database) error {
+		model := dbmodel.Model{}
+		model.SetTag(mt)
+		if err := tx.GetModel(ctx, &model); err != nil {
+			return err
+		}
+		//nolint:gosec // Database IDs will not exceed int32.
+		model.PinnedControllerID = sql.NullInt32{Int32: int32(model.ControllerID), Valid: true}
+		return tx.UpdateModel(ctx, &model)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// UnpinModel removes any pin on the model, allowing it to be moved to
+// another controller. Only JIMM administrators may unpin models.
+func (j *JIMM) UnpinModel(ctx context.Context, user *openfga.User, mt names.ModelTag) error {
+	const op = errors.Op("jimm.UnpinModel")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	err := j.Database.Transaction(func(tx *db.Database) error {
+		model := dbmodel.Model{}
+		model.SetTag(mt)
+		if err := tx.GetModel(ctx, &model); err != nil {
+			return err
+		}
+		model.PinnedControllerID = sql.NullInt32{}
+		return tx.UpdateModel(ctx, &model)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// modelSpecificConfig holds the model configuration keys that identify
+// a model rather than define it, they are never copied to another model.
+var modelSpecificConfig = map[string]bool{
+	"agent-version": true,
+	"name":          true,
+	"type":          true,
+	"uuid":          true,
+}
+
+// privateConfig holds the model configuration keys that hold secrets or
+// are bound to the owner or controller of a model, they are never copied
+// to another model or exported.
+var privateConfig = map[string]bool{
+	"authorized-keys":       true,
+	"cloudinit-userdata":    true,
+	"secret-backend":        true,
+	"snap-store-assertions": true,
+	"syslog-client-cert":    true,
+	"syslog-client-key":     true,
+}
+
+// privateConfigSuffixes holds the suffixes of model configuration keys,
+// such as provider specific keys, that are assumed to hold secrets.
+var privateConfigSuffixes = []string{
+	"-key",
+	"-password",
+	"-secret",
+	"-token",
+}
+
+// isPrivateConfig returns whether the model configuration key holds a
+// secret or is bound to the owner or controller of the model.
+func isPrivateConfig(key string) bool {
+	if privateConfig[key] {
+		return true
+	}
+	for _, suffix := range privateConfigSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportModelSpec returns the specification of the given model suitable
+// for re-creating the model elsewhere. Only configuration explicitly set
+// on the model, that is not a secret or bound to the model's owner, is
+// included and the cloud credential is only referenced by name. If the user is not an administrator of the model an error
+// with a code of CodeUnauthorized is returned.
+func (j *JIMM) ExportModelSpec(ctx context.Context, user *openfga.User, mt names.ModelTag) (*apiparams.ModelSpec, error) {
+	const op = errors.Op("jimm.ExportModelSpec")
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeModelNotFound, err)
+		}
+		return nil, errors.E(op, err)
+	}
+
+	if !user.JimmAdmin {
+		accessLevel, err := j.GetUserModelAccess(ctx, user, mt)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		if !allowedModelAccess["admin"][accessLevel] {
+			return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+	}
+
+	api, err := j.dial(ctx, &m.Controller, mt)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	defer api.Close()
+
+	config, err := api.ModelGet(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	cons, err := api.GetModelConstraints(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	spec := apiparams.ModelSpec{
+		Name:            m.Name,
+		Owner:           m.OwnerIdentityName,
+		Cloud:           m.CloudRegion.Cloud.Name,
+		CloudRegion:     m.CloudRegion.Name,
+		CloudCredential: m.CloudCredential.Name,
+		Constraints:     cons.String(),
+	}
+	spec.Config = inheritableConfig(config)
+	return &spec, nil
+}
+
+// immutableModelConfig holds the model configuration keys that cannot be
+// changed once a model has been created.
+var immutableModelConfig = map[string]bool{
+	"charmhub-url":  true,
+	"firewall-mode": true,
+	"name":          true,
+	"type":          true,
+	"uuid":          true,
+}
+
+// ModifyModelConfig changes the configuration of the given model on its
+// controller, setting the values in set and returning the keys in unset
+// to their defaults. The user must be an administrator of the model. If
+// any of the keys to unset cannot be changed an error with a code of
+// CodeBadRequest is returned without modifying the model.
+func (j *JIMM) ModifyModelConfig(ctx context.Context, user *openfga.User, mt names.ModelTag, set map[string]interface{}, unset []string) error {
+	const op = errors.Op("jimm.ModifyModelConfig")
+
+	for _, k := range unset {
+		if immutableModelConfig[k] {
+			return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("cannot unset immutable model config key %q", k))
+		}
+	}
+	if err := checkLoggingConfig(set); err != nil {
+		return errors.E(op, err)
+	}
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, errors.CodeModelNotFound, err)
+		}
+		return errors.E(op, err)
+	}
+
+	if !user.JimmAdmin {
+		accessLevel, err := j.GetUserModelAccess(ctx, user, mt)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		if !allowedModelAccess["admin"][accessLevel] {
+			return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+	}
+
+	api, err := j.dial(ctx, &m.Controller, mt)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer api.Close()
+
+	if len(set) > 0 {
+		if err := api.ModelSet(ctx, set); err != nil {
+			return errors.E(op, err)
+		}
+	}
+	if len(unset) > 0 {
+		if err := api.ModelUnset(ctx, unset); err != nil {
+			return errors.E(op, err)
+		}
+	}
+	return nil
+}
+
+// inheritableConfig returns the values of the given model configuration
+// that have been explicitly set on the model and may be copied to
+// another model. Secrets and values bound to the model's owner or
+// controller are never included. If there are no such values nil is
+// returned.
+func inheritableConfig(config map[string]jujuparams.ConfigValue) map[string]interface{} {
+	var values map[string]interface{}
+	for k, v := range config {
+		if v.Source != "model" || modelSpecificConfig[k] || isPrivateConfig(k) {
+			continue
+		}
+		if values == nil {
+			values = make(map[string]interface{})
+		}
+		values[k] = v.Value
+	}
+	return values
+}
+
+// validSLALevels contains the SLA levels that may be set on a model.
+var validSLALevels = map[string]bool{
+	"unsupported": true,
+	"essential":   true,
+	"standard":    true,
+	"advanced":    true,
+}
+
+// SetModelSLA sets the SLA level and owner of the model with the given
+// tag. The user must be an administrator of the model. The new SLA is
+// set on the controller hosting the model and then stored in the
+// database.
+func (j *JIMM) SetModelSLA(ctx context.Context, user *openfga.User, mt names.ModelTag, level, owner string) error {
+	const op = errors.Op("jimm.SetModelSLA")
+
+	if !validSLALevels[level] {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid SLA level %q", level))
+	}
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, errors.CodeModelNotFound, err)
+		}
+		return errors.E(op, err)
+	}
+
+	if !user.JimmAdmin {
+		accessLevel, err := j.GetUserModelAccess(ctx, user, mt)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		if !allowedModelAccess["admin"][accessLevel] {
+			return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+	}
+
+	api, err := j.dial(ctx, &m.Controller, mt)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer api.Close()
+
+	if err := api.SetSLALevel(ctx, level, owner); err != nil {
+		return errors.E(op, err)
+	}
+
+	err = j.updateModel(ctx, &m, func(m *dbmodel.Model) error {
+		m.SLA.Level = level
+		m.SLA.Owner = owner
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// validBlockTypes contains the types of block that may be switched on
+// for a model.
+var validBlockTypes = map[string]bool{
+	"BlockDestroy": true,
+	"BlockRemove":  true,
+	"BlockChange":  true,
+}
+
+// ListModelBlocks returns the blocks that are switched on for the model
+// with the given tag. The user must be an administrator of the model.
+func (j *JIMM) ListModelBlocks(ctx context.Context, user *openfga.User, mt names.ModelTag) ([]jujuparams.Block, error) {
+	const op = errors.Op("jimm.ListModelBlocks")
+
+	var blocks []jujuparams.Block
+	err := j.doModelAdmin(ctx, user, mt, func(_ *dbmodel.Model, api API) error {
+		var err error
+		blocks, err = api.ListBlocks(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return blocks, nil
+}
+
+// SetModelBlock switches on the given type of block, with the given
+// message, for the model with the given tag. The block type must be one
+// of "BlockDestroy", "BlockRemove" or "BlockChange". The user must be an
+// administrator of the model. Operations prevented by the block fail on
+// the controller with an error with the code CodeOperationBlocked.
+func (j *JIMM) SetModelBlock(ctx context.Context, user *openfga.User, mt names.ModelTag, blockType, message string) error {
+	const op = errors.Op("jimm.SetModelBlock")
+
+	if !validBlockTypes[blockType] {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid block type %q", blockType))
+	}
+	err := j.doModelAdmin(ctx, user, mt, func(_ *dbmodel.Model, api API) error {
+		return api.SwitchBlockOn(ctx, blockType, message)
+	})
 	if err != nil {
 		return errors.E(op, err)
 	}
+	return nil
+}
+
+// RemoveModelBlock switches off the given type of block for the model
+// with the given tag. The user must be an administrator of the model.
+func (j *JIMM) RemoveModelBlock(ctx context.Context, user *openfga.User, mt names.ModelTag, blockType string) error {
+	const op = errors.Op("jimm.RemoveModelBlock")
 
+	if !validBlockTypes[blockType] {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid block type %q", blockType))
+	}
+	err := j.doModelAdmin(ctx, user, mt, func(_ *dbmodel.Model, api API) error {
+		return api.SwitchBlockOff(ctx, blockType)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
 	return nil
 }
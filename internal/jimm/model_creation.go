@@ -0,0 +1,283 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// The states of an asynchronous model creation.
+const (
+	// ModelCreating is the status of a model that is still being created
+	// on its controller. It is also the life status recorded for the
+	// model in the database until the creation completes.
+	ModelCreating = "creating"
+
+	// ModelCreated is the status of a model that has been successfully
+	// created.
+	ModelCreated = "alive"
+
+	// ModelCreationFailed is the status of a model that could not be
+	// created.
+	ModelCreationFailed = "failed"
+)
+
+// modelCreationTimeout is the maximum time an asynchronous model creation
+// may take.
+var modelCreationTimeout = 10 * time.Minute
+
+// modelCreationRetention is the length of time the failure of an
+// asynchronous model creation is kept for, so that it can be reported.
+var modelCreationRetention = time.Hour
+
+// A ModelCreation describes the progress of an asynchronous model
+// creation started with AddModelAsync.
+type ModelCreation struct {
+	// Handle identifies the model creation.
+	Handle string
+
+	// Status holds the status of the model creation, this is one of
+	// ModelCreating, ModelCreated or ModelCreationFailed.
+	Status string
+
+	// ModelUUID holds the UUID of the model once it has been created.
+	ModelUUID string
+
+	// Error holds the reason the model could not be created.
+	Error string
+}
+
+// modelCreationTracker runs the asynchronous model creations started by
+// JIMM. The progress of each creation is recorded on the model being
+// created, or as a failed model creation if the model is removed.
+type modelCreationTracker struct {
+	mu     sync.Mutex
+	runner *runner
+}
+
+// start runs f in a new goroutine.
+func (t *modelCreationTracker) start(handle string, f func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.runner == nil {
+		t.runner = newRunner()
+	}
+	t.runner.run(handle, f)
+}
+
+// wait blocks until all the model creations in progress have finished.
+func (t *modelCreationTracker) wait() {
+	t.mu.Lock()
+	r := t.runner
+	t.mu.Unlock()
+	if r != nil {
+		r.wait()
+	}
+}
+
+// AddModelAsync starts creating the specified model and returns a handle
+// that can be passed to ModelCreationStatus to follow its progress. The
+// request is validated and the model is recorded in the database with a
+// life of "creating" before AddModelAsync returns, the model is then
+// created on its controller in the background. Should the creation fail
+// the model is removed and the reason it failed is recorded until it is
+// removed by CleanupModelCreations.
+func (j *JIMM) AddModelAsync(ctx context.Context, user *openfga.User, args *ModelCreateArgs) (string, error) {
+	const op = errors.Op("jimm.AddModelAsync")
+
+	builder, err := j.prepareModel(ctx, user, args)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	handle := uuid.NewString()
+	builder.life = ModelCreating
+	builder.creationHandle = handle
+	builder.creationRequester = user.Name
+	builder = builder.CreateDatabaseModel()
+	if err := builder.Error(); err != nil {
+		return "", errors.E(op, err)
+	}
+
+	// The creation must outlive the request that started it.
+	bctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), modelCreationTimeout)
+	builder.ctx = bctx
+	j.modelCreations.start(handle, func() {
+		defer cancel()
+		j.createModel(bctx, builder)
+	})
+	return handle, nil
+}
+
+// createModel completes the creation of a model that has been stored in
+// the database. If the creation fails the failure is recorded before the
+// model is removed.
+func (j *JIMM) createModel(ctx context.Context, builder *modelBuilder) {
+	defer func() {
+		if r := recover(); r != nil {
+			zapctx.Error(ctx, "model creation panicked", zap.String("model", builder.name), zap.Any("panic", r))
+			builder.err = errors.E(fmt.Sprintf("model creation failed: %v", r))
+		}
+		if err := builder.Error(); err != nil {
+			zapctx.Error(ctx, "failed to create model", zap.String("model", builder.name), zap.String("owner", builder.owner.Name), zaputil.Error(err))
+			j.addModelCreationFailure(context.WithoutCancel(ctx), builder.model, err.Error())
+		}
+		builder.Cleanup()
+	}()
+
+	builder = builder.CreateControllerModel()
+	builder = builder.UpdateDatabaseModel()
+	builder = builder.AddModelPermissions()
+}
+
+// addModelCreationFailure records that the asynchronous creation of the
+// given model failed for the given reason.
+func (j *JIMM) addModelCreationFailure(ctx context.Context, m *dbmodel.Model, reason string) {
+	failure := dbmodel.ModelCreationFailure{
+		Handle:            m.CreationHandle.String,
+		Requester:         m.CreationRequester,
+		OwnerIdentityName: m.OwnerIdentityName,
+		ModelName:         m.Name,
+		Error:             reason,
+	}
+	if err := j.Database.AddModelCreationFailure(ctx, &failure); err != nil {
+		zapctx.Error(ctx, "failed to record model creation failure", zap.String("model", m.Name), zap.String("owner", m.OwnerIdentityName), zaputil.Error(err))
+	}
+}
+
+// ModelCreationStatus returns the progress of the asynchronous model
+// creation with the given handle. Only the user that started the
+// creation, the owner of the model and JIMM administrators may see the
+// status of a model creation.
+func (j *JIMM) ModelCreationStatus(ctx context.Context, user *openfga.User, handle string) (*ModelCreation, error) {
+	const op = errors.Op("jimm.ModelCreationStatus")
+
+	m := dbmodel.Model{
+		CreationHandle: sql.NullString{String: handle, Valid: true},
+	}
+	err := j.Database.GetModel(ctx, &m)
+	if errors.ErrorCode(err) == errors.CodeNotFound {
+		return j.modelCreationFailure(ctx, user, handle)
+	}
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if !user.JimmAdmin && user.Name != m.CreationRequester && user.Name != m.OwnerIdentityName {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	mc := ModelCreation{
+		Handle: handle,
+	}
+	if m.Life == ModelCreating {
+		mc.Status = ModelCreating
+	} else {
+		mc.Status = ModelCreated
+		mc.ModelUUID = m.UUID.String
+	}
+	return &mc, nil
+}
+
+// modelCreationFailure returns the status of the failed model creation
+// with the given handle.
+func (j *JIMM) modelCreationFailure(ctx context.Context, user *openfga.User, handle string) (*ModelCreation, error) {
+	const op = errors.Op("jimm.ModelCreationStatus")
+
+	failure := dbmodel.ModelCreationFailure{
+		Handle: handle,
+	}
+	if err := j.Database.GetModelCreationFailure(ctx, &failure); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeNotFound, fmt.Sprintf("model creation %q not found", handle))
+		}
+		return nil, errors.E(op, err)
+	}
+	if !user.JimmAdmin && user.Name != failure.Requester && user.Name != failure.OwnerIdentityName {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	return &ModelCreation{
+		Handle: handle,
+		Status: ModelCreationFailed,
+		Error:  failure.Error,
+	}, nil
+}
+
+// CleanupModelCreations removes the models of any asynchronous model
+// creation that has been running for longer than a creation may take,
+// such as one interrupted by JIMM being stopped, and removes the
+// failures of model creations recorded more than modelCreationRetention
+// ago. The controller of each interrupted creation is checked first, and
+// any model the creation left there is destroyed. If the controller
+// cannot be checked the model is left to be removed by a later cleanup.
+func (j *JIMM) CleanupModelCreations(ctx context.Context) error {
+	const op = errors.Op("jimm.CleanupModelCreations")
+
+	models, err := j.Database.GetModelsByLife(ctx, ModelCreating)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	now := time.Now()
+	for i := range models {
+		m := &models[i]
+		if now.Sub(m.CreatedAt) <= modelCreationTimeout {
+			continue
+		}
+		if err := j.removeInterruptedModel(ctx, m); err != nil {
+			zapctx.Error(ctx, "failed to remove interrupted model creation", zap.String("model", m.Name), zap.String("owner", m.OwnerIdentityName), zaputil.Error(err))
+		}
+	}
+	if _, err := j.Database.DeleteModelCreationFailuresBefore(ctx, now.Add(-modelCreationRetention)); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// removeInterruptedModel removes the given model, whose asynchronous
+// creation was interrupted. The model may have been created on its
+// controller before the creation was interrupted, so the models the
+// owner has on the controller are listed and any with the same name is
+// destroyed, so that it is not leaked.
+func (j *JIMM) removeInterruptedModel(ctx context.Context, m *dbmodel.Model) error {
+	api, err := j.dial(ctx, &m.Controller, names.ModelTag{})
+	if err != nil {
+		return errors.E(err)
+	}
+	defer api.Close()
+
+	owner := names.NewUserTag(m.OwnerIdentityName)
+	userModels, err := api.ListModels(ctx, owner)
+	if err != nil {
+		return errors.E(err)
+	}
+	for _, um := range userModels {
+		if um.Name != m.Name || um.OwnerTag != owner.String() {
+			continue
+		}
+		if err := api.DestroyModel(ctx, names.NewModelTag(um.UUID), nil, nil, nil, nil); err != nil {
+			return errors.E(err)
+		}
+	}
+	if m.CreationHandle.Valid {
+		j.addModelCreationFailure(ctx, m, "model creation did not complete")
+	}
+	return j.Database.DeleteModel(ctx, m)
+}
+
+// RunModelCreationCleanup cleans up asynchronous model creations at the
+// given interval. RunModelCreationCleanup blocks until the given context
+// is canceled.
+func (j *JIMM) RunModelCreationCleanup(ctx context.Context, interval time.Duration) error {
+	return runPeriodically(ctx, interval, "failed to clean up model creations", j.CleanupModelCreations)
+}
@@ -0,0 +1,302 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+func newAsyncModelTestJIMM(c *qt.C, createModel func(context.Context, *jujuparams.ModelCreateArgs, *jujuparams.ModelInfo) error) (*jimm.JIMM, *openfga.User) {
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				UpdateCredential_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+				GrantJIMMModelAdmin_: func(context.Context, names.ModelTag) error {
+					return nil
+				},
+				CreateModel_: createModel,
+				DestroyModel_: func(context.Context, names.ModelTag, *bool, *bool, *time.Duration, *time.Duration) error {
+					return nil
+				},
+			},
+		},
+	}
+	c.Cleanup(j.WaitModelCreations)
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, deprecatedControllerTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+	return j, openfga.NewUser(&dbUser, client)
+}
+
+var asyncModelCreateArgs = jimm.ModelCreateArgs{
+	Name:            "async-model",
+	Owner:           names.NewUserTag("alice@canonical.com"),
+	Cloud:           names.NewCloudTag("test-cloud"),
+	CloudRegion:     "test-cloud-region",
+	CloudCredential: names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1"),
+}
+
+func TestAddModelAsync(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	create := createModel(`
+status:
+  status: started
+  info: running a test
+life: alive
+users:
+- user: alice@canonical.com
+  access: admin
+`[1:])
+	modelUUID := uuid.NewString()
+	release := make(chan struct{})
+	j, user := newAsyncModelTestJIMM(c, func(ctx context.Context, args *jujuparams.ModelCreateArgs, mi *jujuparams.ModelInfo) error {
+		<-release
+		if err := create(ctx, args, mi); err != nil {
+			return err
+		}
+		mi.UUID = modelUUID
+		return nil
+	})
+
+	args := asyncModelCreateArgs
+	handle, err := j.AddModelAsync(ctx, user, &args)
+	c.Assert(err, qt.IsNil)
+
+	// The model is stored as creating while the controller is working.
+	m := dbmodel.Model{
+		OwnerIdentityName: "alice@canonical.com",
+		Name:              "async-model",
+	}
+	err = j.Database.GetModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	c.Check(m.Life, qt.Equals, jimm.ModelCreating)
+
+	mc, err := j.ModelCreationStatus(ctx, user, handle)
+	c.Assert(err, qt.IsNil)
+	c.Check(mc.Status, qt.Equals, jimm.ModelCreating)
+
+	// Other users cannot see the model creation.
+	bob := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, j.OpenFGAClient)
+	_, err = j.ModelCreationStatus(ctx, bob, handle)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	close(release)
+	j.WaitModelCreations()
+
+	mc, err = j.ModelCreationStatus(ctx, user, handle)
+	c.Assert(err, qt.IsNil)
+	c.Check(mc.Status, qt.Equals, jimm.ModelCreated)
+	c.Check(mc.Error, qt.Equals, "")
+	c.Check(mc.ModelUUID, qt.Equals, modelUUID)
+
+	m = dbmodel.Model{
+		UUID: sql.NullString{String: modelUUID, Valid: true},
+	}
+	err = j.Database.GetModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	c.Check(m.Life, qt.Equals, "alive")
+
+	c.Check(user.GetModelAccess(ctx, names.NewModelTag(modelUUID)), qt.Equals, ofganames.AdministratorRelation)
+
+	_, err = j.ModelCreationStatus(ctx, user, uuid.NewString())
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
+
+func TestAddModelAsyncFailure(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j, user := newAsyncModelTestJIMM(c, func(context.Context, *jujuparams.ModelCreateArgs, *jujuparams.ModelInfo) error {
+		return errors.E("a silly error")
+	})
+
+	args := asyncModelCreateArgs
+	handle, err := j.AddModelAsync(ctx, user, &args)
+	c.Assert(err, qt.IsNil)
+	j.WaitModelCreations()
+
+	mc, err := j.ModelCreationStatus(ctx, user, handle)
+	c.Assert(err, qt.IsNil)
+	c.Check(mc.Status, qt.Equals, jimm.ModelCreationFailed)
+	c.Check(mc.Error, qt.Equals, "a silly error")
+	c.Check(mc.ModelUUID, qt.Equals, "")
+
+	// Other users cannot see the failed model creation.
+	bob := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, j.OpenFGAClient)
+	_, err = j.ModelCreationStatus(ctx, bob, handle)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// The failed model is removed.
+	m := dbmodel.Model{
+		OwnerIdentityName: "alice@canonical.com",
+		Name:              "async-model",
+	}
+	err = j.Database.GetModel(ctx, &m)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	args = asyncModelCreateArgs
+	handle2, err := j.AddModelAsync(ctx, user, &args)
+	c.Assert(err, qt.IsNil)
+	j.WaitModelCreations()
+
+	mc, err = j.ModelCreationStatus(ctx, user, handle2)
+	c.Assert(err, qt.IsNil)
+	c.Check(mc.Status, qt.Equals, jimm.ModelCreationFailed)
+}
+
+// addInterruptedModel adds a model with the given name that was left
+// creating, for example because JIMM was stopped part way through
+// creating it.
+func addInterruptedModel(c *qt.C, j *jimm.JIMM, name string) dbmodel.Model {
+	ctx := context.Background()
+
+	existing := dbmodel.Model{
+		UUID: sql.NullString{String: "00000002-0000-0000-0000-000000000002", Valid: true},
+	}
+	err := j.Database.GetModel(ctx, &existing)
+	c.Assert(err, qt.IsNil)
+
+	m := dbmodel.Model{
+		Name:              name,
+		OwnerIdentityName: "alice@canonical.com",
+		CreatedAt:         time.Now().Add(-time.Hour),
+		ControllerID:      existing.ControllerID,
+		CloudRegionID:     existing.CloudRegionID,
+		CloudCredentialID: existing.CloudCredentialID,
+		Life:              jimm.ModelCreating,
+		CreationHandle:    sql.NullString{String: uuid.NewString(), Valid: true},
+		CreationRequester: "alice@canonical.com",
+	}
+	err = j.Database.AddModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	return m
+}
+
+func TestCleanupModelCreations(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j, user := newAsyncModelTestJIMM(c, func(context.Context, *jujuparams.ModelCreateArgs, *jujuparams.ModelInfo) error {
+		return errors.E("a silly error")
+	})
+
+	args := asyncModelCreateArgs
+	handle, err := j.AddModelAsync(ctx, user, &args)
+	c.Assert(err, qt.IsNil)
+	j.WaitModelCreations()
+
+	// Models left creating, for example because JIMM was stopped part
+	// way through creating them.
+	orphaned := addInterruptedModel(c, j, "orphaned-model")
+	leaked := addInterruptedModel(c, j, "leaked-model")
+
+	// The leaked model was created on the controller before the creation
+	// was interrupted.
+	leakedUUID := uuid.NewString()
+	var destroyed []string
+	api := j.Dialer.(*jimmtest.Dialer).API.(*jimmtest.API)
+	api.ListModels_ = func(_ context.Context, user names.UserTag) ([]jujuparams.UserModel, error) {
+		return []jujuparams.UserModel{{
+			Model: jujuparams.Model{
+				Name:     "leaked-model",
+				UUID:     leakedUUID,
+				OwnerTag: user.String(),
+			},
+		}, {
+			Model: jujuparams.Model{
+				Name:     "orphaned-model",
+				UUID:     uuid.NewString(),
+				OwnerTag: names.NewUserTag("bob@canonical.com").String(),
+			},
+		}}, nil
+	}
+	api.DestroyModel_ = func(_ context.Context, mt names.ModelTag, _, _ *bool, _, _ *time.Duration) error {
+		destroyed = append(destroyed, mt.Id())
+		return nil
+	}
+
+	err = j.CleanupModelCreations(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(destroyed, qt.DeepEquals, []string{leakedUUID})
+
+	for _, m := range []dbmodel.Model{orphaned, leaked} {
+		mc, err := j.ModelCreationStatus(ctx, user, m.CreationHandle.String)
+		c.Assert(err, qt.IsNil)
+		c.Check(mc.Status, qt.Equals, jimm.ModelCreationFailed)
+		c.Check(mc.Error, qt.Equals, "model creation did not complete")
+
+		err = j.Database.GetModel(ctx, &dbmodel.Model{OwnerIdentityName: m.OwnerIdentityName, Name: m.Name})
+		c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+	}
+
+	// The recent failure is kept.
+	mc, err := j.ModelCreationStatus(ctx, user, handle)
+	c.Assert(err, qt.IsNil)
+	c.Check(mc.Status, qt.Equals, jimm.ModelCreationFailed)
+
+	c.Patch(jimm.ModelCreationRetention, time.Duration(0))
+	err = j.CleanupModelCreations(ctx)
+	c.Assert(err, qt.IsNil)
+
+	_, err = j.ModelCreationStatus(ctx, user, orphaned.CreationHandle.String)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+	_, err = j.ModelCreationStatus(ctx, user, handle)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
+
+func TestCleanupModelCreationsControllerUnavailable(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j, user := newAsyncModelTestJIMM(c, func(context.Context, *jujuparams.ModelCreateArgs, *jujuparams.ModelInfo) error {
+		return errors.E("a silly error")
+	})
+	api := j.Dialer.(*jimmtest.Dialer).API.(*jimmtest.API)
+	api.ListModels_ = func(context.Context, names.UserTag) ([]jujuparams.UserModel, error) {
+		return nil, errors.E(errors.CodeConnectionFailed, "controller unavailable")
+	}
+
+	m := addInterruptedModel(c, j, "orphaned-model")
+
+	// The model is kept until the controller can be checked.
+	err := j.CleanupModelCreations(ctx)
+	c.Assert(err, qt.IsNil)
+
+	mc, err := j.ModelCreationStatus(ctx, user, m.CreationHandle.String)
+	c.Assert(err, qt.IsNil)
+	c.Check(mc.Status, qt.Equals, jimm.ModelCreating)
+}
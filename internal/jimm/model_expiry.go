@@ -0,0 +1,74 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// The reasons recorded in the audit log for destroying an expired model.
+const (
+	modelExpiryReasonExpired = "expired"
+	modelExpiryReasonIdle    = "idle"
+)
+
+// RunModelExpiry destroys expired models at the given interval.
+// RunModelExpiry blocks until the given context is canceled.
+func (j *JIMM) RunModelExpiry(ctx context.Context, interval time.Duration) error {
+	return runPeriodically(ctx, interval, "failed to destroy expired models", j.DestroyExpiredModels)
+}
+
+// DestroyExpiredModels destroys every alive model that has passed its
+// expiry time, or has had no connections for longer than its idle
+// timeout. The models are destroyed by JIMM itself rather than on behalf
+// of any user, so no access checks are made, and each destruction is
+// recorded in the audit log. Any storage in the models is released
+// rather than destroyed. Models that fail to be destroyed are logged and
+// will be retried the next time DestroyExpiredModels is called.
+func (j *JIMM) DestroyExpiredModels(ctx context.Context) error {
+	const op = errors.Op("jimm.DestroyExpiredModels")
+
+	now := time.Now()
+	models, err := j.Database.GetExpiredModels(ctx, now, state.Alive.String())
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	destroyStorage := false
+	for i := range models {
+		if err := ctx.Err(); err != nil {
+			return errors.E(op, err)
+		}
+		m := &models[i]
+		ctx := zapctx.WithFields(ctx, zap.String("model", m.UUID.String), zap.String("controller", m.Controller.Name))
+		reason := modelExpiryReasonIdle
+		if m.ExpiresAt.Valid && !m.ExpiresAt.Time.After(now) {
+			reason = modelExpiryReasonExpired
+		}
+		if err := j.destroyExpiredModel(ctx, m, &destroyStorage); err != nil {
+			zapctx.Error(ctx, "failed to destroy expired model", zap.Error(err))
+			continue
+		}
+		zapctx.Info(ctx, "destroyed expired model", zap.String("reason", reason))
+		j.addModelExpiryAuditLogEntry(m.ResourceTag(), reason)
+	}
+	return nil
+}
+
+func (j *JIMM) destroyExpiredModel(ctx context.Context, m *dbmodel.Model, destroyStorage *bool) error {
+	api, err := j.dial(ctx, &m.Controller, names.ModelTag{})
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	return j.destroyModel(ctx, m, api, destroyStorage, nil, nil, nil)
+}
@@ -0,0 +1,180 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"github.com/juju/juju/state"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+const modelExpiryTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: expired-model
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: idle-model
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: active-model
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: unexpired-model
+  uuid: 00000002-0000-0000-0000-000000000004
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: dying-model
+  uuid: 00000002-0000-0000-0000-000000000005
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: dying
+`
+
+func TestDestroyExpiredModels(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var destroyed []string
+	api := &jimmtest.API{
+		DestroyModel_: func(_ context.Context, mt names.ModelTag, destroyStorage, force *bool, _, _ *time.Duration) error {
+			mu.Lock()
+			defer mu.Unlock()
+			// Storage is released rather than destroyed.
+			c.Check(destroyStorage, qt.IsNotNil)
+			c.Check(*destroyStorage, qt.IsFalse)
+			c.Check(force, qt.IsNil)
+			destroyed = append(destroyed, mt.Id())
+			return nil
+		},
+	}
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: api,
+		},
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, modelExpiryTestEnv)
+	env.PopulateDB(c, j.Database)
+
+	now := time.Now()
+	setExpiry := func(name string, expiresAt time.Time, idleTimeout time.Duration, lastConnection time.Time) {
+		m := env.Model("alice@canonical.com", name).DBObject(c, j.Database)
+		if !expiresAt.IsZero() {
+			m.ExpiresAt = sql.NullTime{Time: expiresAt, Valid: true}
+		}
+		m.IdleTimeout = idleTimeout
+		err := j.Database.UpdateModel(ctx, &m)
+		c.Assert(err, qt.IsNil)
+		if !lastConnection.IsZero() {
+			err = j.Database.UpdateModelLastConnection(ctx, &m, lastConnection)
+			c.Assert(err, qt.IsNil)
+		}
+	}
+	setExpiry("expired-model", now.Add(-time.Hour), 0, time.Time{})
+	setExpiry("idle-model", time.Time{}, time.Hour, now.Add(-2*time.Hour))
+	setExpiry("active-model", time.Time{}, time.Hour, now)
+	setExpiry("unexpired-model", now.Add(time.Hour), 0, time.Time{})
+	setExpiry("dying-model", now.Add(-time.Hour), 0, time.Time{})
+
+	err = j.DestroyExpiredModels(ctx)
+	c.Assert(err, qt.IsNil)
+
+	sort.Strings(destroyed)
+	c.Check(destroyed, qt.DeepEquals, []string{
+		"00000002-0000-0000-0000-000000000001",
+		"00000002-0000-0000-0000-000000000002",
+	})
+
+	for _, name := range []string{"expired-model", "idle-model"} {
+		m := dbmodel.Model{
+			Name:              name,
+			OwnerIdentityName: "alice@canonical.com",
+		}
+		err := j.Database.GetModel(ctx, &m)
+		c.Assert(err, qt.IsNil)
+		c.Check(m.Life, qt.Equals, state.Dying.String(), qt.Commentf("model %s", name))
+	}
+	m := dbmodel.Model{
+		Name:              "active-model",
+		OwnerIdentityName: "alice@canonical.com",
+	}
+	err = j.Database.GetModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	c.Check(m.Life, qt.Equals, state.Alive.String())
+
+	c.Check(controllerAuditLogEntries(c, j, "DestroyExpiredModel"), qt.DeepEquals, []controllerAuditLogEntry{{
+		Actor: j.ResourceTag().String(),
+		Details: map[string]interface{}{
+			"model":  "model-00000002-0000-0000-0000-000000000001",
+			"reason": "expired",
+		},
+	}, {
+		Actor: j.ResourceTag().String(),
+		Details: map[string]interface{}{
+			"model":  "model-00000002-0000-0000-0000-000000000002",
+			"reason": "idle",
+		},
+	}})
+
+	// Models that have been destroyed are not destroyed again.
+	destroyed = nil
+	err = j.DestroyExpiredModels(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(destroyed, qt.HasLen, 0)
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"time"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// A ModelReconciler removes models that have been destroyed on their
+// controller from JIMM. It complements the Watcher, which may miss the
+// final removal of a model if it is not connected to the controller at
+// the time.
+type ModelReconciler struct {
+	// Database is the database used by the ModelReconciler.
+	Database db.Database
+
+	// Dialer is the API dialer used to contact juju controllers.
+	Dialer Dialer
+
+	// OpenFGAClient is the client used to remove the relations of
+	// destroyed models.
+	OpenFGAClient *openfga.OFGAClient
+}
+
+// Run reconciles dying and dead models at the given interval. Run blocks
+// until the given context is canceled.
+func (r *ModelReconciler) Run(ctx context.Context, interval time.Duration) error {
+	return runPeriodically(ctx, interval, "failed to reconcile models", r.Reconcile)
+}
+
+// Reconcile checks every model that JIMM believes is dying or dead with
+// the controller hosting it. Models that no longer exist on the
+// controller are removed from the database and OpenFGA. Models that
+// still exist have their life updated from the controller.
+func (r *ModelReconciler) Reconcile(ctx context.Context) error {
+	const op = errors.Op("jimm.ModelReconciler.Reconcile")
+
+	models, err := r.Database.GetModelsByLife(ctx, state.Dying.String(), state.Dead.String())
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	byController := make(map[uint][]dbmodel.Model)
+	var controllers []dbmodel.Controller
+	for _, m := range models {
+		if _, ok := byController[m.ControllerID]; !ok {
+			controllers = append(controllers, m.Controller)
+		}
+		byController[m.ControllerID] = append(byController[m.ControllerID], m)
+	}
+
+	for i := range controllers {
+		if err := ctx.Err(); err != nil {
+			return errors.E(op, err)
+		}
+		ctl := &controllers[i]
+		ctx := zapctx.WithFields(ctx, zap.String("controller", ctl.Name))
+		if err := r.reconcileControllerModels(ctx, ctl, byController[ctl.ID]); err != nil {
+			// Carry on with the remaining controllers, the failed
+			// models will be retried on the next run.
+			zapctx.Error(ctx, "failed to reconcile controller models", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (r *ModelReconciler) reconcileControllerModels(ctx context.Context, ctl *dbmodel.Controller, models []dbmodel.Model) error {
+	const op = errors.Op("jimm.ModelReconciler.reconcileControllerModels")
+
+	api, err := r.Dialer.Dial(ctx, ctl, names.ModelTag{}, nil)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer api.Close()
+
+	for i := range models {
+		m := &models[i]
+		mi := jujuparams.ModelInfo{
+			UUID: m.UUID.String,
+		}
+		err := api.ModelInfo(ctx, &mi)
+		switch {
+		case err == nil:
+			if mi.Life != "" && string(mi.Life) != m.Life {
+				m.Life = string(mi.Life)
+				if err := r.Database.UpdateModel(ctx, m); err != nil {
+					return errors.E(op, err)
+				}
+			}
+		case errors.ErrorCode(err) == errors.CodeNotFound:
+			if err := r.removeModel(ctx, m); err != nil {
+				return errors.E(op, err)
+			}
+		default:
+			return errors.E(op, err)
+		}
+	}
+	return nil
+}
+
+// removeModel removes a destroyed model from OpenFGA and then the
+// database. The relations are removed first so that a failure part way
+// through leaves the model in the database, to be removed again the next
+// time the models are reconciled, rather than leaving relations to a
+// model JIMM no longer knows about.
+func (r *ModelReconciler) removeModel(ctx context.Context, m *dbmodel.Model) error {
+	if r.OpenFGAClient != nil {
+		if err := r.OpenFGAClient.RemoveModel(ctx, m.ResourceTag()); err != nil {
+			return err
+		}
+	}
+	if err := r.Database.DeleteModel(ctx, m); err != nil {
+		return err
+	}
+	zapctx.Info(ctx, "removed destroyed model", zap.String("model", m.UUID.String))
+	return nil
+}
+
+// ReconcileModel repairs the information JIMM stores about the given
+// model from the current state of the model on its controller. An all
+// model watcher is started on the controller and the initial state it
+// reports for the model is used to replace the model's stored status,
+// machine, core and unit counts and the charms of its offered
+// applications. The watcher is stopped before ReconcileModel returns.
+// Only model administrators and JIMM administrators may reconcile a
+// model.
+func (j *JIMM) ReconcileModel(ctx context.Context, user *openfga.User, mt names.ModelTag) error {
+	const op = errors.Op("jimm.ReconcileModel")
+
+	err := j.doModelAdmin(ctx, user, mt, func(m *dbmodel.Model, api API) error {
+		id, err := api.WatchAllModels(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := api.AllModelWatcherStop(ctx, id); err != nil {
+				zapctx.Error(ctx, "failed to stop all model watcher", zap.Error(err))
+			}
+		}()
+
+		// The first set of deltas from a new watcher describes the
+		// current state of every model on the controller.
+		deltas, err := api.AllModelWatcherNext(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		w := &Watcher{
+			Database: j.Database,
+		}
+		st := &modelState{
+			id:           m.ID,
+			machines:     make(map[string]int64),
+			applications: make(map[string]bool),
+			units:        make(map[string]bool),
+		}
+		modelStatef := func(uuid string) *modelState {
+			if uuid != mt.Id() {
+				return nil
+			}
+			return st
+		}
+		for _, d := range deltas {
+			if err := w.handleDelta(ctx, modelStatef, d); err != nil {
+				return err
+			}
+		}
+		return w.storeModelState(ctx, st)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,371 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/life"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+const modelReconcilerTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: dying
+  users:
+  - user: alice@canonical.com
+    access: admin
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: dying
+  users:
+  - user: alice@canonical.com
+    access: admin
+- name: model-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+  users:
+  - user: alice@canonical.com
+    access: admin
+`
+
+func TestModelReconcilerReconcile(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	database := db.Database{
+		DB: jimmtest.PostgresDB(c, nil),
+	}
+	err = database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, modelReconcilerTestEnv)
+	env.PopulateDBAndPermissions(c, names.NewControllerTag(jimmtest.ControllerUUID), database, client)
+
+	var mu sync.Mutex
+	var checked []string
+	r := jimm.ModelReconciler{
+		Database:      database,
+		OpenFGAClient: client,
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				ModelInfo_: func(_ context.Context, mi *jujuparams.ModelInfo) error {
+					mu.Lock()
+					checked = append(checked, mi.UUID)
+					mu.Unlock()
+					switch mi.UUID {
+					case "00000002-0000-0000-0000-000000000001":
+						// model-1 has been fully destroyed.
+						return errors.E(errors.CodeNotFound)
+					default:
+						mi.Life = life.Dead
+						return nil
+					}
+				},
+			},
+		},
+	}
+
+	err = r.Reconcile(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(checked, qt.DeepEquals, []string{
+		"00000002-0000-0000-0000-000000000001",
+		"00000002-0000-0000-0000-000000000002",
+	})
+
+	// model-1 has been removed from the database and OpenFGA.
+	m1 := dbmodel.Model{UUID: sql.NullString{String: "00000002-0000-0000-0000-000000000001", Valid: true}}
+	err = database.GetModel(ctx, &m1)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+	alice := env.User("alice@canonical.com").DBObject(c, database)
+	isAdmin, err := openfga.IsAdministrator(ctx, openfga.NewUser(&alice, client), names.NewModelTag(m1.UUID.String))
+	c.Assert(err, qt.IsNil)
+	c.Check(isAdmin, qt.IsFalse)
+
+	// model-2 still exists on the controller, its life is updated.
+	m2 := dbmodel.Model{UUID: sql.NullString{String: "00000002-0000-0000-0000-000000000002", Valid: true}}
+	err = database.GetModel(ctx, &m2)
+	c.Assert(err, qt.IsNil)
+	c.Check(m2.Life, qt.Equals, "dead")
+
+	// model-3 is alive and is left alone.
+	m3 := dbmodel.Model{UUID: sql.NullString{String: "00000002-0000-0000-0000-000000000003", Valid: true}}
+	err = database.GetModel(ctx, &m3)
+	c.Assert(err, qt.IsNil)
+	c.Check(m3.Life, qt.Equals, "alive")
+}
+
+func TestModelReconcilerReconcileUnauthorized(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	database := db.Database{
+		DB: jimmtest.PostgresDB(c, nil),
+	}
+	err = database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, modelReconcilerTestEnv)
+	env.PopulateDBAndPermissions(c, names.NewControllerTag(jimmtest.ControllerUUID), database, client)
+
+	r := jimm.ModelReconciler{
+		Database:      database,
+		OpenFGAClient: client,
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				ModelInfo_: func(_ context.Context, mi *jujuparams.ModelInfo) error {
+					return errors.E(errors.CodeUnauthorized, "permission denied")
+				},
+			},
+		},
+	}
+
+	err = r.Reconcile(ctx)
+	c.Assert(err, qt.IsNil)
+
+	// An unauthorized error does not prove the model has been destroyed,
+	// so it is kept.
+	m1 := dbmodel.Model{UUID: sql.NullString{String: "00000002-0000-0000-0000-000000000001", Valid: true}}
+	err = database.GetModel(ctx, &m1)
+	c.Assert(err, qt.IsNil)
+	c.Check(m1.Life, qt.Equals, "dying")
+}
+
+func TestModelReconcilerRunStopsOnCancel(t *testing.T) {
+	c := qt.New(t)
+
+	database := db.Database{
+		DB: jimmtest.PostgresDB(c, nil),
+	}
+	err := database.Migrate(context.Background(), false)
+	c.Assert(err, qt.IsNil)
+
+	r := jimm.ModelReconciler{
+		Database: database,
+		Dialer:   &jimmtest.Dialer{API: &jimmtest.API{}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	errC := make(chan error, 1)
+	go func() {
+		errC <- r.Run(ctx, time.Millisecond)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-errC:
+		c.Check(err, qt.Equals, context.Canceled)
+	case <-time.After(time.Second):
+		c.Fatal("model reconciler did not stop")
+	}
+}
+
+const reconcileModelTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+  status:
+    status: available
+  cores: 10
+  machines: 5
+  units: 7
+  users:
+  - user: alice@canonical.com
+    access: admin
+  - user: bob@canonical.com
+    access: write
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+  machines: 3
+  users:
+  - user: alice@canonical.com
+    access: admin
+`
+
+func TestReconcileModel(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	stopped := false
+	j := &jimm.JIMM{
+		UUID: jimmtest.ControllerUUID,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				WatchAllModels_: func(context.Context) (string, error) {
+					return "1234", nil
+				},
+				AllModelWatcherNext_: func(_ context.Context, id string) ([]jujuparams.Delta, error) {
+					if id != "1234" {
+						return nil, errors.E("incorrect id")
+					}
+					return []jujuparams.Delta{{
+						Entity: &jujuparams.ModelUpdate{
+							ModelUUID: "00000002-0000-0000-0000-000000000001",
+							Name:      "model-1",
+							Life:      life.Alive,
+							Status: jujuparams.StatusInfo{
+								Current: "busy",
+							},
+						},
+					}, {
+						Entity: &jujuparams.MachineInfo{
+							ModelUUID: "00000002-0000-0000-0000-000000000001",
+							Id:        "0",
+							HardwareCharacteristics: &instance.HardwareCharacteristics{
+								CpuCores: newUint64(2),
+							},
+						},
+					}, {
+						Entity: &jujuparams.MachineInfo{
+							ModelUUID: "00000002-0000-0000-0000-000000000001",
+							Id:        "1",
+							HardwareCharacteristics: &instance.HardwareCharacteristics{
+								CpuCores: newUint64(1),
+							},
+						},
+					}, {
+						Entity: &jujuparams.UnitInfo{
+							ModelUUID: "00000002-0000-0000-0000-000000000001",
+							Name:      "app-1/0",
+						},
+					}, {
+						// Deltas for other models are ignored.
+						Entity: &jujuparams.MachineInfo{
+							ModelUUID: "00000002-0000-0000-0000-000000000002",
+							Id:        "0",
+						},
+					}}, nil
+				},
+				AllModelWatcherStop_: func(_ context.Context, id string) error {
+					if id != "1234" {
+						return errors.E("incorrect id")
+					}
+					stopped = true
+					return nil
+				},
+			},
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, reconcileModelTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	mt := names.NewModelTag("00000002-0000-0000-0000-000000000001")
+
+	bob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	err = j.ReconcileModel(ctx, openfga.NewUser(&bob, client), mt)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	c.Check(stopped, qt.IsFalse)
+
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	err = j.ReconcileModel(ctx, openfga.NewUser(&alice, client), mt)
+	c.Assert(err, qt.IsNil)
+	c.Check(stopped, qt.IsTrue)
+
+	m := dbmodel.Model{
+		UUID: sql.NullString{
+			String: "00000002-0000-0000-0000-000000000001",
+			Valid:  true,
+		},
+	}
+	err = j.Database.GetModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	c.Check(m.Status.Status, qt.Equals, "busy")
+	c.Check(m.Machines, qt.Equals, int64(2))
+	c.Check(m.Cores, qt.Equals, int64(3))
+	c.Check(m.Units, qt.Equals, int64(1))
+
+	// Other models are unchanged.
+	m2 := dbmodel.Model{
+		UUID: sql.NullString{
+			String: "00000002-0000-0000-0000-000000000002",
+			Valid:  true,
+		},
+	}
+	err = j.Database.GetModel(ctx, &m2)
+	c.Assert(err, qt.IsNil)
+	c.Check(m2.Machines, qt.Equals, int64(3))
+}
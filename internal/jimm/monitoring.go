@@ -13,8 +13,11 @@ import (
 )
 
 // UpdateMetrics updates metrics for the total numbers of controllers
-// managed by JIMM as well as how many model each controller manages.
+// managed by JIMM as well as how many model each controller manages. The
+// database connection pool metrics are also updated.
 func (j *JIMM) UpdateMetrics(ctx context.Context) {
+	j.updateDBMetrics(ctx)
+
 	controllerCount := 0
 	err := j.Database.ForEachController(ctx, func(c *dbmodel.Controller) error {
 		controllerCount++
@@ -36,3 +39,17 @@ func (j *JIMM) UpdateMetrics(ctx context.Context) {
 	}
 	servermon.ControllerCount.Set(float64(controllerCount))
 }
+
+// updateDBMetrics updates the database connection pool metrics.
+func (j *JIMM) updateDBMetrics(ctx context.Context) {
+	stats, err := j.Database.Stats()
+	if err != nil {
+		zapctx.Error(ctx, "failed to get database stats", zap.Error(err))
+		return
+	}
+	servermon.DBOpenConnections.Set(float64(stats.OpenConnections))
+	servermon.DBInUseConnections.Set(float64(stats.InUse))
+	servermon.DBIdleConnections.Set(float64(stats.Idle))
+	servermon.DBMaxOpenConnections.Set(float64(stats.MaxOpenConnections))
+	servermon.DBWaitCount.Set(float64(stats.WaitCount))
+}
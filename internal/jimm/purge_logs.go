@@ -28,3 +28,36 @@ func (j *JIMM) PurgeLogs(ctx context.Context, user *openfga.User, before time.Ti
 	}
 	return count, nil
 }
+
+// auditPruneBatchSize is the maximum number of audit log entries deleted
+// in a single transaction when pruning audit events.
+const auditPruneBatchSize = 1000
+
+// PruneAuditEvents removes all audit events older than the given time,
+// deleting them in batches to avoid holding a single large transaction.
+// If an audit sink is configured any events that have not yet been
+// exported are sent to the sink first, no events are removed if this
+// fails. Only JIMM administrators can perform this operation. The number
+// of events removed is returned.
+func (j *JIMM) PruneAuditEvents(ctx context.Context, user *openfga.User, olderThan time.Time) (int64, error) {
+	const op = errors.Op("jimm.PruneAuditEvents")
+	if !user.JimmAdmin {
+		return 0, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if err := j.ExportPendingAuditLogEntries(ctx); err != nil {
+		return 0, errors.E(op, "failed to export audit events", err)
+	}
+
+	var total int64
+	for {
+		n, err := j.Database.DeleteAuditLogsBatchBefore(ctx, olderThan, auditPruneBatchSize)
+		total += n
+		if err != nil {
+			zapctx.Error(ctx, "failed to prune audit events", zap.Error(err), zap.Int64("deleted", total))
+			return total, errors.E(op, "failed to prune audit events", err)
+		}
+		if n < auditPruneBatchSize {
+			return total, nil
+		}
+	}
+}
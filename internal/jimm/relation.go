@@ -5,7 +5,9 @@ package jimm
 import (
 	"context"
 	"fmt"
+	"sort"
 
+	"github.com/juju/names/v5"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
 
@@ -74,6 +76,55 @@ func (j *JIMM) CheckRelation(ctx context.Context, user *openfga.User, tuple apip
 	return allowed, nil
 }
 
+// userRelationTargetKinds holds the kinds of entity that may be the
+// target of a CheckUserRelation request.
+var userRelationTargetKinds = map[openfga.Kind]bool{
+	openfga.ModelType:            true,
+	openfga.ControllerType:       true,
+	openfga.ApplicationOfferType: true,
+	openfga.GroupType:            true,
+	openfga.CloudType:            true,
+}
+
+// CheckUserRelation checks whether the user with the given tag has the
+// given relation to the target entity, which must be a model,
+// controller, application offer, group or cloud. The returned trace
+// starts with the check performed, followed by each relation stored
+// against the target that applies to the user, see
+// openfga.OFGAClient.CheckRelationWithTrace. Only JIMM administrators
+// may call this method.
+func (j *JIMM) CheckUserRelation(ctx context.Context, user *openfga.User, ut names.UserTag, relation, target string) (_ bool, trace []string, err error) {
+	const op = errors.Op("jimm.CheckUserRelation")
+
+	if !user.JimmAdmin {
+		return false, nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	parsedTuple, err := j.parseTuple(ctx, apiparams.RelationshipTuple{
+		Object:       ut.String(),
+		Relation:     relation,
+		TargetObject: target,
+	})
+	if err != nil {
+		return false, nil, errors.E(op, err)
+	}
+	if !userRelationTargetKinds[parsedTuple.Target.Kind] {
+		return false, nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("cannot check relations on %s entities", parsedTuple.Target.Kind))
+	}
+
+	allowed, relations, err := j.OpenFGAClient.CheckRelationWithTrace(ctx, *parsedTuple)
+	if err != nil {
+		return false, nil, errors.E(op, errors.CodeOpenFGARequestFailed, err)
+	}
+
+	trace = append(trace, fmt.Sprintf("checked %s %s %s", parsedTuple.Object, parsedTuple.Relation, parsedTuple.Target))
+	if len(relations) == 0 {
+		trace = append(trace, "no relations to the target apply to the user")
+	}
+	trace = append(trace, relations...)
+	return allowed, trace, nil
+}
+
 // ListRelationshipTuples checks user permission and lists relationship tuples based of tuple struct with pagination.
 // Listing filters can be relaxed: optionally exclude tuple.Relation or tuple.Object or specify only tuple.TargetObject.Kind.
 func (j *JIMM) ListRelationshipTuples(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, pageSize int32, continuationToken string) ([]openfga.Tuple, string, error) {
@@ -135,6 +186,77 @@ func (j *JIMM) ListObjectRelations(ctx context.Context, user *openfga.User, obje
 	return responseTuples, nextEntitlementToken, nil
 }
 
+// relationStatsPageSize is the number of tuples read from OpenFGA at a
+// time when counting relations.
+const relationStatsPageSize = 100
+
+// A RelationStat holds the number of relation tuples in OpenFGA with a
+// particular relation and target kind.
+type RelationStat struct {
+	// Relation is the relation of the counted tuples.
+	Relation string
+
+	// TargetKind is the kind of the target object of the counted tuples,
+	// for example "controller" or "group".
+	TargetKind string
+
+	// Count is the number of tuples.
+	Count int
+}
+
+// RelationStats returns the number of relation tuples stored in OpenFGA
+// grouped by relation and target kind. The tuples are read a page at a
+// time and are not all held in memory. The returned stats are sorted by
+// target kind and then relation. Only JIMM administrators may call this
+// method.
+func (j *JIMM) RelationStats(ctx context.Context, user *openfga.User) ([]RelationStat, error) {
+	const op = errors.Op("jimm.RelationStats")
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	type statKey struct {
+		relation   string
+		targetKind string
+	}
+	counts := make(map[statKey]int)
+	var token string
+	for {
+		tuples, next, err := j.OpenFGAClient.ReadRelatedObjects(ctx, openfga.Tuple{}, relationStatsPageSize, token)
+		if err != nil {
+			return nil, errors.E(op, errors.CodeOpenFGARequestFailed, err)
+		}
+		for _, t := range tuples {
+			var k statKey
+			k.relation = t.Relation.String()
+			if t.Target != nil {
+				k.targetKind = t.Target.Kind.String()
+			}
+			counts[k]++
+		}
+		if next == "" || next == token {
+			break
+		}
+		token = next
+	}
+
+	stats := make([]RelationStat, 0, len(counts))
+	for k, n := range counts {
+		stats = append(stats, RelationStat{
+			Relation:   k.relation,
+			TargetKind: k.targetKind,
+			Count:      n,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].TargetKind != stats[j].TargetKind {
+			return stats[i].TargetKind < stats[j].TargetKind
+		}
+		return stats[i].Relation < stats[j].Relation
+	})
+	return stats, nil
+}
+
 // parseTuples translate the api request struct containing tuples to a slice of openfga tuple keys.
 // This method utilises the parseTuple method which does all the heavy lifting.
 func (j *JIMM) parseTuples(ctx context.Context, tuples []apiparams.RelationshipTuple) ([]openfga.Tuple, error) {
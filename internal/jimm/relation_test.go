@@ -170,6 +170,171 @@ func TestListRelationshipTuples(t *testing.T) {
 	}
 }
 
+func TestRelationStats(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	u := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, ofgaClient)
+	u.JimmAdmin = true
+
+	user, group, controller, model, _, _, _ := createTestControllerEnvironment(ctx, c, j.Database)
+
+	err = j.AddRelation(ctx, u, []apiparams.RelationshipTuple{{
+		Object:       user.Tag().String(),
+		Relation:     names.MemberRelation.String(),
+		TargetObject: group.ResourceTag().String(),
+	}, {
+		Object:       user.Tag().String(),
+		Relation:     names.AdministratorRelation.String(),
+		TargetObject: controller.ResourceTag().String(),
+	}, {
+		Object:       group.ResourceTag().String() + "#member",
+		Relation:     names.AdministratorRelation.String(),
+		TargetObject: controller.ResourceTag().String(),
+	}, {
+		Object:       user.Tag().String(),
+		Relation:     names.ReaderRelation.String(),
+		TargetObject: model.ResourceTag().String(),
+	}, {
+		Object:       user.Tag().String(),
+		Relation:     names.WriterRelation.String(),
+		TargetObject: model.ResourceTag().String(),
+	}})
+	c.Assert(err, qt.IsNil)
+
+	stats, err := j.RelationStats(ctx, u)
+	c.Assert(err, qt.IsNil)
+	c.Check(stats, qt.DeepEquals, []jimm.RelationStat{{
+		Relation:   "administrator",
+		TargetKind: "controller",
+		Count:      2,
+	}, {
+		Relation:   "member",
+		TargetKind: "group",
+		Count:      1,
+	}, {
+		Relation:   "reader",
+		TargetKind: "model",
+		Count:      1,
+	}, {
+		Relation:   "writer",
+		TargetKind: "model",
+		Count:      1,
+	}})
+
+	_, err = j.RelationStats(ctx, openfga.NewUser(&user, ofgaClient))
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+}
+
+func TestCheckUserRelation(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, ofgaClient)
+	admin.JimmAdmin = true
+
+	user, group, controller, model, _, _, _ := createTestControllerEnvironment(ctx, c, j.Database)
+	err = j.AddRelation(ctx, admin, []apiparams.RelationshipTuple{{
+		Object:       user.Tag().String(),
+		Relation:     names.WriterRelation.String(),
+		TargetObject: model.ResourceTag().String(),
+	}})
+	c.Assert(err, qt.IsNil)
+	err = ofgaClient.AddRelation(ctx, openfga.Tuple{
+		Object:   names.ConvertTag(user.ResourceTag()),
+		Relation: names.MemberRelation,
+		Target:   names.ConvertTag(group.ResourceTag()),
+	}, openfga.Tuple{
+		Object:   names.ConvertTagWithRelation(group.ResourceTag(), names.MemberRelation),
+		Relation: names.ReaderRelation,
+		Target:   names.ConvertTag(model.ResourceTag()),
+	}, openfga.Tuple{
+		Object:   names.ConvertTag(controller.ResourceTag()),
+		Relation: names.ControllerRelation,
+		Target:   names.ConvertTag(model.ResourceTag()),
+	})
+	c.Assert(err, qt.IsNil)
+
+	userTag := "user:" + user.Name
+	modelTag := "model:" + model.UUID.String
+	groupTag := "group:" + group.UUID
+	controllerTag := "controller:" + controller.UUID
+
+	// writer implies reader.
+	allowed, trace, err := j.CheckUserRelation(ctx, admin, user.ResourceTag(), "reader", model.ResourceTag().String())
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsTrue)
+	c.Assert(trace, qt.Not(qt.HasLen), 0)
+	c.Check(trace[0], qt.Equals, "checked "+userTag+" reader "+modelTag)
+	c.Check(trace[1:], qt.ContentEquals, []string{
+		userTag + " writer " + modelTag + " (direct)",
+		groupTag + "#member reader " + modelTag + " (member " + groupTag + ")",
+	})
+
+	allowed, _, err = j.CheckUserRelation(ctx, admin, user.ResourceTag(), "administrator", model.ResourceTag().String())
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsFalse)
+
+	// Administrators of the controller administer the model.
+	err = ofgaClient.AddRelation(ctx, openfga.Tuple{
+		Object:   names.ConvertTag(user.ResourceTag()),
+		Relation: names.AdministratorRelation,
+		Target:   names.ConvertTag(controller.ResourceTag()),
+	})
+	c.Assert(err, qt.IsNil)
+	allowed, trace, err = j.CheckUserRelation(ctx, admin, user.ResourceTag(), "administrator", model.ResourceTag().String())
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsTrue)
+	c.Check(trace[1:], qt.ContentEquals, []string{
+		userTag + " writer " + modelTag + " (direct)",
+		groupTag + "#member reader " + modelTag + " (member " + groupTag + ")",
+		controllerTag + " controller " + modelTag + " (administrator " + controllerTag + ")",
+	})
+
+	allowed, trace, err = j.CheckUserRelation(ctx, admin, dbmodel.Identity{Name: "bob@canonical.com"}.ResourceTag(), "reader", model.ResourceTag().String())
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsFalse)
+	c.Check(trace, qt.DeepEquals, []string{
+		"checked user:bob@canonical.com reader " + modelTag,
+		"no relations to the target apply to the user",
+	})
+
+	_, _, err = j.CheckUserRelation(ctx, admin, user.ResourceTag(), "member", user.Tag().String())
+	c.Check(err, qt.ErrorMatches, `cannot check relations on user entities`)
+
+	// Only JIMM administrators may check relations of other users.
+	u := openfga.NewUser(&user, ofgaClient)
+	_, _, err = j.CheckUserRelation(ctx, u, user.ResourceTag(), "administrator", controller.ResourceTag().String())
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+}
+
 func TestListObjectRelations(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
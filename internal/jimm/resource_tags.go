@@ -0,0 +1,150 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// resourceTagsConfigKey is the model config key holding the tags juju
+// applies to the cloud resources (instances, volumes, etc.) of a model.
+const resourceTagsConfigKey = "resource-tags"
+
+// jujuResourceTagPrefix is the prefix of resource tags reserved for use
+// by juju itself.
+const jujuResourceTagPrefix = "juju-"
+
+// resourceTagLimits holds the constraints a cloud provider places on the
+// tags of its resources.
+type resourceTagLimits struct {
+	maxKeyLength     int
+	maxValueLength   int
+	reservedPrefixes []string
+	invalidChars     string
+	keyPattern       *regexp.Regexp
+	valuePattern     *regexp.Regexp
+}
+
+// resourceTagProviderLimits holds the known resource tag constraints,
+// keyed by cloud provider type. Providers that are not listed only have
+// the constraints juju itself imposes applied.
+var resourceTagProviderLimits = map[string]resourceTagLimits{
+	"ec2": {
+		maxKeyLength:     128,
+		maxValueLength:   256,
+		reservedPrefixes: []string{"aws:"},
+	},
+	"azure": {
+		maxKeyLength:   512,
+		maxValueLength: 256,
+		invalidChars:   `<>%&\?/`,
+	},
+	"gce": {
+		maxKeyLength:   63,
+		maxValueLength: 63,
+		keyPattern:     regexp.MustCompile(`^[a-z][a-z0-9_-]*$`),
+		valuePattern:   regexp.MustCompile(`^[a-z0-9_-]*$`),
+	},
+}
+
+// checkResourceTags returns an error with a code of CodeBadRequest if any
+// of the given tags cannot be applied to the resources of a cloud with
+// the given provider type.
+func checkResourceTags(providerType string, tags map[string]string) error {
+	limits := resourceTagProviderLimits[providerType]
+	for _, k := range sortedKeys(tags) {
+		v := tags[k]
+		if err := checkResourceTag(limits, k, v); err != nil {
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid resource tag %q: %s", k, err))
+		}
+	}
+	return nil
+}
+
+func checkResourceTag(limits resourceTagLimits, key, value string) error {
+	if key == "" {
+		return errors.E("key cannot be empty")
+	}
+	if strings.ContainsAny(key, "= \t\n") {
+		return errors.E("key cannot contain whitespace or '='")
+	}
+	if strings.ContainsAny(value, " \t\n") {
+		return errors.E("value cannot contain whitespace")
+	}
+	if strings.HasPrefix(key, jujuResourceTagPrefix) {
+		return errors.E(fmt.Sprintf("prefix %q is reserved", jujuResourceTagPrefix))
+	}
+	for _, prefix := range limits.reservedPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return errors.E(fmt.Sprintf("prefix %q is reserved", prefix))
+		}
+	}
+	if limits.maxKeyLength > 0 && len(key) > limits.maxKeyLength {
+		return errors.E(fmt.Sprintf("key longer than %d characters", limits.maxKeyLength))
+	}
+	if limits.maxValueLength > 0 && len(value) > limits.maxValueLength {
+		return errors.E(fmt.Sprintf("value longer than %d characters", limits.maxValueLength))
+	}
+	if limits.invalidChars != "" && strings.ContainsAny(key+value, limits.invalidChars) {
+		return errors.E(fmt.Sprintf("tags cannot contain any of %q", limits.invalidChars))
+	}
+	if limits.keyPattern != nil && !limits.keyPattern.MatchString(key) {
+		return errors.E(fmt.Sprintf("key does not match %s", limits.keyPattern))
+	}
+	if limits.valuePattern != nil && !limits.valuePattern.MatchString(value) {
+		return errors.E(fmt.Sprintf("value does not match %s", limits.valuePattern))
+	}
+	return nil
+}
+
+// parseResourceTags parses a resource-tags model config value, which may
+// either be a string of space separated key=value pairs or a map.
+func parseResourceTags(v interface{}) (map[string]string, error) {
+	tags := make(map[string]string)
+	switch t := v.(type) {
+	case nil:
+	case string:
+		for _, pair := range strings.Fields(t) {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid resource-tags %q: expected key=value", t))
+			}
+			tags[key] = value
+		}
+	case map[string]string:
+		for key, value := range t {
+			tags[key] = value
+		}
+	case map[string]interface{}:
+		for key, value := range t {
+			tags[key] = fmt.Sprint(value)
+		}
+	default:
+		return nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid resource-tags %v", v))
+	}
+	return tags, nil
+}
+
+// formatResourceTags formats the given tags as a resource-tags model
+// config value.
+func formatResourceTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for _, k := range sortedKeys(tags) {
+		pairs = append(pairs, k+"="+tags[k])
+	}
+	return strings.Join(pairs, " ")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+)
+
+var checkResourceTagsTests = []struct {
+	name          string
+	providerType  string
+	tags          map[string]string
+	expectedError string
+}{{
+	name:         "ValidTags",
+	providerType: "ec2",
+	tags: map[string]string{
+		"cost-center": "1234",
+		"team":        "platform",
+	},
+}, {
+	name:         "WhitespaceInValue",
+	providerType: "test-provider",
+	tags: map[string]string{
+		"Cost:Center": "Team A/B",
+	},
+	expectedError: `invalid resource tag "Cost:Center": value cannot contain whitespace`,
+}, {
+	name:         "JujuPrefix",
+	providerType: "ec2",
+	tags: map[string]string{
+		"juju-model-uuid": "1234",
+	},
+	expectedError: `invalid resource tag "juju-model-uuid": prefix "juju-" is reserved`,
+}, {
+	name:         "KeyContainsEquals",
+	providerType: "ec2",
+	tags: map[string]string{
+		"a=b": "c",
+	},
+	expectedError: `invalid resource tag "a=b": key cannot contain whitespace or '='`,
+}, {
+	name:         "EC2ReservedPrefix",
+	providerType: "ec2",
+	tags: map[string]string{
+		"aws:cost-center": "1234",
+	},
+	expectedError: `invalid resource tag "aws:cost-center": prefix "aws:" is reserved`,
+}, {
+	name:         "AzureInvalidCharacter",
+	providerType: "azure",
+	tags: map[string]string{
+		"cost/center": "1234",
+	},
+	expectedError: `invalid resource tag "cost/center": tags cannot contain any of .*`,
+}, {
+	name:         "GCEUppercaseKey",
+	providerType: "gce",
+	tags: map[string]string{
+		"CostCenter": "1234",
+	},
+	expectedError: `invalid resource tag "CostCenter": key does not match .*`,
+}, {
+	name:         "GCEValueTooLong",
+	providerType: "gce",
+	tags: map[string]string{
+		"cost-center": "0123456789012345678901234567890123456789012345678901234567890123",
+	},
+	expectedError: `invalid resource tag "cost-center": value longer than 63 characters`,
+}}
+
+func TestCheckResourceTags(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range checkResourceTagsTests {
+		c.Run(test.name, func(c *qt.C) {
+			err := jimm.CheckResourceTags(test.providerType, test.tags)
+			if test.expectedError == "" {
+				c.Check(err, qt.IsNil)
+				return
+			}
+			c.Check(err, qt.ErrorMatches, test.expectedError)
+			c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+		})
+	}
+}
@@ -2,7 +2,14 @@
 
 package jimm
 
-import "sync"
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+)
 
 // A runner ensures that only a single instance of a function, identified
 // by a key, is running.
@@ -43,3 +50,22 @@ func (r *runner) run(key string, f func()) {
 func (r *runner) wait() {
 	r.wg.Wait()
 }
+
+// runPeriodically calls f immediately and then again at the given
+// interval until the given context is canceled. Errors returned by f are
+// logged with the given message and do not stop the loop. runPeriodically
+// always returns the context's error.
+func runPeriodically(ctx context.Context, interval time.Duration, msg string, f func(context.Context) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := f(ctx); err != nil {
+			zapctx.Error(ctx, msg, zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
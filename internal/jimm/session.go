@@ -0,0 +1,218 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// A ModelSession describes an active websocket connection to a model
+// that JIMM is proxying to the model's controller.
+type ModelSession struct {
+	// ID uniquely identifies the session.
+	ID string
+
+	// Identity holds the name of the identity that authenticated on the
+	// session. This is empty if the client has not yet logged in.
+	Identity string
+
+	// ModelUUID holds the UUID of the model the session is connected
+	// to.
+	ModelUUID string
+
+	// StartTime holds the time the session was started.
+	StartTime time.Time
+
+	// ClientIP holds the address of the client that made the
+	// connection.
+	ClientIP string
+}
+
+// trackedSession holds an active model session along with the functions
+// used to determine its identity and to close it.
+type trackedSession struct {
+	seq      uint64
+	session  ModelSession
+	identity func() names.UserTag
+	cancel   func()
+}
+
+// sessionTracker holds the set of active model sessions.
+type sessionTracker struct {
+	mu       sync.Mutex
+	seq      uint64
+	sessions map[string]*trackedSession
+
+	// draining is set once the sessions are being drained, after which
+	// no new sessions are accepted. drained is closed once the last
+	// session has finished while draining.
+	draining bool
+	drained  chan struct{}
+}
+
+// removeLocked stops tracking the session with the given ID, the caller
+// must hold t.mu.
+func (t *sessionTracker) removeLocked(id string) {
+	delete(t.sessions, id)
+	if t.draining && len(t.sessions) == 0 && t.drained != nil {
+		close(t.drained)
+		t.drained = nil
+	}
+}
+
+// TrackModelSession records an active model session for the model with
+// the given UUID. The identity function is called whenever the session
+// is listed to determine the authenticated identity, it should return
+// an empty tag until the client has logged in. The cancel function is
+// called to close the connection if the session is revoked. The
+// returned function must be called when the connection closes to stop
+// tracking the session. If the sessions are being drained the session is
+// not tracked and the cancel function is called immediately.
+func (j *JIMM) TrackModelSession(modelUUID, clientIP string, identity func() names.UserTag, cancel func()) (string, func()) {
+	ts := &trackedSession{
+		session: ModelSession{
+			ID:        uuid.NewString(),
+			ModelUUID: modelUUID,
+			StartTime: time.Now().UTC().Round(time.Millisecond),
+			ClientIP:  clientIP,
+		},
+		identity: identity,
+		cancel:   cancel,
+	}
+
+	j.sessions.mu.Lock()
+	defer j.sessions.mu.Unlock()
+	if j.sessions.draining {
+		if cancel != nil {
+			cancel()
+		}
+		return ts.session.ID, func() {}
+	}
+	if j.sessions.sessions == nil {
+		j.sessions.sessions = make(map[string]*trackedSession)
+	}
+	j.sessions.seq++
+	ts.seq = j.sessions.seq
+	j.sessions.sessions[ts.session.ID] = ts
+
+	return ts.session.ID, func() {
+		j.sessions.mu.Lock()
+		defer j.sessions.mu.Unlock()
+		j.sessions.removeLocked(ts.session.ID)
+	}
+}
+
+// DrainModelSessions stops any new model sessions from being accepted
+// and waits for the active sessions to finish. If there are still active
+// sessions when the given context is done they are forcibly closed.
+// DrainModelSessions returns the number of sessions that were forcibly
+// closed. It is intended to be called when JIMM is shutting down, once
+// the server has stopped accepting new connections.
+func (j *JIMM) DrainModelSessions(ctx context.Context) int {
+	j.sessions.mu.Lock()
+	j.sessions.draining = true
+	var drained chan struct{}
+	if len(j.sessions.sessions) > 0 {
+		if j.sessions.drained == nil {
+			j.sessions.drained = make(chan struct{})
+		}
+		drained = j.sessions.drained
+	}
+	j.sessions.mu.Unlock()
+
+	if drained == nil {
+		return 0
+	}
+	zapctx.Info(ctx, "waiting for model sessions to finish")
+	select {
+	case <-drained:
+		return 0
+	case <-ctx.Done():
+	}
+
+	j.sessions.mu.Lock()
+	remaining := make([]*trackedSession, 0, len(j.sessions.sessions))
+	for id, ts := range j.sessions.sessions {
+		remaining = append(remaining, ts)
+		j.sessions.removeLocked(id)
+	}
+	j.sessions.mu.Unlock()
+
+	zapctx.Warn(ctx, "closing model sessions that did not finish", zap.Int("count", len(remaining)))
+	for _, ts := range remaining {
+		if ts.cancel != nil {
+			ts.cancel()
+		}
+	}
+	return len(remaining)
+}
+
+// ListActiveSessions returns the model sessions that are currently
+// active, ordered by start time. Only JIMM administrators may list
+// sessions.
+func (j *JIMM) ListActiveSessions(ctx context.Context, user *openfga.User) ([]ModelSession, error) {
+	const op = errors.Op("jimm.ListActiveSessions")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	j.sessions.mu.Lock()
+	tracked := make([]*trackedSession, 0, len(j.sessions.sessions))
+	for _, ts := range j.sessions.sessions {
+		tracked = append(tracked, ts)
+	}
+	j.sessions.mu.Unlock()
+
+	sort.Slice(tracked, func(i, k int) bool {
+		return tracked[i].seq < tracked[k].seq
+	})
+	sessions := make([]ModelSession, len(tracked))
+	for i, ts := range tracked {
+		sessions[i] = ts.session
+		if ts.identity != nil {
+			sessions[i].Identity = ts.identity().Id()
+		}
+	}
+	return sessions, nil
+}
+
+// RevokeSession forcibly closes the active model session with the given
+// ID. Only JIMM administrators may revoke sessions. If there is no
+// active session with the given ID an error with a code of CodeNotFound
+// is returned.
+func (j *JIMM) RevokeSession(ctx context.Context, user *openfga.User, id string) error {
+	const op = errors.Op("jimm.RevokeSession")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	j.sessions.mu.Lock()
+	ts, ok := j.sessions.sessions[id]
+	if ok {
+		j.sessions.removeLocked(id)
+	}
+	j.sessions.mu.Unlock()
+	if !ok {
+		return errors.E(op, errors.CodeNotFound, fmt.Sprintf("session %q not found", id))
+	}
+
+	zapctx.Info(ctx, "revoking model session", zap.String("session", id), zap.String("model", ts.session.ModelUUID))
+	if ts.cancel != nil {
+		ts.cancel()
+	}
+	return nil
+}
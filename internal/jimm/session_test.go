@@ -0,0 +1,144 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestModelSessions(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j := &jimm.JIMM{}
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, nil)
+	admin.JimmAdmin = true
+	bob := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, nil)
+
+	identity := names.UserTag{}
+	cancelled := false
+	id1, untrack1 := j.TrackModelSession("00000002-0000-0000-0000-000000000001", "10.0.0.1", func() names.UserTag {
+		return identity
+	}, func() {
+		cancelled = true
+	})
+	id2, untrack2 := j.TrackModelSession("00000002-0000-0000-0000-000000000002", "10.0.0.2", nil, nil)
+	defer untrack2()
+
+	sessions, err := j.ListActiveSessions(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	c.Assert(sessions, qt.HasLen, 2)
+	c.Check(sessions[0].ID, qt.Equals, id1)
+	c.Check(sessions[0].Identity, qt.Equals, "")
+	c.Check(sessions[0].ModelUUID, qt.Equals, "00000002-0000-0000-0000-000000000001")
+	c.Check(sessions[0].ClientIP, qt.Equals, "10.0.0.1")
+	c.Check(sessions[1].ID, qt.Equals, id2)
+
+	// The identity is filled in once the client has logged in.
+	identity = names.NewUserTag("charlie@canonical.com")
+	sessions, err = j.ListActiveSessions(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	c.Check(sessions[0].Identity, qt.Equals, "charlie@canonical.com")
+
+	_, err = j.ListActiveSessions(ctx, bob)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	err = j.RevokeSession(ctx, bob, id1)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+	c.Check(cancelled, qt.IsFalse)
+
+	err = j.RevokeSession(ctx, admin, id1)
+	c.Assert(err, qt.IsNil)
+	c.Check(cancelled, qt.IsTrue)
+	untrack1()
+
+	err = j.RevokeSession(ctx, admin, id1)
+	c.Check(err, qt.ErrorMatches, `session ".*" not found`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	sessions, err = j.ListActiveSessions(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	c.Assert(sessions, qt.HasLen, 1)
+	c.Check(sessions[0].ID, qt.Equals, id2)
+}
+
+func TestDrainModelSessions(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j := &jimm.JIMM{}
+	closing := make(chan struct{})
+	_, untrack1 := j.TrackModelSession("00000002-0000-0000-0000-000000000001", "10.0.0.1", nil, func() {
+		c.Error("finishing session unexpectedly cancelled")
+	})
+	go func() {
+		<-closing
+		untrack1()
+	}()
+	var cancelled2 atomic.Bool
+	_, untrack2 := j.TrackModelSession("00000002-0000-0000-0000-000000000002", "10.0.0.2", nil, func() {
+		cancelled2.Store(true)
+	})
+	defer untrack2()
+
+	done := make(chan int)
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+		defer cancel()
+		done <- j.DrainModelSessions(ctx)
+	}()
+
+	// The first connection closes within the drain window.
+	close(closing)
+
+	select {
+	case n := <-done:
+		c.Check(n, qt.Equals, 1)
+	case <-time.After(5 * time.Second):
+		c.Fatal("timeout waiting for sessions to drain")
+	}
+	c.Check(cancelled2.Load(), qt.IsTrue)
+
+	// New sessions are not accepted once draining has started.
+	var cancelled3 atomic.Bool
+	_, untrack3 := j.TrackModelSession("00000002-0000-0000-0000-000000000003", "10.0.0.3", nil, func() {
+		cancelled3.Store(true)
+	})
+	defer untrack3()
+	c.Check(cancelled3.Load(), qt.IsTrue)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, nil)
+	admin.JimmAdmin = true
+	sessions, err := j.ListActiveSessions(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	c.Check(sessions, qt.HasLen, 0)
+}
+
+func TestDrainModelSessionsFinished(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j := &jimm.JIMM{}
+	_, untrack := j.TrackModelSession("00000002-0000-0000-0000-000000000001", "10.0.0.1", nil, func() {
+		c.Error("session unexpectedly cancelled")
+	})
+	time.AfterFunc(10*time.Millisecond, untrack)
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	c.Check(j.DrainModelSessions(ctx), qt.Equals, 0)
+	c.Check(ctx.Err(), qt.IsNil)
+}
@@ -54,7 +54,10 @@ func (j *JIMM) getControllerByName(ctx context.Context, controllerName string) (
 	controller := dbmodel.Controller{Name: controllerName}
 	err := j.Database.GetController(ctx, &controller)
 	if err != nil {
-		return nil, errors.E(errors.CodeNotFound, "controller not found")
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(errors.CodeControllerNotFound, "controller not found")
+		}
+		return nil, errors.E(err)
 	}
 	return &controller, nil
 }
@@ -175,10 +175,28 @@ type modelState struct {
 	// the number of cores reported.
 	machines map[string]int64
 
+	// applications stores the names of all applications that have been
+	// seen.
+	applications map[string]bool
+
 	// units stores the ids of all units that have been seen.
 	units map[string]bool
 }
 
+// applicationUnits returns the number of units of each application that
+// have been seen.
+func (s *modelState) applicationUnits() map[string]int64 {
+	units := make(map[string]int64)
+	for id := range s.units {
+		app, err := names.UnitApplication(id)
+		if err != nil {
+			continue
+		}
+		units[app]++
+	}
+	return units
+}
+
 func (w *Watcher) checkControllerModels(ctx context.Context, ctl *dbmodel.Controller, checks ...func(*dbmodel.Model) error) (map[string]*modelState, error) {
 	const op = errors.Op("jimm.checkControllerModels")
 
@@ -200,9 +218,10 @@ func (w *Watcher) checkControllerModels(ctx context.Context, ctl *dbmodel.Contro
 			}
 		}
 		modelStates[m.UUID.String] = &modelState{
-			id:       m.ID,
-			machines: make(map[string]int64),
-			units:    make(map[string]bool),
+			id:           m.ID,
+			machines:     make(map[string]int64),
+			applications: make(map[string]bool),
+			units:        make(map[string]bool),
 		}
 		return nil
 	})
@@ -293,9 +312,10 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 		switch {
 		case err == nil:
 			st := modelState{
-				id:       m.ID,
-				machines: make(map[string]int64),
-				units:    make(map[string]bool),
+				id:           m.ID,
+				machines:     make(map[string]int64),
+				applications: make(map[string]bool),
+				units:        make(map[string]bool),
 			}
 			modelStates[uuid] = &st
 		case errors.ErrorCode(err) == errors.CodeNotFound:
@@ -331,27 +351,7 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 			if v.changed {
 				v.changed = false
 				// Update changed model.
-				err := w.Database.Transaction(func(tx *db.Database) error {
-					m := dbmodel.Model{
-						ID: v.id,
-					}
-					if err := tx.GetModel(ctx, &m); err != nil {
-						return err
-					}
-					var machines, cores int64
-					for _, n := range v.machines {
-						machines++
-						cores += n
-					}
-					m.Cores = cores
-					m.Machines = machines
-					m.Units = int64(len(v.units))
-					if err := tx.UpdateModel(ctx, &m); err != nil {
-						return err
-					}
-					return nil
-				})
-				if err != nil {
+				if err := w.storeModelState(ctx, v); err != nil {
 					zapctx.Error(ctx, "cannot get model for update", zap.Error(err))
 					continue
 				}
@@ -360,26 +360,130 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 	}
 }
 
+// storeModelState updates the machine, core and unit counts stored for
+// the model and its applications to match the given state, and removes
+// any stored machines and applications that are no longer in the model.
+func (w *Watcher) storeModelState(ctx context.Context, v *modelState) error {
+	return retryModelUpdate(func() error {
+		return w.Database.Transaction(func(tx *db.Database) error {
+			m := dbmodel.Model{
+				ID: v.id,
+			}
+			if err := tx.GetModel(ctx, &m); err != nil {
+				return err
+			}
+			stored, err := tx.GetModelMachines(ctx, &m)
+			if err != nil {
+				return err
+			}
+			for i := range stored {
+				if _, ok := v.machines[stored[i].MachineID]; ok {
+					continue
+				}
+				if err := tx.DeleteMachine(ctx, &stored[i]); err != nil {
+					return err
+				}
+			}
+			apps, err := tx.GetModelApplications(ctx, &m)
+			if err != nil {
+				return err
+			}
+			units := v.applicationUnits()
+			for i := range apps {
+				if !v.applications[apps[i].Name] {
+					if err := tx.DeleteApplication(ctx, &apps[i]); err != nil {
+						return err
+					}
+					continue
+				}
+				if apps[i].Units == units[apps[i].Name] {
+					continue
+				}
+				apps[i].Units = units[apps[i].Name]
+				if err := tx.UpsertApplication(ctx, &apps[i]); err != nil {
+					return err
+				}
+			}
+			var machines, cores int64
+			for _, n := range v.machines {
+				machines++
+				cores += n
+			}
+			m.Cores = cores
+			m.Machines = machines
+			m.Units = int64(len(v.units))
+			if err := tx.UpdateModel(ctx, &m); err != nil {
+				return err
+			}
+			return nil
+		})
+	})
+}
+
+// modelSummaryWatcherDelay is the delay before the first restart of a
+// failed model summary watcher, the delay doubles after every subsequent
+// failure up to maxModelSummaryWatcherDelay.
+var modelSummaryWatcherDelay = time.Second
+
+// maxModelSummaryWatcherDelay is the maximum delay between restarts of a
+// failed model summary watcher.
+const maxModelSummaryWatcherDelay = time.Minute
+
 // watchAllModelSummaries connects to the given controller and watches the
-// summary updates.
+// summary updates. If the model summary watcher fails it is restarted
+// with an exponential backoff until the context is closed. An error is
+// returned if the controller cannot be contacted or does not support
+// model summary watchers.
 func (w *Watcher) watchAllModelSummaries(ctx context.Context, ctl *dbmodel.Controller) error {
 	const op = errors.Op("jimm.watchAllModelSummaries")
 
-	// connect to the controller
-	api, err := w.dialController(ctx, ctl)
-	if err != nil {
-		return errors.E(op, err)
+	delay := modelSummaryWatcherDelay
+	for {
+		// connect to the controller
+		api, err := w.dialController(ctx, ctl)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		received, err := w.runModelSummaryWatcher(ctx, ctl, api)
+		api.Close()
+		if ctx.Err() != nil {
+			return errors.E(op, ctx.Err(), "context cancelled")
+		}
+		if errors.ErrorCode(err) == errors.CodeNotSupported {
+			return errors.E(op, err)
+		}
+		if received {
+			// The watcher was working, so start the backoff again.
+			delay = modelSummaryWatcherDelay
+		}
+		zapctx.Warn(ctx, "restarting model summary watcher", zap.Duration("delay", delay), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return errors.E(op, ctx.Err(), "context cancelled")
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > maxModelSummaryWatcherDelay {
+			delay = maxModelSummaryWatcherDelay
+		}
 	}
-	defer api.Close()
+}
+
+// runModelSummaryWatcher starts a model summary watcher on the given
+// controller connection and publishes the summaries it receives until
+// the watcher fails. The returned bool reports whether any summaries were
+// received before the failure.
+func (w *Watcher) runModelSummaryWatcher(ctx context.Context, ctl *dbmodel.Controller, api API) (received bool, _ error) {
+	const op = errors.Op("jimm.runModelSummaryWatcher")
 
 	if !api.SupportsModelSummaryWatcher() {
-		return errors.E(op, errors.CodeNotSupported)
+		return false, errors.E(op, errors.CodeNotSupported)
 	}
 
 	// start the model summary watcher
 	id, err := api.WatchAllModelSummaries(ctx)
 	if err != nil {
-		return errors.E(op, err)
+		return false, errors.E(op, err)
 	}
 	defer func() {
 		if err := api.ModelSummaryWatcherStop(ctx, id); err != nil {
@@ -391,7 +495,7 @@ func (w *Watcher) watchAllModelSummaries(ctx context.Context, ctl *dbmodel.Contr
 	// controller that JIMM is interested in.
 	modelStates, err := w.checkControllerModels(ctx, ctl)
 	if err != nil {
-		return errors.E(op, err)
+		return false, errors.E(op, err)
 	}
 
 	modelIDf := func(uuid string) uint {
@@ -420,14 +524,15 @@ func (w *Watcher) watchAllModelSummaries(ctx context.Context, ctl *dbmodel.Contr
 	for {
 		select {
 		case <-ctx.Done():
-			return errors.E(op, ctx.Err(), "context cancelled")
+			return received, errors.E(op, ctx.Err(), "context cancelled")
 		default:
 		}
 		// wait for updates from the all model summary watcher.
 		modelSummaries, err := api.ModelSummaryWatcherNext(ctx, id)
 		if err != nil {
-			return errors.E(op, err)
+			return received, errors.E(op, err)
 		}
+		received = true
 		// Sanitize the model abstracts.
 		for _, summary := range modelSummaries {
 			modelID := modelIDf(summary.UUID)
@@ -460,9 +565,17 @@ func (w *Watcher) handleDelta(ctx context.Context, modelIDf func(string) *modelS
 	switch eid.Kind {
 	case "application":
 		if d.Removed {
+			state.changed = true
+			delete(state.applications, eid.Id)
 			return nil
 		}
-		return w.updateApplication(ctx, state.id, d.Entity.(*jujuparams.ApplicationInfo))
+		app := d.Entity.(*jujuparams.ApplicationInfo)
+		w.storeApplication(ctx, state, app)
+		if !state.applications[eid.Id] {
+			state.changed = true
+			state.applications[eid.Id] = true
+		}
+		return w.updateApplication(ctx, state.id, app)
 	case "machine":
 		if d.Removed {
 			state.changed = true
@@ -471,6 +584,7 @@ func (w *Watcher) handleDelta(ctx context.Context, modelIDf func(string) *modelS
 		}
 		var cores int64
 		machine := d.Entity.(*jujuparams.MachineInfo)
+		w.updateMachine(ctx, state.id, machine)
 		if machine.HardwareCharacteristics != nil && machine.HardwareCharacteristics.CpuCores != nil {
 			//nolint:gosec // We expect cpu cores to fit into int64.
 			cores = int64(*machine.HardwareCharacteristics.CpuCores)
@@ -526,14 +640,20 @@ func (w *Watcher) deleteModel(ctx context.Context, model *dbmodel.Model) error {
 func (w *Watcher) updateModel(ctx context.Context, model *dbmodel.Model, info *jujuparams.ModelUpdate) error {
 	const op = errors.Op("watcher.updateModel")
 
-	err := w.Database.Transaction(func(db *db.Database) error {
-		if err := db.GetModel(ctx, model); err != nil {
-			if errors.ErrorCode(err) != errors.CodeNotFound {
-				return err
+	err := retryModelUpdate(func() error {
+		return w.Database.Transaction(func(db *db.Database) error {
+			if err := db.GetModel(ctx, model); err != nil {
+				if errors.ErrorCode(err) != errors.CodeNotFound {
+					return err
+				}
 			}
-		}
-		model.FromJujuModelUpdate(*info)
-		return db.UpdateModel(ctx, model)
+			life := model.Life
+			model.FromJujuModelUpdate(*info)
+			newLife := model.Life
+			model.Life = life
+			setModelLife(model, newLife)
+			return db.UpdateModel(ctx, model)
+		})
 	})
 	if err != nil {
 		return errors.E(op, err)
@@ -541,6 +661,33 @@ func (w *Watcher) updateModel(ctx context.Context, model *dbmodel.Model, info *j
 	return nil
 }
 
+// updateMachine stores the machine reported in a delta. Errors are
+// logged rather than returned so that a machine that cannot be stored
+// does not stop the model being watched.
+func (w *Watcher) updateMachine(ctx context.Context, modelID uint, info *jujuparams.MachineInfo) {
+	m := dbmodel.Machine{
+		ModelID: modelID,
+	}
+	m.FromJujuMachineInfo(*info)
+	if err := w.Database.UpsertMachine(ctx, &m); err != nil {
+		zapctx.Error(ctx, "error updating machine", zap.String("machine", info.Id), zap.Error(err))
+	}
+}
+
+// storeApplication stores the application reported in a delta. Errors
+// are logged rather than returned so that an application that cannot be
+// stored does not stop the model being watched.
+func (w *Watcher) storeApplication(ctx context.Context, state *modelState, info *jujuparams.ApplicationInfo) {
+	a := dbmodel.Application{
+		ModelID: state.id,
+		Units:   state.applicationUnits()[info.Name],
+	}
+	a.FromJujuApplicationInfo(*info)
+	if err := w.Database.UpsertApplication(ctx, &a); err != nil {
+		zapctx.Error(ctx, "error storing application", zap.String("application", info.Name), zap.Error(err))
+	}
+}
+
 func (w *Watcher) updateApplication(ctx context.Context, modelID uint, info *jujuparams.ApplicationInfo) error {
 	err := w.Database.Transaction(func(tx *db.Database) error {
 		m := dbmodel.Model{
@@ -568,3 +715,23 @@ func (w *Watcher) updateApplication(ctx context.Context, modelID uint, info *juj
 	}
 	return nil
 }
+
+// maxModelUpdateAttempts is the number of times JIMM attempts to store a
+// model change that conflicts with a concurrent update of the model.
+const maxModelUpdateAttempts = 3
+
+// retryModelUpdate calls f until it returns an error that is not a
+// conflict, or maxModelUpdateAttempts attempts have been made. f must
+// re-read the model and reapply the change to it on every attempt. Only
+// the Watcher retries model updates, as the state it stores is reported
+// by the controller and does not depend on the model as it was read.
+func retryModelUpdate(f func() error) error {
+	var err error
+	for i := 0; i < maxModelUpdateAttempts; i++ {
+		err = f()
+		if errors.ErrorCode(err) != errors.CodeConflict {
+			return err
+		}
+	}
+	return err
+}
@@ -5,6 +5,7 @@ package jimm_test
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -119,6 +120,13 @@ var watcherTests = []struct {
 
 		c.Check(model.Machines, qt.Equals, int64(1))
 		c.Check(model.Cores, qt.Equals, int64(2))
+
+		machines, err := db.GetModelMachines(ctx, &model)
+		c.Assert(err, qt.IsNil)
+		c.Assert(machines, qt.HasLen, 1)
+		c.Check(machines[0].MachineID, qt.Equals, "2")
+		c.Check(machines[0].InstanceID, qt.Equals, "machine-2")
+		c.Check(machines[0].Hardware.CPUCores, qt.Equals, sql.NullInt64{Int64: 2, Valid: true})
 	},
 }, {
 	name: "UpdateMachine",
@@ -155,6 +163,12 @@ var watcherTests = []struct {
 
 		c.Check(model.Machines, qt.Equals, int64(1))
 		c.Check(model.Cores, qt.Equals, int64(4))
+
+		machines, err := db.GetModelMachines(ctx, &model)
+		c.Assert(err, qt.IsNil)
+		c.Assert(machines, qt.HasLen, 1)
+		c.Check(machines[0].MachineID, qt.Equals, "0")
+		c.Check(machines[0].Hardware.CPUCores, qt.Equals, sql.NullInt64{Int64: 4, Valid: true})
 	},
 }, {
 	name: "DeleteMachine",
@@ -191,6 +205,10 @@ var watcherTests = []struct {
 
 		c.Check(model.Machines, qt.Equals, int64(0))
 		c.Check(model.Cores, qt.Equals, int64(0))
+
+		machines, err := db.GetModelMachines(ctx, &model)
+		c.Assert(err, qt.IsNil)
+		c.Check(machines, qt.HasLen, 0)
 	},
 }, {
 	name: "UpdateApplication",
@@ -234,6 +252,78 @@ var watcherTests = []struct {
 
 		c.Assert(m.Offers, qt.HasLen, 1)
 		c.Assert(m.Offers[0].CharmURL, qt.Equals, "cs:app-1")
+
+		apps, err := db.GetModelApplications(ctx, &m)
+		c.Assert(err, qt.IsNil)
+		c.Assert(apps, qt.HasLen, 1)
+		c.Check(apps[0].Name, qt.Equals, "app-1")
+		c.Check(apps[0].CharmURL, qt.Equals, "cs:app-1")
+		c.Check(apps[0].Exposed, qt.IsTrue)
+		c.Check(apps[0].WorkloadVersion, qt.Equals, "2")
+	},
+}, {
+	name: "ApplicationUnits",
+	deltas: [][]jujuparams.Delta{
+		{{
+			Entity: &jujuparams.ApplicationInfo{
+				ModelUUID: "00000002-0000-0000-0000-000000000001",
+				Name:      "app-1",
+				CharmURL:  "ch:amd64/app-1-3",
+				Life:      life.Value(state.Alive.String()),
+				Status: jujuparams.StatusInfo{
+					Current: "active",
+				},
+			},
+		}, {
+			Entity: &jujuparams.UnitInfo{
+				ModelUUID:   "00000002-0000-0000-0000-000000000001",
+				Name:        "app-1/0",
+				Application: "app-1",
+			},
+		}, {
+			Entity: &jujuparams.UnitInfo{
+				ModelUUID:   "00000002-0000-0000-0000-000000000001",
+				Name:        "app-1/1",
+				Application: "app-1",
+			},
+		}, {
+			Entity: &jujuparams.ApplicationInfo{
+				ModelUUID: "00000002-0000-0000-0000-000000000001",
+				Name:      "app-2",
+				CharmURL:  "ch:amd64/app-2-1",
+				Life:      life.Value(state.Alive.String()),
+			},
+		}}, {{
+			Removed: true,
+			Entity: &jujuparams.UnitInfo{
+				ModelUUID:   "00000002-0000-0000-0000-000000000001",
+				Name:        "app-1/1",
+				Application: "app-1",
+			},
+		}, {
+			Removed: true,
+			Entity: &jujuparams.ApplicationInfo{
+				ModelUUID: "00000002-0000-0000-0000-000000000001",
+				Name:      "app-2",
+			},
+		}},
+		nil,
+	},
+	checkDB: func(c *qt.C, db db.Database) {
+		ctx := context.Background()
+
+		var m dbmodel.Model
+		m.SetTag(names.NewModelTag("00000002-0000-0000-0000-000000000001"))
+		err := db.GetModel(ctx, &m)
+		c.Assert(err, qt.IsNil)
+
+		apps, err := db.GetModelApplications(ctx, &m)
+		c.Assert(err, qt.IsNil)
+		c.Assert(apps, qt.HasLen, 1)
+		c.Check(apps[0].Name, qt.Equals, "app-1")
+		c.Check(apps[0].CharmURL, qt.Equals, "ch:amd64/app-1-3")
+		c.Check(apps[0].Status.Status, qt.Equals, "active")
+		c.Check(apps[0].Units, qt.Equals, int64(1))
 	},
 }, {
 	name: "AddUnit",
@@ -764,6 +854,68 @@ func TestModelSummaryWatcher(t *testing.T) {
 	}
 }
 
+func TestModelSummaryWatcherRestartsAfterError(t *testing.T) {
+	c := qt.New(t)
+	c.Patch(jimm.ModelSummaryWatcherDelay, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	summary := jujuparams.ModelAbstract{
+		UUID:   "00000002-0000-0000-0000-000000000001",
+		Status: "test status",
+		Admins: []string{"alice@canonical.com"},
+	}
+
+	var started, stopped, nexts int32
+	publisher := &testPublisher{}
+	w := &jimm.Watcher{
+		Pubsub: publisher,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				WatchAllModelSummaries_: func(_ context.Context) (string, error) {
+					return fmt.Sprintf("watcher-%d", atomic.AddInt32(&started, 1)), nil
+				},
+				ModelSummaryWatcherNext_: func(ctx context.Context, id string) ([]jujuparams.ModelAbstract, error) {
+					switch atomic.AddInt32(&nexts, 1) {
+					case 1:
+						return nil, errors.E("watcher failed")
+					case 2:
+						c.Check(id, qt.Equals, "watcher-2")
+						return []jujuparams.ModelAbstract{summary}, nil
+					}
+					cancel()
+					<-ctx.Done()
+					return nil, ctx.Err()
+				},
+				ModelSummaryWatcherStop_: func(_ context.Context, id string) error {
+					atomic.AddInt32(&stopped, 1)
+					return nil
+				},
+				SupportsModelSummaryWatcher_: true,
+				ModelInfo_: func(_ context.Context, info *jujuparams.ModelInfo) error {
+					return errors.E(errors.CodeNotFound)
+				},
+			},
+		},
+	}
+
+	env := jimmtest.ParseEnvironment(c, testWatcherEnv)
+	err := w.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env.PopulateDB(c, w.Database)
+
+	err = w.WatchAllModelSummaries(ctx, time.Millisecond)
+	checkIfContextCanceled(c, ctx, err)
+
+	c.Check(atomic.LoadInt32(&started), qt.Equals, int32(2))
+	c.Check(atomic.LoadInt32(&stopped), qt.Equals, int32(2))
+	c.Check(publisher.messages, qt.DeepEquals, []interface{}{summary})
+}
+
 func TestWatcherSetsControllerUnavailable(t *testing.T) {
 	c := qt.New(t)
 
@@ -19,11 +19,16 @@ import (
 type DebugHandler struct {
 	Router       *chi.Mux
 	StatusChecks map[string]StatusCheck
+
+	// ReadinessChecks holds the checks for the critical dependencies of
+	// the server. If any of these checks fail the /status and /ready
+	// endpoints respond with a 503 status code.
+	ReadinessChecks map[string]StatusCheck
 }
 
 // NewDebugHandler returns a new debug handler
-func NewDebugHandler(statusChecks map[string]StatusCheck) *DebugHandler {
-	return &DebugHandler{Router: chi.NewRouter(), StatusChecks: statusChecks}
+func NewDebugHandler(statusChecks, readinessChecks map[string]StatusCheck) *DebugHandler {
+	return &DebugHandler{Router: chi.NewRouter(), StatusChecks: statusChecks, ReadinessChecks: readinessChecks}
 }
 
 // Routes returns the grouped routers routes with group specific middlewares.
@@ -31,6 +36,7 @@ func (dh *DebugHandler) Routes() chi.Router {
 	dh.SetupMiddleware()
 	dh.Router.Get("/info", dh.Info)
 	dh.Router.Get("/status", dh.Status)
+	dh.Router.Get("/ready", dh.Ready)
 	return dh.Router
 }
 
@@ -48,9 +54,33 @@ func (dh *DebugHandler) Info(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, version.VersionInfo)
 }
 
-// Status handles /status, returning the currently registered status checks.
+// Status handles /status, returning the results of all registered status
+// and readiness checks.
 func (dh *DebugHandler) Status(w http.ResponseWriter, r *http.Request) {
-	checks := dh.StatusChecks
+	results := runStatusChecks(r.Context(), dh.StatusChecks)
+	ready := runStatusChecks(r.Context(), dh.ReadinessChecks)
+	for k, v := range ready {
+		results[k] = v
+	}
+	if !allPassed(ready) {
+		render.Status(r, http.StatusServiceUnavailable)
+	}
+	render.JSON(w, r, results)
+}
+
+// Ready handles /ready, returning the results of the registered readiness
+// checks.
+func (dh *DebugHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	results := runStatusChecks(r.Context(), dh.ReadinessChecks)
+	if !allPassed(results) {
+		render.Status(r, http.StatusServiceUnavailable)
+	}
+	render.JSON(w, r, results)
+}
+
+// runStatusChecks runs the given checks concurrently and returns their
+// results.
+func runStatusChecks(ctx context.Context, checks map[string]StatusCheck) map[string]statusResult {
 	var mu sync.Mutex
 	results := make(map[string]statusResult, len(checks))
 	var wg sync.WaitGroup
@@ -63,7 +93,7 @@ func (dh *DebugHandler) Status(w http.ResponseWriter, r *http.Request) {
 				Name: check.Name(),
 			}
 			start := time.Now()
-			v, err := check.Check(r.Context())
+			v, err := check.Check(ctx)
 			result.Duration = time.Since(start)
 			if err == nil {
 				result.Passed = true
@@ -77,7 +107,17 @@ func (dh *DebugHandler) Status(w http.ResponseWriter, r *http.Request) {
 		}()
 	}
 	wg.Wait()
-	render.JSON(w, r, results)
+	return results
+}
+
+// allPassed returns whether all the given results passed.
+func allPassed(results map[string]statusResult) bool {
+	for _, r := range results {
+		if !r.Passed {
+			return false
+		}
+	}
+	return true
 }
 
 // A statusResult is the type that represents the result of a status check
@@ -124,6 +164,24 @@ func (c statusCheck) Check(ctx context.Context) (interface{}, error) {
 	return c.f(ctx)
 }
 
+// A Pinger is a dependency of the server that can be checked for
+// availability.
+type Pinger interface {
+	// Ping returns an error if the dependency cannot be reached.
+	Ping(ctx context.Context) error
+}
+
+// MakePingCheck creates a status check with the given human readable
+// name which pings the given dependency.
+func MakePingCheck(name string, p Pinger) StatusCheck {
+	return MakeStatusCheck(name, func(ctx context.Context) (interface{}, error) {
+		if err := p.Ping(ctx); err != nil {
+			return nil, err
+		}
+		return "ok", nil
+	})
+}
+
 var startTime = time.Now().UTC()
 
 // ServerStartTime is a StatusCheck that returns the server start time.
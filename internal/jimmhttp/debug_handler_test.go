@@ -91,3 +91,83 @@ func TestDebugStatusStatusError(t *testing.T) {
 	c.Check(v["start_time"]["Value"], qt.Equals, "test error")
 	c.Check(v["start_time"]["Passed"], qt.Equals, false)
 }
+
+type testPinger struct {
+	err error
+}
+
+func (p testPinger) Ping(context.Context) error {
+	return p.err
+}
+
+func TestDebugReady(t *testing.T) {
+	c := qt.New(t)
+
+	r := jimmhttp.NewDebugHandler(
+		map[string]jimmhttp.StatusCheck{
+			"start_time": jimmhttp.ServerStartTime,
+		},
+		map[string]jimmhttp.StatusCheck{
+			"database": jimmhttp.MakePingCheck("database", testPinger{}),
+			"openfga":  jimmhttp.MakePingCheck("openfga", testPinger{}),
+		},
+	).Routes()
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/ready", nil)
+	c.Assert(err, qt.IsNil)
+	r.ServeHTTP(rr, req)
+
+	resp := rr.Result()
+	defer resp.Body.Close()
+	c.Check(resp.StatusCode, qt.Equals, http.StatusOK)
+	buf, err := io.ReadAll(resp.Body)
+	c.Assert(err, qt.IsNil)
+
+	var v map[string]map[string]interface{}
+	err = json.Unmarshal(buf, &v)
+	c.Assert(err, qt.IsNil)
+	c.Check(v, qt.HasLen, 2)
+	c.Check(v["database"]["Passed"], qt.Equals, true)
+	c.Check(v["database"]["Value"], qt.Equals, "ok")
+	c.Check(v["openfga"]["Passed"], qt.Equals, true)
+}
+
+func TestDebugReadyOpenFGAUnreachable(t *testing.T) {
+	c := qt.New(t)
+
+	checks := map[string]jimmhttp.StatusCheck{
+		"database": jimmhttp.MakePingCheck("database", testPinger{}),
+		"openfga":  jimmhttp.MakePingCheck("openfga", testPinger{err: errors.E("dial tcp 127.0.0.1:8080: connect: connection refused")}),
+	}
+
+	for _, path := range []string{"/ready", "/status"} {
+		c.Run(path, func(c *qt.C) {
+			r := jimmhttp.NewDebugHandler(
+				map[string]jimmhttp.StatusCheck{
+					"start_time": jimmhttp.ServerStartTime,
+				},
+				checks,
+			).Routes()
+
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", path, nil)
+			c.Assert(err, qt.IsNil)
+			r.ServeHTTP(rr, req)
+
+			resp := rr.Result()
+			defer resp.Body.Close()
+			c.Check(resp.StatusCode, qt.Equals, http.StatusServiceUnavailable)
+			buf, err := io.ReadAll(resp.Body)
+			c.Assert(err, qt.IsNil)
+
+			var v map[string]map[string]interface{}
+			err = json.Unmarshal(buf, &v)
+			c.Assert(err, qt.IsNil)
+			c.Check(v["database"]["Passed"], qt.Equals, true)
+			c.Check(v["openfga"]["Name"], qt.Equals, "openfga")
+			c.Check(v["openfga"]["Passed"], qt.Equals, false)
+			c.Check(v["openfga"]["Value"], qt.Equals, "dial tcp 127.0.0.1:8080: connect: connection refused")
+		})
+	}
+}
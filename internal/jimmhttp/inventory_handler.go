@@ -0,0 +1,88 @@
+// Copyright 2024 Canonical.
+
+package jimmhttp
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/middleware"
+)
+
+// InventoryHandler is a handler that streams the JIMM inventory to JIMM
+// administrators.
+type InventoryHandler struct {
+	Router *chi.Mux
+	jimm   *jimm.JIMM
+}
+
+// NewInventoryHandler creates an inventory http handler.
+func NewInventoryHandler(jimm *jimm.JIMM) *InventoryHandler {
+	return &InventoryHandler{Router: chi.NewRouter(), jimm: jimm}
+}
+
+// Routes returns the grouped routers routes with group specific middlewares.
+func (ih *InventoryHandler) Routes() chi.Router {
+	ih.SetupMiddleware()
+	ih.Router.Get("/", ih.ExportInventory)
+	return ih.Router
+}
+
+// SetupMiddleware applies authn middlewares, the authorization check is
+// performed by JIMM when the inventory is exported.
+func (ih *InventoryHandler) SetupMiddleware() {
+	ih.Router.Use(func(h http.Handler) http.Handler {
+		return middleware.AuthenticateWithSessionTokenViaBasicAuth(h, ih.jimm)
+	})
+}
+
+// ExportInventory writes the inventory document to the response as it is
+// read from the database and OpenFGA. If the export fails after the
+// document has been started the connection is aborted, so that the client
+// gets an error instead of a document that appears to be complete.
+func (ih *InventoryHandler) ExportInventory(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	user, err := middleware.IdentityFromContext(ctx)
+	if err != nil {
+		writeError(ctx, w, http.StatusUnauthorized, err, "cannot get user")
+		return
+	}
+
+	iw := &inventoryResponseWriter{w: w}
+	err = ih.jimm.ExportInventory(ctx, user, iw)
+	if err == nil {
+		return
+	}
+	if iw.written {
+		zapctx.Error(ctx, "failed to export inventory", zap.Error(err))
+		panic(http.ErrAbortHandler)
+	}
+	status := http.StatusInternalServerError
+	if errors.ErrorCode(err) == errors.CodeUnauthorized {
+		status = http.StatusForbidden
+	}
+	writeError(ctx, w, status, err, "cannot export inventory")
+}
+
+// An inventoryResponseWriter sets the content type of the response on the
+// first write and records whether any of the document has been written,
+// after which the status of the response can no longer be changed.
+type inventoryResponseWriter struct {
+	w       http.ResponseWriter
+	written bool
+}
+
+// Write implements io.Writer.
+func (iw *inventoryResponseWriter) Write(p []byte) (int, error) {
+	if !iw.written {
+		iw.w.Header().Set("Content-Type", "application/json")
+		iw.written = true
+	}
+	return iw.w.Write(p)
+}
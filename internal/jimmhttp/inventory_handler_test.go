@@ -0,0 +1,84 @@
+// Copyright 2024 Canonical.
+
+package jimmhttp_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/jimmhttp"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type inventorySuite struct {
+	jimmtest.JIMMSuite
+}
+
+var _ = gc.Suite(&inventorySuite{})
+
+func (s *inventorySuite) SetUpTest(c *gc.C) {
+	s.JIMMSuite.SetUpTest(c)
+	tester := jimmtest.GocheckTester{C: c}
+	env := jimmtest.ParseEnvironment(tester, testEnv)
+	env.PopulateDB(tester, s.JIMM.Database)
+}
+
+func (s *inventorySuite) TestInventoryHandler(c *gc.C) {
+	srv := httptest.NewServer(jimmhttp.NewInventoryHandler(s.JIMM).Routes())
+	defer srv.Close()
+
+	tests := []struct {
+		description    string
+		user           string
+		statusExpected int
+		bodyExpected   string
+	}{{
+		description:    "not authenticated",
+		statusExpected: http.StatusUnauthorized,
+		bodyExpected:   "authentication missing",
+	}, {
+		description:    "not an administrator",
+		user:           "bob@canonical.com",
+		statusExpected: http.StatusForbidden,
+		bodyExpected:   "Forbidden - unauthorized",
+	}, {
+		description:    "administrator",
+		user:           "alice@canonical.com",
+		statusExpected: http.StatusOK,
+	}}
+
+	for _, test := range tests {
+		c.Log(test.description)
+		req, err := http.NewRequest("GET", srv.URL, nil)
+		c.Assert(err, gc.IsNil)
+		if test.user != "" {
+			header, err := jimmtest.NewUserSessionLogin(c, test.user).AuthHeader()
+			c.Assert(err, gc.IsNil)
+			req.Header = header
+		}
+		resp, err := http.DefaultClient.Do(req)
+		c.Assert(err, gc.IsNil)
+		defer resp.Body.Close()
+		c.Check(resp.StatusCode, gc.Equals, test.statusExpected)
+		if test.statusExpected != http.StatusOK {
+			body, err := io.ReadAll(resp.Body)
+			c.Assert(err, gc.IsNil)
+			c.Check(string(body), gc.Equals, test.bodyExpected)
+			continue
+		}
+		c.Check(resp.Header.Get("Content-Type"), gc.Equals, "application/json")
+		var inv apiparams.Inventory
+		err = json.NewDecoder(resp.Body).Decode(&inv)
+		c.Assert(err, gc.IsNil)
+		c.Check(inv.Version, gc.Equals, apiparams.InventoryVersion)
+		c.Assert(inv.Controllers, gc.HasLen, 1)
+		c.Check(inv.Controllers[0].Name, gc.Equals, "controller-1")
+		c.Assert(inv.Models, gc.HasLen, 1)
+		c.Check(inv.Models[0].Name, gc.Equals, "model-1")
+	}
+}
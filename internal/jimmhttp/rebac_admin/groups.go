@@ -41,7 +41,7 @@ func (s *groupsService) ListGroups(ctx context.Context, params *resources.GetGro
 		return nil, err
 	}
 	page, nextPage, pagination := pagination.CreatePagination(params.Size, params.Page, count)
-	groups, err := s.jimm.ListGroups(ctx, user, pagination)
+	groups, err := s.jimm.ListGroups(ctx, user, pagination, "")
 	if err != nil {
 		return nil, err
 	}
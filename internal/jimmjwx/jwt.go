@@ -79,6 +79,23 @@ type JWTParams struct {
 	User string
 	// Access is a claim of key/values denoting what the user wishes to access
 	Access map[string]string
+	// Claims holds any additional claims to include in the JWT. Claims
+	// that would replace the registered claims or the access claim are
+	// ignored.
+	Claims map[string]interface{}
+}
+
+// reservedClaims holds the claims set by NewJWT that cannot be replaced
+// by the additional claims in JWTParams.
+var reservedClaims = map[string]bool{
+	jwt.AudienceKey:   true,
+	jwt.SubjectKey:    true,
+	jwt.IssuerKey:     true,
+	jwt.JwtIDKey:      true,
+	jwt.ExpirationKey: true,
+	jwt.IssuedAtKey:   true,
+	jwt.NotBeforeKey:  true,
+	"access":          true,
 }
 
 // NewJWTService returns a new JWT service for handling JIMMs JWTs.
@@ -142,14 +159,21 @@ func (j *JWTService) NewJWT(ctx context.Context, params JWTParams) ([]byte, erro
 		return nil, err
 	}
 
-	token, err := jwt.NewBuilder().
+	builder := jwt.NewBuilder().
 		Audience([]string{params.Controller}).
 		Subject(params.User).
 		Issuer(j.Host).
 		JwtID(jti).
 		Claim("access", params.Access).
-		Expiration(time.Now().Add(j.Expiry)).
-		Build()
+		Expiration(time.Now().Add(j.Expiry))
+	for k, v := range params.Claims {
+		if reservedClaims[k] {
+			zapctx.Warn(ctx, "ignoring reserved claim", zap.String("claim", k))
+			continue
+		}
+		builder = builder.Claim(k, v)
+	}
+	token, err := builder.Build()
 	if err != nil {
 		zapctx.Error(ctx, "failed to create token", zap.Error(err))
 		return nil, err
@@ -102,6 +102,55 @@ func TestNewJWTIsParsableByExponent(t *testing.T) {
 	c.Assert(token.Issuer(), qt.Equals, u.Host)
 }
 
+func TestNewJWTAdditionalClaims(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	_, srv, store := setupService(ctx, c)
+
+	jwksService := jimmjwx.NewJWKSService(store)
+	startAndTestRotator(c, ctx, store, jwksService)
+	u, _ := url.Parse(srv.URL)
+	jwtService := jimmjwx.NewJWTService(jimmjwx.JWTServiceParams{
+		Host:   u.Host,
+		Store:  store,
+		Expiry: time.Minute,
+	})
+
+	tok, err := jwtService.NewJWT(ctx, jimmjwx.JWTParams{
+		Controller: "controller-my-diglett-controller",
+		User:       "diglett@canonical.com",
+		Access: map[string]string{
+			"controller": "superuser",
+		},
+		Claims: map[string]interface{}{
+			"tenant": "underground",
+			"sub":    "dugtrio@canonical.com",
+			"access": map[string]string{"controller": "login"},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+
+	set, err := jwtService.JWKS.Get(ctx)
+	c.Assert(err, qt.IsNil)
+	token, err := jwt.Parse(tok, jwt.WithKeySet(set))
+	c.Assert(err, qt.IsNil)
+
+	tenant, ok := token.Get("tenant")
+	c.Assert(ok, qt.IsTrue)
+	c.Check(tenant, qt.Equals, "underground")
+
+	// Reserved claims cannot be replaced.
+	c.Check(token.Subject(), qt.Equals, "diglett@canonical.com")
+	accessClaim, ok := token.Get("access")
+	c.Assert(ok, qt.IsTrue)
+	c.Check(accessClaim, qt.DeepEquals, map[string]any{
+		"controller": "superuser",
+	})
+}
+
 func TestNewJWTExpires(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/juju/names/v5"
 	"github.com/juju/zaputil"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
@@ -30,7 +31,7 @@ type GroupService interface {
 	CountGroups(ctx context.Context, user *openfga.User) (int, error)
 	GetGroupByUUID(ctx context.Context, user *openfga.User, uuid string) (*dbmodel.GroupEntry, error)
 	GetGroupByName(ctx context.Context, user *openfga.User, name string) (*dbmodel.GroupEntry, error)
-	ListGroups(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]dbmodel.GroupEntry, error)
+	ListGroups(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination, namePrefixFilter string) ([]dbmodel.GroupEntry, error)
 	RenameGroup(ctx context.Context, user *openfga.User, oldName, newName string) error
 	RemoveGroup(ctx context.Context, user *openfga.User, name string) error
 }
@@ -119,7 +120,7 @@ func (r *controllerRoot) ListGroups(ctx context.Context, req apiparams.ListGroup
 	const op = errors.Op("jujuapi.ListGroups")
 
 	filter := pagination.NewOffsetFilter(req.Limit, req.Offset)
-	groups, err := r.jimm.ListGroups(ctx, r.user, filter)
+	groups, err := r.jimm.ListGroups(ctx, r.user, filter, req.NamePrefix)
 	if err != nil {
 		return apiparams.ListGroupResponse{}, errors.E(op, err)
 	}
@@ -178,6 +179,26 @@ func (r *controllerRoot) CheckRelation(ctx context.Context, req apiparams.CheckR
 	return checkResp, nil
 }
 
+// CheckUserRelation checks whether a user has a relation to an entity,
+// returning a trace of the relations to the entity that apply to the
+// user.
+func (r *controllerRoot) CheckUserRelation(ctx context.Context, req apiparams.CheckUserRelationRequest) (apiparams.CheckUserRelationResponse, error) {
+	const op = errors.Op("jujuapi.CheckUserRelation")
+
+	ut, err := names.ParseUserTag(req.UserTag)
+	if err != nil {
+		return apiparams.CheckUserRelationResponse{}, errors.E(op, err, errors.CodeBadRequest)
+	}
+	allowed, trace, err := r.jimm.CheckUserRelation(ctx, r.user, ut, req.Relation, req.TargetTag)
+	if err != nil {
+		return apiparams.CheckUserRelationResponse{}, errors.E(op, err)
+	}
+	return apiparams.CheckUserRelationResponse{
+		Allowed: allowed,
+		Trace:   trace,
+	}, nil
+}
+
 // ListRelationshipTuples returns a list of tuples matching the specified filter.
 func (r *controllerRoot) ListRelationshipTuples(ctx context.Context, req apiparams.ListRelationshipTuplesRequest) (apiparams.ListRelationshipTuplesResponse, error) {
 	const op = errors.Op("jujuapi.ListRelationshipTuples")
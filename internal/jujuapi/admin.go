@@ -31,6 +31,8 @@ type LoginService interface {
 	LoginWithSessionToken(ctx context.Context, sessionToken string) (*openfga.User, error)
 	// LoginWithSessionCookie verifies a user based on an identity from a cookie obtained during websocket upgrade.
 	LoginWithSessionCookie(ctx context.Context, identityID string) (*openfga.User, error)
+	// LoginWithIDToken verifies a user based on an ID token issued by an external OIDC provider.
+	LoginWithIDToken(ctx context.Context, idToken string) (*openfga.User, error)
 }
 
 // unsupportedLogin returns an appropriate error for login attempts using
@@ -183,6 +185,36 @@ func (r *controllerRoot) LoginWithClientCredentials(ctx context.Context, req par
 	}, nil
 }
 
+// LoginWithIDToken handles logging into JIMM with an ID token issued by
+// an external OIDC provider, this is only available when JIMM has been
+// configured to accept such tokens.
+func (r *controllerRoot) LoginWithIDToken(ctx context.Context, req params.LoginWithIDTokenRequest) (jujuparams.LoginResult, error) {
+	const op = errors.Op("jujuapi.LoginWithIDToken")
+
+	user, err := r.jimm.LoginWithIDToken(ctx, req.IDToken)
+	if err != nil {
+		return jujuparams.LoginResult{}, errors.E(op, err)
+	}
+
+	r.mu.Lock()
+	r.user = user
+	r.mu.Unlock()
+
+	// Get server version for LoginResult
+	srvVersion, err := r.jimm.EarliestControllerVersion(ctx)
+	if err != nil {
+		return jujuparams.LoginResult{}, errors.E(op, err)
+	}
+
+	return jujuparams.LoginResult{
+		PublicDNSName: r.params.PublicDNSName,
+		UserInfo:      setupAuthUserInfo(ctx, r, user),
+		ControllerTag: setupControllerTag(r),
+		Facades:       setupFacades(r),
+		ServerVersion: srvVersion.String(),
+	}, nil
+}
+
 // setupControllerTag returns the String() of a controller tag based on the
 // JIMM controller UUID.
 func setupControllerTag(root *controllerRoot) string {
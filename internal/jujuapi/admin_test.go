@@ -29,6 +29,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/auth"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
 	"github.com/canonical/jimm/v3/pkg/api/params"
 )
@@ -356,6 +357,41 @@ func (s *adminSuite) TestLoginWithClientCredentials(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid client credentials \(unauthorized access\)`)
 }
 
+func (s *adminSuite) TestLoginWithClientCredentialsLockout(c *gc.C) {
+	s.JIMM.LoginAttempts = jimm.NewLoginAttemptTracker(2, time.Minute)
+	defer func() {
+		s.JIMM.LoginAttempts = nil
+	}()
+
+	conn := s.open(c, &api.Info{
+		SkipLogin: true,
+	}, "test")
+	defer conn.Close()
+
+	const (
+		// these are valid client credentials hardcoded into the jimm realm
+		validClientID = "test-client-id"
+		//nolint:gosec // Thinks credentials hardcoded.
+		validClientSecret = "2M2blFbO4GX4zfggQpivQSxwWX1XGgNf"
+	)
+
+	var loginResult jujuparams.LoginResult
+	for n := 0; n < 2; n++ {
+		err := conn.APICall("Admin", 4, "", "LoginWithClientCredentials", params.LoginWithClientCredentialsRequest{
+			ClientID:     validClientID,
+			ClientSecret: "invalid-secret",
+		}, &loginResult)
+		c.Assert(err, gc.ErrorMatches, `invalid client credentials \(unauthorized access\)`)
+	}
+
+	// Once locked out even the valid secret is rejected.
+	err := conn.APICall("Admin", 4, "", "LoginWithClientCredentials", params.LoginWithClientCredentialsRequest{
+		ClientID:     validClientID,
+		ClientSecret: validClientSecret,
+	}, &loginResult)
+	c.Assert(err, gc.ErrorMatches, `too many failed logins for "test-client-id@serviceaccount", try again in .*`)
+}
+
 // getDialWebsocketWithCustomCookieJar is mostly the default dialer configuration exception
 // we need a dial websocket for juju containing a custom cookie jar to send cookies to
 // a new server url when testing LoginWithSessionCookie. As such this closure simply
@@ -6,6 +6,7 @@ package jujuapi
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jimmhttp"
@@ -20,6 +21,10 @@ type Params struct {
 	// PublicDNSName is the name to advertise as the public address of
 	// the juju controller.
 	PublicDNSName string
+
+	// LoginTimeout is the maximum time allowed for a client proxied to
+	// a model to log in. A zero value means there is no limit.
+	LoginTimeout time.Duration
 }
 
 // APIHandler returns an http Handler for the /api endpoint.
@@ -39,7 +44,8 @@ func ModelHandler(ctx context.Context, jimm *jimm.JIMM, p Params) http.Handler {
 	mux.Handle("/{uuid}/api", &jimmhttp.WSHandler{
 		Upgrader: websocketUpgrader,
 		Server: &apiProxier{apiServer: apiServer{
-			jimm: jimm,
+			jimm:   jimm,
+			params: p,
 		}},
 	})
 	mux.Handle("/{uuid}/log", &jimmhttp.WSHandler{
@@ -50,3 +56,15 @@ func ModelHandler(ctx context.Context, jimm *jimm.JIMM, p Params) http.Handler {
 	})
 	return mux
 }
+
+// AuditEventsHandler returns an http Handler for the /audit-events
+// endpoint.
+func AuditEventsHandler(ctx context.Context, jimm *jimm.JIMM, p Params) http.Handler {
+	return &jimmhttp.WSHandler{
+		Upgrader: websocketUpgrader,
+		Server: &auditEventStreamer{apiServer: apiServer{
+			jimm:   jimm,
+			params: p,
+		}},
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// auditEventBufferSize is the number of audit events that will be
+// buffered for a client before new events are dropped.
+const auditEventBufferSize = 100
+
+// auditBacklogKey is the context key holding the requested backlog.
+type auditBacklogKey struct{}
+
+// An auditEventStreamer serves the /audit-events endpoint by pushing
+// audit events to the client as they are written. The number of existing
+// events to send before any new events can be set with the backlog query
+// parameter.
+type auditEventStreamer struct {
+	apiServer
+}
+
+// Authenticate implements WSServer.Authenticate. Clients authenticate in
+// the same way as for the /log endpoint.
+func (s auditEventStreamer) Authenticate(ctx context.Context, w http.ResponseWriter, req *http.Request) (context.Context, error) {
+	ctx, err := streamProxier{apiServer: s.apiServer}.Authenticate(ctx, w, req)
+	if err != nil {
+		return ctx, err
+	}
+	return context.WithValue(ctx, auditBacklogKey{}, req.URL.Query().Get("backlog")), nil
+}
+
+// ServeWS implements jimmhttp.WSServer.
+func (s auditEventStreamer) ServeWS(ctx context.Context, conn *websocket.Conn) {
+	writeError := func(msg string, code errors.Code) {
+		var errResult jujuparams.ErrorResult
+		errResult.Error = &jujuparams.Error{
+			Message: msg,
+			Code:    string(code),
+		}
+		err := conn.WriteJSON(errResult)
+		if err != nil {
+			zapctx.Error(ctx, "failed to write error message to client", zap.Error(err), zap.Any("client message", errResult))
+		}
+	}
+
+	user, err := s.jimm.UserLogin(ctx, auth.SessionIdentityFromContext(ctx))
+	if err != nil {
+		zapctx.Error(ctx, "user login error", zap.Error(err))
+		writeError(err.Error(), errors.CodeUnauthorized)
+		return
+	}
+
+	var backlog int
+	if v, _ := ctx.Value(auditBacklogKey{}).(string); v != "" {
+		backlog, err = strconv.Atoi(v)
+		if err != nil || backlog < 0 {
+			writeError("invalid backlog "+strconv.Quote(v), errors.CodeBadRequest)
+			return
+		}
+	}
+
+	events := make(chan dbmodel.AuditLogEntry, auditEventBufferSize)
+	entries, stop, err := s.jimm.WatchAuditEvents(ctx, user, backlog, func(entry dbmodel.AuditLogEntry) {
+		select {
+		case events <- entry:
+		default:
+			zapctx.Warn(ctx, "audit event buffer full, dropping event", zap.Uint("id", entry.ID))
+		}
+	})
+	if err != nil {
+		writeError(err.Error(), errors.ErrorCode(err))
+		return
+	}
+	defer stop()
+
+	// The client does not send any messages, but the connection must be
+	// read to notice when the client goes away.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for _, entry := range entries {
+		if err := conn.WriteJSON(entry.ToAPIAuditEvent()); err != nil {
+			zapctx.Error(ctx, "failed to write audit event", zap.Error(err))
+			return
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-closed:
+			return
+		case entry := <-events:
+			if err := conn.WriteJSON(entry.ToAPIAuditEvent()); err != nil {
+				zapctx.Error(ctx, "failed to write audit event", zap.Error(err))
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+	jujuparams "github.com/juju/juju/rpc/params"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type auditStreamSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&auditStreamSuite{})
+
+// dialAuditEvents opens a connection to the /audit-events endpoint
+// authenticated as the given user.
+func (s *auditStreamSuite) dialAuditEvents(c *gc.C, username, backlog string) *websocket.Conn {
+	u, err := url.Parse(s.HTTP.URL)
+	c.Assert(err, gc.IsNil)
+	u.Scheme = "wss"
+	u.Path = "/audit-events"
+	if backlog != "" {
+		u.RawQuery = url.Values{"backlog": {backlog}}.Encode()
+	}
+	token := jimmtest.NewSessionToken(c, username)
+	header := http.Header{}
+	header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+token)))
+	dialer := websocket.Dialer{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // test server.
+	}
+	conn, _, err := dialer.Dial(u.String(), header)
+	c.Assert(err, gc.IsNil)
+	err = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	c.Assert(err, gc.IsNil)
+	return conn
+}
+
+func (s *auditStreamSuite) TestStreamAuditEvents(c *gc.C) {
+	// The backlog is only sent once the subscription is active, so
+	// waiting for it ensures the following action is streamed.
+	s.JIMM.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC(),
+		IdentityTag:  "user-alice@canonical.com",
+		FacadeMethod: "Sync",
+	})
+	ws := s.dialAuditEvents(c, "alice", "1")
+	defer ws.Close()
+
+	var event apiparams.AuditEvent
+	err := ws.ReadJSON(&event)
+	c.Assert(err, gc.IsNil)
+	c.Assert(event.FacadeMethod, gc.Equals, "Sync")
+
+	// Perform an auditable action.
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	var resp apiparams.ListControllersResponse
+	err = conn.APICall("JIMM", 4, "", "ListControllers", nil, &resp)
+	c.Assert(err, gc.IsNil)
+
+	for {
+		var event apiparams.AuditEvent
+		err := ws.ReadJSON(&event)
+		c.Assert(err, gc.IsNil)
+		if event.FacadeMethod == "ListControllers" && !event.IsResponse {
+			c.Check(event.FacadeName, gc.Equals, "JIMM")
+			c.Check(event.UserTag, gc.Equals, "user-alice@canonical.com")
+			return
+		}
+	}
+}
+
+func (s *auditStreamSuite) TestStreamAuditEventsBacklog(c *gc.C) {
+	for _, method := range []string{"AddModel", "Deploy", "DestroyModel"} {
+		s.JIMM.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+			Time:         time.Now().UTC(),
+			IdentityTag:  "user-alice@canonical.com",
+			FacadeMethod: method,
+		})
+	}
+
+	ws := s.dialAuditEvents(c, "alice", "2")
+	defer ws.Close()
+
+	var event apiparams.AuditEvent
+	err := ws.ReadJSON(&event)
+	c.Assert(err, gc.IsNil)
+	c.Check(event.FacadeMethod, gc.Equals, "Deploy")
+	err = ws.ReadJSON(&event)
+	c.Assert(err, gc.IsNil)
+	c.Check(event.FacadeMethod, gc.Equals, "DestroyModel")
+}
+
+func (s *auditStreamSuite) TestStreamAuditEventsUnauthorized(c *gc.C) {
+	ws := s.dialAuditEvents(c, "bob", "")
+	defer ws.Close()
+
+	var result jujuparams.ErrorResult
+	err := ws.ReadJSON(&result)
+	c.Assert(err, gc.IsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Equals, "unauthorized")
+	c.Check(result.Error.Code, gc.Equals, jujuparams.CodeUnauthorized)
+}
+
+func (s *auditStreamSuite) TestStreamAuditEventsInvalidBacklog(c *gc.C) {
+	ws := s.dialAuditEvents(c, "alice", "-1")
+	defer ws.Close()
+
+	var result jujuparams.ErrorResult
+	err := ws.ReadJSON(&result)
+	c.Assert(err, gc.IsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Equals, `invalid backlog "-1"`)
+	c.Check(result.Error.Code, gc.Equals, jujuparams.CodeBadRequest)
+}
@@ -88,7 +88,7 @@ func (s *cloudSuite) TestUserCredentials(c *gc.C) {
 func (s *cloudSuite) TestUserCredentialsWithDomain(c *gc.C) {
 	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/test@domain/cred1")
 	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{
-		AuthType: "credtype",
+		AuthType: "userpass",
 		Attributes: map[string]string{
 			"attr1": "val1",
 			"attr2": "val2",
@@ -126,7 +126,7 @@ func (s *cloudSuite) TestUpdateCloudCredentials(c *gc.C) {
 	client := cloudapi.NewClient(conn)
 	credentialTag := names.NewCloudCredentialTag(fmt.Sprintf(jimmtest.TestCloudName + "/test@canonical.com/cred3"))
 	reqCreds := map[string]cloud.Credential{
-		credentialTag.String(): cloud.NewCredential("credtype", map[string]string{
+		credentialTag.String(): cloud.NewCredential("userpass", map[string]string{
 			"attr1": "val31",
 			"attr2": "val32",
 		}),
@@ -139,7 +139,7 @@ func (s *cloudSuite) TestUpdateCloudCredentials(c *gc.C) {
 	creds, err := client.UserCredentials(names.NewUserTag("test@canonical.com"), names.NewCloudTag(jimmtest.TestCloudName))
 	c.Assert(err, gc.Equals, nil)
 	c.Assert(creds, jc.DeepEquals, []names.CloudCredentialTag{credentialTag})
-	_, err = client.UpdateCredentialsCheckModels(credentialTag, cloud.NewCredential("credtype", map[string]string{"attr1": "val33", "attr2": "val34"}))
+	_, err = client.UpdateCredentialsCheckModels(credentialTag, cloud.NewCredential("userpass", map[string]string{"attr1": "val33", "attr2": "val34"}))
 	c.Assert(err, gc.Equals, nil)
 	creds, err = client.UserCredentials(names.NewUserTag("test@canonical.com"), names.NewCloudTag(jimmtest.TestCloudName))
 	c.Assert(err, gc.Equals, nil)
@@ -153,7 +153,7 @@ func (s *cloudSuite) TestUpdateCloudCredentialsErrors(c *gc.C) {
 		Credentials: []jujuparams.TaggedCredential{{
 			Tag: "not-a-cloud-credentials-tag",
 			Credential: jujuparams.CloudCredential{
-				AuthType: "credtype",
+				AuthType: "userpass",
 				Attributes: map[string]string{
 					"attr1": "val1",
 				},
@@ -161,7 +161,7 @@ func (s *cloudSuite) TestUpdateCloudCredentialsErrors(c *gc.C) {
 		}, {
 			Tag: names.NewCloudCredentialTag(jimmtest.TestCloudName + "/test2@canonical.com/cred1").String(),
 			Credential: jujuparams.CloudCredential{
-				AuthType: "credtype",
+				AuthType: "userpass",
 				Attributes: map[string]string{
 					"attr1": "val1",
 				},
@@ -169,7 +169,7 @@ func (s *cloudSuite) TestUpdateCloudCredentialsErrors(c *gc.C) {
 		}, {
 			Tag: names.NewCloudCredentialTag(jimmtest.TestCloudName + "/test@canonical.com/bad-name-").String(),
 			Credential: jujuparams.CloudCredential{
-				AuthType: "credtype",
+				AuthType: "userpass",
 				Attributes: map[string]string{
 					"attr1": "val1",
 				},
@@ -197,11 +197,13 @@ func (s *cloudSuite) TestUpdateCloudCredentialsForce(c *gc.C) {
 	_, err = mmclient.CreateModel("model1", "test@canonical.com", jimmtest.TestCloudName, "", credentialTag, nil)
 	c.Assert(err, gc.Equals, nil)
 
+	// The auth type is supported by the cloud, but the attributes do
+	// not match its schema so the controller rejects the credential.
 	args := jujuparams.UpdateCredentialArgs{
 		Credentials: []jujuparams.TaggedCredential{{
 			Tag: credentialTag.String(),
 			Credential: jujuparams.CloudCredential{
-				AuthType: "badauthtype",
+				AuthType: "userpass",
 				Attributes: map[string]string{
 					"bad1attr": "cloud-user2",
 					"bad2attr": "cloud-pass2",
@@ -233,7 +235,7 @@ func (s *cloudSuite) TestUpdateCloudCredentialsForce(c *gc.C) {
 	args.Force = true
 	err = conn.APICall("Cloud", 7, "", "UpdateCredentialsCheckModels", args, &resp)
 	c.Assert(err, gc.Equals, nil)
-	c.Check(resp.Results[0].Error, gc.ErrorMatches, `updating cloud credentials: validating credential "`+jimmtest.TestCloudName+`/test@canonical.com/cred3" for cloud "`+jimmtest.TestCloudName+`": supported auth-types \["empty" "userpass"\], "badauthtype" not supported`)
+	c.Check(resp.Results[0].Error, gc.ErrorMatches, `updating cloud credentials: validating credential "`+jimmtest.TestCloudName+`/test@canonical.com/cred3" for cloud "`+jimmtest.TestCloudName+`": .*`)
 
 	// Check that the credentials have been updated even though
 	// we got an error.
@@ -242,12 +244,64 @@ func (s *cloudSuite) TestUpdateCloudCredentialsForce(c *gc.C) {
 	sort.Strings(creds[0].Result.Redacted)
 	c.Assert(creds, jc.DeepEquals, []jujuparams.CloudCredentialResult{{
 		Result: &jujuparams.CloudCredential{
-			AuthType: "badauthtype",
+			AuthType: "userpass",
 			Redacted: []string{"bad1attr", "bad2attr"},
 		},
 	}})
 }
 
+func (s *cloudSuite) TestUpdateCloudCredentialsUnsupportedAuthType(c *gc.C) {
+	conn := s.open(c, nil, "test")
+	defer conn.Close()
+	client := cloudapi.NewClient(conn)
+	credentialTag := names.NewCloudCredentialTag(fmt.Sprintf(jimmtest.TestCloudName + "/test@canonical.com/cred3"))
+	_, err := client.UpdateCredentialsCheckModels(credentialTag, cloud.NewCredential("userpass", map[string]string{"username": "a", "password": "b"}))
+	c.Assert(err, gc.Equals, nil)
+
+	mmclient := modelmanager.NewClient(conn)
+	_, err = mmclient.CreateModel("model1", "test@canonical.com", jimmtest.TestCloudName, "", credentialTag, nil)
+	c.Assert(err, gc.Equals, nil)
+
+	args := jujuparams.UpdateCredentialArgs{
+		Credentials: []jujuparams.TaggedCredential{{
+			Tag: credentialTag.String(),
+			Credential: jujuparams.CloudCredential{
+				AuthType: "badauthtype",
+				Attributes: map[string]string{
+					"bad1attr": "cloud-user2",
+					"bad2attr": "cloud-pass2",
+				},
+			},
+		}},
+	}
+	// The auth type is not supported by the cloud so the update is
+	// rejected before any controller is contacted, even with Force.
+	expectCreds := []jujuparams.CloudCredentialResult{{
+		Result: &jujuparams.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "a",
+			},
+			Redacted: []string{
+				"password",
+			},
+		},
+	}}
+	for _, force := range []bool{false, true} {
+		args.Force = force
+		var resp jujuparams.UpdateCredentialResults
+		err = conn.APICall("Cloud", 7, "", "UpdateCredentialsCheckModels", args, &resp)
+		c.Assert(err, gc.Equals, nil)
+		c.Check(resp.Results[0].Error, gc.ErrorMatches, `auth type "badauthtype" not supported by cloud "`+jimmtest.TestCloudName+`", supported auth types \["empty" "userpass"\]`)
+		c.Check(resp.Results[0].Error.Code, gc.Equals, jujuparams.CodeBadRequest)
+
+		// Check that the credentials have not been updated.
+		creds, err := client.Credentials(credentialTag)
+		c.Assert(err, gc.Equals, nil)
+		c.Assert(creds, jc.DeepEquals, expectCreds)
+	}
+}
+
 func (s *cloudSuite) TestCheckCredentialsModels(c *gc.C) {
 	conn := s.open(c, nil, "test")
 	defer conn.Close()
@@ -316,7 +370,7 @@ func (s *cloudSuite) TestCheckCredentialsModelsInvalidCreds(c *gc.C) {
 	c.Assert(err, gc.Equals, nil)
 
 	mmclient := modelmanager.NewClient(conn)
-	model1, err := mmclient.CreateModel("model1", "test@canonical.com", jimmtest.TestCloudName, "", credTag, nil)
+	_, err = mmclient.CreateModel("model1", "test@canonical.com", jimmtest.TestCloudName, "", credTag, nil)
 	c.Assert(err, gc.Equals, nil)
 
 	var resp jujuparams.UpdateCredentialResults
@@ -335,17 +389,10 @@ func (s *cloudSuite) TestCheckCredentialsModelsInvalidCreds(c *gc.C) {
 	c.Assert(resp, jc.DeepEquals, jujuparams.UpdateCredentialResults{
 		Results: []jujuparams.UpdateCredentialResult{{
 			CredentialTag: "cloudcred-" + jimmtest.TestCloudName + "_test@canonical.com_cred",
-			Error:         &jujuparams.Error{Message: "some models are no longer visible"},
-			Models: []jujuparams.UpdateCredentialModelResult{{
-				ModelUUID: model1.UUID,
-				ModelName: "model1",
-				Errors: []jujuparams.ErrorResult{{
-					Error: &jujuparams.Error{
-						Message: `validating credential "` + jimmtest.TestCloudName + `/test@canonical.com/cred" for cloud "` + jimmtest.TestCloudName + `": supported auth-types ["empty" "userpass"], "unknowntype" not supported`,
-						Code:    "not supported",
-					},
-				}},
-			}},
+			Error: &jujuparams.Error{
+				Message: `auth type "unknowntype" not supported by cloud "` + jimmtest.TestCloudName + `", supported auth types ["empty" "userpass"]`,
+				Code:    jujuparams.CodeBadRequest,
+			},
 		}},
 	})
 }
@@ -737,7 +784,7 @@ func (s *cloudSuite) TestCredentialContentsWithEmptyAttributes(c *gc.C) {
 	err := client.AddCredential(
 		credentialTag.String(),
 		cloud.NewCredential(
-			"certificate",
+			"empty",
 			nil,
 		),
 	)
@@ -749,7 +796,7 @@ func (s *cloudSuite) TestCredentialContentsWithEmptyAttributes(c *gc.C) {
 			Content: jujuparams.CredentialContent{
 				Name:       "cred3",
 				Cloud:      jimmtest.TestCloudName,
-				AuthType:   "certificate",
+				AuthType:   "empty",
 				Attributes: nil,
 			},
 		},
@@ -56,6 +56,7 @@ type ControllerService interface {
 	AddController(ctx context.Context, user *openfga.User, ctl *dbmodel.Controller) error
 	ControllerInfo(ctx context.Context, name string) (*dbmodel.Controller, error)
 	EarliestControllerVersion(ctx context.Context) (version.Number, error)
+	ForEachControllerForUser(ctx context.Context, user *openfga.User, f func(*dbmodel.Controller) error) error
 	ListControllers(ctx context.Context, user *openfga.User) ([]dbmodel.Controller, error)
 	GetControllerConfig(ctx context.Context, user *dbmodel.Identity) (*dbmodel.ControllerConfig, error)
 	SetControllerConfig(ctx context.Context, user *openfga.User, args jujuparams.ControllerConfigSet) error
@@ -149,17 +150,41 @@ func (r *controllerRoot) WatchAllModelSummaries(ctx context.Context) (jujuparams
 		return jujuparams.SummaryWatcherID{}, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
-	err := r.setupUUIDGenerator()
+	id, err := r.watchFilteredModelSummaries(ctx, modelSummaryFilter{})
 	if err != nil {
 		return jujuparams.SummaryWatcherID{}, errors.E(op, err)
 	}
+	return jujuparams.SummaryWatcherID{
+		WatcherID: id,
+	}, nil
+}
+
+// watchFilteredModelSummaries starts a model summary watcher that
+// receives the summaries of the models that match the given filter. JIMM
+// administrators may watch every model, other users only the models they
+// have access to. The summaries published by the controllers are not
+// filtered, only the models the watcher subscribes to are restricted. The
+// ID of the new watcher is returned.
+func (r *controllerRoot) watchFilteredModelSummaries(ctx context.Context, filter modelSummaryFilter) (string, error) {
+	const op = errors.Op("jujuapi.watchFilteredModelSummaries")
+
+	err := r.setupUUIDGenerator()
+	if err != nil {
+		return "", errors.E(op, err)
+	}
 
 	id := fmt.Sprintf("%v", r.generator.Next())
 
-	getAllModels := func(ctx context.Context) ([]string, error) {
+	forEachModel := r.jimm.ForEachUserModel
+	if r.user.JimmAdmin {
+		forEachModel = r.jimm.ForEachModel
+	}
+	getModels := func(ctx context.Context) ([]string, error) {
 		var modelUUIDs []string
-		err := r.jimm.ForEachModel(ctx, r.user, func(m *dbmodel.Model, _ jujuparams.UserAccessPermission) error {
-			modelUUIDs = append(modelUUIDs, m.UUID.String)
+		err := forEachModel(ctx, r.user, func(m *dbmodel.Model, _ jujuparams.UserAccessPermission) error {
+			if filter.match(m) {
+				modelUUIDs = append(modelUUIDs, m.UUID.String)
+			}
 			return nil
 		})
 		if err != nil {
@@ -168,15 +193,13 @@ func (r *controllerRoot) WatchAllModelSummaries(ctx context.Context) (jujuparams
 		return modelUUIDs, nil
 	}
 
-	watcher, err := newModelSummaryWatcher(ctx, id, r.jimm.PubSubHub(), getAllModels)
+	watcher, err := newModelSummaryWatcher(ctx, id, r.jimm.PubSubHub(), getModels)
 	if err != nil {
-		return jujuparams.SummaryWatcherID{}, errors.E(op, err)
+		return "", errors.E(op, err)
 	}
 	r.watchers.register(watcher)
 
-	return jujuparams.SummaryWatcherID{
-		WatcherID: id,
-	}, nil
+	return id, nil
 }
 
 // AllModels implments the AllModels command on the Controller facade.
@@ -22,10 +22,13 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jujuapi"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 	jimmversion "github.com/canonical/jimm/v3/version"
 )
 
@@ -321,6 +324,139 @@ func (s *watcherSuite) TestWatchAllModelSummaries(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `not found \(not found\)`)
 }
 
+func (s *watcherSuite) TestWatchFilteredModelSummaries(c *gc.C) {
+	for _, m := range []*dbmodel.Model{s.Model, s.Model2, s.Model3} {
+		done := s.JIMM.Pubsub.Publish(m.UUID.String, jujuparams.ModelAbstract{
+			UUID:  m.UUID.String,
+			Cloud: jimmtest.TestCloudName,
+			Name:  m.Name,
+		})
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			c.Fatalf("timed out")
+		}
+	}
+
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	tests := []struct {
+		about          string
+		req            apiparams.WatchFilteredModelSummariesRequest
+		expectedModels []*dbmodel.Model
+	}{{
+		about:          "filter by owner",
+		req:            apiparams.WatchFilteredModelSummariesRequest{Owner: "charlie@canonical.com"},
+		expectedModels: []*dbmodel.Model{s.Model2, s.Model3},
+	}, {
+		about:          "filter by model uuid",
+		req:            apiparams.WatchFilteredModelSummariesRequest{ModelUUIDs: []string{s.Model.UUID.String}},
+		expectedModels: []*dbmodel.Model{s.Model},
+	}, {
+		about:          "filter by cloud",
+		req:            apiparams.WatchFilteredModelSummariesRequest{Cloud: jimmtest.TestCloudName},
+		expectedModels: []*dbmodel.Model{s.Model, s.Model2, s.Model3},
+	}, {
+		about: "filter by unknown cloud",
+		req:   apiparams.WatchFilteredModelSummariesRequest{Cloud: "no-such-cloud"},
+	}, {
+		about: "filters are combined",
+		req: apiparams.WatchFilteredModelSummariesRequest{
+			Owner:      "charlie@canonical.com",
+			ModelUUIDs: []string{s.Model.UUID.String, s.Model3.UUID.String},
+		},
+		expectedModels: []*dbmodel.Model{s.Model3},
+	}}
+	for _, test := range tests {
+		c.Log(test.about)
+		expectedModels := []jujuparams.ModelAbstract{}
+		for _, m := range test.expectedModels {
+			expectedModels = append(expectedModels, jujuparams.ModelAbstract{
+				UUID:  m.UUID.String,
+				Cloud: jimmtest.TestCloudName,
+				Name:  m.Name,
+			})
+		}
+		sort.Slice(expectedModels, func(i, j int) bool {
+			return expectedModels[i].UUID < expectedModels[j].UUID
+		})
+
+		watcherID, err := client.WatchFilteredModelSummaries(&test.req)
+		c.Assert(err, jc.ErrorIsNil)
+
+		var summaries jujuparams.SummaryWatcherNextResults
+		err = conn.APICall("ModelSummaryWatcher", 1, watcherID.WatcherID, "Next", nil, &summaries)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(summaries.Models, gc.DeepEquals, expectedModels)
+
+		err = conn.APICall("ModelSummaryWatcher", 1, watcherID.WatcherID, "Stop", nil, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *watcherSuite) TestWatchFilteredModelSummariesUserAccess(c *gc.C) {
+	for _, m := range []*dbmodel.Model{s.Model, s.Model2, s.Model3} {
+		done := s.JIMM.Pubsub.Publish(m.UUID.String, jujuparams.ModelAbstract{
+			UUID:  m.UUID.String,
+			Cloud: jimmtest.TestCloudName,
+			Name:  m.Name,
+		})
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			c.Fatalf("timed out")
+		}
+	}
+
+	// bob has access to model-1 and model-3, but not to model-2.
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	tests := []struct {
+		about          string
+		req            apiparams.WatchFilteredModelSummariesRequest
+		expectedModels []*dbmodel.Model
+	}{{
+		about:          "no filter",
+		expectedModels: []*dbmodel.Model{s.Model, s.Model3},
+	}, {
+		about:          "filter by owner",
+		req:            apiparams.WatchFilteredModelSummariesRequest{Owner: "charlie@canonical.com"},
+		expectedModels: []*dbmodel.Model{s.Model3},
+	}, {
+		about: "filter by inaccessible model uuid",
+		req:   apiparams.WatchFilteredModelSummariesRequest{ModelUUIDs: []string{s.Model2.UUID.String}},
+	}}
+	for _, test := range tests {
+		c.Log(test.about)
+		expectedModels := []jujuparams.ModelAbstract{}
+		for _, m := range test.expectedModels {
+			expectedModels = append(expectedModels, jujuparams.ModelAbstract{
+				UUID:  m.UUID.String,
+				Cloud: jimmtest.TestCloudName,
+				Name:  m.Name,
+			})
+		}
+		sort.Slice(expectedModels, func(i, j int) bool {
+			return expectedModels[i].UUID < expectedModels[j].UUID
+		})
+
+		watcherID, err := client.WatchFilteredModelSummaries(&test.req)
+		c.Assert(err, jc.ErrorIsNil)
+
+		var summaries jujuparams.SummaryWatcherNextResults
+		err = conn.APICall("ModelSummaryWatcher", 1, watcherID.WatcherID, "Next", nil, &summaries)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(summaries.Models, gc.DeepEquals, expectedModels)
+
+		err = conn.APICall("ModelSummaryWatcher", 1, watcherID.WatcherID, "Stop", nil, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
 func TestInitiateMigration(t *testing.T) {
 	c := qt.New(t)
 
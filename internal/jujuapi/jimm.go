@@ -37,6 +37,8 @@ func init() {
 		revokeAuditLogAccessMethod := rpc.Method(r.RevokeAuditLogAccess)
 		setControllerDeprecatedMethod := rpc.Method(r.SetControllerDeprecated)
 		fullModelStatusMethod := rpc.Method(r.FullModelStatus)
+		getCloudCredentialMethod := rpc.Method(r.GetCloudCredential)
+		setCloudCredentialLabelsMethod := rpc.Method(r.SetCloudCredentialLabels)
 		updateMigratedModelMethod := rpc.Method(r.UpdateMigratedModel)
 		addCloudToControllerMethod := rpc.Method(r.AddCloudToController)
 		removeCloudFromControllerMethod := rpc.Method(r.RemoveCloudFromController)
@@ -48,9 +50,11 @@ func init() {
 		addRelationMethod := rpc.Method(r.AddRelation)
 		removeRelationMethod := rpc.Method(r.RemoveRelation)
 		checkRelationMethod := rpc.Method(r.CheckRelation)
+		checkUserRelationMethod := rpc.Method(r.CheckUserRelation)
 		listRelationshipTuplesMethod := rpc.Method(r.ListRelationshipTuples)
 		crossModelQueryMethod := rpc.Method(r.CrossModelQuery)
 		purgeLogsMethod := rpc.Method(r.PurgeLogs)
+		pruneAuditEventsMethod := rpc.Method(r.PruneAuditEvents)
 		migrateModel := rpc.Method(r.MigrateModel)
 		addServiceAccountMethod := rpc.Method(r.AddServiceAccount)
 		copyServiceAccountCredentialMethod := rpc.Method(r.CopyServiceAccountCredential)
@@ -58,12 +62,16 @@ func init() {
 		listServiceAccountCredentials := rpc.Method(r.ListServiceAccountCredentials)
 		grantServiceAccountAccess := rpc.Method(r.GrantServiceAccountAccess)
 		version := rpc.Method(r.Version)
+		watchFilteredModelSummariesMethod := rpc.Method(r.WatchFilteredModelSummaries)
+		exportModelSpecMethod := rpc.Method(r.ExportModelSpec)
 
 		// JIMM Generic RPC
 		r.AddMethod("JIMM", 4, "AddController", addControllerMethod)
 		r.AddMethod("JIMM", 4, "DisableControllerUUIDMasking", disableControllerUUIDMaskingMethod)
 		r.AddMethod("JIMM", 4, "FindAuditEvents", findAuditEventsMethod)
 		r.AddMethod("JIMM", 4, "FullModelStatus", fullModelStatusMethod)
+		r.AddMethod("JIMM", 4, "GetCloudCredential", getCloudCredentialMethod)
+		r.AddMethod("JIMM", 4, "SetCloudCredentialLabels", setCloudCredentialLabelsMethod)
 		r.AddMethod("JIMM", 4, "GrantAuditLogAccess", grantAuditLogAccessMethod)
 		r.AddMethod("JIMM", 4, "ImportModel", importModelMethod)
 		r.AddMethod("JIMM", 4, "ListControllers", listControllersMethod)
@@ -74,7 +82,10 @@ func init() {
 		r.AddMethod("JIMM", 4, "AddCloudToController", addCloudToControllerMethod)
 		r.AddMethod("JIMM", 4, "RemoveCloudFromController", removeCloudFromControllerMethod)
 		r.AddMethod("JIMM", 4, "PurgeLogs", purgeLogsMethod)
+		r.AddMethod("JIMM", 4, "PruneAuditEvents", pruneAuditEventsMethod)
 		r.AddMethod("JIMM", 4, "MigrateModel", migrateModel)
+		r.AddMethod("JIMM", 4, "WatchFilteredModelSummaries", watchFilteredModelSummariesMethod)
+		r.AddMethod("JIMM", 4, "ExportModelSpec", exportModelSpecMethod)
 		// JIMM ReBAC RPC
 		r.AddMethod("JIMM", 4, "AddGroup", addGroupMethod)
 		r.AddMethod("JIMM", 4, "GetGroup", getGroupMethod)
@@ -84,6 +95,7 @@ func init() {
 		r.AddMethod("JIMM", 4, "AddRelation", addRelationMethod)
 		r.AddMethod("JIMM", 4, "RemoveRelation", removeRelationMethod)
 		r.AddMethod("JIMM", 4, "CheckRelation", checkRelationMethod)
+		r.AddMethod("JIMM", 4, "CheckUserRelation", checkUserRelationMethod)
 		r.AddMethod("JIMM", 4, "ListRelationshipTuples", listRelationshipTuplesMethod)
 		// JIMM Cross-model queries
 		r.AddMethod("JIMM", 4, "CrossModelQuery", crossModelQueryMethod)
@@ -192,6 +204,17 @@ func (r *controllerRoot) AddController(ctx context.Context, req apiparams.AddCon
 		}
 	}
 
+	var dialTimeout time.Duration
+	if req.DialTimeout != "" {
+		dialTimeout, err = time.ParseDuration(req.DialTimeout)
+		if err != nil {
+			return apiparams.ControllerInfo{}, errors.E(op, errors.CodeBadRequest, err)
+		}
+		if dialTimeout < 0 {
+			return apiparams.ControllerInfo{}, errors.E(op, errors.CodeBadRequest, "dial timeout cannot be negative")
+		}
+	}
+
 	// TODO(ale8k): Don't build dbmodel here, do it as params to AddController.
 	ctl := dbmodel.Controller{
 		UUID:              req.UUID,
@@ -202,6 +225,7 @@ func (r *controllerRoot) AddController(ctx context.Context, req apiparams.AddCon
 		AdminPassword:     req.Password,
 		TLSHostname:       req.TLSHostname,
 		Addresses:         dbmodel.HostPorts{jujuparams.FromProviderHostPorts(nphps)},
+		DialTimeout:       dialTimeout,
 	}
 	if err := r.jimm.AddController(ctx, r.user, &ctl); err != nil {
 		zapctx.Error(ctx, "failed to add controller", zaputil.Error(err))
@@ -210,16 +234,53 @@ func (r *controllerRoot) AddController(ctx context.Context, req apiparams.AddCon
 	return ctl.ToAPIControllerInfo(), nil
 }
 
+// GetCloudCredential returns the details, including any labels, of a
+// cloud credential. The credential attributes are never returned.
+func (r *controllerRoot) GetCloudCredential(ctx context.Context, req apiparams.GetCloudCredentialRequest) (apiparams.CloudCredentialInfo, error) {
+	const op = errors.Op("jujuapi.GetCloudCredential")
+
+	tag, err := names.ParseCloudCredentialTag(req.CredentialTag)
+	if err != nil {
+		return apiparams.CloudCredentialInfo{}, errors.E(op, err, errors.CodeBadRequest)
+	}
+	cred, err := r.jimm.GetCloudCredential(ctx, r.user, tag)
+	if err != nil {
+		return apiparams.CloudCredentialInfo{}, errors.E(op, err)
+	}
+	return apiparams.CloudCredentialInfo{
+		CredentialTag: cred.ResourceTag().String(),
+		AuthType:      cred.AuthType,
+		Labels:        cred.Labels,
+	}, nil
+}
+
+// SetCloudCredentialLabels replaces the labels attached to a cloud
+// credential.
+func (r *controllerRoot) SetCloudCredentialLabels(ctx context.Context, req apiparams.SetCloudCredentialLabelsRequest) error {
+	const op = errors.Op("jujuapi.SetCloudCredentialLabels")
+
+	tag, err := names.ParseCloudCredentialTag(req.CredentialTag)
+	if err != nil {
+		return errors.E(op, err, errors.CodeBadRequest)
+	}
+	if err := r.jimm.SetCloudCredentialLabels(ctx, r.user, tag, req.Labels); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
 // ListControllers returns the list of juju controllers hosting models
 // as part of this JAAS system.
-// If the user is not an admin, they will only receive information about
-// JIMM itself - note that the controller name returned is "jaas".
+// If the user is not an admin, they will receive information about
+// JIMM itself - note that the controller name returned is "jaas" - and
+// any controllers they have been given access to. The addresses, CA
+// certificate and admin user of those controllers are not included.
 func (r *controllerRoot) ListControllers(ctx context.Context) (apiparams.ListControllersResponse, error) {
 	const op = errors.Op("jujuapi.ListControllersV3")
 
 	if !r.user.JimmAdmin {
 		// if the user isn't a controller admin return JAAS
-		// itself as the only controller.
+		// itself followed by the controllers the user can access.
 		srvVersion, err := r.jimm.EarliestControllerVersion(ctx)
 		if err != nil {
 			return apiparams.ListControllersResponse{}, errors.E(op, err)
@@ -234,6 +295,20 @@ func (r *controllerRoot) ListControllers(ctx context.Context) (apiparams.ListCon
 			},
 		}
 		controllers := []apiparams.ControllerInfo{jimmCtl}
+		err = r.jimm.ForEachControllerForUser(ctx, r.user, func(ctl *dbmodel.Controller) error {
+			ci := ctl.ToAPIControllerInfo()
+			// Only JIMM administrators may connect to the
+			// controllers directly.
+			ci.PublicAddress = ""
+			ci.APIAddresses = nil
+			ci.CACertificate = ""
+			ci.Username = ""
+			controllers = append(controllers, ci)
+			return nil
+		})
+		if err != nil {
+			return apiparams.ListControllersResponse{}, errors.E(op, err)
+		}
 		return apiparams.ListControllersResponse{Controllers: controllers}, nil
 	}
 	dbControllers, err := r.jimm.ListControllers(ctx, r.user)
@@ -430,7 +505,7 @@ func (r *controllerRoot) ImportModel(ctx context.Context, req apiparams.ImportMo
 		return errors.E(op, err, errors.CodeBadRequest)
 	}
 
-	err = r.jimm.ImportModel(ctx, r.user, req.Controller, mt, req.Owner)
+	err = r.jimm.ImportModel(ctx, r.user, req.Controller, mt, req.Owner, req.UUID)
 	if err != nil {
 		return errors.E(op, err)
 	}
@@ -484,6 +559,20 @@ func (r *controllerRoot) PurgeLogs(ctx context.Context, req apiparams.PurgeLogsR
 	}, nil
 }
 
+// PruneAuditEvents removes all audit events older than the specified
+// time, exporting any events pending export to the audit sink first.
+func (r *controllerRoot) PruneAuditEvents(ctx context.Context, req apiparams.PruneAuditEventsRequest) (apiparams.PruneAuditEventsResponse, error) {
+	const op = errors.Op("jujuapi.PruneAuditEvents")
+
+	count, err := r.jimm.PruneAuditEvents(ctx, r.user, req.OlderThan)
+	if err != nil {
+		return apiparams.PruneAuditEventsResponse{}, errors.E(op, err)
+	}
+	return apiparams.PruneAuditEventsResponse{
+		DeletedCount: count,
+	}, nil
+}
+
 // MigrateModel is a JIMM specific method for migrating models between two controllers that
 // are already attached to JIMM. See InitiateMigration in controller.go to migrate a model
 // in a controller attached to JIMM to one not managed by JIMM.
@@ -517,3 +606,36 @@ func (r *controllerRoot) Version(ctx context.Context) (apiparams.VersionResponse
 	}
 	return versionInfo, nil
 }
+
+// WatchFilteredModelSummaries starts a model summary watcher that only
+// returns the summaries of the models matching the requested filter.
+// Users who are not JIMM administrators only receive the summaries of the
+// models they have access to. The returned watcher is used with the
+// ModelSummaryWatcher facade.
+func (r *controllerRoot) WatchFilteredModelSummaries(ctx context.Context, req apiparams.WatchFilteredModelSummariesRequest) (jujuparams.SummaryWatcherID, error) {
+	const op = errors.Op("jujuapi.WatchFilteredModelSummaries")
+
+	id, err := r.watchFilteredModelSummaries(ctx, newModelSummaryFilter(req))
+	if err != nil {
+		return jujuparams.SummaryWatcherID{}, errors.E(op, err)
+	}
+	return jujuparams.SummaryWatcherID{
+		WatcherID: id,
+	}, nil
+}
+
+// ExportModelSpec returns the specification of the requested model,
+// suitable for re-creating the model elsewhere.
+func (r *controllerRoot) ExportModelSpec(ctx context.Context, req apiparams.ExportModelSpecRequest) (apiparams.ModelSpec, error) {
+	const op = errors.Op("jujuapi.ExportModelSpec")
+
+	mt, err := names.ParseModelTag(req.ModelTag)
+	if err != nil {
+		return apiparams.ModelSpec{}, errors.E(op, err, errors.CodeBadRequest)
+	}
+	spec, err := r.jimm.ExportModelSpec(ctx, r.user, mt)
+	if err != nil {
+		return apiparams.ModelSpec{}, errors.E(op, err)
+	}
+	return *spec, nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jujuapi"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
 	"github.com/canonical/jimm/v3/pkg/api"
 	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
@@ -99,6 +100,52 @@ func (s *jimmSuite) TestListControllersUnauthorized(c *gc.C) {
 	}})
 }
 
+func (s *jimmSuite) TestListControllersControllerAccess(c *gc.C) {
+	ctx := context.Background()
+	s.AddController(c, "controller-0", s.APIInfo(c))
+
+	bob, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, gc.IsNil)
+	err = s.JIMM.Database.GetIdentity(ctx, bob)
+	c.Assert(err, gc.IsNil)
+	// The test controllers share a UUID, so bob can access all of them.
+	err = s.NewUser(bob).SetControllerAccess(ctx, names.NewControllerTag(s.Model.Controller.UUID), ofganames.AdministratorRelation)
+	c.Assert(err, gc.IsNil)
+
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+
+	client := api.NewClient(conn)
+	cis, err := client.ListControllers()
+	c.Assert(err, gc.Equals, nil)
+	c.Check(cis, jc.DeepEquals, []apiparams.ControllerInfo{{
+		Name:         "jaas",
+		UUID:         jimmtest.ControllerUUID,
+		AgentVersion: s.Model.Controller.AgentVersion,
+		Status: jujuparams.EntityStatus{
+			Status: "available",
+		},
+	}, {
+		Name:         "controller-0",
+		UUID:         s.Model.Controller.UUID,
+		CloudTag:     names.NewCloudTag(jimmtest.TestCloudName).String(),
+		CloudRegion:  jimmtest.TestCloudRegionName,
+		AgentVersion: s.Model.Controller.AgentVersion,
+		Status: jujuparams.EntityStatus{
+			Status: "available",
+		},
+	}, {
+		Name:         "controller-1",
+		UUID:         s.Model.Controller.UUID,
+		CloudTag:     names.NewCloudTag(jimmtest.TestCloudName).String(),
+		CloudRegion:  jimmtest.TestCloudRegionName,
+		AgentVersion: s.Model.Controller.AgentVersion,
+		Status: jujuparams.EntityStatus{
+			Status: "available",
+		},
+	}})
+}
+
 func (s *jimmSuite) TestAddControllerPublicAddressWithoutPort(c *gc.C) {
 	conn := s.open(c, nil, "alice")
 	defer conn.Close()
@@ -153,6 +200,13 @@ func (s *jimmSuite) TestAddController(c *gc.C) {
 		Password:      info.Password,
 	}
 
+	// The juju controller has already been added as controller-1.
+	_, err := client.AddController(&acr)
+	c.Assert(err, gc.ErrorMatches, `controller `+info.ControllerUUID+` already exists as "controller-1" \(already exists\)`)
+	c.Assert(jujuparams.IsCodeAlreadyExists(err), gc.Equals, true)
+	_, err = client.RemoveController(&apiparams.RemoveControllerRequest{Name: "controller-1", Force: true})
+	c.Assert(err, gc.Equals, nil)
+
 	ci, err := client.AddController(&acr)
 	c.Assert(err, gc.Equals, nil)
 	c.Assert(ci, jc.DeepEquals, apiparams.ControllerInfo{
@@ -202,6 +256,10 @@ func (s *jimmSuite) TestRemoveAndAddController(c *gc.C) {
 		Password:      info.Password,
 	}
 
+	// Remove controller-1 as it is the same juju controller.
+	_, err := client.RemoveController(&apiparams.RemoveControllerRequest{Name: "controller-1", Force: true})
+	c.Assert(err, gc.Equals, nil)
+
 	ci, err := client.AddController(&acr)
 	c.Assert(err, gc.Equals, nil)
 	_, err = client.RemoveController(&apiparams.RemoveControllerRequest{Name: acr.Name, Force: true})
@@ -229,7 +287,11 @@ func (s *jimmSuite) TestAddControllerCustomTLSHostname(c *gc.C) {
 		TLSHostname:   "foo",
 	}
 
-	_, err := client.AddController(&acr)
+	// Remove controller-1 as it is the same juju controller.
+	_, err := client.RemoveController(&apiparams.RemoveControllerRequest{Name: "controller-1", Force: true})
+	c.Assert(err, gc.Equals, nil)
+
+	_, err = client.AddController(&acr)
 	c.Assert(err, gc.ErrorMatches, "failed to dial the controller")
 	acr.TLSHostname = "juju-apiserver"
 	ci, err := client.AddController(&acr)
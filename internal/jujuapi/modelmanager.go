@@ -62,12 +62,13 @@ type ModelManager interface {
 	DestroyModel(ctx context.Context, u *openfga.User, mt names.ModelTag, destroyStorage *bool, force *bool, maxWait *time.Duration, timeout *time.Duration) error
 	DumpModel(ctx context.Context, u *openfga.User, mt names.ModelTag, simplified bool) (string, error)
 	DumpModelDB(ctx context.Context, u *openfga.User, mt names.ModelTag) (map[string]interface{}, error)
+	ExportModelSpec(ctx context.Context, user *openfga.User, mt names.ModelTag) (*params.ModelSpec, error)
 	ForEachModel(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
 	ForEachUserModel(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
 	FullModelStatus(ctx context.Context, user *openfga.User, modelTag names.ModelTag, patterns []string) (*jujuparams.FullStatus, error)
 	GetModel(ctx context.Context, uuid string) (dbmodel.Model, error)
 	IdentityModelDefaults(ctx context.Context, user *dbmodel.Identity) (map[string]interface{}, error)
-	ImportModel(ctx context.Context, user *openfga.User, controllerName string, modelTag names.ModelTag, newOwner string) error
+	ImportModel(ctx context.Context, user *openfga.User, controllerName string, modelTag names.ModelTag, newOwner, uuidOverride string) error
 	ModelDefaultsForCloud(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag) (jujuparams.ModelDefaultsResult, error)
 	ModelInfo(ctx context.Context, u *openfga.User, mt names.ModelTag) (*jujuparams.ModelInfo, error)
 	ModelStatus(ctx context.Context, u *openfga.User, mt names.ModelTag) (*jujuparams.ModelStatus, error)
@@ -150,7 +151,7 @@ func (r *controllerRoot) ModelInfo(ctx context.Context, args jujuparams.Entities
 		}
 		results[i].Result, err = r.jimm.ModelInfo(ctx, r.user, mt)
 		if err != nil {
-			if errors.ErrorCode(err) == errors.CodeNotFound {
+			if errors.IsNotFound(err) {
 				// Map not-found errors to unauthorized, this is what juju
 				// does.
 				err = errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -206,7 +207,7 @@ func (r *controllerRoot) DestroyModels(ctx context.Context, args jujuparams.Dest
 		}
 
 		if err := r.jimm.DestroyModel(ctx, r.user, mt, model.DestroyStorage, model.Force, model.MaxWait, model.Timeout); err != nil {
-			if errors.ErrorCode(err) != errors.CodeNotFound {
+			if !errors.IsNotFound(err) {
 				// It isn't an error to try and destroy an already
 				// destroyed model.
 				results[i].Error = mapError(errors.E(op, err))
@@ -245,7 +246,7 @@ func (r *controllerRoot) ModifyModelAccess(ctx context.Context, args jujuparams.
 		default:
 			err = errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid action %q", change.Action))
 		}
-		if errors.ErrorCode(err) == errors.CodeNotFound {
+		if errors.IsNotFound(err) {
 			err = errors.E(op, errors.CodeUnauthorized, "unauthorized")
 		}
 		results[i].Error = mapError(err)
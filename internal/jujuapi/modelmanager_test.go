@@ -4,6 +4,7 @@ package jujuapi_test
 
 import (
 	"context"
+	"database/sql"
 	"sort"
 	"time"
 
@@ -915,6 +916,45 @@ func (s *modelManagerSuite) TestCreateModel(c *gc.C) {
 	}
 }
 
+func (s *modelManagerSuite) TestCreateModelWithExpiry(c *gc.C) {
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	var mi jujuparams.ModelInfo
+	err := conn.APICall("ModelManager", 9, "", "CreateModel", jujuparams.ModelCreateArgs{
+		Name:     "expiring-model",
+		OwnerTag: names.NewUserTag("bob@canonical.com").String(),
+		Config: map[string]interface{}{
+			"jimm-expires-at":   expiresAt.Format(time.RFC3339),
+			"jimm-idle-timeout": "1h",
+		},
+		CloudTag:           names.NewCloudTag(jimmtest.TestCloudName).String(),
+		CloudCredentialTag: "cloudcred-" + jimmtest.TestCloudName + "_bob@canonical.com_cred",
+	}, &mi)
+	c.Assert(err, gc.Equals, nil)
+
+	m := dbmodel.Model{
+		UUID: sql.NullString{String: mi.UUID, Valid: true},
+	}
+	err = s.JIMM.Database.GetModel(context.Background(), &m)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(m.ExpiresAt.Valid, gc.Equals, true)
+	c.Check(m.ExpiresAt.Time.Equal(expiresAt), gc.Equals, true)
+	c.Check(m.IdleTimeout, gc.Equals, time.Hour)
+
+	err = conn.APICall("ModelManager", 9, "", "CreateModel", jujuparams.ModelCreateArgs{
+		Name:     "expiring-model-2",
+		OwnerTag: names.NewUserTag("bob@canonical.com").String(),
+		Config: map[string]interface{}{
+			"jimm-idle-timeout": "a while",
+		},
+		CloudTag:           names.NewCloudTag(jimmtest.TestCloudName).String(),
+		CloudCredentialTag: "cloudcred-" + jimmtest.TestCloudName + "_bob@canonical.com_cred",
+	}, &mi)
+	c.Assert(err, gc.ErrorMatches, `invalid jimm-idle-timeout a while, expected a duration \(bad request\)`)
+}
+
 func (s *modelManagerSuite) TestCreateDuplicateModelsFails(c *gc.C) {
 	conn := s.open(c, nil, "bob")
 	defer conn.Close()
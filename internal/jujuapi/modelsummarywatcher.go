@@ -14,9 +14,11 @@ import (
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
 
+	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
 	"github.com/canonical/jimm/v3/internal/pubsub"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
 
 func init() {
@@ -186,6 +188,44 @@ func (w *modelSummaryWatcher) Stop() error {
 	return nil
 }
 
+// A modelSummaryFilter restricts the models whose summaries are sent to
+// a model summary watcher. Empty fields match every model.
+type modelSummaryFilter struct {
+	owner string
+	cloud string
+	uuids map[string]bool
+}
+
+// newModelSummaryFilter creates a modelSummaryFilter from the given
+// request.
+func newModelSummaryFilter(req apiparams.WatchFilteredModelSummariesRequest) modelSummaryFilter {
+	f := modelSummaryFilter{
+		owner: req.Owner,
+		cloud: req.Cloud,
+	}
+	if len(req.ModelUUIDs) > 0 {
+		f.uuids = make(map[string]bool, len(req.ModelUUIDs))
+		for _, uuid := range req.ModelUUIDs {
+			f.uuids[uuid] = true
+		}
+	}
+	return f
+}
+
+// match determines whether the given model matches the filter.
+func (f modelSummaryFilter) match(m *dbmodel.Model) bool {
+	if f.owner != "" && m.OwnerIdentityName != f.owner {
+		return false
+	}
+	if f.cloud != "" && m.CloudRegion.CloudName != f.cloud {
+		return false
+	}
+	if f.uuids != nil && !f.uuids[m.UUID.String] {
+		return false
+	}
+	return true
+}
+
 //nolint:unused // Used in export-test.
 func newModelAccessWatcher(ctx context.Context, period time.Duration, modelGetterFunc func(context.Context) ([]string, error)) *modelAccessWatcher {
 	return &modelAccessWatcher{
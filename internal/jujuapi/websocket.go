@@ -5,6 +5,7 @@ package jujuapi
 import (
 	"context"
 	"database/sql"
+	"net"
 	"net/http"
 	"regexp"
 	"time"
@@ -133,9 +134,16 @@ func mapError(err error) *jujuparams.Error {
 	// TODO the error mapper should really accept a context from the RPC package.
 	zapctx.Debug(context.TODO(), "rpc error", zaputil.Error(err))
 
+	code := errors.ErrorCode(err)
+	switch code {
+	case errors.CodeControllerNotFound, errors.CodeCloudNotFound:
+		// Juju clients only understand the generic not-found code
+		// for these entities.
+		code = errors.CodeNotFound
+	}
 	return &jujuparams.Error{
 		Message: err.Error(),
-		Code:    string(errors.ErrorCode(err)),
+		Code:    string(code),
 	}
 }
 
@@ -175,8 +183,17 @@ func modelInfoFromPath(path string) (uuid string, finalPath string, err error) {
 // requests to the appropriate Juju controller.
 func (s apiProxier) ServeWS(ctx context.Context, clientConn *websocket.Conn) {
 	jwtGenerator := jimm.NewJWTGenerator(&s.jimm.Database, s.jimm, s.jimm.JWTService)
+	jwtGenerator.AddClaimsSources(s.jimm.JWTClaimsSources...)
+	jwtGenerator.SetLoginAttemptTracker(s.jimm.LoginAttempts)
 	connectionFunc := controllerConnectionFunc(s, &jwtGenerator)
 	zapctx.Debug(ctx, "Starting proxier")
+	// Track the session so that it can be listed and revoked by
+	// administrators, revoking the session cancels the proxy context.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	modelUUID, _, _ := modelInfoFromPath(jimmhttp.PathElementFromContext(ctx, "path"))
+	_, untrack := s.jimm.TrackModelSession(modelUUID, clientIP(clientConn), jwtGenerator.GetUser, cancel)
+	defer untrack()
 	auditLogger := s.jimm.AddAuditLogEntry
 	proxyHelpers := jimmRPC.ProxyHelpers{
 		ConnClient:              clientConn,
@@ -185,12 +202,24 @@ func (s apiProxier) ServeWS(ctx context.Context, clientConn *websocket.Conn) {
 		AuditLog:                auditLogger,
 		LoginService:            s.jimm,
 		AuthenticatedIdentityID: auth.SessionIdentityFromContext(ctx),
+		LoginTimeout:            s.params.LoginTimeout,
 	}
 	if err := jimmRPC.ProxySockets(ctx, proxyHelpers); err != nil {
 		zapctx.Error(ctx, "failed to start jimm model proxy", zap.Error(err))
 	}
 }
 
+// clientIP returns the IP address of the client on the other end of the
+// given websocket connection.
+func clientIP(conn *websocket.Conn) string {
+	addr := conn.RemoteAddr().String()
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
 // controllerConnectionFunc returns a function that will be used to
 // connect to a controller when a client makes a request.
 func controllerConnectionFunc(s apiProxier, jwtGenerator *jimm.JWTGenerator) func(context.Context) (jimmRPC.WebsocketConnectionWithMetadata, error) {
@@ -221,6 +250,11 @@ func controllerConnectionFunc(s apiProxier, jwtGenerator *jimm.JWTGenerator) fun
 			zapctx.Error(ctx, "cannot dial controller", zap.String("controller", m.Controller.Name), zap.Error(err))
 			return jimmRPC.WebsocketConnectionWithMetadata{}, err
 		}
+		// The connection is recorded so that idle models can be
+		// destroyed, failing to record it must not fail the connection.
+		if err := s.jimm.Database.UpdateModelLastConnection(ctx, &m, time.Now()); err != nil {
+			zapctx.Error(ctx, "failed to record model connection", zap.String("uuid", uuid), zap.Error(err))
+		}
 		fullModelName := m.Controller.Name + "/" + m.Name
 		return jimmRPC.WebsocketConnectionWithMetadata{
 			Conn:           controllerConn,
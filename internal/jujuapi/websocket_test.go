@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/juju/juju/api"
@@ -61,6 +62,7 @@ func (s *websocketSuite) SetUpTest(c *gc.C) {
 		jimmhttp.NewHTTPProxyHandler(s.JIMM),
 	)
 	mux.Handle("/model/*", http.StripPrefix("/model", jujuapi.ModelHandler(ctx, s.JIMM, s.Params)))
+	mux.Handle("/audit-events", jujuapi.AuditEventsHandler(ctx, s.JIMM, s.Params))
 	jwks := jimmhttp.NewWellKnownHandler(s.JIMM.CredentialStore)
 	mux.HandleFunc("/.well-known/jwks.json", jwks.JWKS)
 
@@ -249,6 +251,62 @@ func (s *apiProxySuite) TestModelStatusWithoutPermission(c *gc.C) {
 	c.Check(outputNoNewLine, gc.Matches, `Please visit .* and enter code.*`)
 }
 
+func (s *apiProxySuite) TestActiveSessions(c *gc.C) {
+	ctx := context.Background()
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, s.JIMM.OpenFGAClient)
+	admin.JimmAdmin = true
+
+	conn := s.open(c, &api.Info{
+		ModelTag:  s.Model.ResourceTag(),
+		SkipLogin: false,
+	}, "alice@canonical.com")
+
+	sessions, err := s.JIMM.ListActiveSessions(ctx, admin)
+	c.Assert(err, gc.IsNil)
+	c.Assert(sessions, gc.HasLen, 1)
+	c.Check(sessions[0].Identity, gc.Equals, "alice@canonical.com")
+	c.Check(sessions[0].ModelUUID, gc.Equals, s.Model.UUID.String)
+	c.Check(sessions[0].ClientIP, gc.Equals, "127.0.0.1")
+
+	conn.Close()
+	// The session is removed once the server notices the connection
+	// has closed.
+	for i := 0; i < 100; i++ {
+		sessions, err = s.JIMM.ListActiveSessions(ctx, admin)
+		c.Assert(err, gc.IsNil)
+		if len(sessions) == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Check(sessions, gc.HasLen, 0)
+}
+
+func (s *apiProxySuite) TestRevokeSession(c *gc.C) {
+	ctx := context.Background()
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, s.JIMM.OpenFGAClient)
+	admin.JimmAdmin = true
+
+	conn := s.open(c, &api.Info{
+		ModelTag:  s.Model.ResourceTag(),
+		SkipLogin: false,
+	}, "alice@canonical.com")
+	defer conn.Close()
+
+	sessions, err := s.JIMM.ListActiveSessions(ctx, admin)
+	c.Assert(err, gc.IsNil)
+	c.Assert(sessions, gc.HasLen, 1)
+
+	err = s.JIMM.RevokeSession(ctx, admin, sessions[0].ID)
+	c.Assert(err, gc.IsNil)
+
+	select {
+	case <-conn.Broken():
+	case <-time.After(time.Second):
+		c.Fatalf("session not closed")
+	}
+}
+
 // TODO(Kian): This test aims to verify that JIMM gracefully handles clients that end their connection
 // during the login flow after JIMM starts polling the OIDC server.
 // After https://github.com/juju/juju/pull/17606 lands we can begin work on this.
@@ -0,0 +1,71 @@
+// Copyright 2024 Canonical.
+
+package jujuclient
+
+import (
+	"context"
+
+	jujuerrors "github.com/juju/errors"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// ListBlocks returns the blocks that are switched on for the model the
+// connection is connected to. This uses the List method on the Block
+// facade.
+func (c Connection) ListBlocks(ctx context.Context) ([]jujuparams.Block, error) {
+	const op = errors.Op("jujuclient.ListBlocks")
+
+	var resp jujuparams.BlockResults
+	if err := c.Call(ctx, "Block", 2, "", "List", nil, &resp); err != nil {
+		return nil, errors.E(op, jujuerrors.Cause(err))
+	}
+	blocks := make([]jujuparams.Block, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		if r.Error != nil {
+			return nil, errors.E(op, r.Error)
+		}
+		blocks = append(blocks, r.Result)
+	}
+	return blocks, nil
+}
+
+// SwitchBlockOn switches on the given type of block, with the given
+// message, for the model the connection is connected to. This uses the
+// SwitchBlockOn method on the Block facade.
+func (c Connection) SwitchBlockOn(ctx context.Context, blockType, message string) error {
+	const op = errors.Op("jujuclient.SwitchBlockOn")
+
+	args := jujuparams.BlockSwitchParams{
+		Type:    blockType,
+		Message: message,
+	}
+	var resp jujuparams.ErrorResult
+	if err := c.Call(ctx, "Block", 2, "", "SwitchBlockOn", &args, &resp); err != nil {
+		return errors.E(op, jujuerrors.Cause(err))
+	}
+	if resp.Error != nil {
+		return errors.E(op, resp.Error)
+	}
+	return nil
+}
+
+// SwitchBlockOff switches off the given type of block for the model the
+// connection is connected to. This uses the SwitchBlockOff method on the
+// Block facade.
+func (c Connection) SwitchBlockOff(ctx context.Context, blockType string) error {
+	const op = errors.Op("jujuclient.SwitchBlockOff")
+
+	args := jujuparams.BlockSwitchParams{
+		Type: blockType,
+	}
+	var resp jujuparams.ErrorResult
+	if err := c.Call(ctx, "Block", 2, "", "SwitchBlockOff", &args, &resp); err != nil {
+		return errors.E(op, jujuerrors.Cause(err))
+	}
+	if resp.Error != nil {
+		return errors.E(op, resp.Error)
+	}
+	return nil
+}
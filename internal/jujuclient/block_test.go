@@ -0,0 +1,84 @@
+// Copyright 2024 Canonical.
+package jujuclient_test
+
+import (
+	"context"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+type blockSuite struct {
+	jujuclientSuite
+}
+
+var _ = gc.Suite(&blockSuite{})
+
+func (s *blockSuite) TestBlocks(c *gc.C) {
+	ctx := context.Background()
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/bob@canonical.com/pw1").String()
+	cred := jujuparams.TaggedCredential{
+		Tag: cct,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "alibaba",
+				"password": "open sesame",
+			},
+		},
+	}
+
+	info := s.APIInfo(c)
+	ctl := dbmodel.Controller{
+		UUID:              info.ControllerUUID,
+		Name:              s.ControllerConfig.ControllerName(),
+		CACertificate:     info.CACert,
+		AdminIdentityName: info.Tag.Id(),
+		AdminPassword:     info.Password,
+		PublicAddress:     info.Addrs[0],
+	}
+
+	_, err := s.API.UpdateCredential(ctx, cred)
+	c.Assert(err, gc.Equals, nil)
+
+	var modelInfo jujuparams.ModelInfo
+	err = s.API.CreateModel(ctx, &jujuparams.ModelCreateArgs{
+		Name:               "model-1",
+		OwnerTag:           names.NewUserTag("bob@canonical.com").String(),
+		CloudCredentialTag: cct,
+	}, &modelInfo)
+	c.Assert(err, gc.Equals, nil)
+	mt := names.NewModelTag(modelInfo.UUID)
+
+	api, err := s.Dialer.Dial(ctx, &ctl, mt, nil)
+	c.Assert(err, gc.IsNil)
+	defer api.Close()
+
+	blocks, err := api.ListBlocks(ctx)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(blocks, gc.HasLen, 0)
+
+	err = api.SwitchBlockOn(ctx, "BlockDestroy", "do not destroy")
+	c.Assert(err, gc.Equals, nil)
+
+	blocks, err = api.ListBlocks(ctx)
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(blocks, gc.HasLen, 1)
+	c.Check(blocks[0].Type, gc.Equals, "BlockDestroy")
+	c.Check(blocks[0].Message, gc.Equals, "do not destroy")
+
+	err = s.API.DestroyModel(ctx, mt, nil, nil, nil, nil)
+	c.Check(jujuparams.ErrCode(err), gc.Equals, jujuparams.CodeOperationBlocked)
+
+	err = api.SwitchBlockOff(ctx, "BlockDestroy")
+	c.Assert(err, gc.Equals, nil)
+
+	blocks, err = api.ListBlocks(ctx)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(blocks, gc.HasLen, 0)
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Canonical.
+
+package jujuclient
+
+import (
+	"context"
+
+	jujuerrors "github.com/juju/errors"
+	"github.com/juju/juju/core/constraints"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// ModelGet returns the configuration of the model the connection is
+// connected to, along with the source of each value. This uses the
+// ModelGet method on the ModelConfig facade.
+func (c Connection) ModelGet(ctx context.Context) (map[string]jujuparams.ConfigValue, error) {
+	const op = errors.Op("jujuclient.ModelGet")
+
+	var resp jujuparams.ModelConfigResults
+	if err := c.Call(ctx, "ModelConfig", 3, "", "ModelGet", nil, &resp); err != nil {
+		return nil, errors.E(op, jujuerrors.Cause(err))
+	}
+	return resp.Config, nil
+}
+
+// ModelSet sets the given configuration values on the model the
+// connection is connected to. This uses the ModelSet method on the
+// ModelConfig facade.
+func (c Connection) ModelSet(ctx context.Context, config map[string]interface{}) error {
+	const op = errors.Op("jujuclient.ModelSet")
+
+	args := jujuparams.ModelSet{
+		Config: config,
+	}
+	if err := c.Call(ctx, "ModelConfig", 3, "", "ModelSet", &args, nil); err != nil {
+		return errors.E(op, jujuerrors.Cause(err))
+	}
+	return nil
+}
+
+// ModelUnset removes the given configuration keys from the model the
+// connection is connected to, returning them to their default values.
+// This uses the ModelUnset method on the ModelConfig facade.
+func (c Connection) ModelUnset(ctx context.Context, keys []string) error {
+	const op = errors.Op("jujuclient.ModelUnset")
+
+	args := jujuparams.ModelUnset{
+		Keys: keys,
+	}
+	if err := c.Call(ctx, "ModelConfig", 3, "", "ModelUnset", &args, nil); err != nil {
+		return errors.E(op, jujuerrors.Cause(err))
+	}
+	return nil
+}
+
+// GetModelConstraints returns the constraints of the model the
+// connection is connected to. This uses the GetModelConstraints method
+// on the ModelConfig facade.
+func (c Connection) GetModelConstraints(ctx context.Context) (constraints.Value, error) {
+	const op = errors.Op("jujuclient.GetModelConstraints")
+
+	var resp jujuparams.GetConstraintsResults
+	if err := c.Call(ctx, "ModelConfig", 3, "", "GetModelConstraints", nil, &resp); err != nil {
+		return constraints.Value{}, errors.E(op, jujuerrors.Cause(err))
+	}
+	return resp.Constraints, nil
+}
+
+// SetSLALevel sets the SLA level and owner of the model the connection
+// is connected to. This uses the SetSLALevel method on the ModelConfig
+// facade.
+func (c Connection) SetSLALevel(ctx context.Context, level, owner string) error {
+	const op = errors.Op("jujuclient.SetSLALevel")
+
+	args := jujuparams.ModelSLA{
+		ModelSLAInfo: jujuparams.ModelSLAInfo{
+			Level: level,
+			Owner: owner,
+		},
+	}
+	if err := c.Call(ctx, "ModelConfig", 3, "", "SetSLALevel", &args, nil); err != nil {
+		return errors.E(op, jujuerrors.Cause(err))
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Canonical.
+package jujuclient_test
+
+import (
+	"context"
+
+	"github.com/juju/juju/core/constraints"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+type modelConfigSuite struct {
+	jujuclientSuite
+}
+
+var _ = gc.Suite(&modelConfigSuite{})
+
+func (s *modelConfigSuite) TestModelGetAndConstraints(c *gc.C) {
+	ctx := context.Background()
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/bob@canonical.com/pw1").String()
+	cred := jujuparams.TaggedCredential{
+		Tag: cct,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "alibaba",
+				"password": "open sesame",
+			},
+		},
+	}
+
+	info := s.APIInfo(c)
+	ctl := dbmodel.Controller{
+		UUID:              info.ControllerUUID,
+		Name:              s.ControllerConfig.ControllerName(),
+		CACertificate:     info.CACert,
+		AdminIdentityName: info.Tag.Id(),
+		AdminPassword:     info.Password,
+		PublicAddress:     info.Addrs[0],
+	}
+
+	_, err := s.API.UpdateCredential(ctx, cred)
+	c.Assert(err, gc.Equals, nil)
+
+	var modelInfo jujuparams.ModelInfo
+	err = s.API.CreateModel(ctx, &jujuparams.ModelCreateArgs{
+		Name:               "model-1",
+		OwnerTag:           names.NewUserTag("bob@canonical.com").String(),
+		CloudCredentialTag: cct,
+		Config: map[string]interface{}{
+			"logging-config": "<root>=DEBUG",
+		},
+	}, &modelInfo)
+	c.Assert(err, gc.Equals, nil)
+
+	api, err := s.Dialer.Dial(ctx, &ctl, names.NewModelTag(modelInfo.UUID), nil)
+	c.Assert(err, gc.IsNil)
+	defer api.Close()
+
+	config, err := api.ModelGet(ctx)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(config["name"].Value, gc.Equals, "model-1")
+	c.Check(config["logging-config"], gc.DeepEquals, jujuparams.ConfigValue{
+		Value:  "<root>=DEBUG",
+		Source: "model",
+	})
+
+	cons, err := api.GetModelConstraints(ctx)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(cons, gc.DeepEquals, constraints.Value{})
+
+	err = api.ModelSet(ctx, map[string]interface{}{"http-proxy": "http://proxy.example.com"})
+	c.Assert(err, gc.Equals, nil)
+	config, err = api.ModelGet(ctx)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(config["http-proxy"].Value, gc.Equals, "http://proxy.example.com")
+
+	err = api.ModelUnset(ctx, []string{"http-proxy"})
+	c.Assert(err, gc.Equals, nil)
+	config, err = api.ModelGet(ctx)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(config["http-proxy"].Value, gc.Equals, "")
+}
@@ -85,6 +85,23 @@ func (c Connection) GrantJIMMModelAdmin(ctx context.Context, tag names.ModelTag)
 	return nil
 }
 
+// ListModels returns the models the given user has access to on the
+// controller. Listing the models of a user other than the authenticated
+// user requires superuser access to the controller. ListModels uses the
+// ListModels procedure on the ModelManager facade.
+func (c Connection) ListModels(ctx context.Context, user names.UserTag) ([]jujuparams.UserModel, error) {
+	const op = errors.Op("jujuclient.ListModels")
+	args := jujuparams.Entity{
+		Tag: user.String(),
+	}
+
+	var resp jujuparams.UserModelList
+	if err := c.Call(ctx, "ModelManager", 9, "", "ListModels", &args, &resp); err != nil {
+		return nil, errors.E(op, jujuerrors.Cause(err))
+	}
+	return resp.UserModels, nil
+}
+
 // DumpModel dumps debugging details for the given model. If the simplied
 // dump is requested then a simplified dump is returned. DumpModel uses the
 // DumpModels method on the ModelManager facade.
@@ -208,6 +225,30 @@ func (c Connection) ControllerModelSummary(ctx context.Context, ms *jujuparams.M
 	return errors.E(op, "controller model not found", errors.CodeNotFound)
 }
 
+// ModelDefaultsForCloud returns the model-defaults configured on the
+// controller for the given cloud, keyed by configuration attribute.
+// ModelDefaultsForCloud uses the ModelDefaultsForClouds procedure on the
+// ModelManager facade.
+func (c Connection) ModelDefaultsForCloud(ctx context.Context, tag names.CloudTag) (map[string]jujuparams.ModelDefaults, error) {
+	const op = errors.Op("jujuclient.ModelDefaultsForCloud")
+	args := jujuparams.Entities{
+		Entities: []jujuparams.Entity{{
+			Tag: tag.String(),
+		}},
+	}
+	resp := jujuparams.ModelDefaultsResults{
+		Results: make([]jujuparams.ModelDefaultsResult, 1),
+	}
+	err := c.Call(ctx, "ModelManager", 9, "", "ModelDefaultsForClouds", &args, &resp)
+	if err != nil {
+		return nil, errors.E(op, jujuerrors.Cause(err))
+	}
+	if resp.Results[0].Error != nil {
+		return nil, errors.E(op, resp.Results[0].Error)
+	}
+	return resp.Results[0].Config, nil
+}
+
 // ValidateModelUpgrade validates if a model is allowed to perform an upgrade. It
 // uses ValidateModelUpgrades on the ModelManager facade.
 func (c Connection) ValidateModelUpgrade(ctx context.Context, model names.ModelTag, force bool) error {
@@ -123,6 +123,25 @@ func (s *modelmanagerSuite) TestModelInfoError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `permission denied`)
 }
 
+func (s *modelmanagerSuite) TestListModels(c *gc.C) {
+	ctx := context.Background()
+
+	owner := names.NewUserTag("test-user@canonical.com")
+	var info jujuparams.ModelInfo
+	err := s.API.CreateModel(ctx, &jujuparams.ModelCreateArgs{
+		Name:     "test-model",
+		OwnerTag: owner.String(),
+	}, &info)
+	c.Assert(err, gc.Equals, nil)
+
+	models, err := s.API.ListModels(ctx, owner)
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(models, gc.HasLen, 1)
+	c.Check(models[0].Name, gc.Equals, "test-model")
+	c.Check(models[0].UUID, gc.Equals, info.UUID)
+	c.Check(models[0].OwnerTag, gc.Equals, owner.String())
+}
+
 func (s *modelmanagerSuite) TestGrantRevokeModel(c *gc.C) {
 	ctx := context.Background()
 
@@ -203,6 +222,14 @@ func (s *modelmanagerSuite) TestValidateModelUpgrade(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("model %q not found", uuid))
 }
 
+func (s *modelmanagerSuite) TestModelDefaultsForCloud(c *gc.C) {
+	ctx := context.Background()
+
+	defaults, err := s.API.ModelDefaultsForCloud(ctx, names.NewCloudTag(jimmtest.TestCloudName))
+	c.Assert(err, gc.Equals, nil)
+	c.Check(defaults["automatically-retry-hooks"].Default, gc.Equals, true)
+}
+
 func (s *modelmanagerSuite) TestDestroyModel(c *gc.C) {
 	ctx := context.Background()
 
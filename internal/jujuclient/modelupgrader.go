@@ -0,0 +1,34 @@
+// Copyright 2024 Canonical.
+
+package jujuclient
+
+import (
+	"context"
+
+	jujuerrors "github.com/juju/errors"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// UpgradeModel starts the upgrade of the given model to the given agent
+// version, returning the version the controller chose to upgrade to. This
+// uses the UpgradeModel method on the ModelUpgrader facade.
+func (c Connection) UpgradeModel(ctx context.Context, mt names.ModelTag, targetVersion version.Number) (version.Number, error) {
+	const op = errors.Op("jujuclient.UpgradeModel")
+
+	args := jujuparams.UpgradeModelParams{
+		ModelTag:      mt.String(),
+		TargetVersion: targetVersion,
+	}
+	var resp jujuparams.UpgradeModelResult
+	if err := c.Call(ctx, "ModelUpgrader", 1, "", "UpgradeModel", &args, &resp); err != nil {
+		return version.Number{}, errors.E(op, jujuerrors.Cause(err))
+	}
+	if resp.Error != nil {
+		return version.Number{}, errors.E(op, resp.Error)
+	}
+	return resp.ChosenVersion, nil
+}
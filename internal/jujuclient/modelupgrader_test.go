@@ -0,0 +1,32 @@
+// Copyright 2024 Canonical.
+package jujuclient_test
+
+import (
+	"context"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+)
+
+type modelUpgraderSuite struct {
+	jujuclientSuite
+}
+
+var _ = gc.Suite(&modelUpgraderSuite{})
+
+func (s *modelUpgraderSuite) TestUpgradeModelDowngrade(c *gc.C) {
+	ctx := context.Background()
+
+	var info jujuparams.ModelInfo
+	err := s.API.CreateModel(ctx, &jujuparams.ModelCreateArgs{
+		Name:     "test-model",
+		OwnerTag: names.NewUserTag("test-user@canonical.com").String(),
+	}, &info)
+	c.Assert(err, gc.Equals, nil)
+
+	// The controller refuses to downgrade a model.
+	_, err = s.API.UpgradeModel(ctx, names.NewModelTag(info.UUID), version.MustParse("1.0.0"))
+	c.Assert(err, gc.NotNil)
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 Canonical.
+
+package openfga
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// AuthModelID returns the ID of the authorization model the client is
+// configured to use.
+func (o *OFGAClient) AuthModelID() string {
+	return o.cofgaClient.AuthModelID()
+}
+
+// ListAuthModelIDs returns the IDs of all the authorization models in the
+// configured store, most recent first.
+func (o *OFGAClient) ListAuthModelIDs(ctx context.Context) (_ []string, err error) {
+	op := errors.Op("openfga.ListAuthModelIDs")
+
+	durationObserver := servermon.DurationObserver(servermon.OpenFGACallDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.OpenFGACallErrorCount, &err, string(op))
+
+	var ids []string
+	var continuationToken string
+	for {
+		resp, err := o.cofgaClient.ListAuthModels(ctx, 0, continuationToken)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		for _, m := range resp.GetAuthorizationModels() {
+			ids = append(ids, m.GetId())
+		}
+		ct := resp.GetContinuationToken()
+		if ct == "" || ct == continuationToken {
+			return ids, nil
+		}
+		continuationToken = ct
+	}
+}
@@ -15,5 +15,12 @@ func UnsetMultipleResourceAccesses[T ofganames.ResourceTagger](ctx context.Conte
 }
 
 func (o *OFGAClient) RemoveTuples(ctx context.Context, tuple Tuple) error {
-	return o.removeTuples(ctx, tuple)
+	_, err := o.removeTuples(ctx, tuple)
+	return err
 }
+
+func (o *OFGAClient) Retry(ctx context.Context, f func() error) error {
+	return o.retry(ctx, f)
+}
+
+var IsRetryable = isRetryable
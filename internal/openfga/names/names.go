@@ -59,6 +59,7 @@ type ResourceTagger interface {
 		names.ModelTag |
 		names.ApplicationOfferTag |
 		names.CloudTag |
+		names.CloudCredentialTag |
 		jimmnames.ServiceAccountTag
 
 	Id() string
@@ -105,7 +106,7 @@ func BlankKindTag(kind string) (*Tag, error) {
 	case names.UserTagKind, jimmnames.GroupTagKind,
 		names.ControllerTagKind, names.ModelTagKind,
 		names.ApplicationOfferTagKind, names.CloudTagKind,
-		jimmnames.ServiceAccountTagKind:
+		names.CloudCredentialTagKind, jimmnames.ServiceAccountTagKind:
 		return &Tag{
 			Kind: cofga.Kind(kind),
 		}, nil
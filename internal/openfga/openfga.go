@@ -4,6 +4,7 @@ package openfga
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	cofga "github.com/canonical/ofga"
@@ -45,6 +46,8 @@ var (
 	ApplicationOfferType Kind = names.ApplicationOfferTagKind
 	// CloudType represents a cloud object.
 	CloudType Kind = names.CloudTagKind
+	// CloudCredentialType represents a cloud credential object.
+	CloudCredentialType Kind = names.CloudCredentialTagKind
 	// ControllerType represents a controller object.
 	ControllerType Kind = names.ControllerTagKind
 	// GroupType represents a group object.
@@ -71,11 +74,21 @@ var (
 // an administrator.
 type OFGAClient struct {
 	cofgaClient *cofga.Client
+	retryParams RetryParams
 }
 
 // NewOpenFGAClient returns a new JIMM-specific client that wraps the given core OpenFGA client.
-func NewOpenFGAClient(cofgaClient *cofga.Client) *OFGAClient {
-	return &OFGAClient{cofgaClient: cofgaClient}
+// Calls that fail with a transient error are retried using DefaultRetryParams unless
+// configured otherwise with the WithRetry option.
+func NewOpenFGAClient(cofgaClient *cofga.Client, opts ...Option) *OFGAClient {
+	o := &OFGAClient{
+		cofgaClient: cofgaClient,
+		retryParams: DefaultRetryParams,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
 }
 
 // publicAccessAdaptor handles cases where a tuple need to be transformed before being
@@ -137,7 +150,12 @@ func (o *OFGAClient) unsetResourceAccess(ctx context.Context, object, target nam
 //
 // The results may be paginated via a pageSize and the initial returned continuation token from the first request.
 func (o *OFGAClient) getRelatedObjects(ctx context.Context, tuple Tuple, pageSize int32, continuationToken string) ([]Tuple, string, error) {
-	timestampedTuples, ct, err := o.cofgaClient.FindMatchingTuples(ctx, tuple, pageSize, continuationToken)
+	var timestampedTuples []cofga.TimestampedTuple
+	var ct string
+	err := o.retry(ctx, func() (err error) {
+		timestampedTuples, ct, err = o.cofgaClient.FindMatchingTuples(ctx, tuple, pageSize, continuationToken)
+		return err
+	})
 	if err != nil {
 		return nil, "", err
 	}
@@ -157,11 +175,15 @@ func (o *OFGAClient) getRelatedObjects(ctx context.Context, tuple Tuple, pageSiz
 //
 //   - "group:" vs "group:mygroup", where "mygroup" is the ID and the correct objType would be "group".
 func (o *OFGAClient) listObjects(ctx context.Context, user *Tag, relation Relation, objType Kind, contextualTuples []Tuple) (objectIds []Tag, err error) {
-	entities, err := o.cofgaClient.FindAccessibleObjectsByRelation(ctx, Tuple{
-		Object:   user,
-		Relation: relation,
-		Target:   &Tag{Kind: objType},
-	}, contextualTuples...)
+	var entities []Tag
+	err = o.retry(ctx, func() (err error) {
+		entities, err = o.cofgaClient.FindAccessibleObjectsByRelation(ctx, Tuple{
+			Object:   user,
+			Relation: relation,
+			Target:   &Tag{Kind: objType},
+		}, contextualTuples...)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +198,9 @@ func (o *OFGAClient) AddRelation(ctx context.Context, tuples ...Tuple) (err erro
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.OpenFGACallErrorCount, &err, string(op))
 
-	return o.cofgaClient.AddRelation(ctx, tuples...)
+	return o.retryWrite(ctx, func(tuples ...Tuple) error {
+		return o.cofgaClient.AddRelation(ctx, tuples...)
+	}, isExistingTupleError, tuples...)
 }
 
 // RemoveRelation removes given relations (tuples).
@@ -187,7 +211,72 @@ func (o *OFGAClient) RemoveRelation(ctx context.Context, tuples ...Tuple) (err e
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.OpenFGACallErrorCount, &err, string(op))
 
-	return o.cofgaClient.RemoveRelation(ctx, tuples...)
+	return o.retryWrite(ctx, func(tuples ...Tuple) error {
+		return o.cofgaClient.RemoveRelation(ctx, tuples...)
+	}, isMissingTupleError, tuples...)
+}
+
+// maxAtomicWriteTuples is the maximum number of tuples OpenFGA accepts
+// in a single write request.
+const maxAtomicWriteTuples = 100
+
+// AddRelationsAtomic adds the given relations (tuples) in a single
+// OpenFGA write request. OpenFGA applies a write request atomically, so
+// if an error is returned none of the tuples have been written and the
+// call can safely be retried.
+//
+// OpenFGA rejects the whole write if any of the tuples already exist, for
+// example because the call is being retried or another writer added them
+// first. The tuples are then written one at a time, skipping those that
+// already exist, so in that case the write is not atomic.
+func (o *OFGAClient) AddRelationsAtomic(ctx context.Context, tuples ...Tuple) (err error) {
+	op := errors.Op("openfga.AddRelationsAtomic")
+
+	durationObserver := servermon.DurationObserver(servermon.OpenFGACallDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.OpenFGACallErrorCount, &err, string(op))
+
+	if len(tuples) == 0 {
+		return nil
+	}
+	if len(tuples) > maxAtomicWriteTuples {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("cannot atomically write %d tuples, the maximum is %d", len(tuples), maxAtomicWriteTuples))
+	}
+	add := func(tuples ...Tuple) error {
+		return o.cofgaClient.AddRelation(ctx, tuples...)
+	}
+	err = o.retryWrite(ctx, add, isExistingTupleError, tuples...)
+	if err == nil {
+		return nil
+	}
+	if !isExistingTupleError(err) {
+		return errors.E(op, err)
+	}
+	for _, t := range tuples {
+		err := o.retryWrite(ctx, add, isExistingTupleError, t)
+		if err != nil && !isExistingTupleError(err) {
+			return errors.E(op, err)
+		}
+	}
+	return nil
+}
+
+// isExistingTupleError reports whether the given error is OpenFGA
+// rejecting a write because one of the tuples already exists. The core
+// OpenFGA client does not wrap the errors it returns, so only the message
+// can be used to classify it.
+func isExistingTupleError(err error) bool {
+	// OpenFGA reports "cannot write a tuple which already exists" and
+	// "tuple to be written already existed" for such writes.
+	return strings.Contains(err.Error(), "already exist")
+}
+
+// isMissingTupleError reports whether the given error is OpenFGA
+// rejecting a write because one of the tuples to delete does not exist.
+func isMissingTupleError(err error) bool {
+	// OpenFGA reports "cannot delete a tuple which does not exist" for
+	// such writes.
+	return strings.Contains(err.Error(), "does not exist")
 }
 
 // ListObjects returns all object IDs of <objType> that a user has the relation <relation> to.
@@ -226,14 +315,38 @@ func (o *OFGAClient) CheckRelation(ctx context.Context, tuple Tuple, trace bool)
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.OpenFGACallErrorCount, &err, string(op))
 
-	if trace {
-		return o.cofgaClient.CheckRelationWithTracing(ctx, tuple)
+	var allowed bool
+	err = o.retry(ctx, func() (err error) {
+		if trace {
+			allowed, err = o.cofgaClient.CheckRelationWithTracing(ctx, tuple)
+		} else {
+			allowed, err = o.cofgaClient.CheckRelation(ctx, tuple)
+		}
+		return err
+	})
+	return allowed, err
+}
+
+// Ping checks that OpenFGA can be reached by performing a trivial
+// relation check. The result of the check is ignored, only failures to
+// perform the check are reported.
+func (o *OFGAClient) Ping(ctx context.Context) error {
+	const op = errors.Op("openfga.Ping")
+
+	_, err := o.CheckRelation(ctx, Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag(ofganames.EveryoneUser)),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(names.NewControllerTag("00000000-0000-0000-0000-000000000000")),
+	}, false)
+	if err != nil {
+		return errors.E(op, err)
 	}
-	return o.cofgaClient.CheckRelation(ctx, tuple)
+	return nil
 }
 
 // removeTuples iteratively reads through all the tuples with the parameters as supplied by tuple and deletes them.
-func (o *OFGAClient) removeTuples(ctx context.Context, tuple Tuple) (err error) {
+// It returns the number of tuples removed.
+func (o *OFGAClient) removeTuples(ctx context.Context, tuple Tuple) (removed int, err error) {
 	op := errors.Op("openfga.removeTuples")
 
 	durationObserver := servermon.DurationObserver(servermon.OpenFGACallDurationHistogram, string(op))
@@ -253,16 +366,17 @@ func (o *OFGAClient) removeTuples(ctx context.Context, tuple Tuple) (err error)
 		//nolint:gosec // The page size will not exceed int32.
 		tuples, ct, err := o.ReadRelatedObjects(ctx, tuple, int32(pageSize), "")
 		if err != nil {
-			return err
+			return removed, err
 		}
 		if len(tuples) > 0 {
 			err = o.RemoveRelation(ctx, tuples...)
 			if err != nil {
-				return err
+				return removed, err
 			}
+			removed += len(tuples)
 		}
 		if ct == "" {
-			return nil
+			return removed, nil
 		}
 	}
 }
@@ -279,7 +393,7 @@ func (o *OFGAClient) RemoveControllerModel(ctx context.Context, controller names
 
 // RemoveModel removes a model.
 func (o *OFGAClient) RemoveModel(ctx context.Context, model names.ModelTag) error {
-	if err := o.removeTuples(
+	if _, err := o.removeTuples(
 		ctx,
 		Tuple{
 			Target: ofganames.ConvertTag(model),
@@ -297,7 +411,7 @@ func (o *OFGAClient) AddModelApplicationOffer(ctx context.Context, model names.M
 
 // RemoveApplicationOffer removes an application offer.
 func (o *OFGAClient) RemoveApplicationOffer(ctx context.Context, offer names.ApplicationOfferTag) error {
-	if err := o.removeTuples(
+	if _, err := o.removeTuples(
 		ctx,
 		Tuple{
 			Target: ofganames.ConvertTag(offer),
@@ -308,10 +422,48 @@ func (o *OFGAClient) RemoveApplicationOffer(ctx context.Context, offer names.App
 	return nil
 }
 
+// SetGroupAuditLogViewerAccess grants the members of the given group
+// audit log viewer access on the given controller.
+// Note that the action is idempotent (does not return error if the relation already exists).
+func (o *OFGAClient) SetGroupAuditLogViewerAccess(ctx context.Context, group jimmnames.GroupTag, controller names.ControllerTag) error {
+	err := o.AddRelation(ctx, Tuple{
+		Object:   ofganames.ConvertTagWithRelation(group, ofganames.MemberRelation),
+		Relation: ofganames.AuditLogViewerRelation,
+		Target:   ofganames.ConvertTag(controller),
+	})
+	if err != nil {
+		// TODO we should opt to check against specific errors via checking their code/metadata.
+		if strings.Contains(err.Error(), "cannot write a tuple which already exists") {
+			return nil
+		}
+		return errors.E(err)
+	}
+	return nil
+}
+
+// UnsetGroupAuditLogViewerAccess removes the audit log viewer access
+// granted to the members of the given group on the given controller.
+// Note that the action is idempotent (i.e., does not return error if the relation does not exist).
+func (o *OFGAClient) UnsetGroupAuditLogViewerAccess(ctx context.Context, group jimmnames.GroupTag, controller names.ControllerTag) error {
+	err := o.RemoveRelation(ctx, Tuple{
+		Object:   ofganames.ConvertTagWithRelation(group, ofganames.MemberRelation),
+		Relation: ofganames.AuditLogViewerRelation,
+		Target:   ofganames.ConvertTag(controller),
+	})
+	if err != nil {
+		// TODO we should opt to check against specific errors via checking their code/metadata.
+		if strings.Contains(err.Error(), "cannot delete a tuple which does not exist") {
+			return nil
+		}
+		return errors.E(err)
+	}
+	return nil
+}
+
 // RemoveGroup removes a group.
 func (o *OFGAClient) RemoveGroup(ctx context.Context, group jimmnames.GroupTag) error {
 	// Remove all access to a group. I.e. user->group
-	if err := o.removeTuples(
+	if _, err := o.removeTuples(
 		ctx,
 		Tuple{
 			Relation: ofganames.MemberRelation,
@@ -332,7 +484,7 @@ func (o *OFGAClient) RemoveGroup(ctx context.Context, group jimmnames.GroupTag)
 			Object: ofganames.ConvertTagWithRelation(group, ofganames.MemberRelation),
 			Target: kt,
 		}
-		err = o.removeTuples(ctx, newTuple)
+		_, err = o.removeTuples(ctx, newTuple)
 		if err != nil {
 			return errors.E(err)
 		}
@@ -340,9 +492,36 @@ func (o *OFGAClient) RemoveGroup(ctx context.Context, group jimmnames.GroupTag)
 	return nil
 }
 
+// RemoveUserRelations removes all relations where the given user is the
+// object, i.e. all access the user has been granted to any resource
+// including group memberships. It returns the number of relations
+// removed.
+func (o *OFGAClient) RemoveUserRelations(ctx context.Context, user names.UserTag) (int, error) {
+	// As in RemoveGroup we need to loop through all resource types
+	// because the OpenFGA Read API requires an object type. Users may
+	// also be granted access to clouds and cloud credentials.
+	kinds := append(resourceTypes[:], names.CloudTagKind, names.CloudCredentialTagKind)
+	removed := 0
+	for _, kind := range kinds {
+		kt, err := ofganames.BlankKindTag(kind)
+		if err != nil {
+			return removed, errors.E(err)
+		}
+		n, err := o.removeTuples(ctx, Tuple{
+			Object: ofganames.ConvertTag(user),
+			Target: kt,
+		})
+		removed += n
+		if err != nil {
+			return removed, errors.E(err)
+		}
+	}
+	return removed, nil
+}
+
 // RemoveCloud removes a cloud.
 func (o *OFGAClient) RemoveCloud(ctx context.Context, cloud names.CloudTag) error {
-	if err := o.removeTuples(
+	if _, err := o.removeTuples(
 		ctx,
 		Tuple{
 			Target: ofganames.ConvertTag(cloud),
@@ -353,6 +532,19 @@ func (o *OFGAClient) RemoveCloud(ctx context.Context, cloud names.CloudTag) erro
 	return nil
 }
 
+// RemoveCloudCredential removes all relations to a cloud credential.
+func (o *OFGAClient) RemoveCloudCredential(ctx context.Context, credential names.CloudCredentialTag) error {
+	if _, err := o.removeTuples(
+		ctx,
+		Tuple{
+			Target: ofganames.ConvertTag(credential),
+		},
+	); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
 // AddCloudController adds a controller relation between a controller and
 // a cloud.
 func (o *OFGAClient) AddCloudController(ctx context.Context, cloud names.CloudTag, controller names.ControllerTag) error {
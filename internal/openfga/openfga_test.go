@@ -3,8 +3,10 @@ package openfga_test
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	cofga "github.com/canonical/ofga"
@@ -143,6 +145,93 @@ func (s *openFGATestSuite) TestCheckRelationSucceeds(c *gc.C) {
 	c.Assert(allowed, gc.Equals, true)
 }
 
+func (s *openFGATestSuite) TestAddRelationsAtomic(c *gc.C) {
+	ctx := context.Background()
+
+	controller := names.NewControllerTag(uuid.NewString())
+	cloud := names.NewCloudTag("test-cloud")
+	cloudToController := openfga.Tuple{
+		Object:   ofganames.ConvertTag(controller),
+		Relation: ofganames.ControllerRelation,
+		Target:   ofganames.ConvertTag(cloud),
+	}
+	userToController := openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag("eve@canonical.com")),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(controller),
+	}
+	// The user type has no "controller" relation, so writing this tuple
+	// is rejected by OpenFGA.
+	invalid := openfga.Tuple{
+		Object:   ofganames.ConvertTag(controller),
+		Relation: ofganames.ControllerRelation,
+		Target:   ofganames.ConvertTag(names.NewUserTag("eve@canonical.com")),
+	}
+
+	err := s.ofgaClient.AddRelationsAtomic(ctx, cloudToController, userToController, invalid)
+	c.Assert(err, gc.NotNil)
+
+	// None of the tuples are written when the write fails.
+	for _, t := range []openfga.Tuple{cloudToController, userToController} {
+		tuples, _, err := s.ofgaClient.ReadRelatedObjects(ctx, t, 10, "")
+		c.Assert(err, gc.IsNil)
+		c.Check(tuples, gc.HasLen, 0)
+	}
+
+	err = s.ofgaClient.AddRelationsAtomic(ctx, cloudToController)
+	c.Assert(err, gc.IsNil)
+
+	// Existing tuples are skipped.
+	err = s.ofgaClient.AddRelationsAtomic(ctx, cloudToController, userToController)
+	c.Assert(err, gc.IsNil)
+	for _, t := range []openfga.Tuple{cloudToController, userToController} {
+		tuples, _, err := s.ofgaClient.ReadRelatedObjects(ctx, t, 10, "")
+		c.Assert(err, gc.IsNil)
+		c.Check(tuples, gc.HasLen, 1)
+	}
+}
+
+func (s *openFGATestSuite) TestAddRelationsAtomicConcurrently(c *gc.C) {
+	ctx := context.Background()
+
+	controller := names.NewControllerTag(uuid.NewString())
+	var tuples []openfga.Tuple
+	for i := 0; i < 5; i++ {
+		tuples = append(tuples, openfga.Tuple{
+			Object:   ofganames.ConvertTag(controller),
+			Relation: ofganames.ControllerRelation,
+			Target:   ofganames.ConvertTag(names.NewCloudTag(fmt.Sprintf("test-cloud-%d", i))),
+		})
+	}
+
+	// Writers racing to add the same tuples all succeed, even when
+	// their write is rejected because another writer added the tuples
+	// first.
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.ofgaClient.AddRelationsAtomic(ctx, tuples...)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		c.Check(err, gc.IsNil)
+	}
+	for _, t := range tuples {
+		existing, _, err := s.ofgaClient.ReadRelatedObjects(ctx, t, 10, "")
+		c.Assert(err, gc.IsNil)
+		c.Check(existing, gc.HasLen, 1)
+	}
+}
+
+func (s *openFGATestSuite) TestPing(c *gc.C) {
+	err := s.ofgaClient.Ping(context.Background())
+	c.Assert(err, gc.IsNil)
+}
+
 func (s *openFGATestSuite) TestRemoveTuplesSucceeds(c *gc.C) {
 	groupUUID := uuid.NewString()
 
@@ -0,0 +1,141 @@
+// Copyright 2024 Canonical.
+
+package openfga
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// RetryParams holds the parameters used to retry OpenFGA calls that
+// fail with a transient error.
+type RetryParams struct {
+	// MaxAttempts is the maximum number of times a call is attempted,
+	// values less than 2 disable retries.
+	MaxAttempts int
+	// MinDelay is the delay before the first retry, subsequent
+	// retries double the delay.
+	MinDelay time.Duration
+	// MaxDelay is the upper bound on the delay between retries.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryParams are the retry parameters used by clients created
+// without the WithRetry option.
+var DefaultRetryParams = RetryParams{
+	MaxAttempts: 3,
+	MinDelay:    100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+// An Option configures an OFGAClient.
+type Option func(*OFGAClient)
+
+// WithRetry returns an Option that sets the parameters used to retry
+// OpenFGA calls that fail with a transient error.
+func WithRetry(p RetryParams) Option {
+	return func(o *OFGAClient) {
+		o.retryParams = p
+	}
+}
+
+// statusCoder is implemented by the OpenFGA API errors that carry the
+// HTTP status code of the failed response.
+type statusCoder interface {
+	ResponseStatusCode() int
+}
+
+// transientErrorMessages are fragments of the messages of errors that
+// indicate a transient failure. The core OpenFGA client does not wrap
+// the errors it returns, so once an error has passed through it only
+// the message remains to classify it. Other server errors are not
+// retried, as the message does not say whether the server was
+// unavailable or failed to handle the request.
+var transientErrorMessages = []string{
+	// Returned by the OpenFGA SDK for 429 responses.
+	" rate limit error for ",
+	"connection refused",
+	"connection reset by peer",
+	// Returned when the server closes the connection without
+	// responding.
+	": EOF",
+}
+
+// isRetryable reports whether the given error is transient such that
+// the failed call may be retried. Only rate limited and unavailable
+// responses, and connections that were refused or reset, are retried.
+func isRetryable(err error) bool {
+	var sc statusCoder
+	if stderrors.As(err, &sc) {
+		switch sc.ResponseStatusCode() {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return true
+		}
+		return false
+	}
+	if stderrors.Is(err, syscall.ECONNRESET) || stderrors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	msg := err.Error()
+	for _, s := range transientErrorMessages {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// retry calls f until it succeeds, returns a non-retryable error or the
+// configured number of attempts has been made. The delay between
+// attempts grows exponentially up to the configured maximum.
+func (o *OFGAClient) retry(ctx context.Context, f func() error) error {
+	delay := o.retryParams.MinDelay
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= o.retryParams.MaxAttempts || !isRetryable(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return errors.E(ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if o.retryParams.MaxDelay > 0 && delay > o.retryParams.MaxDelay {
+			delay = o.retryParams.MaxDelay
+		}
+	}
+}
+
+// retryWrite calls write with the given tuples, retrying as retry does.
+// A write that failed because the connection was reset, or the server was
+// unavailable, may still have been applied, in which case the retried
+// write is rejected. If a retried write fails with an error for which
+// applied returns true, such as the tuples already existing, the tuples
+// are written again one at a time, ignoring those errors, so that the
+// call succeeds once every tuple has been written.
+func (o *OFGAClient) retryWrite(ctx context.Context, write func(...Tuple) error, applied func(error) bool, tuples ...Tuple) error {
+	attempts := 0
+	err := o.retry(ctx, func() error {
+		attempts++
+		return write(tuples...)
+	})
+	if err == nil || attempts == 1 || !applied(err) {
+		return err
+	}
+	for _, t := range tuples {
+		err := o.retry(ctx, func() error {
+			return write(t)
+		})
+		if err != nil && !applied(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,352 @@
+// Copyright 2024 Canonical.
+package openfga_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+
+	cofga "github.com/canonical/ofga"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+type retrySuite struct{}
+
+var _ = gc.Suite(&retrySuite{})
+
+// statusError mimics the OpenFGA API errors that report the HTTP status
+// code of the failed response.
+type statusError struct {
+	code int
+}
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("unexpected status %d", e.code)
+}
+
+func (e statusError) ResponseStatusCode() int {
+	return e.code
+}
+
+// fakeTransport returns each of the configured status codes in turn,
+// then responds with 200 OK.
+type fakeTransport struct {
+	codes []int
+	calls int
+}
+
+func (t *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	code := http.StatusOK
+	if t.calls < len(t.codes) {
+		code = t.codes[t.calls]
+	}
+	t.calls++
+	return &http.Response{
+		StatusCode: code,
+		Body:       http.NoBody,
+		Request:    req,
+	}, nil
+}
+
+func doRequest(ctx context.Context, client *http.Client) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://openfga.example.com/stores", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return statusError{code: resp.StatusCode}
+	}
+	return nil
+}
+
+func newRetryClient() *openfga.OFGAClient {
+	return openfga.NewOpenFGAClient(nil, openfga.WithRetry(openfga.RetryParams{
+		MaxAttempts: 3,
+		MinDelay:    time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	}))
+}
+
+func (s *retrySuite) TestRetrySucceedsAfterTransientErrors(c *gc.C) {
+	ctx := context.Background()
+	transport := &fakeTransport{codes: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable}}
+	client := &http.Client{Transport: transport}
+
+	err := newRetryClient().Retry(ctx, func() error {
+		return doRequest(ctx, client)
+	})
+	c.Assert(err, gc.IsNil)
+	c.Assert(transport.calls, gc.Equals, 3)
+}
+
+func (s *retrySuite) TestRetryGivesUpAfterMaxAttempts(c *gc.C) {
+	ctx := context.Background()
+	transport := &fakeTransport{codes: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests}}
+	client := &http.Client{Transport: transport}
+
+	err := newRetryClient().Retry(ctx, func() error {
+		return doRequest(ctx, client)
+	})
+	c.Assert(err, gc.ErrorMatches, "unexpected status 429")
+	c.Assert(transport.calls, gc.Equals, 3)
+}
+
+func (s *retrySuite) TestRetryDoesNotRetryClientErrors(c *gc.C) {
+	ctx := context.Background()
+	transport := &fakeTransport{codes: []int{http.StatusBadRequest}}
+	client := &http.Client{Transport: transport}
+
+	err := newRetryClient().Retry(ctx, func() error {
+		return doRequest(ctx, client)
+	})
+	c.Assert(err, gc.ErrorMatches, "unexpected status 400")
+	c.Assert(transport.calls, gc.Equals, 1)
+}
+
+func (s *retrySuite) TestRetryStopsWhenContextCancelled(c *gc.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	transport := &fakeTransport{codes: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable}}
+	client := &http.Client{Transport: transport}
+
+	err := openfga.NewOpenFGAClient(nil, openfga.WithRetry(openfga.RetryParams{
+		MaxAttempts: 3,
+		MinDelay:    time.Minute,
+	})).Retry(ctx, func() error {
+		err := doRequest(ctx, client)
+		cancel()
+		return err
+	})
+	c.Assert(err, gc.ErrorMatches, "context canceled")
+	c.Assert(transport.calls, gc.Equals, 1)
+}
+
+func (s *retrySuite) TestRetryConnectionReset(c *gc.C) {
+	ctx := context.Background()
+	calls := 0
+	err := newRetryClient().Retry(ctx, func() error {
+		calls++
+		if calls == 1 {
+			return fmt.Errorf("read tcp: %w", syscall.ECONNRESET)
+		}
+		return nil
+	})
+	c.Assert(err, gc.IsNil)
+	c.Assert(calls, gc.Equals, 2)
+}
+
+const retryTestStoreID = "01GP1254CHWJC1MNGVB0WDG1T0"
+
+// checkServer is an OpenFGA server that responds to each check request
+// using the next of the configured handlers, once they have all been
+// used checks are allowed. Write requests are handled in the same way
+// using the write handlers.
+type checkServer struct {
+	mu            sync.Mutex
+	handlers      []http.HandlerFunc
+	checks        int
+	writeHandlers []http.HandlerFunc
+	writes        int
+}
+
+func (s *checkServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case req.Method == http.MethodGet && req.URL.Path == "/stores":
+		fmt.Fprint(w, `{"stores":[],"continuation_token":""}`)
+	case req.Method == http.MethodGet && req.URL.Path == "/stores/"+retryTestStoreID:
+		fmt.Fprintf(w, `{"id":%q,"name":"test"}`, retryTestStoreID)
+	case req.Method == http.MethodPost && req.URL.Path == "/stores/"+retryTestStoreID+"/check":
+		s.mu.Lock()
+		n := s.checks
+		s.checks++
+		s.mu.Unlock()
+		if n < len(s.handlers) {
+			s.handlers[n](w, req)
+			return
+		}
+		fmt.Fprint(w, `{"allowed":true}`)
+	case req.Method == http.MethodPost && req.URL.Path == "/stores/"+retryTestStoreID+"/write":
+		s.mu.Lock()
+		n := s.writes
+		s.writes++
+		s.mu.Unlock()
+		if n < len(s.writeHandlers) {
+			s.writeHandlers[n](w, req)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// closeConnection closes the connection without writing a response.
+func closeConnection(w http.ResponseWriter, req *http.Request) {
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		panic(err)
+	}
+	conn.Close()
+}
+
+func badRequest(w http.ResponseWriter, req *http.Request) {
+	w.WriteHeader(http.StatusBadRequest)
+	fmt.Fprint(w, `{"code":"validation_error","message":"invalid tuple"}`)
+}
+
+func tupleExists(w http.ResponseWriter, req *http.Request) {
+	w.WriteHeader(http.StatusBadRequest)
+	fmt.Fprint(w, `{"code":"write_failed_due_to_invalid_input","message":"cannot write a tuple which already exists"}`)
+}
+
+func tupleDoesNotExist(w http.ResponseWriter, req *http.Request) {
+	w.WriteHeader(http.StatusBadRequest)
+	fmt.Fprint(w, `{"code":"write_failed_due_to_invalid_input","message":"cannot delete a tuple which does not exist"}`)
+}
+
+func internalError(w http.ResponseWriter, req *http.Request) {
+	w.WriteHeader(http.StatusInternalServerError)
+	fmt.Fprint(w, `{"code":"internal_error","message":"internal server error"}`)
+}
+
+// newCheckClient returns an OFGAClient that uses a real core OpenFGA
+// client to talk to the given server.
+func newCheckClient(c *gc.C, srv *httptest.Server) *openfga.OFGAClient {
+	u, err := url.Parse(srv.URL)
+	c.Assert(err, gc.IsNil)
+	host, port, err := net.SplitHostPort(u.Host)
+	c.Assert(err, gc.IsNil)
+	cofgaClient, err := cofga.NewClient(context.Background(), cofga.OpenFGAParams{
+		Scheme:  u.Scheme,
+		Host:    host,
+		Port:    port,
+		StoreID: retryTestStoreID,
+	})
+	c.Assert(err, gc.IsNil)
+	return openfga.NewOpenFGAClient(cofgaClient, openfga.WithRetry(openfga.RetryParams{
+		MaxAttempts: 3,
+		MinDelay:    time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	}))
+}
+
+var checkTuple = openfga.Tuple{
+	Object:   &openfga.Tag{Kind: "user", ID: "alice"},
+	Relation: "member",
+	Target:   &openfga.Tag{Kind: "group", ID: "test-group"},
+}
+
+func (s *retrySuite) TestCheckRelationRetriesDroppedConnections(c *gc.C) {
+	cs := &checkServer{handlers: []http.HandlerFunc{closeConnection, closeConnection}}
+	srv := httptest.NewServer(cs)
+	defer srv.Close()
+
+	allowed, err := newCheckClient(c, srv).CheckRelation(context.Background(), checkTuple, false)
+	c.Assert(err, gc.IsNil)
+	c.Check(allowed, gc.Equals, true)
+	c.Check(cs.checks, gc.Equals, 3)
+}
+
+func (s *retrySuite) TestCheckRelationGivesUpAfterMaxAttempts(c *gc.C) {
+	cs := &checkServer{handlers: []http.HandlerFunc{closeConnection, closeConnection, closeConnection}}
+	srv := httptest.NewServer(cs)
+	defer srv.Close()
+
+	_, err := newCheckClient(c, srv).CheckRelation(context.Background(), checkTuple, false)
+	c.Assert(err, gc.ErrorMatches, `cannot check relation: .*EOF`)
+	c.Check(cs.checks, gc.Equals, 3)
+}
+
+func (s *retrySuite) TestCheckRelationDoesNotRetryValidationErrors(c *gc.C) {
+	cs := &checkServer{handlers: []http.HandlerFunc{badRequest}}
+	srv := httptest.NewServer(cs)
+	defer srv.Close()
+
+	_, err := newCheckClient(c, srv).CheckRelation(context.Background(), checkTuple, false)
+	c.Assert(err, gc.ErrorMatches, `cannot check relation: Check validation error .*`)
+	c.Check(cs.checks, gc.Equals, 1)
+}
+
+func (s *retrySuite) TestRefusedConnectionIsRetryable(c *gc.C) {
+	srv := httptest.NewServer(&checkServer{})
+	client := newCheckClient(c, srv)
+	srv.Close()
+
+	_, err := client.CheckRelation(context.Background(), checkTuple, false)
+	c.Assert(err, gc.ErrorMatches, `cannot check relation: .*connection refused`)
+	c.Check(openfga.IsRetryable(err), gc.Equals, true)
+}
+
+func (s *retrySuite) TestCheckRelationDoesNotRetryInternalErrors(c *gc.C) {
+	cs := &checkServer{handlers: []http.HandlerFunc{internalError}}
+	srv := httptest.NewServer(cs)
+	defer srv.Close()
+
+	_, err := newCheckClient(c, srv).CheckRelation(context.Background(), checkTuple, false)
+	c.Assert(err, gc.ErrorMatches, `cannot check relation: Check internal error .*`)
+	c.Check(cs.checks, gc.Equals, 1)
+}
+
+func (s *retrySuite) TestIsRetryable(c *gc.C) {
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{err: statusError{code: http.StatusTooManyRequests}, retryable: true},
+		{err: statusError{code: http.StatusServiceUnavailable}, retryable: true},
+		{err: statusError{code: http.StatusInternalServerError}, retryable: false},
+		{err: statusError{code: http.StatusGatewayTimeout}, retryable: false},
+		{err: fmt.Errorf("read tcp: %w", syscall.ECONNRESET), retryable: true},
+		{err: fmt.Errorf("Write rate limit error for POST Write with body {}"), retryable: true},
+		{err: fmt.Errorf("Write internal error for POST Write with body {}"), retryable: false},
+		{err: fmt.Errorf("dial tcp: i/o timeout"), retryable: false},
+	}
+	for _, test := range tests {
+		c.Check(openfga.IsRetryable(test.err), gc.Equals, test.retryable, gc.Commentf("%v", test.err))
+	}
+}
+
+func (s *retrySuite) TestAddRelationRetriedAfterBeingApplied(c *gc.C) {
+	// The connection is dropped after the first write has been
+	// applied, so the retried write finds that the tuple exists.
+	cs := &checkServer{writeHandlers: []http.HandlerFunc{closeConnection, tupleExists, tupleExists}}
+	srv := httptest.NewServer(cs)
+	defer srv.Close()
+
+	err := newCheckClient(c, srv).AddRelation(context.Background(), checkTuple)
+	c.Assert(err, gc.IsNil)
+	c.Check(cs.writes, gc.Equals, 3)
+}
+
+func (s *retrySuite) TestAddRelationExistingTupleNotRetried(c *gc.C) {
+	cs := &checkServer{writeHandlers: []http.HandlerFunc{tupleExists}}
+	srv := httptest.NewServer(cs)
+	defer srv.Close()
+
+	err := newCheckClient(c, srv).AddRelation(context.Background(), checkTuple)
+	c.Assert(err, gc.ErrorMatches, `cannot add or remove relations: .*already exists.*`)
+	c.Check(cs.writes, gc.Equals, 1)
+}
+
+func (s *retrySuite) TestRemoveRelationRetriedAfterBeingApplied(c *gc.C) {
+	cs := &checkServer{writeHandlers: []http.HandlerFunc{closeConnection, tupleDoesNotExist, tupleDoesNotExist}}
+	srv := httptest.NewServer(cs)
+	defer srv.Close()
+
+	err := newCheckClient(c, srv).RemoveRelation(context.Background(), checkTuple)
+	c.Assert(err, gc.IsNil)
+	c.Check(cs.writes, gc.Equals, 3)
+}
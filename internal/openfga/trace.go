@@ -0,0 +1,91 @@
+// Copyright 2024 Canonical.
+
+package openfga
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// traceReadPageSize is the number of tuples read from OpenFGA at a time
+// when tracing a check.
+const traceReadPageSize = 100
+
+// CheckRelationWithTrace performs the same check as CheckRelation and
+// additionally returns a trace of the relations stored against the
+// target that apply to the object. OpenFGA does not report how a check
+// was resolved, so the trace is built by reading every tuple on the
+// target and checking whether its subject is the object itself, every
+// user, a group the object is a member of or a parent entity the object
+// administers. Each entry in the trace describes one such tuple, whether
+// or not its relation implies the relation being checked.
+func (o *OFGAClient) CheckRelationWithTrace(ctx context.Context, tuple Tuple) (_ bool, _ []string, err error) {
+	op := errors.Op("openfga.CheckRelationWithTrace")
+
+	durationObserver := servermon.DurationObserver(servermon.OpenFGACallDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.OpenFGACallErrorCount, &err, string(op))
+
+	allowed, err := o.CheckRelation(ctx, tuple, false)
+	if err != nil {
+		return false, nil, errors.E(op, err)
+	}
+
+	var trace []string
+	var token string
+	for {
+		tuples, ct, err := o.getRelatedObjects(ctx, Tuple{Target: tuple.Target}, traceReadPageSize, token)
+		if err != nil {
+			return false, nil, errors.E(op, err)
+		}
+		for _, t := range tuples {
+			reason, err := o.traceReason(ctx, tuple.Object, t)
+			if err != nil {
+				return false, nil, errors.E(op, err)
+			}
+			if reason != "" {
+				trace = append(trace, fmt.Sprintf("%s %s %s (%s)", t.Object, t.Relation, t.Target, reason))
+			}
+		}
+		if ct == "" || ct == token {
+			break
+		}
+		token = ct
+	}
+	return allowed, trace, nil
+}
+
+// traceReason returns why the given stored tuple applies to the object,
+// or an empty string if it does not.
+func (o *OFGAClient) traceReason(ctx context.Context, object *Tag, t Tuple) (string, error) {
+	switch {
+	case t.Object.Relation != "":
+		// The subject is a set of users, such as the members of a
+		// group.
+		subject := &Tag{Kind: t.Object.Kind, ID: t.Object.ID}
+		ok, err := o.CheckRelation(ctx, Tuple{Object: object, Relation: t.Object.Relation, Target: subject}, false)
+		if err != nil || !ok {
+			return "", err
+		}
+		return fmt.Sprintf("%s %s", t.Object.Relation, subject), nil
+	case t.Object.Kind == object.Kind && t.Object.ID == object.ID:
+		return "direct", nil
+	case t.Object.Kind == UserType && t.Object.ID == ofganames.EveryoneUser:
+		return "every user", nil
+	case t.Object.Kind == UserType || t.Object.Kind == ServiceAccountType:
+		return "", nil
+	default:
+		// The subject is a parent entity, such as the controller
+		// hosting a model. Every relation inherited from a parent is
+		// inherited from its administrators.
+		ok, err := o.CheckRelation(ctx, Tuple{Object: object, Relation: ofganames.AdministratorRelation, Target: t.Object}, false)
+		if err != nil || !ok {
+			return "", err
+		}
+		return fmt.Sprintf("%s %s", ofganames.AdministratorRelation, t.Object), nil
+	}
+}
@@ -79,6 +79,15 @@ func (u *User) IsModelWriter(ctx context.Context, resource names.ModelTag) (bool
 	return isWriter, nil
 }
 
+// IsGroupMember returns true if the user is a member of the group.
+func (u *User) IsGroupMember(ctx context.Context, group jimmnames.GroupTag) (bool, error) {
+	isMember, err := checkRelation(ctx, u, group, ofganames.MemberRelation)
+	if err != nil {
+		return false, errors.E(err)
+	}
+	return isMember, nil
+}
+
 // IsServiceAccountAdmin returns true if the user has administrator relation to the service account.
 func (u *User) IsServiceAccountAdmin(ctx context.Context, clientID jimmnames.ServiceAccountTag) (bool, error) {
 	isAdmin, err := checkRelation(ctx, u, clientID, ofganames.AdministratorRelation)
@@ -110,6 +119,28 @@ func (u *User) GetCloudAccess(ctx context.Context, resource names.CloudTag) Rela
 	return ofganames.NoRelation
 }
 
+// GetCloudCredentialAccess returns the relation the user has to the
+// specified cloud credential.
+func (u *User) GetCloudCredentialAccess(ctx context.Context, resource names.CloudCredentialTag) Relation {
+	isAdmin, err := IsAdministrator(ctx, u, resource)
+	if err != nil {
+		zapctx.Error(ctx, "openfga check failed", zap.Error(err))
+		return ofganames.NoRelation
+	}
+	if isAdmin {
+		return ofganames.AdministratorRelation
+	}
+	isReader, err := checkRelation(ctx, u, resource, ofganames.ReaderRelation)
+	if err != nil {
+		zapctx.Error(ctx, "openfga check failed", zap.Error(err))
+		return ofganames.NoRelation
+	}
+	if isReader {
+		return ofganames.ReaderRelation
+	}
+	return ofganames.NoRelation
+}
+
 // GetAuditLogViewerAccess returns if the user has audit log viewer relation with the given controller.
 func (u *User) GetAuditLogViewerAccess(ctx context.Context, resource names.ControllerTag) Relation {
 	hasAccess, err := checkRelation(ctx, u, resource, ofganames.AuditLogViewerRelation)
@@ -232,6 +263,18 @@ func (u *User) UnsetCloudAccess(ctx context.Context, resource names.CloudTag, re
 	return unsetMultipleResourceAccesses(ctx, u, resource, relations, 0)
 }
 
+// SetCloudCredentialAccess adds a direct relation between the user and the cloud credential.
+// Note that the action is idempotent (does not return error if the relation already exists).
+func (u *User) SetCloudCredentialAccess(ctx context.Context, resource names.CloudCredentialTag, relation Relation) error {
+	return u.client.setResourceAccess(ctx, u.ResourceTag(), resource, relation)
+}
+
+// UnsetCloudCredentialAccess removes direct relations between the user and the cloud credential.
+// Note that the action is idempotent (i.e., does not return error if the relation does not exist).
+func (u *User) UnsetCloudCredentialAccess(ctx context.Context, resource names.CloudCredentialTag, relations ...Relation) error {
+	return unsetMultipleResourceAccesses(ctx, u, resource, relations, 0)
+}
+
 // SetApplicationOfferAccess adds a direct relation between the user and the application offer.
 // Note that the action is idempotent (does not return error if the relation already exists).
 func (u *User) SetApplicationOfferAccess(ctx context.Context, resource names.ApplicationOfferTag, relation Relation) error {
@@ -271,8 +314,24 @@ func (u *User) ListApplicationOffers(ctx context.Context, relation ofga.Relation
 	return appOfferUUIDs, err
 }
 
+// ListCloudCredentials returns the tags of the cloud credentials that this user has the relation <relation> to.
+func (u *User) ListCloudCredentials(ctx context.Context, relation ofga.Relation) ([]names.CloudCredentialTag, error) {
+	entities, err := u.client.ListObjects(ctx, ofganames.ConvertTag(u.ResourceTag()), relation, CloudCredentialType, nil)
+	if err != nil {
+		return nil, err
+	}
+	credentials := make([]names.CloudCredentialTag, 0, len(entities))
+	for _, credential := range entities {
+		if !names.IsValidCloudCredential(credential.ID) {
+			continue
+		}
+		credentials = append(credentials, names.NewCloudCredentialTag(credential.ID))
+	}
+	return credentials, nil
+}
+
 type administratorT interface {
-	names.ControllerTag | names.ModelTag | names.ApplicationOfferTag | names.CloudTag
+	names.ControllerTag | names.ModelTag | names.ApplicationOfferTag | names.CloudTag | names.CloudCredentialTag
 
 	Id() string
 	Kind() string
@@ -78,6 +78,11 @@ type ProxyHelpers struct {
 	AuditLog                func(*dbmodel.AuditLogEntry)
 	LoginService            LoginService
 	AuthenticatedIdentityID string
+	// LoginTimeout is the maximum time allowed to authenticate the
+	// user and generate a login token when the client logs in. If the
+	// login takes longer than this the connection is closed. A zero
+	// value means there is no limit.
+	LoginTimeout time.Duration
 }
 
 // ProxySockets will proxy requests from a client connection through to a controller
@@ -114,6 +119,7 @@ func ProxySockets(ctx context.Context, helpers ProxyHelpers) error {
 		},
 		errChan:              errChan,
 		createControllerConn: helpers.ConnectController,
+		loginTimeout:         helpers.LoginTimeout,
 	}
 	clProxy.wg.Add(1)
 	go func() {
@@ -331,6 +337,7 @@ type clientProxy struct {
 	errChan              chan error
 	createControllerConn func(context.Context) (WebsocketConnectionWithMetadata, error)
 	connectController    sync.Once
+	loginTimeout         time.Duration
 }
 
 // start begins the client->controller proxier.
@@ -364,9 +371,12 @@ func (p *clientProxy) start(ctx context.Context) error {
 		// except for auth related requests like Login because JIMM is auth gateway.
 		if msg.Type == "Admin" {
 			zapctx.Debug(ctx, "handling an Admin facade call")
-			toClient, toController, err := p.handleAdminFacade(ctx, msg)
+			toClient, toController, err := p.handleAdminFacadeWithTimeout(ctx, msg)
 			if err != nil {
 				p.sendError(p.src, msg, err)
+				if errors.ErrorCode(err) == errLoginTimeout {
+					return err
+				}
 				continue
 			}
 			// If there is a response for the client, send it to the client and continue.
@@ -610,6 +620,55 @@ func modifyControllerResponse(msg *message) error {
 	return nil
 }
 
+// errLoginTimeout is the code of the error returned when a login takes
+// longer than the configured login timeout.
+const errLoginTimeout = errors.Code("login timeout")
+
+// loginRequests contains the Admin facade requests that authenticate the
+// user and so are subject to the login timeout. The device login requests
+// are not included as they wait for the user to complete the login in a
+// browser.
+var loginRequests = map[string]bool{
+	"LoginWithSessionToken":      true,
+	"LoginWithClientCredentials": true,
+	"LoginWithSessionCookie":     true,
+}
+
+// handleAdminFacadeWithTimeout processes the admin facade call in the
+// same way as handleAdminFacade. If the call is a login request and a
+// login timeout has been configured then an error with the code
+// errLoginTimeout is returned if the user is not authenticated, and a
+// login token generated, within that time.
+func (p *clientProxy) handleAdminFacadeWithTimeout(ctx context.Context, msg *message) (*message, *message, error) {
+	const op = errors.Op("rpc.handleAdminFacadeWithTimeout")
+
+	if p.loginTimeout <= 0 || !loginRequests[msg.Request] {
+		return p.handleAdminFacade(ctx, msg)
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.loginTimeout)
+	defer cancel()
+
+	type result struct {
+		toClient, toController *message
+		err                    error
+	}
+	// The channel is buffered so that a login that completes after the
+	// timeout does not block forever.
+	resultc := make(chan result, 1)
+	go func() {
+		var r result
+		r.toClient, r.toController, r.err = p.handleAdminFacade(ctx, msg)
+		resultc <- r
+	}()
+	select {
+	case r := <-resultc:
+		return r.toClient, r.toController, r.err
+	case <-ctx.Done():
+		zapctx.Warn(ctx, "login timed out", zap.String("request", msg.Request), zap.Duration("timeout", p.loginTimeout))
+		return nil, nil, errors.E(op, errLoginTimeout, fmt.Sprintf("login timed out after %s", p.loginTimeout))
+	}
+}
+
 // handleAdminFacade processes the admin facade call and returns:
 // a message to be returned to the source
 // a message to be sent to the destination
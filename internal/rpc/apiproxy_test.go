@@ -293,11 +293,82 @@ func TestProxySocketsAdminFacade(t *testing.T) {
 	}
 }
 
+func TestProxySocketsLoginTimeout(t *testing.T) {
+	c := qt.New(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	clientWebsocket := newMockWebsocketConnection(10)
+	controllerWebsocket := newMockWebsocketConnection(10)
+	// The login service blocks until the test completes.
+	unblock := make(chan struct{})
+	defer close(unblock)
+	loginSvc := &mockLoginService{
+		email: "alice@wonderland.io",
+		wait:  unblock,
+	}
+
+	helpers := rpc.ProxyHelpers{
+		ConnClient: clientWebsocket,
+		TokenGen:   &mockTokenGenerator{},
+		ConnectController: func(ctx context.Context) (rpc.WebsocketConnectionWithMetadata, error) {
+			return rpc.WebsocketConnectionWithMetadata{
+				Conn:           controllerWebsocket,
+				ModelName:      "test model",
+				ControllerUUID: uuid.NewString(),
+			}, nil
+		},
+		AuditLog:     func(*dbmodel.AuditLogEntry) {},
+		LoginService: loginSvc,
+		LoginTimeout: 100 * time.Millisecond,
+	}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- rpc.ProxySockets(ctx, helpers)
+	}()
+
+	data, err := json.Marshal(message{
+		RequestID: 1,
+		Type:      "Admin",
+		Version:   4,
+		Request:   "LoginWithSessionToken",
+		Params:    []byte(`{"session-token":"test-token"}`),
+	})
+	c.Assert(err, qt.IsNil)
+	clientWebsocket.read <- data
+
+	select {
+	case data := <-clientWebsocket.write:
+		c.Check(string(data), qt.JSONEquals, &message{
+			RequestID: 1,
+			Error:     "login timed out after 100ms",
+			ErrorCode: "login timeout",
+		})
+	case <-time.After(2 * time.Second):
+		c.Fatal("timed out waiting for response")
+	}
+	select {
+	case err := <-errc:
+		c.Check(err, qt.ErrorMatches, "login timed out after 100ms")
+	case <-time.After(2 * time.Second):
+		c.Fatal("timed out waiting for the connection to close")
+	}
+	// The client connection has been closed.
+	_, ok := <-clientWebsocket.read
+	c.Check(ok, qt.IsFalse)
+	c.Check(controllerWebsocket.write, qt.HasLen, 0)
+}
+
 type mockLoginService struct {
 	err          error
 	email        string
 	clientID     string
 	clientSecret string
+
+	// wait, if set, is waited on before a session token login
+	// completes.
+	wait chan struct{}
 }
 
 func (j *mockLoginService) LoginDevice(ctx context.Context) (*oauth2.DeviceAuthResponse, error) {
@@ -337,6 +408,9 @@ func (j *mockLoginService) LoginClientCredentials(ctx context.Context, clientID
 	return openfga.NewUser(identity, nil), nil
 }
 func (j *mockLoginService) LoginWithSessionToken(ctx context.Context, sessionToken string) (*openfga.User, error) {
+	if j.wait != nil {
+		<-j.wait
+	}
 	if j.err != nil {
 		return nil, j.err
 	}
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/juju/juju/core/network"
@@ -23,10 +24,20 @@ import (
 	"github.com/canonical/jimm/v3/internal/errors"
 )
 
+// APIOpenTimeout is the default amount of time allowed to establish a
+// connection to a controller. It is used for any controller that does not
+// specify its own DialTimeout.
+var APIOpenTimeout = 45 * time.Second
+
 // A Dialer is used to create client connections to an RPC URL.
 type Dialer struct {
 	// TLSConfig is used to configure TLS for the client connection.
 	TLSConfig *tls.Config
+
+	// HandshakeTimeout is the maximum amount of time allowed to
+	// establish the websocket connection. If this is zero there is no
+	// timeout.
+	HandshakeTimeout time.Duration
 }
 
 // Dial establishes a new client RPC connection to the given URL.
@@ -43,7 +54,8 @@ func (d Dialer) DialWebsocket(ctx context.Context, url string, headers http.Head
 	const op = errors.Op("rpc.BasicDial")
 
 	dialer := websocket.Dialer{
-		TLSClientConfig: d.TLSConfig,
+		TLSClientConfig:  d.TLSConfig,
+		HandshakeTimeout: d.HandshakeTimeout,
 	}
 	conn, resp, err := dialer.DialContext(context.Background(), url, headers)
 	if err != nil {
@@ -72,7 +84,8 @@ func Dial(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag,
 		}
 	}
 	dialer := Dialer{
-		TLSConfig: tlsConfig,
+		TLSConfig:        tlsConfig,
+		HandshakeTimeout: dialTimeout(ctl),
 	}
 
 	if ctl.PublicAddress != "" {
@@ -101,6 +114,15 @@ func Dial(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag,
 	return conn, nil
 }
 
+// dialTimeout returns the time allowed to establish a connection to the
+// given controller.
+func dialTimeout(ctl *dbmodel.Controller) time.Duration {
+	if ctl.DialTimeout > 0 {
+		return ctl.DialTimeout
+	}
+	return APIOpenTimeout
+}
+
 // maybeReachable decides what kinds of links JIMM should try to connect via.
 // Local IPs like localhost for example are excluded but public IPs and Cloud local IPs are potentially reachable.
 func maybeReachable(scope string) bool {
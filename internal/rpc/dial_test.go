@@ -0,0 +1,45 @@
+// Copyright 2024 Canonical.
+
+package rpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/rpc"
+)
+
+func TestDialUsesControllerDialTimeout(t *testing.T) {
+	c := qt.New(t)
+
+	// Start a listener that accepts connections but never completes
+	// the TLS handshake, so the dial can only end by timing out.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ctl := dbmodel.Controller{
+		Name:          "controller-1",
+		PublicAddress: ln.Addr().String(),
+		DialTimeout:   100 * time.Millisecond,
+	}
+	start := time.Now()
+	_, err = rpc.Dial(context.Background(), &ctl, names.ModelTag{}, "", nil)
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Check(time.Since(start) < rpc.APIOpenTimeout, qt.IsTrue)
+}
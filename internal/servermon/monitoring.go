@@ -37,6 +37,36 @@ var (
 		Name:      "error_total",
 		Help:      "The number of database errors.",
 	}, []string{"method"})
+	DBOpenConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "db",
+		Name:      "open_connections",
+		Help:      "The number of open database connections.",
+	})
+	DBInUseConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "db",
+		Name:      "in_use_connections",
+		Help:      "The number of database connections currently in use.",
+	})
+	DBIdleConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "db",
+		Name:      "idle_connections",
+		Help:      "The number of idle database connections.",
+	})
+	DBMaxOpenConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "db",
+		Name:      "max_open_connections",
+		Help:      "The maximum number of open database connections.",
+	})
+	DBWaitCount = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "db",
+		Name:      "wait_count",
+		Help:      "The total number of times a database connection was waited for.",
+	})
 	OpenFGACallDurationHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: "jimm",
 		Subsystem: "openfga",
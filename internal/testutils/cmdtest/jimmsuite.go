@@ -196,6 +196,9 @@ func (s *JimmCmdSuite) AddController(c *gc.C, name string, info *api.Info) {
 			Port:    hp.Port(),
 		}})
 	}
+	if jimmtest.AddControllerAlias(c, s.JIMM, ctl) {
+		return
+	}
 	adminUser := openfga.NewUser(s.AdminUser, s.OFGAClient)
 	adminUser.JimmAdmin = true
 	err := s.JIMM.AddController(context.Background(), adminUser, ctl)
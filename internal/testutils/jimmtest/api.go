@@ -10,10 +10,12 @@ import (
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	jujuparams "github.com/juju/juju/rpc/params"
-	"github.com/juju/juju/version"
+	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
@@ -64,7 +66,7 @@ func (d *Dialer) Dial(_ context.Context, ctl *dbmodel.Controller, _ names.ModelT
 	}
 	ctl.AgentVersion = d.AgentVersion
 	if ctl.AgentVersion == "" {
-		ctl.AgentVersion = version.Current.String()
+		ctl.AgentVersion = jujuversion.Current.String()
 	}
 	ctl.Addresses = dbmodel.HostPorts(d.Addresses)
 	return apiWrapper{
@@ -139,13 +141,20 @@ type API struct {
 	FindApplicationOffers_             func(context.Context, []jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
 	GetApplicationOffer_               func(context.Context, *jujuparams.ApplicationOfferAdminDetailsV5) error
 	GetApplicationOfferConsumeDetails_ func(context.Context, names.UserTag, *jujuparams.ConsumeOfferDetails, bakery.Version) error
+	GetModelConstraints_               func(context.Context) (constraints.Value, error)
 	GrantApplicationOfferAccess_       func(context.Context, string, names.UserTag, jujuparams.OfferAccessPermission) error
 	GrantCloudAccess_                  func(context.Context, names.CloudTag, names.UserTag, string) error
 	GrantJIMMModelAdmin_               func(context.Context, names.ModelTag) error
 	GrantModelAccess_                  func(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error
 	IsBroken_                          bool
 	ListApplicationOffers_             func(context.Context, []jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
+	ListBlocks_                        func(context.Context) ([]jujuparams.Block, error)
+	ListModels_                        func(context.Context, names.UserTag) ([]jujuparams.UserModel, error)
+	ModelGet_                          func(context.Context) (map[string]jujuparams.ConfigValue, error)
+	ModelDefaultsForCloud_             func(context.Context, names.CloudTag) (map[string]jujuparams.ModelDefaults, error)
 	ModelInfo_                         func(context.Context, *jujuparams.ModelInfo) error
+	ModelSet_                          func(context.Context, map[string]interface{}) error
+	ModelUnset_                        func(context.Context, []string) error
 	ModelStatus_                       func(context.Context, *jujuparams.ModelStatus) error
 	ModelSummaryWatcherNext_           func(context.Context, string) ([]jujuparams.ModelAbstract, error)
 	ModelSummaryWatcherStop_           func(context.Context, string) error
@@ -158,11 +167,15 @@ type API struct {
 	RevokeCloudAccess_                 func(context.Context, names.CloudTag, names.UserTag, string) error
 	RevokeCredential_                  func(context.Context, names.CloudCredentialTag) error
 	RevokeModelAccess_                 func(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error
+	SetSLALevel_                       func(ctx context.Context, level, owner string) error
+	SwitchBlockOff_                    func(ctx context.Context, blockType string) error
+	SwitchBlockOn_                     func(ctx context.Context, blockType, message string) error
 	SupportsCheckCredentialModels_     bool
 	SupportsModelSummaryWatcher_       bool
 	Status_                            func(context.Context, []string) (*jujuparams.FullStatus, error)
 	UpdateCloud_                       func(context.Context, names.CloudTag, jujuparams.Cloud) error
 	UpdateCredential_                  func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error)
+	UpgradeModel_                      func(context.Context, names.ModelTag, version.Number) (version.Number, error)
 	ValidateModelUpgrade_              func(context.Context, names.ModelTag, bool) error
 	WatchAll_                          func(context.Context) (string, error)
 	WatchAllModelSummaries_            func(context.Context) (string, error)
@@ -305,6 +318,13 @@ func (a *API) GrantCloudAccess(ctx context.Context, ct names.CloudTag, ut names.
 	return a.GrantCloudAccess_(ctx, ct, ut, access)
 }
 
+func (a *API) GetModelConstraints(ctx context.Context) (constraints.Value, error) {
+	if a.GetModelConstraints_ == nil {
+		return constraints.Value{}, errors.E(errors.CodeNotImplemented)
+	}
+	return a.GetModelConstraints_(ctx)
+}
+
 func (a *API) GrantJIMMModelAdmin(ctx context.Context, tag names.ModelTag) error {
 	if a.GrantJIMMModelAdmin_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -330,6 +350,48 @@ func (a *API) ListApplicationOffers(ctx context.Context, f []jujuparams.OfferFil
 	return a.ListApplicationOffers_(ctx, f)
 }
 
+func (a *API) ListBlocks(ctx context.Context) ([]jujuparams.Block, error) {
+	if a.ListBlocks_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return a.ListBlocks_(ctx)
+}
+
+func (a *API) ListModels(ctx context.Context, user names.UserTag) ([]jujuparams.UserModel, error) {
+	if a.ListModels_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return a.ListModels_(ctx, user)
+}
+
+func (a *API) ModelGet(ctx context.Context) (map[string]jujuparams.ConfigValue, error) {
+	if a.ModelGet_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return a.ModelGet_(ctx)
+}
+
+func (a *API) ModelSet(ctx context.Context, config map[string]interface{}) error {
+	if a.ModelSet_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return a.ModelSet_(ctx, config)
+}
+
+func (a *API) ModelUnset(ctx context.Context, keys []string) error {
+	if a.ModelUnset_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return a.ModelUnset_(ctx, keys)
+}
+
+func (a *API) ModelDefaultsForCloud(ctx context.Context, tag names.CloudTag) (map[string]jujuparams.ModelDefaults, error) {
+	if a.ModelDefaultsForCloud_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return a.ModelDefaultsForCloud_(ctx, tag)
+}
+
 func (a *API) ModelInfo(ctx context.Context, mi *jujuparams.ModelInfo) error {
 	if a.ModelInfo_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -407,6 +469,27 @@ func (a *API) RevokeModelAccess(ctx context.Context, mt names.ModelTag, ut names
 	return a.RevokeModelAccess_(ctx, mt, ut, p)
 }
 
+func (a *API) SetSLALevel(ctx context.Context, level, owner string) error {
+	if a.SetSLALevel_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return a.SetSLALevel_(ctx, level, owner)
+}
+
+func (a *API) SwitchBlockOff(ctx context.Context, blockType string) error {
+	if a.SwitchBlockOff_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return a.SwitchBlockOff_(ctx, blockType)
+}
+
+func (a *API) SwitchBlockOn(ctx context.Context, blockType, message string) error {
+	if a.SwitchBlockOn_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return a.SwitchBlockOn_(ctx, blockType, message)
+}
+
 func (a *API) SupportsCheckCredentialModels() bool {
 	return a.SupportsCheckCredentialModels_
 }
@@ -436,6 +519,13 @@ func (a *API) UpdateCredential(ctx context.Context, cred jujuparams.TaggedCreden
 	return a.UpdateCredential_(ctx, cred)
 }
 
+func (a *API) UpgradeModel(ctx context.Context, tag names.ModelTag, targetVersion version.Number) (version.Number, error) {
+	if a.UpgradeModel_ == nil {
+		return version.Number{}, errors.E(errors.CodeNotImplemented)
+	}
+	return a.UpgradeModel_(ctx, tag, targetVersion)
+}
+
 func (a *API) ValidateModelUpgrade(ctx context.Context, tag names.ModelTag, force bool) error {
 	if a.ValidateModelUpgrade_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -198,6 +198,12 @@ func newSessionToken(c SimpleTester, username string, signatureSecret string) st
 	return base64.StdEncoding.EncodeToString(serialisedToken)
 }
 
+// NewSessionToken returns a base64 encoded session token for the given
+// user, signed with the test secret.
+func NewSessionToken(c SimpleTester, username string) string {
+	return newSessionToken(c, username, JWTTestSecret)
+}
+
 // NewUserSessionLogin returns a login provider than be used with Juju Dial Opts
 // to define how login will take place. In this case we login using a session token
 // that the JIMM server should verify with the same test secret.
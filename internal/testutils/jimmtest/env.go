@@ -313,6 +313,8 @@ type Cloud struct {
 	Name            string        `json:"name"`
 	Type            string        `json:"type"`
 	HostCloudRegion string        `json:"host-cloud-region"`
+	AuthTypes       []string      `json:"auth-types"`
+	Endpoint        string        `json:"endpoint"`
 	Regions         []CloudRegion `json:"regions"`
 	Users           []UserAccess  `json:"users"`
 
@@ -335,6 +337,8 @@ func (cl *Cloud) DBObject(c Tester, db db.Database) dbmodel.Cloud {
 
 	cl.dbo.Name = cl.Name
 	cl.dbo.Type = cl.Type
+	cl.dbo.AuthTypes = cl.AuthTypes
+	cl.dbo.Endpoint = cl.Endpoint
 	cl.dbo.HostCloudRegion = cl.HostCloudRegion
 	for _, r := range cl.Regions {
 		cl.dbo.Regions = append(cl.dbo.Regions, dbmodel.CloudRegion{
@@ -71,6 +71,7 @@ type JIMM struct {
 	ListResources_                     func(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination, namePrefixFilter, typeFilter string) ([]db.Resource, error)
 	Offer_                             func(ctx context.Context, user *openfga.User, offer jimm.AddApplicationOfferParams) error
 	PubSubHub_                         func() *pubsub.Hub
+	PruneAuditEvents_                  func(ctx context.Context, user *openfga.User, olderThan time.Time) (int64, error)
 	PurgeLogs_                         func(ctx context.Context, user *openfga.User, before time.Time) (int64, error)
 	RemoveCloud_                       func(ctx context.Context, u *openfga.User, ct names.CloudTag) error
 	RemoveCloudFromController_         func(ctx context.Context, u *openfga.User, controllerName string, ct names.CloudTag) error
@@ -80,6 +81,7 @@ type JIMM struct {
 	RevokeCloudCredential_             func(ctx context.Context, user *dbmodel.Identity, tag names.CloudCredentialTag, force bool) error
 	RevokeModelAccess_                 func(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error
 	RevokeOfferAccess_                 func(ctx context.Context, user *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) (err error)
+	SetCloudCredentialLabels_          func(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, labels map[string]string) error
 	SetIdentityModelDefaults_          func(ctx context.Context, user *dbmodel.Identity, configs map[string]interface{}) error
 	ToJAASTag_                         func(ctx context.Context, tag *ofganames.Tag, resolveUUIDs bool) (string, error)
 	UpdateApplicationOffer_            func(ctx context.Context, controller *dbmodel.Controller, offerUUID string, removed bool) error
@@ -319,6 +321,12 @@ func (j *JIMM) PubSubHub() *pubsub.Hub {
 	}
 	return j.PubSubHub_()
 }
+func (j *JIMM) PruneAuditEvents(ctx context.Context, user *openfga.User, olderThan time.Time) (int64, error) {
+	if j.PruneAuditEvents_ == nil {
+		return 0, errors.E(errors.CodeNotImplemented)
+	}
+	return j.PruneAuditEvents_(ctx, user, olderThan)
+}
 func (j *JIMM) PurgeLogs(ctx context.Context, user *openfga.User, before time.Time) (int64, error) {
 	if j.PurgeLogs_ == nil {
 		return 0, errors.E(errors.CodeNotImplemented)
@@ -373,6 +381,12 @@ func (j *JIMM) RevokeOfferAccess(ctx context.Context, user *openfga.User, offerU
 	}
 	return j.RevokeOfferAccess_(ctx, user, offerURL, ut, access)
 }
+func (j *JIMM) SetCloudCredentialLabels(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, labels map[string]string) error {
+	if j.SetCloudCredentialLabels_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetCloudCredentialLabels_(ctx, user, tag, labels)
+}
 func (j *JIMM) SetIdentityModelDefaults(ctx context.Context, user *dbmodel.Identity, configs map[string]interface{}) error {
 	if j.SetIdentityModelDefaults_ == nil {
 		return errors.E(errors.CodeNotImplemented)
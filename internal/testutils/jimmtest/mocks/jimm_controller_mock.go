@@ -18,6 +18,7 @@ type ControllerService struct {
 	ControllerInfo_            func(ctx context.Context, name string) (*dbmodel.Controller, error)
 	GetControllerConfig_       func(ctx context.Context, u *dbmodel.Identity) (*dbmodel.ControllerConfig, error)
 	EarliestControllerVersion_ func(ctx context.Context) (version.Number, error)
+	ForEachControllerForUser_  func(ctx context.Context, user *openfga.User, f func(*dbmodel.Controller) error) error
 	ListControllers_           func(ctx context.Context, user *openfga.User) ([]dbmodel.Controller, error)
 	RemoveController_          func(ctx context.Context, user *openfga.User, controllerName string, force bool) error
 	SetControllerConfig_       func(ctx context.Context, u *openfga.User, args jujuparams.ControllerConfigSet) error
@@ -45,6 +46,13 @@ func (j *ControllerService) EarliestControllerVersion(ctx context.Context) (vers
 	return j.EarliestControllerVersion_(ctx)
 }
 
+func (j *ControllerService) ForEachControllerForUser(ctx context.Context, user *openfga.User, f func(*dbmodel.Controller) error) error {
+	if j.ForEachControllerForUser_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.ForEachControllerForUser_(ctx, user, f)
+}
+
 func (j *ControllerService) GetControllerConfig(ctx context.Context, u *dbmodel.Identity) (*dbmodel.ControllerConfig, error) {
 	if j.GetControllerConfig_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
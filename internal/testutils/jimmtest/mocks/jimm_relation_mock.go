@@ -5,6 +5,8 @@ package mocks
 import (
 	"context"
 
+	"github.com/juju/names/v5"
+
 	"github.com/canonical/jimm/v3/internal/common/pagination"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
@@ -16,6 +18,7 @@ type RelationService struct {
 	AddRelation_            func(ctx context.Context, user *openfga.User, tuples []apiparams.RelationshipTuple) error
 	RemoveRelation_         func(ctx context.Context, user *openfga.User, tuples []apiparams.RelationshipTuple) error
 	CheckRelation_          func(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, trace bool) (_ bool, err error)
+	CheckUserRelation_      func(ctx context.Context, user *openfga.User, ut names.UserTag, relation, target string) (_ bool, trace []string, err error)
 	ListRelationshipTuples_ func(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, pageSize int32, continuationToken string) ([]openfga.Tuple, string, error)
 	ListObjectRelations_    func(ctx context.Context, user *openfga.User, object string, pageSize int32, continuationToken pagination.EntitlementToken) ([]openfga.Tuple, pagination.EntitlementToken, error)
 }
@@ -41,6 +44,13 @@ func (j *RelationService) CheckRelation(ctx context.Context, user *openfga.User,
 	return j.CheckRelation_(ctx, user, tuple, trace)
 }
 
+func (j *RelationService) CheckUserRelation(ctx context.Context, user *openfga.User, ut names.UserTag, relation, target string) (_ bool, trace []string, err error) {
+	if j.CheckUserRelation_ == nil {
+		return false, nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.CheckUserRelation_(ctx, user, ut, relation, target)
+}
+
 func (j *RelationService) ListRelationshipTuples(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, pageSize int32, continuationToken string) ([]openfga.Tuple, string, error) {
 	if j.ListRelationshipTuples_ == nil {
 		return []openfga.Tuple{}, "", errors.E(errors.CodeNotImplemented)
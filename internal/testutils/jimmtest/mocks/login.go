@@ -18,6 +18,7 @@ type LoginService struct {
 	LoginClientCredentials_     func(ctx context.Context, clientID string, clientSecret string) (*openfga.User, error)
 	LoginWithSessionToken_      func(ctx context.Context, sessionToken string) (*openfga.User, error)
 	LoginWithSessionCookie_     func(ctx context.Context, identityID string) (*openfga.User, error)
+	LoginWithIDToken_           func(ctx context.Context, idToken string) (*openfga.User, error)
 }
 
 func (j *LoginService) AuthenticateBrowserSession(ctx context.Context, w http.ResponseWriter, req *http.Request) (context.Context, error) {
@@ -61,3 +62,10 @@ func (j *LoginService) LoginWithSessionCookie(ctx context.Context, identityID st
 	}
 	return j.LoginWithSessionCookie_(ctx, identityID)
 }
+
+func (j *LoginService) LoginWithIDToken(ctx context.Context, idToken string) (*openfga.User, error) {
+	if j.LoginWithIDToken_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.LoginWithIDToken_(ctx, idToken)
+}
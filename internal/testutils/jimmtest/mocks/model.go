@@ -22,11 +22,12 @@ type ModelManager struct {
 	DestroyModel_           func(ctx context.Context, u *openfga.User, mt names.ModelTag, destroyStorage *bool, force *bool, maxWait *time.Duration, timeout *time.Duration) error
 	DumpModel_              func(ctx context.Context, u *openfga.User, mt names.ModelTag, simplified bool) (string, error)
 	DumpModelDB_            func(ctx context.Context, u *openfga.User, mt names.ModelTag) (map[string]interface{}, error)
+	ExportModelSpec_        func(ctx context.Context, user *openfga.User, mt names.ModelTag) (*params.ModelSpec, error)
 	ForEachModel_           func(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
 	ForEachUserModel_       func(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
 	FullModelStatus_        func(ctx context.Context, user *openfga.User, modelTag names.ModelTag, patterns []string) (*jujuparams.FullStatus, error)
 	GetModel_               func(ctx context.Context, uuid string) (dbmodel.Model, error)
-	ImportModel_            func(ctx context.Context, user *openfga.User, controllerName string, modelTag names.ModelTag, newOwner string) error
+	ImportModel_            func(ctx context.Context, user *openfga.User, controllerName string, modelTag names.ModelTag, newOwner, uuidOverride string) error
 	IdentityModelDefaults_  func(ctx context.Context, user *dbmodel.Identity) (map[string]interface{}, error)
 	ModelDefaultsForCloud_  func(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag) (jujuparams.ModelDefaultsResult, error)
 	ModelInfo_              func(ctx context.Context, u *openfga.User, mt names.ModelTag) (*jujuparams.ModelInfo, error)
@@ -87,6 +88,13 @@ func (j *ModelManager) ForEachUserModel(ctx context.Context, u *openfga.User, f
 	return j.ForEachUserModel_(ctx, u, f)
 }
 
+func (j *ModelManager) ExportModelSpec(ctx context.Context, user *openfga.User, mt names.ModelTag) (*params.ModelSpec, error) {
+	if j.ExportModelSpec_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ExportModelSpec_(ctx, user, mt)
+}
+
 func (j *ModelManager) FullModelStatus(ctx context.Context, user *openfga.User, modelTag names.ModelTag, patterns []string) (*jujuparams.FullStatus, error) {
 	if j.FullModelStatus_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
@@ -101,11 +109,11 @@ func (j *ModelManager) GetModel(ctx context.Context, uuid string) (dbmodel.Model
 	return j.GetModel_(ctx, uuid)
 }
 
-func (j *ModelManager) ImportModel(ctx context.Context, user *openfga.User, controllerName string, modelTag names.ModelTag, newOwner string) error {
+func (j *ModelManager) ImportModel(ctx context.Context, user *openfga.User, controllerName string, modelTag names.ModelTag, newOwner, uuidOverride string) error {
 	if j.ImportModel_ == nil {
 		return errors.E(errors.CodeNotImplemented)
 	}
-	return j.ImportModel_(ctx, user, controllerName, modelTag, newOwner)
+	return j.ImportModel_(ctx, user, controllerName, modelTag, newOwner, uuidOverride)
 }
 
 func (j *ModelManager) ModelDefaultsForCloud(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag) (jujuparams.ModelDefaultsResult, error) {
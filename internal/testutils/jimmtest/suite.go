@@ -223,10 +223,45 @@ func (s *JIMMSuite) AddController(c *gc.C, name string, info *api.Info) {
 			Port:    hp.Port(),
 		}})
 	}
+	if AddControllerAlias(c, s.JIMM, ctl) {
+		return
+	}
 	err := s.JIMM.AddController(context.Background(), s.AdminUser, ctl)
 	c.Assert(err, gc.Equals, nil)
 }
 
+// AddControllerAlias adds the given controller to JIMM's database as
+// another name for an already added controller with the same UUID. JIMM
+// refuses to add the same controller twice, so tests use aliases to
+// emulate several controllers with the single juju controller available
+// to them. The alias shares the cloud regions and OpenFGA relations of
+// the existing controller. If there is no existing controller with the
+// same UUID then nothing is added and false is returned.
+func AddControllerAlias(c *gc.C, j *jimm.JIMM, ctl *dbmodel.Controller) bool {
+	ctx := context.Background()
+
+	existing := dbmodel.Controller{UUID: ctl.UUID}
+	if ctl.UUID == "" || j.Database.GetController(ctx, &existing) != nil {
+		return false
+	}
+	ctl.CloudName = existing.CloudName
+	ctl.CloudRegion = existing.CloudRegion
+	ctl.AgentVersion = existing.AgentVersion
+	for _, cr := range existing.CloudRegions {
+		ctl.CloudRegions = append(ctl.CloudRegions, dbmodel.CloudRegionControllerPriority{
+			CloudRegionID: cr.CloudRegionID,
+			Priority:      cr.Priority,
+		})
+	}
+	err := j.CredentialStore.PutControllerCredentials(ctx, ctl.Name, ctl.AdminIdentityName, ctl.AdminPassword)
+	c.Assert(err, gc.Equals, nil)
+	ctl.AdminIdentityName = ""
+	ctl.AdminPassword = ""
+	err = j.Database.AddController(ctx, ctl)
+	c.Assert(err, gc.Equals, nil)
+	return true
+}
+
 func (s *JIMMSuite) UpdateCloudCredential(c *gc.C, tag names.CloudCredentialTag, cred jujuparams.CloudCredential) {
 	ctx := context.Background()
 	u, err := dbmodel.NewIdentity(tag.Owner().Id())
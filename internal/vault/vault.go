@@ -7,8 +7,10 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	goerr "errors"
+	"fmt"
 	"net/http"
 	"path"
+	"strings"
 	"sync"
 	"time"
 
@@ -35,6 +37,17 @@ const (
 	oAuthSecretKey = "oauth-secret"
 )
 
+// The placeholders that are substituted in a credential path template.
+const (
+	cloudPlaceholder = "{cloud}"
+	ownerPlaceholder = "{owner}"
+	namePlaceholder  = "{name}"
+)
+
+// DefaultCredentialPathTemplate is the credential path template used
+// when a VaultStore does not specify one.
+const DefaultCredentialPathTemplate = "creds/" + cloudPlaceholder + "/" + ownerPlaceholder + "/" + namePlaceholder
+
 // A VaultStore stores cloud credential attributes and
 // controller credentials in vault.
 type VaultStore struct {
@@ -52,6 +65,13 @@ type VaultStore struct {
 	// storage.
 	KVPath string
 
+	// CredentialPathTemplate is the template for the path, within
+	// KVPath, at which cloud credential attributes are stored. The
+	// placeholders {cloud}, {owner} and {name} are replaced with the
+	// cloud, owner and name of the credential. If this is empty
+	// DefaultCredentialPathTemplate is used.
+	CredentialPathTemplate string
+
 	// mu protects the fields below it.
 	mu      sync.Mutex
 	expires time.Time
@@ -67,12 +87,17 @@ func (s *VaultStore) Get(ctx context.Context, tag names.CloudCredentialTag) (_ m
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.VaultCallErrorCount, &err, string(op))
 
+	p, err := s.path(tag)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
 	client, err := s.client(ctx)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
 
-	secret, err := client.KVv2(s.KVPath).Get(ctx, s.path(tag))
+	secret, err := client.KVv2(s.KVPath).Get(ctx, p)
 	if err != nil && goerr.Unwrap(err) != api.ErrSecretNotFound {
 		return nil, errors.E(op, err)
 	}
@@ -105,6 +130,11 @@ func (s *VaultStore) Put(ctx context.Context, tag names.CloudCredentialTag, attr
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.VaultCallErrorCount, &err, string(op))
 
+	p, err := s.path(tag)
+	if err != nil {
+		return errors.E(op, err)
+	}
+
 	client, err := s.client(ctx)
 	if err != nil {
 		return errors.E(op, err)
@@ -114,7 +144,7 @@ func (s *VaultStore) Put(ctx context.Context, tag names.CloudCredentialTag, attr
 	for k, v := range attr {
 		data[k] = v
 	}
-	_, err = client.KVv2(s.KVPath).Put(ctx, s.path(tag), data)
+	_, err = client.KVv2(s.KVPath).Put(ctx, p, data)
 	if err != nil {
 		return errors.E(op, err)
 	}
@@ -130,11 +160,16 @@ func (s *VaultStore) delete(ctx context.Context, tag names.CloudCredentialTag) (
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.VaultCallErrorCount, &err, string(op))
 
+	p, err := s.path(tag)
+	if err != nil {
+		return errors.E(op, err)
+	}
+
 	client, err := s.client(ctx)
 	if err != nil {
 		return errors.E(op, err)
 	}
-	err = client.KVv2(s.KVPath).Delete(ctx, s.path(tag))
+	err = client.KVv2(s.KVPath).Delete(ctx, p)
 	if rerr, ok := err.(*api.ResponseError); ok && rerr.StatusCode == http.StatusNotFound {
 		// Ignore the error if attempting to delete something that isn't there.
 		err = nil
@@ -510,8 +545,57 @@ func (s *VaultStore) client(ctx context.Context) (*api.Client, error) {
 	return s.client_, nil
 }
 
-func (s *VaultStore) path(tag names.CloudCredentialTag) string {
-	return path.Join("creds", tag.Cloud().Id(), tag.Owner().Id(), tag.Name())
+// path returns the path at which the attributes of the given cloud
+// credential are stored.
+func (s *VaultStore) path(tag names.CloudCredentialTag) (string, error) {
+	tmpl := s.CredentialPathTemplate
+	if tmpl == "" {
+		tmpl = DefaultCredentialPathTemplate
+	}
+	if err := ValidateCredentialPathTemplate(tmpl); err != nil {
+		return "", err
+	}
+	r := strings.NewReplacer(
+		cloudPlaceholder, tag.Cloud().Id(),
+		ownerPlaceholder, tag.Owner().Id(),
+		namePlaceholder, tag.Name(),
+	)
+	return r.Replace(tmpl), nil
+}
+
+// ValidateCredentialPathTemplate checks that the given credential path
+// template produces a safe path. The template must be a relative path
+// in which every element is either a fixed name or one of the
+// placeholders {cloud}, {owner} and {name}. Each placeholder must appear
+// exactly once so that every credential is stored at a distinct path.
+func ValidateCredentialPathTemplate(tmpl string) error {
+	const op = errors.Op("vault.ValidateCredentialPathTemplate")
+
+	if tmpl == "" || path.IsAbs(tmpl) {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid credential path template %q", tmpl))
+	}
+	seen := make(map[string]bool)
+	for _, elem := range strings.Split(tmpl, "/") {
+		switch elem {
+		case cloudPlaceholder, ownerPlaceholder, namePlaceholder:
+			if seen[elem] {
+				return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid credential path template %q: %s repeated", tmpl, elem))
+			}
+			seen[elem] = true
+		case "", ".", "..", ".well-known":
+			return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid credential path template %q: invalid element %q", tmpl, elem))
+		default:
+			if strings.ContainsAny(elem, "{}") {
+				return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid credential path template %q: invalid element %q", tmpl, elem))
+			}
+		}
+	}
+	for _, p := range []string{cloudPlaceholder, ownerPlaceholder, namePlaceholder} {
+		if !seen[p] {
+			return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid credential path template %q: %s missing", tmpl, p))
+		}
+	}
+	return nil
 }
 
 func (s *VaultStore) controllerCredentialsPath(controllerName string) string {
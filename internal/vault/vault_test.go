@@ -9,6 +9,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"os"
+	"regexp"
 	"testing"
 	"time"
 
@@ -79,6 +80,80 @@ func TestVaultCloudCredentialAttributeStoreRoundTrip(t *testing.T) {
 	c.Check(attr, qt.HasLen, 0)
 }
 
+func TestVaultCloudCredentialAttributeStoreCloudNamespacedPath(t *testing.T) {
+	c := qt.New(t)
+
+	st := newStore(c)
+	st.CredentialPathTemplate = "{cloud}/creds/{owner}/{name}"
+	ctx := context.Background()
+	tag := names.NewCloudCredentialTag("aws/alice@canonical.com/" + c.Name())
+	err := st.Put(ctx, tag, map[string]string{"a": "A", "b": "1234"})
+	c.Assert(err, qt.IsNil)
+
+	attr, err := st.Get(ctx, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(attr, qt.DeepEquals, map[string]string{"a": "A", "b": "1234"})
+
+	// The credential is not stored at the default path.
+	defaultStore := newStore(c)
+	attr, err = defaultStore.Get(ctx, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(attr, qt.HasLen, 0)
+
+	err = st.Put(ctx, tag, nil)
+	c.Assert(err, qt.IsNil)
+	attr, err = st.Get(ctx, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(attr, qt.HasLen, 0)
+}
+
+func TestValidateCredentialPathTemplate(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		template    string
+		expectError string
+	}{{
+		template: vault.DefaultCredentialPathTemplate,
+	}, {
+		template: "{cloud}/creds/{owner}/{name}",
+	}, {
+		template:    "",
+		expectError: `invalid credential path template ""`,
+	}, {
+		template:    "/creds/{cloud}/{owner}/{name}",
+		expectError: `invalid credential path template "/creds/{cloud}/{owner}/{name}"`,
+	}, {
+		template:    "creds/../{cloud}/{owner}/{name}",
+		expectError: `invalid credential path template "creds/../{cloud}/{owner}/{name}": invalid element ".."`,
+	}, {
+		template:    "creds//{cloud}/{owner}/{name}",
+		expectError: `invalid credential path template "creds//{cloud}/{owner}/{name}": invalid element ""`,
+	}, {
+		template:    "creds/{cloud}-{owner}/{name}",
+		expectError: `invalid credential path template "creds/{cloud}-{owner}/{name}": invalid element "{cloud}-{owner}"`,
+	}, {
+		template:    "creds/{cloud}/{name}",
+		expectError: `invalid credential path template "creds/{cloud}/{name}": {owner} missing`,
+	}, {
+		template:    "creds/{cloud}/{cloud}/{owner}/{name}",
+		expectError: `invalid credential path template "creds/{cloud}/{cloud}/{owner}/{name}": {cloud} repeated`,
+	}, {
+		template:    "creds/.well-known/{cloud}/{owner}/{name}",
+		expectError: `invalid credential path template "creds/.well-known/{cloud}/{owner}/{name}": invalid element ".well-known"`,
+	}}
+	for _, test := range tests {
+		c.Run(test.template, func(c *qt.C) {
+			err := vault.ValidateCredentialPathTemplate(test.template)
+			if test.expectError == "" {
+				c.Check(err, qt.IsNil)
+				return
+			}
+			c.Check(err, qt.ErrorMatches, regexp.QuoteMeta(test.expectError))
+		})
+	}
+}
+
 func TestVaultCloudCredentialAtrributeStoreEmpty(t *testing.T) {
 	c := qt.New(t)
 
@@ -53,6 +53,20 @@ func (c *Client) FindAuditEvents(req *params.FindAuditEventsRequest) (params.Aud
 	return resp, nil
 }
 
+// GetCloudCredential returns the details, including any labels, of a
+// cloud credential.
+func (c *Client) GetCloudCredential(req *params.GetCloudCredentialRequest) (params.CloudCredentialInfo, error) {
+	var resp params.CloudCredentialInfo
+	err := c.caller.APICall("JIMM", 4, "", "GetCloudCredential", req, &resp)
+	return resp, err
+}
+
+// SetCloudCredentialLabels replaces the labels attached to a cloud
+// credential.
+func (c *Client) SetCloudCredentialLabels(req *params.SetCloudCredentialLabelsRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetCloudCredentialLabels", req, nil)
+}
+
 // GrantAuditLogAccess grants the given access to the audit log to the
 // given user.
 func (c *Client) GrantAuditLogAccess(req *params.AuditLogAccessRequest) error {
@@ -170,6 +184,15 @@ func (c *Client) CheckRelation(req *params.CheckRelationRequest) (params.CheckRe
 	return checkResp, err
 }
 
+// CheckUserRelation checks whether a user has a relation to a model,
+// controller, application offer, group or cloud, returning the result
+// along with a trace of how the check was resolved.
+func (c *Client) CheckUserRelation(req *params.CheckUserRelationRequest) (params.CheckUserRelationResponse, error) {
+	var resp params.CheckUserRelationResponse
+	err := c.caller.APICall("JIMM", 4, "", "CheckUserRelation", req, &resp)
+	return resp, err
+}
+
 // ListRelationshipTuples returns a list of tuples matching the specified criteria.
 func (c *Client) ListRelationshipTuples(req *params.ListRelationshipTuplesRequest) (*params.ListRelationshipTuplesResponse, error) {
 	var response params.ListRelationshipTuplesResponse
@@ -193,6 +216,13 @@ func (c *Client) PurgeLogs(req *params.PurgeLogsRequest) (*params.PurgeLogsRespo
 	return &response, err
 }
 
+// PruneAuditEvents removes audit events older than the given time.
+func (c *Client) PruneAuditEvents(req *params.PruneAuditEventsRequest) (*params.PruneAuditEventsResponse, error) {
+	var response params.PruneAuditEventsResponse
+	err := c.caller.APICall("JIMM", 4, "", "PruneAuditEvents", req, &response)
+	return &response, err
+}
+
 // MigrateModel migrates a model between two controllers that are attached to JIMM.
 func (c *Client) MigrateModel(req *params.MigrateModelRequest) (*jujuparams.InitiateMigrationResults, error) {
 	var response jujuparams.InitiateMigrationResults
@@ -231,6 +261,23 @@ func (c *Client) GrantServiceAccountAccess(req *params.GrantServiceAccountAccess
 	return c.caller.APICall("JIMM", 4, "", "GrantServiceAccountAccess", req, nil)
 }
 
+// WatchFilteredModelSummaries starts a watcher that returns the summaries
+// of the models matching the given filter that the user has access to.
+// The returned watcher is used with the ModelSummaryWatcher facade.
+func (c *Client) WatchFilteredModelSummaries(req *params.WatchFilteredModelSummariesRequest) (*jujuparams.SummaryWatcherID, error) {
+	var response jujuparams.SummaryWatcherID
+	err := c.caller.APICall("JIMM", 4, "", "WatchFilteredModelSummaries", req, &response)
+	return &response, err
+}
+
+// ExportModelSpec returns the specification of a model, suitable for
+// re-creating the model elsewhere.
+func (c *Client) ExportModelSpec(req *params.ExportModelSpecRequest) (*params.ModelSpec, error) {
+	var response params.ModelSpec
+	err := c.caller.APICall("JIMM", 4, "", "ExportModelSpec", req, &response)
+	return &response, err
+}
+
 // Version returns version info of the controller.
 func (c *Client) Version() (params.VersionResponse, error) {
 	var response params.VersionResponse
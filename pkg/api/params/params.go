@@ -63,6 +63,11 @@ type AddControllerRequest struct {
 	// Password contains the password that JIMM should use to connect to
 	// the controller.
 	Password string `json:"password"`
+
+	// DialTimeout optionally contains the maximum time allowed to
+	// connect to the controller, for example "90s". If this is not
+	// specified then JIMM's default timeout is used.
+	DialTimeout string `json:"dial-timeout,omitempty"`
 }
 
 // AuditLogAccessRequest is the request used to modify a user's access
@@ -270,6 +275,11 @@ type ImportModelRequest struct {
 	// Owner specifies the new owner of the model after import.
 	// Can be empty to skip switching the owner.
 	Owner string `json:"owner"`
+
+	// UUID optionally holds the UUID the model is expected to have. If
+	// it is set and does not match the UUID of the model on the
+	// controller the import is rejected.
+	UUID string `json:"uuid,omitempty"`
 }
 
 // Authorisation request parameters / responses:
@@ -316,6 +326,9 @@ type RemoveGroupRequest struct {
 type ListGroupsRequest struct {
 	Limit  int `json:"limit"`
 	Offset int `json:"offset"`
+	// NamePrefix, if set, restricts the groups returned to those whose
+	// name starts with it.
+	NamePrefix string `json:"name-prefix,omitempty"`
 }
 
 // Group holds the details of a group currently residing in JIMM.
@@ -364,6 +377,28 @@ type CheckRelationResponse struct {
 	Allowed bool `json:"allowed" yaml:"allowed"`
 }
 
+// CheckUserRelationRequest holds the request information to check a
+// user's relation to an entity.
+type CheckUserRelationRequest struct {
+	// UserTag holds the tag of the user being checked.
+	UserTag string `json:"user_tag"`
+	// Relation holds the relation being checked.
+	Relation string `json:"relation"`
+	// TargetTag holds the tag of the model, controller, application
+	// offer, group or cloud the relation is checked against.
+	TargetTag string `json:"target_tag"`
+}
+
+// CheckUserRelationResponse holds the result of checking a user's
+// relation to an entity.
+type CheckUserRelationResponse struct {
+	// Allowed reports whether the user has the relation.
+	Allowed bool `json:"allowed" yaml:"allowed"`
+	// Trace describes the check performed and each relation stored
+	// against the target that applies to the user.
+	Trace []string `json:"trace" yaml:"trace"`
+}
+
 // ListRelationshipTuplesRequests holds the request information to list tuples.
 type ListRelationshipTuplesRequest struct {
 	Tuple             RelationshipTuple `json:"tuple,omitempty"`
@@ -408,6 +443,19 @@ type PurgeLogsResponse struct {
 	DeletedCount int64 `json:"deleted-count" yaml:"deleted-count"`
 }
 
+// PruneAuditEventsRequest is the request used to prune audit events.
+type PruneAuditEventsRequest struct {
+	// OlderThan is the time before which audit events are removed.
+	OlderThan time.Time `json:"older-than"`
+}
+
+// PruneAuditEventsResponse is the response returned by the
+// PruneAuditEvents method.
+type PruneAuditEventsResponse struct {
+	// DeletedCount is the number of audit events removed.
+	DeletedCount int64 `json:"deleted-count" yaml:"deleted-count"`
+}
+
 // MigrateModelInfo represents a single migration where a source model
 // target controller must be specified with both the source model and
 // target controller residing within JIMM.
@@ -457,6 +505,13 @@ type LoginWithSessionTokenRequest struct {
 	SessionToken string `json:"session-token"`
 }
 
+// LoginWithIDTokenRequest accepts an ID token issued by an external OIDC
+// provider and logs in the user it identifies.
+type LoginWithIDTokenRequest struct {
+	// IDToken holds the raw, signed, ID token.
+	IDToken string `json:"id-token"`
+}
+
 // Service Account related request parameters
 
 // LoginWithClientCredentialsRequest holds the client id and secret used
@@ -505,6 +560,50 @@ type GrantServiceAccountAccess struct {
 	ClientID string `json:"client-id"`
 }
 
+// GetCloudCredentialRequest holds a request to get the details of a
+// cloud credential.
+type GetCloudCredentialRequest struct {
+	// CredentialTag holds the tag of the cloud credential.
+	CredentialTag string `json:"credential-tag"`
+}
+
+// SetCloudCredentialLabelsRequest holds a request to replace the labels
+// attached to a cloud credential.
+type SetCloudCredentialLabelsRequest struct {
+	// CredentialTag holds the tag of the cloud credential.
+	CredentialTag string `json:"credential-tag"`
+
+	// Labels holds the new labels for the cloud credential.
+	Labels map[string]string `json:"labels"`
+}
+
+// WatchFilteredModelSummariesRequest holds a request to watch the
+// summaries of the models matching a filter. Fields that are not set
+// match every model.
+type WatchFilteredModelSummariesRequest struct {
+	// Owner holds the name of the user owning the models.
+	Owner string `json:"owner,omitempty"`
+
+	// Cloud holds the name of the cloud hosting the models.
+	Cloud string `json:"cloud,omitempty"`
+
+	// ModelUUIDs holds the UUIDs of the models to watch.
+	ModelUUIDs []string `json:"model-uuids,omitempty"`
+}
+
+// CloudCredentialInfo holds the details of a cloud credential. It never
+// contains the credential attributes.
+type CloudCredentialInfo struct {
+	// CredentialTag holds the tag of the cloud credential.
+	CredentialTag string `json:"credential-tag" yaml:"credential-tag"`
+
+	// AuthType holds the auth-type of the cloud credential.
+	AuthType string `json:"auth-type" yaml:"auth-type"`
+
+	// Labels holds the labels attached to the cloud credential.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
 // WhoamiResponse holds the response for a /auth/whoami call.
 type WhoamiResponse struct {
 	DisplayName string `json:"display-name" yaml:"display-name"`
@@ -516,3 +615,112 @@ type VersionResponse struct {
 	Version string `json:"version" yaml:"version"`
 	Commit  string `json:"commit" yaml:"commit"`
 }
+
+// ExportModelSpecRequest holds a request to export the specification of
+// a model.
+type ExportModelSpecRequest struct {
+	// ModelTag holds the tag of the model to export.
+	ModelTag string `json:"model-tag"`
+}
+
+// ModelSpec holds the specification of a model, suitable for re-creating
+// the model elsewhere. It never contains any secrets, the cloud
+// credential is referenced by name.
+type ModelSpec struct {
+	// Name holds the name of the model.
+	Name string `json:"name" yaml:"name"`
+
+	// Owner holds the name of the user that owns the model.
+	Owner string `json:"owner" yaml:"owner"`
+
+	// Cloud holds the name of the cloud hosting the model.
+	Cloud string `json:"cloud" yaml:"cloud"`
+
+	// CloudRegion holds the name of the cloud region hosting the model.
+	CloudRegion string `json:"region,omitempty" yaml:"region,omitempty"`
+
+	// CloudCredential holds the name of the cloud credential used by
+	// the model.
+	CloudCredential string `json:"credential,omitempty" yaml:"credential,omitempty"`
+
+	// Config holds the model configuration that has been explicitly set
+	// on the model.
+	Config map[string]interface{} `json:"config,omitempty" yaml:"config,omitempty"`
+
+	// Constraints holds the model constraints, in the format accepted by
+	// juju set-model-constraints.
+	Constraints string `json:"constraints,omitempty" yaml:"constraints,omitempty"`
+}
+
+// InventoryVersion is the version of the inventory document produced by
+// ExportInventory. It is incremented whenever the format of the document
+// changes incompatibly.
+const InventoryVersion = 1
+
+// InventoryPath is the path of the HTTP endpoint that serves the inventory
+// document. A GET request to the path streams an Inventory document to the
+// client.
+const InventoryPath = "/inventory"
+
+// Inventory is a snapshot of the entities known to JIMM and the access
+// relations between them. It never contains any secrets.
+type Inventory struct {
+	// Version holds the version of the inventory document format.
+	Version int `json:"version" yaml:"version"`
+
+	// Controllers holds the controllers known to JIMM.
+	Controllers []InventoryController `json:"controllers" yaml:"controllers"`
+
+	// Clouds holds the clouds known to JIMM.
+	Clouds []InventoryCloud `json:"clouds" yaml:"clouds"`
+
+	// Models holds the models known to JIMM.
+	Models []InventoryModel `json:"models" yaml:"models"`
+
+	// Groups holds the groups known to JIMM.
+	Groups []InventoryGroup `json:"groups" yaml:"groups"`
+
+	// Relations holds the access relations stored in OpenFGA.
+	Relations []RelationshipTuple `json:"relations" yaml:"relations"`
+}
+
+// InventoryController describes a controller in the inventory.
+type InventoryController struct {
+	Name          string `json:"name" yaml:"name"`
+	UUID          string `json:"uuid" yaml:"uuid"`
+	Cloud         string `json:"cloud" yaml:"cloud"`
+	CloudRegion   string `json:"region,omitempty" yaml:"region,omitempty"`
+	PublicAddress string `json:"public-address,omitempty" yaml:"public-address,omitempty"`
+	AgentVersion  string `json:"agent-version,omitempty" yaml:"agent-version,omitempty"`
+	Deprecated    bool   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+// InventoryCloud describes a cloud in the inventory.
+type InventoryCloud struct {
+	Name            string   `json:"name" yaml:"name"`
+	Type            string   `json:"type" yaml:"type"`
+	HostCloudRegion string   `json:"host-cloud-region,omitempty" yaml:"host-cloud-region,omitempty"`
+	Regions         []string `json:"regions,omitempty" yaml:"regions,omitempty"`
+}
+
+// InventoryModel describes a model in the inventory. The cloud
+// credential is referenced by its path, its attributes are never
+// included.
+type InventoryModel struct {
+	UUID            string `json:"uuid" yaml:"uuid"`
+	Name            string `json:"name" yaml:"name"`
+	Owner           string `json:"owner" yaml:"owner"`
+	Type            string `json:"type,omitempty" yaml:"type,omitempty"`
+	Controller      string `json:"controller" yaml:"controller"`
+	Cloud           string `json:"cloud" yaml:"cloud"`
+	CloudRegion     string `json:"region" yaml:"region"`
+	CloudCredential string `json:"credential,omitempty" yaml:"credential,omitempty"`
+	Life            string `json:"life" yaml:"life"`
+	Status          string `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// InventoryGroup describes a group in the inventory.
+type InventoryGroup struct {
+	UUID string `json:"uuid" yaml:"uuid"`
+	Name string `json:"name" yaml:"name"`
+}